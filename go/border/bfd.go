@@ -0,0 +1,138 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file manages the BFD sessions that run on the external interfaces.
+
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/border/bfd"
+	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/ifstate"
+	"github.com/scionproto/scion/go/border/rcmn"
+	"github.com/scionproto/scion/go/border/rctrl"
+	"github.com/scionproto/scion/go/border/rctx"
+	"github.com/scionproto/scion/go/border/rpkt"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/ringbuf"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// bfdSessions holds the BFD sessions of all external interfaces.
+type bfdSessions struct {
+	mtx      sync.Mutex
+	sessions map[common.IFIDType]*bfd.Session
+}
+
+// updateBFD starts BFD sessions for new external interfaces and stops the
+// sessions of removed interfaces. If BFD is disabled, all sessions are
+// stopped. It must be called after the new context has been set.
+func (r *Router) updateBFD(ctx *rctx.Ctx, bfdCfg brconf.BFD) {
+	r.bfd.mtx.Lock()
+	defer r.bfd.mtx.Unlock()
+	if r.bfd.sessions == nil {
+		r.bfd.sessions = make(map[common.IFIDType]*bfd.Session)
+	}
+	if bfdCfg.Enable {
+		cfg := bfd.Config{
+			DesiredMinTxInterval:  bfdCfg.DesiredMinTxInterval.Duration,
+			RequiredMinRxInterval: bfdCfg.RequiredMinRxInterval.Duration,
+			DetectMult:            bfdCfg.DetectMult,
+		}
+		for ifid := range ctx.Conf.BR.IFs {
+			if _, ok := r.bfd.sessions[ifid]; ok {
+				continue
+			}
+			s := bfd.NewSession(cfg, bfdSender(ifid), log.New("ifid", ifid),
+				bfdStateChange(ifid))
+			r.bfd.sessions[ifid] = s
+			go s.Run()
+		}
+	}
+	for ifid, s := range r.bfd.sessions {
+		if _, ok := ctx.Conf.BR.IFs[ifid]; ok && bfdCfg.Enable {
+			continue
+		}
+		s.Close()
+		delete(r.bfd.sessions, ifid)
+		// Without a session, the link is no longer monitored and is
+		// considered up.
+		ifstate.SetLinkState(ifid, true)
+	}
+}
+
+// handleBFD passes a BFD control packet that was received on an external
+// interface to the session of that interface.
+func (r *Router) handleBFD(rp *rpkt.RtrPkt) {
+	r.bfd.mtx.Lock()
+	s, ok := r.bfd.sessions[rp.Ingress.IfID]
+	r.bfd.mtx.Unlock()
+	if !ok {
+		return
+	}
+	if err := s.Receive(rp.Raw); err != nil {
+		log.Debug("Invalid BFD control packet", "ifid", rp.Ingress.IfID, "err", err)
+	}
+}
+
+// bfdStateChange returns the callback that updates the link state of the
+// interface whenever the state of its BFD session changes.
+func bfdStateChange(ifid common.IFIDType) func(old, new bfd.State) {
+	return func(old, new bfd.State) {
+		switch {
+		case new == bfd.Up:
+			ifstate.SetLinkState(ifid, true)
+		case old == bfd.Up:
+			ifstate.SetLinkState(ifid, false)
+			if err := rctrl.RequestRevocation(ifid); err != nil {
+				log.Error("Unable to request revocation of failed link",
+					"ifid", ifid, "err", err)
+			}
+		}
+	}
+}
+
+// bfdSender sends BFD control packets on the socket of an external interface.
+// The packets are queued on the egress ring of the socket, so that they are
+// written by the same IO backend and are accounted for in the same output
+// metrics as all other packets leaving on the interface.
+type bfdSender common.IFIDType
+
+func (s bfdSender) Send(b common.RawBytes) error {
+	ifid := common.IFIDType(s)
+	sock, ok := rctx.Get().ExtSockOut[ifid]
+	if !ok {
+		return serrors.New("no socket for interface", "ifid", ifid)
+	}
+	rp := rpkt.NewRtrPkt()
+	rp.Raw = append(rp.Raw[:0], b...)
+	rp.TimeIn = time.Now()
+	rp.Id = log.NewDebugID().String()
+	rp.Logger = log.New("rpkt", rp.Id)
+	rp.DirFrom = rcmn.DirSelf
+	// Do not block the session if the ring is full, the control packet is
+	// simply lost, as it would be on a congested link.
+	n, _ := sock.Ring.Write(ringbuf.EntryList{&rpkt.EgressRtrPkt{Rp: rp}}, false)
+	switch {
+	case n < 0:
+		return serrors.New("egress ring closed", "ifid", ifid)
+	case n == 0:
+		return serrors.New("egress ring full", "ifid", ifid)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bfd implements Bidirectional Forwarding Detection (RFC 5880) for the
+// links between neighboring border routers.
+//
+// Sessions run in asynchronous mode without authentication. The control
+// packets are sent directly on the underlay of the external interface, next to
+// the SCION packets. Both can be told apart by the version in the first three
+// bits of the packet, which is 1 for BFD and 0 for SCION, see IsBFD.
+//
+// A session is declared down if no control packet has been received from the
+// neighbor within the detection time, i.e., DetectMult times the agreed
+// receive interval. With intervals in the order of 10ms, link failures are
+// detected within tens of milliseconds.
+package bfd
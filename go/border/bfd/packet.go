@@ -0,0 +1,169 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bfd
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// Version is the BFD protocol version.
+	Version = 1
+	// PacketLen is the length of a BFD control packet without authentication
+	// section.
+	PacketLen = 24
+)
+
+// State is the state of a BFD session.
+type State uint8
+
+const (
+	AdminDown State = iota
+	Down
+	Init
+	Up
+)
+
+func (s State) String() string {
+	switch s {
+	case AdminDown:
+		return "AdminDown"
+	case Down:
+		return "Down"
+	case Init:
+		return "Init"
+	case Up:
+		return "Up"
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", uint8(s))
+}
+
+// Diagnostic indicates the reason for the last change in session state.
+type Diagnostic uint8
+
+const (
+	DiagNone Diagnostic = iota
+	DiagDetectionTimeExpired
+	DiagEchoFailed
+	DiagNeighborDown
+	DiagForwardingPlaneReset
+	DiagPathDown
+	DiagConcatenatedPathDown
+	DiagAdminDown
+	DiagReverseConcatenatedPathDown
+)
+
+// Packet is a BFD control packet (RFC 5880, section 4.1). Authentication is
+// not supported. All intervals are in microseconds.
+type Packet struct {
+	Diag                      Diagnostic
+	State                     State
+	Poll                      bool
+	Final                     bool
+	ControlPlaneIndependent   bool
+	Demand                    bool
+	DetectMult                uint8
+	MyDiscriminator           uint32
+	YourDiscriminator         uint32
+	DesiredMinTxInterval      uint32
+	RequiredMinRxInterval     uint32
+	RequiredMinEchoRxInterval uint32
+}
+
+// IsBFD returns whether b looks like a BFD control packet. BFD packets are
+// exchanged on the same underlay as SCION packets, they are distinguished by
+// the version in the first three bits, which is always 0 for SCION packets.
+func IsBFD(b common.RawBytes) bool {
+	return len(b) > 0 && b[0]>>5 == Version
+}
+
+// Parse parses a BFD control packet.
+func Parse(b common.RawBytes) (*Packet, error) {
+	if len(b) < PacketLen {
+		return nil, serrors.New("packet too short", "len", len(b))
+	}
+	if v := b[0] >> 5; v != Version {
+		return nil, serrors.New("unsupported version", "version", v)
+	}
+	if l := int(b[3]); l < PacketLen || l > len(b) {
+		return nil, serrors.New("invalid length", "length", l, "actual", len(b))
+	}
+	flags := b[1]
+	if flags&0x04 != 0 {
+		return nil, serrors.New("authentication not supported")
+	}
+	p := &Packet{
+		Diag:                      Diagnostic(b[0] & 0x1f),
+		State:                     State(b[1] >> 6),
+		Poll:                      flags&0x20 != 0,
+		Final:                     flags&0x10 != 0,
+		ControlPlaneIndependent:   flags&0x08 != 0,
+		Demand:                    flags&0x02 != 0,
+		DetectMult:                b[2],
+		MyDiscriminator:           binary.BigEndian.Uint32(b[4:]),
+		YourDiscriminator:         binary.BigEndian.Uint32(b[8:]),
+		DesiredMinTxInterval:      binary.BigEndian.Uint32(b[12:]),
+		RequiredMinRxInterval:     binary.BigEndian.Uint32(b[16:]),
+		RequiredMinEchoRxInterval: binary.BigEndian.Uint32(b[20:]),
+	}
+	if p.DetectMult == 0 {
+		return nil, serrors.New("detect multiplier must not be zero")
+	}
+	if p.MyDiscriminator == 0 {
+		return nil, serrors.New("my discriminator must not be zero")
+	}
+	if flags&0x01 != 0 {
+		return nil, serrors.New("multipoint not supported")
+	}
+	return p, nil
+}
+
+// Pack serializes the packet.
+func (p *Packet) Pack() common.RawBytes {
+	b := make(common.RawBytes, PacketLen)
+	b[0] = Version<<5 | uint8(p.Diag)&0x1f
+	b[1] = uint8(p.State) << 6
+	if p.Poll {
+		b[1] |= 0x20
+	}
+	if p.Final {
+		b[1] |= 0x10
+	}
+	if p.ControlPlaneIndependent {
+		b[1] |= 0x08
+	}
+	if p.Demand {
+		b[1] |= 0x02
+	}
+	b[2] = p.DetectMult
+	b[3] = PacketLen
+	binary.BigEndian.PutUint32(b[4:], p.MyDiscriminator)
+	binary.BigEndian.PutUint32(b[8:], p.YourDiscriminator)
+	binary.BigEndian.PutUint32(b[12:], p.DesiredMinTxInterval)
+	binary.BigEndian.PutUint32(b[16:], p.RequiredMinRxInterval)
+	binary.BigEndian.PutUint32(b[20:], p.RequiredMinEchoRxInterval)
+	return b
+}
+
+func (p *Packet) String() string {
+	return fmt.Sprintf("State: %s Diag: %d MyDisc: %d YourDisc: %d DetectMult: %d "+
+		"DesiredMinTx: %dus RequiredMinRx: %dus Poll: %t Final: %t", p.State, p.Diag,
+		p.MyDiscriminator, p.YourDiscriminator, p.DetectMult, p.DesiredMinTxInterval,
+		p.RequiredMinRxInterval, p.Poll, p.Final)
+}
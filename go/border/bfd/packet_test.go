@@ -0,0 +1,86 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bfd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+func TestPacketPackParse(t *testing.T) {
+	p := &Packet{
+		Diag:                      DiagDetectionTimeExpired,
+		State:                     Init,
+		Poll:                      true,
+		DetectMult:                3,
+		MyDiscriminator:           42,
+		YourDiscriminator:         1337,
+		DesiredMinTxInterval:      10000,
+		RequiredMinRxInterval:     20000,
+		RequiredMinEchoRxInterval: 0,
+	}
+	raw := p.Pack()
+	assert.Len(t, raw, PacketLen)
+	assert.True(t, IsBFD(raw))
+	parsed, err := Parse(raw)
+	require.NoError(t, err)
+	assert.Equal(t, p, parsed)
+}
+
+func TestParseErrors(t *testing.T) {
+	valid := func() common.RawBytes {
+		return (&Packet{State: Down, DetectMult: 3, MyDiscriminator: 1}).Pack()
+	}
+	tests := map[string]func(b common.RawBytes) common.RawBytes{
+		"too short": func(b common.RawBytes) common.RawBytes { return b[:PacketLen-1] },
+		"bad version": func(b common.RawBytes) common.RawBytes {
+			b[0] = 2 << 5
+			return b
+		},
+		"bad length": func(b common.RawBytes) common.RawBytes {
+			b[3] = PacketLen + 1
+			return b
+		},
+		"auth present": func(b common.RawBytes) common.RawBytes {
+			b[1] |= 0x04
+			return b
+		},
+		"zero detect mult": func(b common.RawBytes) common.RawBytes {
+			b[2] = 0
+			return b
+		},
+		"zero my discriminator": func(b common.RawBytes) common.RawBytes {
+			copy(b[4:8], []byte{0, 0, 0, 0})
+			return b
+		},
+	}
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(modify(valid()))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestIsBFD(t *testing.T) {
+	assert.False(t, IsBFD(nil))
+	// SCION common header with version 0.
+	assert.False(t, IsBFD(common.RawBytes{0x00, 0x41}))
+	assert.True(t, IsBFD(common.RawBytes{0x20}))
+}
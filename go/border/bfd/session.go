@@ -0,0 +1,341 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bfd
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+)
+
+const (
+	// SlowTxInterval is the minimum transmit interval while the session is
+	// not up.
+	SlowTxInterval = time.Second
+	// msgQueueSize is the number of received packets that can be queued for
+	// processing.
+	msgQueueSize = 16
+)
+
+// Sender sends BFD control packets to the neighbor.
+type Sender interface {
+	Send(b common.RawBytes) error
+}
+
+// Config is the configuration of a BFD session.
+type Config struct {
+	// DesiredMinTxInterval is the minimum interval at which the local system
+	// would like to send control packets while the session is up.
+	DesiredMinTxInterval time.Duration
+	// RequiredMinRxInterval is the minimum interval between received control
+	// packets that the local system is capable of supporting.
+	RequiredMinRxInterval time.Duration
+	// DetectMult is the number of missed packets after which the session is
+	// declared down.
+	DetectMult uint8
+}
+
+// Session is a BFD session in asynchronous mode. It implements the state
+// machine of RFC 5880, section 6.2. Changes of the session parameters through
+// poll sequences are not supported, but poll messages of the neighbor are
+// answered.
+type Session struct {
+	cfg           Config
+	sender        Sender
+	onStateChange func(old, new State)
+	logger        log.Logger
+	msgs          chan *Packet
+	stop          chan struct{}
+	stopped       chan struct{}
+	closeOnce     sync.Once
+
+	mtx                 sync.Mutex
+	state               State
+	diag                Diagnostic
+	localDisc           uint32
+	remoteDisc          uint32
+	remoteState         State
+	remoteMinRxInterval time.Duration
+	remoteMinTxInterval time.Duration
+	remoteDetectMult    uint8
+	detectDeadline      time.Time
+	pendingFinal        bool
+	nextTx              time.Time
+}
+
+// NewSession creates a new session. The onStateChange callback is invoked from
+// the session goroutine on every state change. The session starts in state
+// Down; call Run to start it.
+func NewSession(cfg Config, sender Sender, logger log.Logger,
+	onStateChange func(old, new State)) *Session {
+
+	if logger == nil {
+		logger = log.Root()
+	}
+	return &Session{
+		cfg:                 cfg,
+		sender:              sender,
+		onStateChange:       onStateChange,
+		logger:              logger,
+		msgs:                make(chan *Packet, msgQueueSize),
+		stop:                make(chan struct{}),
+		stopped:             make(chan struct{}),
+		state:               Down,
+		localDisc:           newDiscriminator(),
+		remoteMinRxInterval: time.Microsecond,
+	}
+}
+
+// Run runs the session until Close is called.
+func (s *Session) Run() {
+	defer log.HandlePanic()
+	defer close(s.stopped)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		now := time.Now()
+		s.tick(now)
+		// Stop the timer and drain it if necessary, before resetting it.
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(s.nextEvent(now))
+		select {
+		case p := <-s.msgs:
+			s.handlePacket(p, time.Now())
+		case <-timer.C:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// Close stops the session and waits for Run to return. It must only be called
+// after Run has been started.
+func (s *Session) Close() {
+	s.closeOnce.Do(func() {
+		close(s.stop)
+	})
+	<-s.stopped
+}
+
+// Receive parses a control packet received from the neighbor and queues it
+// for processing. Packets are dropped if the queue is full.
+func (s *Session) Receive(b common.RawBytes) error {
+	p, err := Parse(b)
+	if err != nil {
+		return err
+	}
+	select {
+	case s.msgs <- p:
+	default:
+		s.logger.Debug("[BFD] Dropping control packet, queue full")
+	}
+	return nil
+}
+
+// State returns the current state of the session.
+func (s *Session) State() State {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.state
+}
+
+// tick sends periodic control packets and checks the detection timer.
+func (s *Session) tick(now time.Time) {
+	s.checkDetection(now)
+	s.mtx.Lock()
+	send := s.pendingFinal || (!now.Before(s.nextTx) && s.remoteMinRxInterval != 0)
+	if !now.Before(s.nextTx) {
+		s.nextTx = now.Add(s.txInterval())
+	}
+	s.mtx.Unlock()
+	if send {
+		s.send()
+	}
+}
+
+// nextEvent returns the duration until the next transmission or detection
+// timeout.
+func (s *Session) nextEvent(now time.Time) time.Duration {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	next := s.nextTx
+	if !s.detectDeadline.IsZero() && s.detectDeadline.Before(next) {
+		next = s.detectDeadline
+	}
+	if d := next.Sub(now); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// handlePacket processes a received control packet according to RFC 5880,
+// section 6.8.6.
+func (s *Session) handlePacket(p *Packet, now time.Time) {
+	s.mtx.Lock()
+	if p.YourDiscriminator != 0 && p.YourDiscriminator != s.localDisc {
+		s.mtx.Unlock()
+		return
+	}
+	if p.YourDiscriminator == 0 && p.State != Down && p.State != AdminDown {
+		s.mtx.Unlock()
+		return
+	}
+	s.remoteDisc = p.MyDiscriminator
+	s.remoteState = p.State
+	s.remoteMinRxInterval = usec(p.RequiredMinRxInterval)
+	s.remoteMinTxInterval = usec(p.DesiredMinTxInterval)
+	s.remoteDetectMult = p.DetectMult
+	s.detectDeadline = now.Add(s.detectionTime())
+	if p.Poll {
+		s.pendingFinal = true
+	}
+	old := s.state
+	switch {
+	case s.state == AdminDown:
+	case p.State == AdminDown:
+		if s.state != Down {
+			s.setState(Down, DiagNeighborDown)
+		}
+	case s.state == Down:
+		if p.State == Down {
+			s.setState(Init, DiagNone)
+		} else if p.State == Init {
+			s.setState(Up, DiagNone)
+		}
+	case s.state == Init:
+		if p.State == Init || p.State == Up {
+			s.setState(Up, DiagNone)
+		}
+	case s.state == Up:
+		if p.State == Down {
+			s.setState(Down, DiagNeighborDown)
+		}
+	}
+	current := s.state
+	s.mtx.Unlock()
+	s.notify(old, current)
+}
+
+// checkDetection declares the session down if no control packet has been
+// received within the detection time.
+func (s *Session) checkDetection(now time.Time) {
+	s.mtx.Lock()
+	if s.detectDeadline.IsZero() || now.Before(s.detectDeadline) {
+		s.mtx.Unlock()
+		return
+	}
+	s.detectDeadline = time.Time{}
+	old := s.state
+	if s.state == Init || s.state == Up {
+		s.setState(Down, DiagDetectionTimeExpired)
+		s.remoteDisc = 0
+	}
+	current := s.state
+	s.mtx.Unlock()
+	s.notify(old, current)
+}
+
+// setState must be called while holding the lock.
+func (s *Session) setState(state State, diag Diagnostic) {
+	s.state = state
+	s.diag = diag
+	// Send the new state to the neighbor immediately.
+	s.nextTx = time.Time{}
+}
+
+func (s *Session) notify(old, current State) {
+	if old == current {
+		return
+	}
+	s.logger.Info("[BFD] Session state changed", "old", old, "new", current)
+	if s.onStateChange != nil {
+		s.onStateChange(old, current)
+	}
+}
+
+func (s *Session) send() {
+	s.mtx.Lock()
+	p := &Packet{
+		Diag:                  s.diag,
+		State:                 s.state,
+		Final:                 s.pendingFinal,
+		DetectMult:            s.cfg.DetectMult,
+		MyDiscriminator:       s.localDisc,
+		YourDiscriminator:     s.remoteDisc,
+		DesiredMinTxInterval:  toUsec(s.desiredMinTxInterval()),
+		RequiredMinRxInterval: toUsec(s.cfg.RequiredMinRxInterval),
+	}
+	s.pendingFinal = false
+	s.mtx.Unlock()
+	if err := s.sender.Send(p.Pack()); err != nil {
+		s.logger.Debug("[BFD] Unable to send control packet", "err", err)
+	}
+}
+
+// desiredMinTxInterval must be called while holding the lock.
+func (s *Session) desiredMinTxInterval() time.Duration {
+	if s.state != Up && s.cfg.DesiredMinTxInterval < SlowTxInterval {
+		return SlowTxInterval
+	}
+	return s.cfg.DesiredMinTxInterval
+}
+
+// txInterval returns the jittered interval until the next periodic
+// transmission. It must be called while holding the lock.
+func (s *Session) txInterval() time.Duration {
+	interval := s.desiredMinTxInterval()
+	if s.remoteMinRxInterval > interval {
+		interval = s.remoteMinRxInterval
+	}
+	// Reduce the interval by a random 0-25% (10-25% if DetectMult is 1).
+	jitter := 0.75 + 0.25*rand.Float64()
+	if s.cfg.DetectMult == 1 {
+		jitter = 0.75 + 0.15*rand.Float64()
+	}
+	return time.Duration(float64(interval) * jitter)
+}
+
+// detectionTime must be called while holding the lock.
+func (s *Session) detectionTime() time.Duration {
+	interval := s.cfg.RequiredMinRxInterval
+	if s.remoteMinTxInterval > interval {
+		interval = s.remoteMinTxInterval
+	}
+	return time.Duration(s.remoteDetectMult) * interval
+}
+
+func newDiscriminator() uint32 {
+	for {
+		if d := rand.Uint32(); d != 0 {
+			return d
+		}
+	}
+}
+
+func usec(v uint32) time.Duration {
+	return time.Duration(v) * time.Microsecond
+}
+
+func toUsec(d time.Duration) uint32 {
+	return uint32(d / time.Microsecond)
+}
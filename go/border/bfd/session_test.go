@@ -0,0 +1,206 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bfd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+var testCfg = Config{
+	DesiredMinTxInterval:  10 * time.Millisecond,
+	RequiredMinRxInterval: 10 * time.Millisecond,
+	DetectMult:            3,
+}
+
+// recordSender records all sent packets.
+type recordSender struct {
+	pkts []common.RawBytes
+}
+
+func (s *recordSender) Send(b common.RawBytes) error {
+	s.pkts = append(s.pkts, b)
+	return nil
+}
+
+// last returns the last sent packet.
+func (s *recordSender) last(t *testing.T) *Packet {
+	require.NotEmpty(t, s.pkts)
+	p, err := Parse(s.pkts[len(s.pkts)-1])
+	require.NoError(t, err)
+	return p
+}
+
+type transitions []State
+
+func newTestSession(sender Sender, ts *transitions) *Session {
+	return NewSession(testCfg, sender, nil, func(_, new State) {
+		*ts = append(*ts, new)
+	})
+}
+
+// exchange sends a control packet from a to b.
+func exchange(t *testing.T, from *Session, fromSender *recordSender, to *Session,
+	now time.Time) {
+
+	from.send()
+	to.handlePacket(fromSender.last(t), now)
+}
+
+func TestSessionThreeWayHandshake(t *testing.T) {
+	now := time.Now()
+	var sa, sb recordSender
+	var ta, tb transitions
+	a, b := newTestSession(&sa, &ta), newTestSession(&sb, &tb)
+
+	exchange(t, a, &sa, b, now)
+	assert.Equal(t, Init, b.State())
+	exchange(t, b, &sb, a, now)
+	assert.Equal(t, Up, a.State())
+	exchange(t, a, &sa, b, now)
+	assert.Equal(t, Up, b.State())
+	assert.Equal(t, transitions{Up}, ta)
+	assert.Equal(t, transitions{Init, Up}, tb)
+	// Once up, the configured interval is advertised.
+	assert.Equal(t, uint32(10000), sa.last(t).DesiredMinTxInterval)
+	assert.Equal(t, b.localDisc, sa.last(t).YourDiscriminator)
+}
+
+func TestSessionDetectionTimeout(t *testing.T) {
+	now := time.Now()
+	var sa, sb recordSender
+	var ta, tb transitions
+	a, b := newTestSession(&sa, &ta), newTestSession(&sb, &tb)
+	exchange(t, a, &sa, b, now)
+	exchange(t, b, &sb, a, now)
+	exchange(t, a, &sa, b, now)
+	require.Equal(t, Up, b.State())
+
+	// The detection time is 3 * 10ms.
+	b.checkDetection(now.Add(29 * time.Millisecond))
+	assert.Equal(t, Up, b.State())
+	b.checkDetection(now.Add(31 * time.Millisecond))
+	assert.Equal(t, Down, b.State())
+	assert.Equal(t, transitions{Init, Up, Down}, tb)
+	b.send()
+	p := sb.last(t)
+	assert.Equal(t, DiagDetectionTimeExpired, p.Diag)
+	assert.Equal(t, uint32(0), p.YourDiscriminator)
+}
+
+func TestSessionNeighborDown(t *testing.T) {
+	now := time.Now()
+	var sa, sb recordSender
+	var ta, tb transitions
+	a, b := newTestSession(&sa, &ta), newTestSession(&sb, &tb)
+	exchange(t, a, &sa, b, now)
+	exchange(t, b, &sb, a, now)
+	exchange(t, a, &sa, b, now)
+	require.Equal(t, Up, a.State())
+
+	b.handlePacket(&Packet{State: AdminDown, DetectMult: 3, MyDiscriminator: a.localDisc,
+		YourDiscriminator: b.localDisc}, now)
+	assert.Equal(t, Down, b.State())
+	exchange(t, b, &sb, a, now)
+	assert.Equal(t, Down, a.State())
+	assert.Equal(t, transitions{Up, Down}, ta)
+}
+
+func TestSessionIgnoresInvalidDiscriminators(t *testing.T) {
+	now := time.Now()
+	var sa recordSender
+	var ta transitions
+	a := newTestSession(&sa, &ta)
+	// Unknown discriminator.
+	a.handlePacket(&Packet{State: Down, DetectMult: 3, MyDiscriminator: 1,
+		YourDiscriminator: a.localDisc + 1}, now)
+	// Zero discriminator in a packet that is not Down.
+	a.handlePacket(&Packet{State: Up, DetectMult: 3, MyDiscriminator: 1}, now)
+	assert.Equal(t, Down, a.State())
+	assert.Empty(t, ta)
+}
+
+func TestSessionPoll(t *testing.T) {
+	now := time.Now()
+	var sa recordSender
+	var ta transitions
+	a := newTestSession(&sa, &ta)
+	a.handlePacket(&Packet{State: Down, Poll: true, DetectMult: 3, MyDiscriminator: 1}, now)
+	a.tick(now)
+	assert.True(t, sa.last(t).Final)
+}
+
+type chanSender chan common.RawBytes
+
+func (s chanSender) Send(b common.RawBytes) error {
+	select {
+	case s <- b:
+	default:
+	}
+	return nil
+}
+
+func TestSessionRun(t *testing.T) {
+	ab, ba := make(chanSender, 64), make(chanSender, 64)
+	up := make(chan struct{}, 2)
+	down := make(chan struct{}, 2)
+	onChange := func(_, new State) {
+		switch new {
+		case Up:
+			up <- struct{}{}
+		case Down:
+			down <- struct{}{}
+		}
+	}
+	a := NewSession(testCfg, ab, nil, onChange)
+	b := NewSession(testCfg, ba, nil, onChange)
+	stopFwd := make(chan struct{})
+	forward := func(from chanSender, to *Session) {
+		for {
+			select {
+			case raw := <-from:
+				to.Receive(raw)
+			case <-stopFwd:
+				return
+			}
+		}
+	}
+	go forward(ab, b)
+	go forward(ba, a)
+	go a.Run()
+	go b.Run()
+	defer b.Close()
+	for i := 0; i < 2; i++ {
+		select {
+		case <-up:
+		case <-time.After(time.Second):
+			t.Fatal("Sessions did not come up")
+		}
+	}
+	// Stopping a must bring b down within a few detection times.
+	a.Close()
+	close(stopFwd)
+	select {
+	case <-down:
+	case <-time.After(time.Second):
+		t.Fatal("Session not declared down")
+	}
+	assert.Equal(t, Down, b.State())
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/ifstate"
+	"github.com/scionproto/scion/go/border/rctx"
+	"github.com/scionproto/scion/go/border/rpkt"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ringbuf"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+func TestUpdateBFD(t *testing.T) {
+	r := initTestRouter(1)
+	ctx := rctx.New(loadConfig(t))
+	// The sessions send control packets through the current context.
+	rctx.Set(ctx)
+	enabled := brconf.BFD{
+		Enable:                true,
+		DesiredMinTxInterval:  util.DurWrap{Duration: time.Second},
+		RequiredMinRxInterval: util.DurWrap{Duration: time.Second},
+		DetectMult:            3,
+	}
+	ifids := func() []int {
+		r.bfd.mtx.Lock()
+		defer r.bfd.mtx.Unlock()
+		var ifids []int
+		for ifid := range r.bfd.sessions {
+			ifids = append(ifids, int(ifid))
+		}
+		return ifids
+	}
+
+	r.updateBFD(ctx, enabled)
+	assert.Len(t, ifids(), len(ctx.Conf.BR.IFs))
+	// Reloading with an unchanged config keeps the sessions.
+	r.updateBFD(ctx, enabled)
+	assert.Len(t, ifids(), len(ctx.Conf.BR.IFs))
+
+	t.Run("disabled on reload", func(t *testing.T) {
+		for ifid := range ctx.Conf.BR.IFs {
+			ifstate.SetLinkState(ifid, false)
+		}
+		r.updateBFD(ctx, brconf.BFD{})
+		assert.Empty(t, ifids())
+		for ifid := range ctx.Conf.BR.IFs {
+			assert.True(t, ifstate.LinkUp(ifid), "ifid %d", ifid)
+		}
+	})
+}
+
+func TestBFDSenderSend(t *testing.T) {
+	ctx := rctx.New(loadConfig(t))
+	ring := ringbuf.New(1, nil, "test")
+	ctx.ExtSockOut[1] = &rctx.Sock{Ring: ring, Ifid: 1}
+	rctx.Set(ctx)
+
+	// The packet is queued on the egress ring of the interface, it is
+	// written by the IO backend of the socket.
+	b := common.RawBytes{1, 2, 3}
+	require.NoError(t, bfdSender(1).Send(b))
+	b[0] = 0
+	assert.Error(t, bfdSender(1).Send(b), "full ring")
+	entries := make(ringbuf.EntryList, 1)
+	n, _ := ring.Read(entries, false)
+	require.Equal(t, 1, n)
+	epkt := entries[0].(*rpkt.EgressRtrPkt)
+	assert.Equal(t, common.RawBytes{1, 2, 3}, epkt.Rp.Raw)
+	assert.Nil(t, epkt.Dst)
+
+	ring.Close()
+	assert.Error(t, bfdSender(1).Send(b), "closed ring")
+	assert.Error(t, bfdSender(99).Send(b), "unknown interface")
+}
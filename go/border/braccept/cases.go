@@ -0,0 +1,429 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/go/border/braccept/shared"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// TestCase is a declarative acceptance test case. Test cases are loaded from
+// the YAML files in the test cases directory, see testcases/child.yml for
+// examples. Each file contains a list of test cases.
+//
+// The packets of a test case are built in order. A packet is either defined
+// from scratch, or derived from a previous packet by updating some of its
+// layers. The layers use the syntax of the parser package, with nested layers
+// indented by two spaces instead of a tab. The layers can refer to the
+// serialization of a previous packet with $(name), e.g., to define the quoted
+// packet of an SCMP error. Fields of expected packets that cannot be
+// predicted can be excluded from the comparison with ignore, e.g.,
+// ignore: [IP4.Id, SCMP.Timestamp].
+//
+// A simple test case sends some packets and expects some packets in return,
+// see Send and Expect. Test cases that need to change the state of the router
+// first, e.g., by revoking an interface, list a sequence of steps instead,
+// see testcases/revocation.yml for examples.
+type TestCase struct {
+	// ID uniquely identifies the test case. It is used to refer to the test
+	// case from the test groups.
+	ID string `yaml:"id"`
+	// Name is the human readable description of the test case.
+	Name string `yaml:"name"`
+	// Packets are the packets used in the test case. They are built in order,
+	// such that a packet can only be derived from a previous packet.
+	Packets []PacketSpec `yaml:"packets"`
+	// Send lists the names of the packets that are sent to the router.
+	Send []string `yaml:"send"`
+	// Expect lists the names of the packets that are expected from the
+	// router. If empty, no packet is expected.
+	Expect []string `yaml:"expect"`
+	// Timeout is the time to wait for the expected packets. If not set, the
+	// default timeout is used.
+	Timeout string `yaml:"timeout"`
+	// Steps are run in order instead of Send and Expect.
+	Steps []StepSpec `yaml:"steps"`
+}
+
+// StepSpec describes a step of a test case. A step sends packets to the
+// router, and then either sleeps or waits for the expected packets.
+type StepSpec struct {
+	// Send lists the names of the packets that are sent to the router.
+	Send []string `yaml:"send"`
+	// Expect lists the names of the packets that are expected from the
+	// router. If empty and Sleep is not set, no packet is expected.
+	Expect []string `yaml:"expect"`
+	// Timeout is the time to wait for the expected packets. If not set, the
+	// default timeout is used.
+	Timeout string `yaml:"timeout"`
+	// Sleep is the time to wait after sending the packets, e.g., for the
+	// router to process a state change. Packets received in the meantime are
+	// not checked.
+	Sleep string `yaml:"sleep"`
+}
+
+// PacketSpec describes a packet of a test case.
+type PacketSpec struct {
+	// Name identifies the packet within the test case.
+	Name string `yaml:"name"`
+	// From is the name of the packet this packet is derived from.
+	From string `yaml:"from"`
+	// Dev is the device the packet is sent on or expected on.
+	Dev string `yaml:"dev"`
+	// Layers is the packet definition, or the layers to update if the packet
+	// is derived from another packet.
+	Layers string `yaml:"layers"`
+	// Checksums lists the checksums to compute.
+	Checksums []ChecksumSpec `yaml:"checksums"`
+	// Macs lists the hop field MACs to compute.
+	Macs []MacSpec `yaml:"macs"`
+	// Ignore lists the fields that are not compared for expected packets,
+	// in the form Tag.Field, e.g., IP4.Id or SCMP.Timestamp. Nested fields
+	// are separated by dots.
+	Ignore []string `yaml:"ignore"`
+}
+
+// ChecksumSpec identifies the layers of a checksum computation.
+type ChecksumSpec struct {
+	L4 string `yaml:"l4"`
+	L3 string `yaml:"l3"`
+}
+
+// MacSpec identifies the hop field of a MAC computation. Mac optionally
+// identifies the hop field that is chained into the MAC.
+type MacSpec struct {
+	Scion string `yaml:"scion"`
+	Info  string `yaml:"info"`
+	Hop   string `yaml:"hop"`
+	Mac   string `yaml:"mac"`
+}
+
+// groupsFile is the file in the test cases directory that defines the test
+// groups. It is not loaded as a test case file.
+const groupsFile = "groups.yml"
+
+var (
+	// testCases holds all loaded test cases by ID.
+	testCases = map[string]*TestCase{}
+	// testGroups holds all loaded test groups by name.
+	testGroups = TestGroups{}
+)
+
+// LoadTestCases loads all test cases from the YAML files in dir.
+func LoadTestCases(dir string) (map[string]*TestCase, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	cases := make(map[string]*TestCase)
+	for _, file := range files {
+		if filepath.Base(file) == groupsFile {
+			continue
+		}
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var fileCases []*TestCase
+		if err := yaml.UnmarshalStrict(raw, &fileCases); err != nil {
+			return nil, serrors.WrapStr("unable to parse test cases", err, "file", file)
+		}
+		for _, tc := range fileCases {
+			if err := tc.Validate(); err != nil {
+				return nil, serrors.WrapStr("invalid test case", err, "file", file)
+			}
+			if err := tc.checkPackets(); err != nil {
+				return nil, serrors.WrapStr("invalid test case", err, "file", file)
+			}
+			if _, ok := cases[tc.ID]; ok {
+				return nil, serrors.New("duplicate test case", "file", file, "id", tc.ID)
+			}
+			cases[tc.ID] = tc
+		}
+	}
+	if len(cases) == 0 {
+		return nil, serrors.New("no test cases found", "dir", dir)
+	}
+	return cases, nil
+}
+
+// Validate checks that the test case is well formed.
+func (tc *TestCase) Validate() error {
+	if tc.ID == "" {
+		return serrors.New("id must be set")
+	}
+	if tc.Name == "" {
+		return serrors.New("name must be set", "id", tc.ID)
+	}
+	// hasDev tracks the known packets, and whether their device is set.
+	hasDev := make(map[string]bool, len(tc.Packets))
+	for _, p := range tc.Packets {
+		if _, ok := hasDev[p.Name]; p.Name == "" || ok {
+			return serrors.New("packet names must be unique and non-empty",
+				"id", tc.ID, "packet", p.Name)
+		}
+		if _, ok := hasDev[p.From]; p.From != "" && !ok {
+			return serrors.New("packet derived from unknown packet",
+				"id", tc.ID, "packet", p.Name, "from", p.From)
+		}
+		for _, ref := range packetRefs(p.Layers) {
+			if _, ok := hasDev[ref]; !ok {
+				return serrors.New("packet refers to unknown packet",
+					"id", tc.ID, "packet", p.Name, "ref", ref)
+			}
+		}
+		for _, f := range p.Ignore {
+			if len(strings.Split(f, ".")) < 2 {
+				return serrors.New("ignored field must be of the form Tag.Field",
+					"id", tc.ID, "packet", p.Name, "field", f)
+			}
+		}
+		hasDev[p.Name] = p.Dev != "" || hasDev[p.From]
+	}
+	if len(tc.Steps) != 0 && (len(tc.Send) != 0 || len(tc.Expect) != 0 || tc.Timeout != "") {
+		return serrors.New("steps cannot be combined with send, expect or timeout",
+			"id", tc.ID)
+	}
+	for i, step := range tc.steps() {
+		if len(step.Send) == 0 {
+			return serrors.New("no packets to send", "id", tc.ID, "step", i)
+		}
+		if step.Sleep != "" && (len(step.Expect) != 0 || step.Timeout != "") {
+			return serrors.New("sleep cannot be combined with expect or timeout",
+				"id", tc.ID, "step", i)
+		}
+		for _, d := range []string{step.Timeout, step.Sleep} {
+			if _, err := time.ParseDuration(d); d != "" && err != nil {
+				return serrors.WrapStr("invalid duration", err, "id", tc.ID, "step", i)
+			}
+		}
+		for _, name := range append(append([]string(nil), step.Send...), step.Expect...) {
+			dev, ok := hasDev[name]
+			if !ok {
+				return serrors.New("unknown packet", "id", tc.ID, "packet", name)
+			}
+			if !dev {
+				return serrors.New("packet device must be set", "id", tc.ID, "packet", name)
+			}
+		}
+	}
+	return nil
+}
+
+// checkPackets builds the packets of the test case, such that bad layer
+// definitions and ignored fields are reported when loading the test case
+// instead of failing the test run. The parser package panics on bad
+// definitions.
+func (tc *TestCase) checkPackets() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = serrors.New("invalid packet layers", "id", tc.ID, "err", r)
+		}
+	}()
+	for _, pkt := range tc.buildPackets() {
+		toWildcards(pkt)
+	}
+	return nil
+}
+
+// Run sends the packets of the test case and checks that the expected
+// packets are received. It returns the number of failures.
+func (tc *TestCase) Run() int {
+	// Refresh the time used for timestamps, such that they are current
+	// regardless of how long the previous test cases took.
+	shared.UpdateNow()
+	pkts := tc.buildPackets()
+	var failures int
+	for _, step := range tc.steps() {
+		SendPackets(tc.lookup(pkts, step.Send)...)
+		if step.Sleep != "" {
+			Sleep(step.Sleep)
+			continue
+		}
+		timeout := step.Timeout
+		if timeout == "" {
+			timeout = defaultTimeout
+		}
+		failures += ExpectedPackets(tc.Name, timeout, tc.lookup(pkts, step.Expect)...)
+	}
+	return failures
+}
+
+// steps returns the steps of the test case. Send and Expect are treated as a
+// single step.
+func (tc *TestCase) steps() []StepSpec {
+	if len(tc.Steps) != 0 {
+		return tc.Steps
+	}
+	return []StepSpec{{Send: tc.Send, Expect: tc.Expect, Timeout: tc.Timeout}}
+}
+
+func (tc *TestCase) buildPackets() map[string]*DevTaggedLayers {
+	pkts := make(map[string]*DevTaggedLayers, len(tc.Packets))
+	for _, spec := range tc.Packets {
+		layers := indentTabs(expandRefs(spec.Layers, pkts))
+		var pkt *DevTaggedLayers
+		if spec.From != "" {
+			pkt = pkts[spec.From].CloneAndUpdate(layers)
+		} else {
+			pkt = AllocatePacket()
+			pkt.ParsePacket(layers)
+		}
+		if spec.Dev != "" {
+			pkt.SetDev(spec.Dev)
+		}
+		for _, c := range spec.Checksums {
+			pkt.SetChecksum(c.L4, c.L3)
+		}
+		for _, m := range spec.Macs {
+			pkt.GenerateMac(m.Scion, m.Info, m.Hop, m.Mac)
+		}
+		// Ignored fields are not inherited by derived packets.
+		pkt.Ignore = spec.Ignore
+		pkts[spec.Name] = pkt
+	}
+	return pkts
+}
+
+func (tc *TestCase) lookup(pkts map[string]*DevTaggedLayers,
+	names []string) []*DevTaggedLayers {
+
+	res := make([]*DevTaggedLayers, 0, len(names))
+	for _, name := range names {
+		res = append(res, pkts[name])
+	}
+	return res
+}
+
+var (
+	leadingSpaces = regexp.MustCompile(`(?m)^( {2})+`)
+	packetRef     = regexp.MustCompile(`\$\((\w+)\)`)
+)
+
+// indentTabs converts the two space indentation used in the test case files
+// to the tab indentation expected by the parser package.
+func indentTabs(layers string) string {
+	return leadingSpaces.ReplaceAllStringFunc(layers, func(s string) string {
+		return strings.Repeat("\t", len(s)/2)
+	})
+}
+
+// packetRefs returns the names of the packets referred to in the layers.
+func packetRefs(layers string) []string {
+	var refs []string
+	for _, m := range packetRef.FindAllStringSubmatch(layers, -1) {
+		refs = append(refs, m[1])
+	}
+	return refs
+}
+
+// expandRefs replaces the packet references in the layers with the hex
+// encoded serialization of the referred packets.
+func expandRefs(layers string, pkts map[string]*DevTaggedLayers) string {
+	return packetRef.ReplaceAllStringFunc(layers, func(s string) string {
+		return pkts[packetRef.FindStringSubmatch(s)[1]].Serialize().String()
+	})
+}
+
+// TestGroups maps the name of a test group to its members. A member is either
+// a test case ID or the name of another group.
+type TestGroups map[string][]string
+
+// LoadTestGroups loads the test groups from the groups file in dir. All
+// members must refer to one of the given test cases or to another group.
+func LoadTestGroups(dir string, cases map[string]*TestCase) (TestGroups, error) {
+	file := filepath.Join(dir, groupsFile)
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var groups TestGroups
+	if err := yaml.UnmarshalStrict(raw, &groups); err != nil {
+		return nil, serrors.WrapStr("unable to parse test groups", err, "file", file)
+	}
+	for name, members := range groups {
+		if _, ok := cases[name]; ok {
+			return nil, serrors.New("group name clashes with test case", "group", name)
+		}
+		for _, m := range members {
+			_, isCase := cases[m]
+			_, isGroup := groups[m]
+			if !isCase && !isGroup {
+				return nil, serrors.New("unknown group member", "group", name, "member", m)
+			}
+		}
+	}
+	for name := range groups {
+		if err := groups.checkCycle(name, map[string]bool{}); err != nil {
+			return nil, err
+		}
+	}
+	return groups, nil
+}
+
+func (g TestGroups) checkCycle(name string, visiting map[string]bool) error {
+	if visiting[name] {
+		return serrors.New("test groups contain a cycle", "group", name)
+	}
+	visiting[name] = true
+	for _, m := range g[name] {
+		if _, ok := g[m]; !ok {
+			continue
+		}
+		if err := g.checkCycle(m, visiting); err != nil {
+			return err
+		}
+	}
+	delete(visiting, name)
+	return nil
+}
+
+// runTest runs the test group or test case with the given name and returns
+// the number of failures.
+func runTest(name string) int {
+	members, ok := testGroups[name]
+	if !ok {
+		return runCase(name)
+	}
+	var failures int
+	for _, m := range members {
+		failures += runTest(m)
+	}
+	return failures
+}
+
+// runCase runs the loaded test case with the given ID and returns the number
+// of failures.
+func runCase(id string) int {
+	tc, ok := testCases[id]
+	if !ok {
+		log.Info(fmt.Sprintf("Test %s: %s\n", id, fail()))
+		log.Error("Test case not loaded", "id", id)
+		return 1
+	}
+	return tc.Run()
+}
@@ -0,0 +1,230 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"hash"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/braccept/shared"
+	"github.com/scionproto/scion/go/lib/scrypto"
+)
+
+const simpleCase = `
+- id: simple
+  name: simple test case
+  packets:
+    - name: pkt0
+      dev: veth_int
+      layers: |
+        Ethernet: SrcMAC=f0:0d:ca:fe:be:ef DstMAC=f0:0d:ca:fe:00:01 EthernetType=IPv4
+        IP4: Src=192.168.0.51 Dst=192.168.0.11 NextHdr=UDP
+        UDP: Src=40000 Dst=40001
+      checksums:
+        - {l4: UDP, l3: IP4}
+    - name: pkt1
+      from: pkt0
+      layers: |
+        UDP: Src=40001 Dst=40000
+      ignore: [IP4.Id]
+  send: [pkt0]
+  expect: [pkt1]
+`
+
+// caseWithPacket returns a test case file with a single packet with the given
+// layers.
+func caseWithPacket(layers string) string {
+	return `
+- id: bad
+  name: bad test case
+  packets:
+    - name: pkt0
+      dev: veth_int
+      layers: |
+        ` + layers + `
+  send: [pkt0]
+`
+}
+
+func TestLoadTestCases(t *testing.T) {
+	tests := map[string]struct {
+		Files  map[string]string
+		ErrMsg string
+	}{
+		"valid": {
+			Files: map[string]string{"a.yml": simpleCase},
+		},
+		"groups file is skipped": {
+			Files: map[string]string{"a.yml": simpleCase, groupsFile: "not: [a, list"},
+		},
+		"unknown test case key": {
+			Files:  map[string]string{"a.yml": simpleCase + "  sent: [pkt0]\n"},
+			ErrMsg: "unable to parse test cases",
+		},
+		"unknown packet key": {
+			Files: map[string]string{
+				"a.yml": caseWithPacket("UDP: Src=40000 Dst=40001\n      device: veth_int"),
+			},
+			ErrMsg: "unable to parse test cases",
+		},
+		"unknown layer type": {
+			Files:  map[string]string{"a.yml": caseWithPacket("FOO: Src=40000")},
+			ErrMsg: "invalid packet layers",
+		},
+		"unknown layer field": {
+			Files:  map[string]string{"a.yml": caseWithPacket("UDP: Sport=40000")},
+			ErrMsg: "invalid packet layers",
+		},
+		"bad layer syntax": {
+			Files:  map[string]string{"a.yml": caseWithPacket("UDP Src=40000")},
+			ErrMsg: "invalid packet layers",
+		},
+		"bad key value syntax": {
+			Files:  map[string]string{"a.yml": caseWithPacket("UDP: Src 40000")},
+			ErrMsg: "invalid packet layers",
+		},
+		"ignored field of unknown layer": {
+			Files: map[string]string{
+				"a.yml": caseWithPacket("UDP: Src=40000\n      ignore: [IP4.Id]"),
+			},
+			ErrMsg: "invalid packet layers",
+		},
+		"derived from unknown packet": {
+			Files: map[string]string{
+				"a.yml": caseWithPacket("UDP: Src=40000\n      from: pkt9"),
+			},
+			ErrMsg: "packet derived from unknown packet",
+		},
+		"unknown packet sent": {
+			Files: map[string]string{
+				"a.yml": caseWithPacket("UDP: Src=40000") + "  expect: [pkt9]\n",
+			},
+			ErrMsg: "unknown packet",
+		},
+		"duplicate id": {
+			Files:  map[string]string{"a.yml": simpleCase, "b.yml": simpleCase},
+			ErrMsg: "duplicate test case",
+		},
+		"no test cases": {
+			Files:  map[string]string{groupsFile: "group: [simple]"},
+			ErrMsg: "no test cases found",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := writeFiles(t, test.Files)
+			defer os.RemoveAll(dir)
+			cases, err := LoadTestCases(dir)
+			if test.ErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.ErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, cases, "simple")
+			assert.Equal(t, []string{"pkt0"}, cases["simple"].Send)
+		})
+	}
+}
+
+func TestLoadTestGroups(t *testing.T) {
+	cases := map[string]*TestCase{"a": {ID: "a"}, "b": {ID: "b"}}
+	tests := map[string]struct {
+		Groups *string
+		ErrMsg string
+	}{
+		"valid": {
+			Groups: str("inner: [a]\nouter: [inner, b]\n"),
+		},
+		"missing groups file": {
+			ErrMsg: "no such file",
+		},
+		"duplicate group": {
+			Groups: str("inner: [a]\ninner: [b]\n"),
+			ErrMsg: "unable to parse test groups",
+		},
+		"missing group": {
+			Groups: str("outer: [inner, b]\n"),
+			ErrMsg: "unknown group member",
+		},
+		"name clashes with test case": {
+			Groups: str("a: [b]\n"),
+			ErrMsg: "group name clashes with test case",
+		},
+		"cycle": {
+			Groups: str("x: [y]\ny: [a, x]\n"),
+			ErrMsg: "test groups contain a cycle",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			files := map[string]string{}
+			if test.Groups != nil {
+				files[groupsFile] = *test.Groups
+			}
+			dir := writeFiles(t, files)
+			defer os.RemoveAll(dir)
+			groups, err := LoadTestGroups(dir, cases)
+			if test.ErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.ErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, TestGroups{"inner": {"a"}, "outer": {"inner", "b"}}, groups)
+		})
+	}
+}
+
+func TestRunTestMissingCase(t *testing.T) {
+	defer func(cases map[string]*TestCase, groups TestGroups) {
+		testCases, testGroups = cases, groups
+	}(testCases, testGroups)
+	testCases = map[string]*TestCase{}
+	testGroups = TestGroups{"outer": {"missing", "inner"}, "inner": {"other"}}
+	assert.Equal(t, 2, runTest("outer"))
+	assert.Equal(t, 1, runTest("missing"))
+}
+
+func TestLoadShippedTestCases(t *testing.T) {
+	defer func(hashMac hash.Hash) { shared.HashMac = hashMac }(shared.HashMac)
+	hfMacFactory, err := scrypto.HFMacFactory(make([]byte, 16))
+	require.NoError(t, err)
+	shared.HashMac = hfMacFactory()
+	cases, err := LoadTestCases("testcases")
+	require.NoError(t, err)
+	_, err = LoadTestGroups("testcases", cases)
+	require.NoError(t, err)
+}
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "braccept")
+	require.NoError(t, err)
+	for name, content := range files {
+		err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		require.NoError(t, err)
+	}
+	return dir
+}
+
+func str(s string) *string {
+	return &s
+}
@@ -16,6 +16,7 @@ package main
 
 import (
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/google/gopacket"
@@ -65,6 +66,53 @@ func ComparePackets(act, exp gopacket.Packet) error {
 	return nil
 }
 
+// applyWildcards copies the fields that are not compared from the actual
+// packet to the expected packet.
+func applyWildcards(act gopacket.Packet, exp *DevPkt) error {
+	layersAct := act.Layers()
+	layersExp := exp.Pkt.Layers()
+	for _, w := range exp.Wildcards {
+		if w.Layer >= len(layersAct) || w.Layer >= len(layersExp) {
+			return fmt.Errorf("Wildcard %s: layer missing", w)
+		}
+		actL, expL := layersAct[w.Layer], layersExp[w.Layer]
+		if actL.LayerType() != w.LayerType || expL.LayerType() != w.LayerType {
+			return fmt.Errorf("Wildcard %s: layer type mismatch: actual %s expected %s",
+				w, actL.LayerType(), expL.LayerType())
+		}
+		if err := copyField(expL, actL, w.Field); err != nil {
+			return fmt.Errorf("Wildcard %s: %s", w, err)
+		}
+	}
+	return nil
+}
+
+// copyField sets the field of dst identified by path to the value of the same
+// field in src.
+func copyField(dst, src gopacket.Layer, path []string) error {
+	d, s := reflect.ValueOf(dst), reflect.ValueOf(src)
+	for _, name := range path {
+		for d.Kind() == reflect.Ptr || d.Kind() == reflect.Interface {
+			if d.IsNil() || s.IsNil() {
+				return fmt.Errorf("nil value at %s", name)
+			}
+			d, s = d.Elem(), s.Elem()
+		}
+		if d.Kind() != reflect.Struct || d.Type() != s.Type() {
+			return fmt.Errorf("not a struct at %s", name)
+		}
+		d, s = d.FieldByName(name), s.FieldByName(name)
+		if !d.IsValid() {
+			return fmt.Errorf("unknown field %s", name)
+		}
+	}
+	if !d.CanSet() {
+		return fmt.Errorf("field cannot be set")
+	}
+	d.Set(s)
+	return nil
+}
+
 func compareLayersIP4(act, exp gopacket.Layer) error {
 	actIP4 := act.(*golayers.IPv4)
 	expIP4, ok := exp.(*golayers.IPv4)
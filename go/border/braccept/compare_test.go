@@ -0,0 +1,129 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/braccept/shared"
+)
+
+const comparePkt = `
+Ethernet: SrcMAC=f0:0d:ca:fe:be:ef DstMAC=f0:0d:ca:fe:00:01 EthernetType=IPv4
+IP4: Src=192.168.0.51 Dst=192.168.0.11 NextHdr=UDP Id=1
+UDP: Src=40000 Dst=40001
+`
+
+// devPkt builds the packet with the layers of comparePkt updated by the
+// given layers.
+func devPkt(dev, update string, ignore ...string) *DevPkt {
+	pkt := AllocatePacket()
+	pkt.ParsePacket(comparePkt)
+	if update != "" {
+		pkt = pkt.CloneAndUpdate(update)
+	}
+	pkt.SetDev(dev)
+	pkt.SetChecksum("UDP", "IP4")
+	pkt.Ignore = ignore
+	return toGoPackets(pkt)[0]
+}
+
+func TestCompareStrings(t *testing.T) {
+	defer func(c bool) { colorTerm = c }(colorTerm)
+	colorTerm = false
+	assert.NoError(t, compareStrings("UDP Src=1", "UDP Src=1"))
+	err := compareStrings("UDP Src=1", "UDP Src=2")
+	require.Error(t, err)
+	assert.Equal(t, "Expected: UDP Src=2\nActual:   UDP Src=1\n", err.Error())
+}
+
+func TestComparePackets(t *testing.T) {
+	defer func(c bool) { colorTerm = c }(colorTerm)
+	colorTerm = false
+	act := devPkt("veth_int", "")
+	assert.NoError(t, ComparePackets(act.Pkt, devPkt("veth_int", "").Pkt))
+	err := ComparePackets(act.Pkt, devPkt("veth_int", "UDP: Src=40000 Dst=40002").Pkt)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Layer Mismatch!")
+	assert.Contains(t, err.Error(), "Expected: UDP")
+	assert.Regexp(t, `(?s)Expected: .*DstPort=40002`, err.Error())
+	assert.Regexp(t, `(?s)Actual: .*DstPort=40001`, err.Error())
+}
+
+func TestCheckPkt(t *testing.T) {
+	defer func(devs []*shared.DevInfo) { shared.DevList = devs }(shared.DevList)
+	defer func(c bool) { colorTerm = c }(colorTerm)
+	colorTerm = false
+	shared.DevList = []*shared.DevInfo{{ContDev: "veth_int"}}
+	tests := map[string]struct {
+		Expected []*DevPkt
+		Idx      int
+		ErrMsgs  []string
+	}{
+		"match": {
+			Expected: []*DevPkt{
+				devPkt("veth_int", "UDP: Src=40000 Dst=40002"),
+				devPkt("veth_int", ""),
+			},
+			Idx: 1,
+		},
+		"ignored field": {
+			Expected: []*DevPkt{
+				devPkt("veth_int", "IP4: Id=2", "IP4.Id", "IP4.Checksum"),
+			},
+		},
+		"mismatch": {
+			Expected: []*DevPkt{devPkt("veth_int", "UDP: Src=40000 Dst=40002")},
+			Idx:      -1,
+			ErrMsgs: []string{
+				"[ERROR] Layer Mismatch!",
+				"DstPort=40002",
+				"Unexpected packet on interface veth_int",
+			},
+		},
+		"other device": {
+			Expected: []*DevPkt{devPkt("veth_141", "")},
+			Idx:      -1,
+			ErrMsgs:  []string{"Unexpected packet on interface veth_int"},
+		},
+		"no packet expected": {
+			Idx:     -1,
+			ErrMsgs: []string{"Packet received when no packet was expected"},
+		},
+		"unknown ignored field": {
+			Expected: []*DevPkt{devPkt("veth_int", "", "UDP.Foo")},
+			Idx:      -1,
+			ErrMsgs:  []string{"[ERROR] Wildcard UDP.Foo: unknown field Foo"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			act := devPkt("veth_int", "")
+			idx, err := checkPkt(test.Expected, 0, act.Pkt)
+			assert.Equal(t, test.Idx, idx)
+			if len(test.ErrMsgs) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, msg := range test.ErrMsgs {
+				assert.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}
@@ -15,6 +15,9 @@
 package main
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
 )
@@ -22,14 +25,55 @@ import (
 type DevPkt struct {
 	Dev string
 	Pkt gopacket.Packet
+	// Wildcards are the fields that are not compared.
+	Wildcards []Wildcard
+}
+
+// Wildcard identifies a field of a layer of a packet that is not compared.
+type Wildcard struct {
+	// Layer is the index of the layer in the packet.
+	Layer int
+	// LayerType is the expected type of the layer.
+	LayerType gopacket.LayerType
+	// Field is the path to the field within the layer.
+	Field []string
+}
+
+func (w Wildcard) String() string {
+	return fmt.Sprintf("%s.%s", w.LayerType, strings.Join(w.Field, "."))
 }
 
 func toGoPackets(pkts ...*DevTaggedLayers) []*DevPkt {
 	goPkts := make([]*DevPkt, len(pkts))
 	for i := range pkts {
-		goPkts[i] = &DevPkt{Dev: pkts[i].Dev}
+		goPkts[i] = &DevPkt{Dev: pkts[i].Dev, Wildcards: toWildcards(pkts[i])}
 		raw := pkts[i].TaggedLayers.Serialize()
 		goPkts[i].Pkt = gopacket.NewPacket(raw, layers.LayerTypeEthernet, gopacket.DecodeOptions{})
 	}
 	return goPkts
 }
+
+// toWildcards resolves the tags of the ignored fields to the index of the
+// layer in the packet.
+func toWildcards(pkt *DevTaggedLayers) []Wildcard {
+	var wildcards []Wildcard
+	for _, f := range pkt.Ignore {
+		path := strings.Split(f, ".")
+		idx := -1
+		for i, tl := range pkt.TaggedLayers {
+			if tl.Tag() == path[0] {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			panic(fmt.Errorf("Ignored field of unknown layer: %s\n", f))
+		}
+		wildcards = append(wildcards, Wildcard{
+			Layer:     idx,
+			LayerType: pkt.TaggedLayers[idx].Layer().LayerType(),
+			Field:     path[1:],
+		})
+	}
+	return wildcards
+}
@@ -23,6 +23,9 @@ import (
 type DevTaggedLayers struct {
 	Dev          string
 	TaggedLayers parser.TaggedLayers
+	// Ignore lists the fields, in the form Tag.Field, that are not compared
+	// if this is an expected packet.
+	Ignore []string
 }
 
 func AllocatePacket() *DevTaggedLayers {
@@ -89,6 +89,10 @@ func checkPkt(expPkts []*DevPkt, devIdx int, pkt gopacket.Packet) (int, error) {
 		if dev != expPkts[i].Dev {
 			continue
 		}
+		if err := applyWildcards(pkt, expPkts[i]); err != nil {
+			errStr = append(errStr, fmt.Sprintf("[ERROR] %s", err))
+			continue
+		}
 		if err := ComparePackets(pkt, expPkts[i].Pkt); err != nil {
 			errStr = append(errStr, fmt.Sprintf("[ERROR] %s", err))
 			actStr := fmt.Sprintf("%s", pkt)
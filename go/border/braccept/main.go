@@ -19,6 +19,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"time"
 
@@ -37,18 +38,23 @@ const (
 	promiscuous    bool          = true
 	defaultTimeout               = "250ms"
 	defaultDelay   time.Duration = 1 * time.Second
+	// defaultCasesDir is relative to the repository root, which is also the
+	// layout of the runfiles of the binary.
+	defaultCasesDir = "go/border/braccept/testcases"
 )
 
 // Flag vars
 var (
 	testName    string
 	keysDirPath string
+	casesDir    string
 	logConsole  string
 )
 
 func init() {
 	flag.StringVar(&testName, "testName", "", "Test to run")
 	flag.StringVar(&keysDirPath, "keysDirPath", "", "AS keys directory path")
+	flag.StringVar(&casesDir, "casesDir", defaultCasesDir, "Test cases directory path")
 	flag.StringVar(&logConsole, "log.console", "info",
 		"Console logging level: trace|debug|info|warn|error|crit")
 }
@@ -80,11 +86,24 @@ func realMain() int {
 		log.Crit("Initialization failed", "err", err)
 		return 1
 	}
+	dir := resolveCasesDir(casesDir)
+	var err error
+	if testCases, err = LoadTestCases(dir); err != nil {
+		log.Crit("Loading test cases failed", "err", err)
+		return 1
+	}
+	if testGroups, err = LoadTestGroups(dir, testCases); err != nil {
+		log.Crit("Loading test groups failed", "err", err)
+		return 1
+	}
+	if _, ok := testGroups[testName]; !ok && testCases[testName] == nil {
+		log.Crit("Wrong BR acceptance test name", "testName", testName)
+		return 1
+	}
 	// We setup the select cases in main so we can easily defer device handle close on exit
 	timerIdx = len(shared.DevList)
 	cases = make([]reflect.SelectCase, timerIdx+1)
 	for i, di := range shared.DevList {
-		var err error
 		di.Handle, err = afpacket.NewTPacket(afpacket.OptInterface(di.Host.Name))
 		if err != nil {
 			log.Crit("Error creating packet", "err", err)
@@ -108,28 +127,8 @@ func realMain() int {
 
 	IgnorePkts()
 
-	var failures int
 	log.Info("Acceptance tests:", "testName", testName)
-	switch testName {
-	case "br_multi":
-		failures += br_multi()
-	case "br_child":
-		failures += br_child()
-	case "br_parent":
-		failures += br_parent()
-	case "br_peer":
-		failures += br_peer()
-	case "br_core_multi":
-		failures += br_core_multi()
-	case "br_core_coreIf":
-		failures += br_core_coreIf()
-	case "br_core_childIf":
-		failures += br_core_childIf()
-	default:
-		log.Crit("Wrong BR acceptance test name", "testName", testName)
-		return 1
-	}
-	return failures
+	return runTest(testName)
 }
 
 func checkFlags() error {
@@ -143,6 +142,30 @@ func checkFlags() error {
 	return nil
 }
 
+// resolveCasesDir returns the test cases directory. Relative paths that do
+// not exist in the working directory are looked up in the runfiles of the
+// binary, such that the default works both from the repository root and with
+// bazel run.
+func resolveCasesDir(dir string) string {
+	if _, err := os.Stat(dir); err == nil || filepath.IsAbs(dir) {
+		return dir
+	}
+	var roots []string
+	if runfiles := os.Getenv("RUNFILES_DIR"); runfiles != "" {
+		roots = append(roots, runfiles)
+	}
+	if exe, err := os.Executable(); err == nil {
+		roots = append(roots, exe+".runfiles")
+	}
+	for _, root := range roots {
+		candidate := filepath.Join(root, "__main__", dir)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return dir
+}
+
 // registerScionPorts basically register the following UDP ports in gopacket such as SCION is the
 // next layer. In other words, map the following ports to expect SCION as the payload.
 func registerScionPorts() {
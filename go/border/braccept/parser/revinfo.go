@@ -17,6 +17,7 @@ package parser
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/gopacket"
 
@@ -42,6 +43,7 @@ type SignedRevInfoTaggedLayer struct {
 //
 // SignRevInfo: IfID=121 IA=1-ff00:0:1 Link=peer TS=now TTL=60
 //
+// TS can also be relative to now, e.g., TS=now-9s.
 func SignedRevInfoParser(lines []string) TaggedLayer {
 	// default SignedRevInfo layer values
 	i := &SignedRevInfoTaggedLayer{}
@@ -118,10 +120,18 @@ func (i *RevInfo) updateFields(kvs propMap) {
 			}
 			i.RawIsdas = ia.IAInt()
 		case "TS":
-			if v != "now" {
+			// The timestamp is either absolute, "now", or relative to now, e.g., "now-9s".
+			if !strings.HasPrefix(v, "now") {
 				i.RawTimestamp = uint32(StrToInt(v))
-			} else {
-				i.RawTimestamp = shared.TsNow32
+				break
+			}
+			i.RawTimestamp = shared.TsNow32
+			if offset := strings.TrimPrefix(v, "now"); offset != "" {
+				d, err := time.ParseDuration(offset)
+				if err != nil {
+					panic(fmt.Errorf("Bad relative TS: %s", v))
+				}
+				i.RawTimestamp = uint32(shared.Now.Add(d).Unix())
 			}
 		case "TTL":
 			i.RawTTL = uint32(StrToInt(v))
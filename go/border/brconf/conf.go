@@ -20,12 +20,17 @@ package brconf
 import (
 	"path/filepath"
 
+	"github.com/scionproto/scion/go/border/policing"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/keyconf"
 	"github.com/scionproto/scion/go/lib/topology"
 )
 
+// PolicingFile is the name of the optional ingress policing configuration file
+// in the config directory.
+const PolicingFile = "policing.json"
+
 // BRConf is the main config structure. It contains the dynamic
 // configuration at runtime.
 type BRConf struct {
@@ -38,6 +43,8 @@ type BRConf struct {
 	BR *topology.BRInfo
 	// MasterKeys holds the local AS master keys.
 	MasterKeys keyconf.Master
+	// Policing holds the ingress policing configuration.
+	Policing policing.Config
 	// Dir is the configuration directory.
 	Dir string
 }
@@ -53,6 +60,9 @@ func Load(id, confDir string) (*BRConf, error) {
 	if err := conf.loadMasterKeys(); err != nil {
 		return nil, err
 	}
+	if err := conf.loadPolicing(); err != nil {
+		return nil, err
+	}
 	return conf, nil
 }
 
@@ -62,6 +72,7 @@ func WithNewTopo(id string, topo topology.Topology, oldConf *BRConf) (*BRConf, e
 	conf := &BRConf{
 		Dir:        oldConf.Dir,
 		MasterKeys: oldConf.MasterKeys,
+		Policing:   oldConf.Policing,
 	}
 	if err := conf.initTopo(id, topo); err != nil {
 		return nil, common.NewBasicError("Unable to initialize topo", err)
@@ -106,3 +117,14 @@ func (cfg *BRConf) loadMasterKeys() error {
 	}
 	return nil
 }
+
+// loadPolicing loads the optional ingress policing configuration from the
+// config directory.
+func (cfg *BRConf) loadPolicing() error {
+	var err error
+	cfg.Policing, err = policing.LoadConfig(filepath.Join(cfg.Dir, PolicingFile))
+	if err != nil {
+		return common.NewBasicError("Unable to load policing config", err)
+	}
+	return nil
+}
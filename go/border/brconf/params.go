@@ -17,11 +17,13 @@ package brconf
 import (
 	"io"
 	"strings"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/util"
 )
 
 var _ config.Config = (*Config)(nil)
@@ -71,31 +73,203 @@ func (cfg *Config) ConfigName() string {
 
 var _ config.Config = (*BR)(nil)
 
+// DefaultDrainGracePeriod is the default time that a draining interface keeps
+// forwarding traffic.
+const DefaultDrainGracePeriod = 30 * time.Second
+
+// DefaultIOBackend is the default I/O backend of the router sockets.
+const DefaultIOBackend SockType = "posix"
+
 // BR contains the border router specific parts of the configuration.
 type BR struct {
 	// RollbackFailAction indicates the action that should be taken
 	// if the rollback fails.
 	RollbackFailAction FailAction `toml:"rollback_fail_action,omitempty"`
+	// NumWorkers is the number of packet processing goroutines. Packets are
+	// distributed among them by flow. If zero, each socket is served by its
+	// own processing goroutine.
+	NumWorkers int `toml:"num_workers,omitempty"`
+	// IOBackend is the I/O backend used for all router sockets.
+	IOBackend SockType `toml:"io_backend,omitempty"`
+	// BFD is the configuration of BFD sessions on external interfaces.
+	BFD BFD `toml:"bfd,omitempty"`
+	// SCMPRateLimit is the rate limit of SCMP errors originated by the router.
+	SCMPRateLimit SCMPRateLimit `toml:"scmp_rate_limit,omitempty"`
+	// DrainGracePeriod is the default time that a draining interface keeps
+	// forwarding traffic after the revocation was requested.
+	DrainGracePeriod util.DurWrap `toml:"drain_grace_period,omitempty"`
 }
 
 func (cfg *BR) InitDefaults() {
 	if cfg.RollbackFailAction != FailActionContinue {
 		cfg.RollbackFailAction = FailActionFatal
 	}
+	if cfg.DrainGracePeriod.Duration == 0 {
+		cfg.DrainGracePeriod.Duration = DefaultDrainGracePeriod
+	}
+	if cfg.IOBackend == "" {
+		cfg.IOBackend = DefaultIOBackend
+	}
+	config.InitAll(&cfg.BFD, &cfg.SCMPRateLimit)
 }
 
 func (cfg *BR) Validate() error {
-	return cfg.RollbackFailAction.Validate()
+	if err := cfg.RollbackFailAction.Validate(); err != nil {
+		return err
+	}
+	if cfg.NumWorkers < 0 {
+		return common.NewBasicError("num_workers must not be negative", nil,
+			"value", cfg.NumWorkers)
+	}
+	if cfg.DrainGracePeriod.Duration < 0 {
+		return common.NewBasicError("drain_grace_period must not be negative", nil,
+			"value", cfg.DrainGracePeriod)
+	}
+	return config.ValidateAll(&cfg.BFD, &cfg.SCMPRateLimit)
 }
 
-func (cfg *BR) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+func (cfg *BR) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, brSample)
+	config.WriteSample(dst, path, ctx, &cfg.BFD, &cfg.SCMPRateLimit)
 }
 
 func (cfg *BR) ConfigName() string {
 	return "br"
 }
 
+const (
+	// DefaultBFDDesiredMinTxInterval is the default interval at which BFD
+	// control packets are sent while the session is up.
+	DefaultBFDDesiredMinTxInterval = 200 * time.Millisecond
+	// DefaultBFDRequiredMinRxInterval is the default minimum interval between
+	// received BFD control packets.
+	DefaultBFDRequiredMinRxInterval = 200 * time.Millisecond
+	// DefaultBFDDetectMult is the default number of missed BFD control
+	// packets after which a link is declared down.
+	DefaultBFDDetectMult = 3
+)
+
+var _ config.Config = (*BFD)(nil)
+
+// BFD contains the configuration of the BFD sessions that run on all external
+// interfaces.
+type BFD struct {
+	// Enable enables BFD on all external interfaces. The neighboring border
+	// routers must have BFD enabled as well.
+	Enable bool `toml:"enable,omitempty"`
+	// DesiredMinTxInterval is the minimum interval at which control packets
+	// are sent while the session is up.
+	DesiredMinTxInterval util.DurWrap `toml:"desired_min_tx_interval,omitempty"`
+	// RequiredMinRxInterval is the minimum interval between received control
+	// packets that this router supports.
+	RequiredMinRxInterval util.DurWrap `toml:"required_min_rx_interval,omitempty"`
+	// DetectMult is the number of missed control packets after which the link
+	// is declared down.
+	DetectMult uint8 `toml:"detect_mult,omitempty"`
+}
+
+func (cfg *BFD) InitDefaults() {
+	if cfg.DesiredMinTxInterval.Duration == 0 {
+		cfg.DesiredMinTxInterval.Duration = DefaultBFDDesiredMinTxInterval
+	}
+	if cfg.RequiredMinRxInterval.Duration == 0 {
+		cfg.RequiredMinRxInterval.Duration = DefaultBFDRequiredMinRxInterval
+	}
+	if cfg.DetectMult == 0 {
+		cfg.DetectMult = DefaultBFDDetectMult
+	}
+}
+
+func (cfg *BFD) Validate() error {
+	if cfg.DesiredMinTxInterval.Duration < time.Microsecond {
+		return common.NewBasicError("desired_min_tx_interval must be at least 1us", nil,
+			"value", cfg.DesiredMinTxInterval)
+	}
+	if cfg.RequiredMinRxInterval.Duration < time.Microsecond {
+		return common.NewBasicError("required_min_rx_interval must be at least 1us", nil,
+			"value", cfg.RequiredMinRxInterval)
+	}
+	if cfg.DetectMult == 0 {
+		return common.NewBasicError("detect_mult must not be zero", nil)
+	}
+	return nil
+}
+
+func (cfg *BFD) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteString(dst, bfdSample)
+}
+
+func (cfg *BFD) ConfigName() string {
+	return "bfd"
+}
+
+const (
+	// DefaultSCMPGlobalRate is the default rate of all SCMP errors in messages
+	// per second.
+	DefaultSCMPGlobalRate = 1000
+	// DefaultSCMPGlobalBurst is the default burst size of all SCMP errors.
+	DefaultSCMPGlobalBurst = 1000
+	// DefaultSCMPDestinationRate is the default rate of SCMP errors to a
+	// single destination in messages per second.
+	DefaultSCMPDestinationRate = 10
+	// DefaultSCMPDestinationBurst is the default burst size of SCMP errors to
+	// a single destination.
+	DefaultSCMPDestinationBurst = 20
+)
+
+var _ config.Config = (*SCMPRateLimit)(nil)
+
+// SCMPRateLimit contains the rate limits of the SCMP error messages that are
+// originated by the router. A destination is the host that sent the packet
+// that caused the error.
+type SCMPRateLimit struct {
+	// GlobalRate is the rate of all SCMP errors in messages per second.
+	GlobalRate float64 `toml:"global_rate,omitempty"`
+	// GlobalBurst is the burst size of all SCMP errors.
+	GlobalBurst float64 `toml:"global_burst,omitempty"`
+	// DestinationRate is the rate of SCMP errors to a single destination in
+	// messages per second.
+	DestinationRate float64 `toml:"destination_rate,omitempty"`
+	// DestinationBurst is the burst size of SCMP errors to a single
+	// destination.
+	DestinationBurst float64 `toml:"destination_burst,omitempty"`
+}
+
+func (cfg *SCMPRateLimit) InitDefaults() {
+	if cfg.GlobalRate == 0 {
+		cfg.GlobalRate = DefaultSCMPGlobalRate
+	}
+	if cfg.GlobalBurst == 0 {
+		cfg.GlobalBurst = DefaultSCMPGlobalBurst
+	}
+	if cfg.DestinationRate == 0 {
+		cfg.DestinationRate = DefaultSCMPDestinationRate
+	}
+	if cfg.DestinationBurst == 0 {
+		cfg.DestinationBurst = DefaultSCMPDestinationBurst
+	}
+}
+
+func (cfg *SCMPRateLimit) Validate() error {
+	if cfg.GlobalRate < 0 || cfg.GlobalBurst < 1 {
+		return common.NewBasicError("global rate must not be negative and burst must be at"+
+			" least 1", nil, "rate", cfg.GlobalRate, "burst", cfg.GlobalBurst)
+	}
+	if cfg.DestinationRate < 0 || cfg.DestinationBurst < 1 {
+		return common.NewBasicError("destination rate must not be negative and burst must"+
+			" be at least 1", nil, "rate", cfg.DestinationRate, "burst", cfg.DestinationBurst)
+	}
+	return nil
+}
+
+func (cfg *SCMPRateLimit) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteString(dst, scmpRateLimitSample)
+}
+
+func (cfg *SCMPRateLimit) ConfigName() string {
+	return "scmp_rate_limit"
+}
+
 type FailAction string
 
 const (
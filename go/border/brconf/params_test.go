@@ -54,4 +54,15 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 
 func CheckTestBRConfig(t *testing.T, cfg *BR) {
 	assert.Equal(t, FailActionFatal, cfg.RollbackFailAction)
+	assert.Equal(t, 0, cfg.NumWorkers)
+	assert.Equal(t, DefaultIOBackend, cfg.IOBackend)
+	assert.Equal(t, DefaultDrainGracePeriod, cfg.DrainGracePeriod.Duration)
+	assert.False(t, cfg.BFD.Enable)
+	assert.Equal(t, DefaultBFDDesiredMinTxInterval, cfg.BFD.DesiredMinTxInterval.Duration)
+	assert.Equal(t, DefaultBFDRequiredMinRxInterval, cfg.BFD.RequiredMinRxInterval.Duration)
+	assert.Equal(t, uint8(DefaultBFDDetectMult), cfg.BFD.DetectMult)
+	assert.Equal(t, float64(DefaultSCMPGlobalRate), cfg.SCMPRateLimit.GlobalRate)
+	assert.Equal(t, float64(DefaultSCMPGlobalBurst), cfg.SCMPRateLimit.GlobalBurst)
+	assert.Equal(t, float64(DefaultSCMPDestinationRate), cfg.SCMPRateLimit.DestinationRate)
+	assert.Equal(t, float64(DefaultSCMPDestinationBurst), cfg.SCMPRateLimit.DestinationBurst)
 }
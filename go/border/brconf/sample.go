@@ -20,4 +20,51 @@ const brSample = `
 # Action that should be taken when an error occurs during a context rollback.
 # (fatal | continue) (default fatal)
 rollback_fail_action = "fatal"
+
+# Number of packet processing goroutines. Packets are distributed among them
+# by flow, such that the order of packets within a flow is preserved. If 0,
+# each socket is served by its own processing goroutine. (default 0)
+num_workers = 0
+
+# I/O backend used for all router sockets. "posix" uses UDP sockets of the
+# host. "mem" binds the sockets on an in-process network, which is only useful
+# if the neighbors run in the same process, e.g., in tests. (default posix)
+io_backend = "posix"
+
+# Time that a drained interface keeps forwarding traffic after the beacon
+# services were asked to revoke it. (default 30s)
+drain_grace_period = "30s"
+`
+
+const bfdSample = `
+# Enable BFD on all external interfaces. (default false)
+enable = false
+
+# Minimum interval at which BFD control packets are sent while the session is
+# up. (default 200ms)
+desired_min_tx_interval = "200ms"
+
+# Minimum interval between received BFD control packets that this router
+# supports. (default 200ms)
+required_min_rx_interval = "200ms"
+
+# Number of missed BFD control packets after which the link is declared down.
+# (default 3)
+detect_mult = 3
+`
+
+const scmpRateLimitSample = `
+# Rate of all SCMP errors originated by the router in messages per second.
+# (default 1000)
+global_rate = 1000.0
+
+# Burst size of all SCMP errors originated by the router. (default 1000)
+global_burst = 1000.0
+
+# Rate of SCMP errors to a single destination in messages per second.
+# (default 10)
+destination_rate = 10.0
+
+# Burst size of SCMP errors to a single destination. (default 20)
+destination_burst = 20.0
 `
@@ -0,0 +1,252 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package capture implements on-demand packet capture in the border router.
+//
+// The router reports packets at three capture points: when they are read from
+// a socket (Input), after they have been processed and routed (Output), and
+// when they are dropped (Drop). Capture sessions register a filter and receive
+// a copy of every matching packet. While no session is active, reporting a
+// packet costs a single atomic load; callers should check Enabled before
+// collecting the information passed to Capture.
+package capture
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/spkt"
+)
+
+// Point is a capture point in the packet processing pipeline.
+type Point uint8
+
+const (
+	// Any matches all capture points. It is only valid in filters.
+	Any Point = iota
+	// Input is the point at which the packet is read from the socket.
+	Input
+	// Output is the point after the packet has been processed and routed.
+	Output
+	// Drop is the point at which the packet is dropped.
+	Drop
+)
+
+func (p Point) String() string {
+	switch p {
+	case Any:
+		return "any"
+	case Input:
+		return "in"
+	case Output:
+		return "out"
+	case Drop:
+		return "drop"
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", uint8(p))
+}
+
+// ParsePoint parses the string representation of a capture point.
+func ParsePoint(s string) (Point, error) {
+	switch strings.ToLower(s) {
+	case "", "any":
+		return Any, nil
+	case "in":
+		return Input, nil
+	case "out":
+		return Output, nil
+	case "drop":
+		return Drop, nil
+	}
+	return Any, serrors.New("unknown capture point", "point", s)
+}
+
+// Record is a captured packet.
+type Record struct {
+	// Time is the time the packet was captured.
+	Time time.Time
+	// Point is the capture point.
+	Point Point
+	// IfID is the interface the packet was received on for the Input and Drop
+	// points, and the interface it is sent on for the Output point. It is 0
+	// for the local interface.
+	IfID common.IFIDType
+	// DropReason is the reason the packet was dropped, as reported by
+	// rcmn.DropReason.String. It is only set for the Drop point.
+	DropReason string
+	// Raw is a copy of the raw packet.
+	Raw common.RawBytes
+}
+
+// Filter selects the packets that are captured. Zero values match all
+// packets.
+type Filter struct {
+	// Point is the capture point.
+	Point Point
+	// IfID is the interface ID, see Record.IfID.
+	IfID common.IFIDType
+	// SrcIA is the source ISD-AS of the packet. Wildcards are supported.
+	SrcIA addr.IA
+	// DstIA is the destination ISD-AS of the packet. Wildcards are supported.
+	DstIA addr.IA
+	// DropReason is the drop reason. Setting it implies the Drop point.
+	DropReason string
+}
+
+// Match returns whether the filter matches the packet.
+func (f Filter) Match(point Point, ifid common.IFIDType, raw common.RawBytes,
+	reason string) bool {
+
+	if f.Point != Any && f.Point != point {
+		return false
+	}
+	if f.IfID != 0 && f.IfID != ifid {
+		return false
+	}
+	if f.DropReason != "" && (point != Drop || f.DropReason != reason) {
+		return false
+	}
+	if f.SrcIA.IsZero() && f.DstIA.IsZero() {
+		return true
+	}
+	// The address header starts with the destination and source ISD-AS. It
+	// is read directly from the raw bytes, such that packets can be matched
+	// before they are parsed.
+	if len(raw) < spkt.CmnHdrLen+2*addr.IABytes {
+		return false
+	}
+	dstIA := addr.IAFromRaw(raw[spkt.CmnHdrLen:])
+	srcIA := addr.IAFromRaw(raw[spkt.CmnHdrLen+addr.IABytes:])
+	return matchIA(f.SrcIA, srcIA) && matchIA(f.DstIA, dstIA)
+}
+
+func matchIA(filter, ia addr.IA) bool {
+	if filter.I != 0 && filter.I != ia.I {
+		return false
+	}
+	return filter.A == 0 || filter.A == ia.A
+}
+
+var (
+	// numSessions is the number of active sessions. It is accessed atomically.
+	numSessions int32
+	// sessionsMtx protects modifications of sessions.
+	sessionsMtx sync.Mutex
+	// sessions holds the active sessions as []*Session. It is replaced on
+	// every modification, such that readers do not need to lock.
+	sessions atomic.Value
+)
+
+// Enabled returns whether any capture session is active.
+func Enabled() bool {
+	return atomic.LoadInt32(&numSessions) > 0
+}
+
+// Capture reports a packet at the given capture point. A copy of the packet
+// is passed to all sessions whose filter matches.
+func Capture(point Point, ifid common.IFIDType, raw common.RawBytes, reason string) {
+	if !Enabled() {
+		return
+	}
+	active, _ := sessions.Load().([]*Session)
+	var now time.Time
+	for _, s := range active {
+		if !s.filter.Match(point, ifid, raw, reason) {
+			continue
+		}
+		if now.IsZero() {
+			now = time.Now()
+		}
+		s.add(Record{
+			Time:       now,
+			Point:      point,
+			IfID:       ifid,
+			DropReason: reason,
+			Raw:        append(common.RawBytes(nil), raw...),
+		})
+	}
+}
+
+// Session is a capture session.
+type Session struct {
+	filter  Filter
+	records chan Record
+	// lost is the number of records that did not fit into the buffer. It is
+	// accessed atomically.
+	lost     uint64
+	stopOnce sync.Once
+}
+
+// Start starts a capture session with the given filter. Up to bufSize
+// records are buffered, further records are lost until the buffer is read.
+func Start(filter Filter, bufSize int) *Session {
+	if filter.DropReason != "" {
+		filter.Point = Drop
+	}
+	s := &Session{
+		filter:  filter,
+		records: make(chan Record, bufSize),
+	}
+	sessionsMtx.Lock()
+	defer sessionsMtx.Unlock()
+	active, _ := sessions.Load().([]*Session)
+	updated := make([]*Session, 0, len(active)+1)
+	updated = append(updated, active...)
+	sessions.Store(append(updated, s))
+	atomic.AddInt32(&numSessions, 1)
+	return s
+}
+
+// Records returns the channel of captured records. The channel is never
+// closed.
+func (s *Session) Records() <-chan Record {
+	return s.records
+}
+
+// Lost returns the number of records that were lost because the buffer was
+// full.
+func (s *Session) Lost() uint64 {
+	return atomic.LoadUint64(&s.lost)
+}
+
+// Stop stops the session. It is safe to call Stop multiple times.
+func (s *Session) Stop() {
+	s.stopOnce.Do(func() {
+		sessionsMtx.Lock()
+		defer sessionsMtx.Unlock()
+		active, _ := sessions.Load().([]*Session)
+		updated := make([]*Session, 0, len(active))
+		for _, other := range active {
+			if other != s {
+				updated = append(updated, other)
+			}
+		}
+		sessions.Store(updated)
+		atomic.AddInt32(&numSessions, -1)
+	})
+}
+
+func (s *Session) add(r Record) {
+	select {
+	case s.records <- r:
+	default:
+		atomic.AddUint64(&s.lost, 1)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket/pcapgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/capture"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/spkt"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+// rawPkt returns a packet with the given destination and source ISD-AS in
+// the address header.
+func rawPkt(dst, src string) common.RawBytes {
+	raw := make(common.RawBytes, spkt.CmnHdrLen+2*addr.IABytes+8)
+	xtest.MustParseIA(dst).Write(raw[spkt.CmnHdrLen:])
+	xtest.MustParseIA(src).Write(raw[spkt.CmnHdrLen+addr.IABytes:])
+	return raw
+}
+
+func TestFilterMatch(t *testing.T) {
+	raw := rawPkt("1-ff00:0:110", "2-ff00:0:210")
+	tests := map[string]struct {
+		filter capture.Filter
+		point  capture.Point
+		ifid   common.IFIDType
+		reason string
+		match  bool
+	}{
+		"empty filter": {
+			point: capture.Input,
+			ifid:  1,
+			match: true,
+		},
+		"point mismatch": {
+			filter: capture.Filter{Point: capture.Output},
+			point:  capture.Input,
+		},
+		"ifid match": {
+			filter: capture.Filter{IfID: 1},
+			point:  capture.Output,
+			ifid:   1,
+			match:  true,
+		},
+		"ifid mismatch": {
+			filter: capture.Filter{IfID: 2},
+			point:  capture.Output,
+			ifid:   1,
+		},
+		"reason match": {
+			filter: capture.Filter{DropReason: "bad_mac"},
+			point:  capture.Drop,
+			reason: "bad_mac",
+			match:  true,
+		},
+		"reason mismatch": {
+			filter: capture.Filter{DropReason: "bad_mac"},
+			point:  capture.Drop,
+			reason: "no_route",
+		},
+		"reason on other point": {
+			filter: capture.Filter{DropReason: "bad_mac"},
+			point:  capture.Input,
+		},
+		"IAs match": {
+			filter: capture.Filter{
+				SrcIA: xtest.MustParseIA("2-ff00:0:210"),
+				DstIA: xtest.MustParseIA("1-ff00:0:110"),
+			},
+			point: capture.Input,
+			match: true,
+		},
+		"wildcard IA match": {
+			filter: capture.Filter{SrcIA: xtest.MustParseIA("2-0")},
+			point:  capture.Input,
+			match:  true,
+		},
+		"src IA mismatch": {
+			filter: capture.Filter{SrcIA: xtest.MustParseIA("1-ff00:0:110")},
+			point:  capture.Input,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.match, test.filter.Match(test.point, test.ifid, raw,
+				test.reason))
+		})
+	}
+	t.Run("truncated packet", func(t *testing.T) {
+		f := capture.Filter{SrcIA: xtest.MustParseIA("2-ff00:0:210")}
+		assert.False(t, f.Match(capture.Input, 0, raw[:spkt.CmnHdrLen], ""))
+	})
+}
+
+func TestSession(t *testing.T) {
+	raw := rawPkt("1-ff00:0:110", "2-ff00:0:210")
+	assert.False(t, capture.Enabled())
+	s := capture.Start(capture.Filter{Point: capture.Drop}, 1)
+	assert.True(t, capture.Enabled())
+
+	capture.Capture(capture.Input, 1, raw, "")
+	capture.Capture(capture.Drop, 1, raw, "bad_mac")
+	capture.Capture(capture.Drop, 2, raw, "no_route")
+	select {
+	case rec := <-s.Records():
+		assert.Equal(t, capture.Drop, rec.Point)
+		assert.Equal(t, common.IFIDType(1), rec.IfID)
+		assert.Equal(t, "bad_mac", rec.DropReason)
+		assert.Equal(t, raw, rec.Raw)
+	default:
+		t.Fatal("No record captured")
+	}
+	assert.Equal(t, uint64(1), s.Lost())
+
+	s.Stop()
+	s.Stop()
+	assert.False(t, capture.Enabled())
+	capture.Capture(capture.Drop, 1, raw, "bad_mac")
+	assert.Len(t, s.Records(), 0)
+}
+
+func TestParseRequest(t *testing.T) {
+	req := httptest.NewRequest("GET",
+		"/capture?point=drop&ifid=3&src_ia=1-ff00:0:110&reason=bad_mac&count=5&duration=1s",
+		nil)
+	parsed, err := capture.ParseRequest(req.URL.Query())
+	require.NoError(t, err)
+	assert.Equal(t, capture.Request{
+		Filter: capture.Filter{
+			Point:      capture.Drop,
+			IfID:       3,
+			SrcIA:      xtest.MustParseIA("1-ff00:0:110"),
+			DropReason: "bad_mac",
+		},
+		Count:    5,
+		Duration: time.Second,
+	}, parsed)
+
+	for _, query := range []string{"point=x", "ifid=x", "src_ia=x", "dst_ia=x", "count=0",
+		"duration=1h", "duration=x"} {
+		req := httptest.NewRequest("GET", "/capture?"+query, nil)
+		_, err := capture.ParseRequest(req.URL.Query())
+		assert.Error(t, err, query)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	raw := rawPkt("1-ff00:0:110", "2-ff00:0:210")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req := httptest.NewRequest("GET", "/capture?point=in&count=2", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		capture.Handler(rec, req)
+	}()
+	// Feed packets until the handler has captured enough of them.
+	for func() bool {
+		select {
+		case <-done:
+			return false
+		default:
+			return true
+		}
+	}() {
+		capture.Capture(capture.Input, 1, raw, "")
+		time.Sleep(time.Millisecond)
+	}
+	assert.False(t, capture.Enabled())
+
+	r, err := pcapgo.NewReader(bytes.NewReader(rec.Body.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, capture.LinkType, r.LinkType())
+	for i := 0; i < 2; i++ {
+		data, _, err := r.ReadPacketData()
+		require.NoError(t, err)
+		assert.Equal(t, []byte(raw), data)
+	}
+	_, _, err = r.ReadPacketData()
+	assert.Equal(t, io.EOF, err)
+}
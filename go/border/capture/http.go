@@ -0,0 +1,163 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// DefaultCount is the default number of packets captured per request.
+	DefaultCount = 1000
+	// MaxCount is the maximum number of packets captured per request.
+	MaxCount = 100000
+	// DefaultDuration is the default duration of a capture.
+	DefaultDuration = 10 * time.Second
+	// MaxDuration is the maximum duration of a capture.
+	MaxDuration = 10 * time.Minute
+	// LinkType is the pcap link type of the captured packets. The packets
+	// start with the SCION common header, which has no registered link type.
+	LinkType = layers.LinkType(147) // LINKTYPE_USER0
+	// snapLen is the snap length advertised in the pcap header.
+	snapLen = 65535
+	// bufSize is the number of records buffered per session.
+	bufSize = 1024
+)
+
+// Request is a parsed capture request.
+type Request struct {
+	Filter   Filter
+	Count    int
+	Duration time.Duration
+}
+
+// ParseRequest parses the capture request from the URL query parameters:
+//   - point: capture point (in|out|drop|any), default any.
+//   - ifid: interface ID, default any.
+//   - src_ia, dst_ia: source/destination ISD-AS, wildcards are supported.
+//   - reason: drop reason (e.g. bad_mac), implies point=drop.
+//   - count: maximum number of packets, default DefaultCount.
+//   - duration: maximum duration, e.g. 30s, default DefaultDuration.
+func ParseRequest(q url.Values) (Request, error) {
+	req := Request{Count: DefaultCount, Duration: DefaultDuration}
+	var err error
+	if req.Filter.Point, err = ParsePoint(q.Get("point")); err != nil {
+		return Request{}, err
+	}
+	if v := q.Get("ifid"); v != "" {
+		ifid, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return Request{}, serrors.WrapStr("invalid ifid", err, "ifid", v)
+		}
+		req.Filter.IfID = common.IFIDType(ifid)
+	}
+	if v := q.Get("src_ia"); v != "" {
+		if req.Filter.SrcIA, err = addr.IAFromString(v); err != nil {
+			return Request{}, serrors.WrapStr("invalid src_ia", err)
+		}
+	}
+	if v := q.Get("dst_ia"); v != "" {
+		if req.Filter.DstIA, err = addr.IAFromString(v); err != nil {
+			return Request{}, serrors.WrapStr("invalid dst_ia", err)
+		}
+	}
+	req.Filter.DropReason = q.Get("reason")
+	if v := q.Get("count"); v != "" {
+		if req.Count, err = strconv.Atoi(v); err != nil {
+			return Request{}, serrors.WrapStr("invalid count", err, "count", v)
+		}
+		if req.Count <= 0 || req.Count > MaxCount {
+			return Request{}, serrors.New("count out of range", "count", req.Count,
+				"max", MaxCount)
+		}
+	}
+	if v := q.Get("duration"); v != "" {
+		if req.Duration, err = time.ParseDuration(v); err != nil {
+			return Request{}, serrors.WrapStr("invalid duration", err, "duration", v)
+		}
+		if req.Duration <= 0 || req.Duration > MaxDuration {
+			return Request{}, serrors.New("duration out of range", "duration", req.Duration,
+				"max", MaxDuration)
+		}
+	}
+	return req, nil
+}
+
+// Handler starts a capture session with the parameters of the request (see
+// ParseRequest) and streams the captured packets in pcap format. The capture
+// ends when the packet count or the duration is reached, or the client
+// disconnects.
+func Handler(w http.ResponseWriter, r *http.Request) {
+	req, err := ParseRequest(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/vnd.tcpdump.pcap")
+	w.Header().Set("Content-Disposition", `attachment; filename="capture.pcap"`)
+	pw := pcapgo.NewWriter(w)
+	if err := pw.WriteFileHeader(snapLen, LinkType); err != nil {
+		return
+	}
+	flush := func() {
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+	flush()
+
+	log.Info("Packet capture started", "filter", req.Filter, "count", req.Count,
+		"duration", req.Duration)
+	s := Start(req.Filter, bufSize)
+	defer s.Stop()
+	timeout := time.NewTimer(req.Duration)
+	defer timeout.Stop()
+	captured := 0
+	for captured < req.Count {
+		select {
+		case rec := <-s.Records():
+			ci := gopacket.CaptureInfo{
+				Timestamp:     rec.Time,
+				CaptureLength: len(rec.Raw),
+				Length:        len(rec.Raw),
+			}
+			if err := pw.WritePacket(ci, rec.Raw); err != nil {
+				log.Debug("Packet capture aborted", "err", err)
+				return
+			}
+			flush()
+			captured++
+		case <-timeout.C:
+			log.Info("Packet capture done", "captured", captured, "lost", s.Lost())
+			return
+		case <-r.Context().Done():
+			log.Info("Packet capture cancelled", "captured", captured, "lost", s.Lost())
+			return
+		}
+	}
+	log.Info("Packet capture done", "captured", captured, "lost", s.Lost())
+}
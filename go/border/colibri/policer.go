@@ -0,0 +1,148 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package colibri implements the policing of COLIBRI reservation traffic in
+// the border router.
+//
+// Every reservation index is policed with its own token bucket, whose rate is
+// derived from the bandwidth class of the reservation. Traffic exceeding the
+// reserved bandwidth is dropped, such that a misbehaving source cannot take
+// bandwidth from other reservations.
+//
+// The buckets of reservations that expired or have been idle for IdleTimeout
+// are removed, and at most MaxReservations buckets are kept. If the limit is
+// reached, the least recently used bucket is evicted.
+package colibri
+
+import (
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/layers"
+	"github.com/scionproto/scion/go/lib/tokenbucket"
+)
+
+const (
+	// BurstDuration is the duration of traffic at the reserved rate that is
+	// allowed in a single burst.
+	BurstDuration = 100 * time.Millisecond
+	// MinBurst is the minimal burst size in bytes, such that every
+	// reservation can send at least a full-sized packet.
+	MinBurst = common.MinMTU
+	// IdleTimeout is the duration after which the bucket of a reservation
+	// without traffic is removed.
+	IdleTimeout = 10 * time.Second
+	// MaxReservations is the maximum number of reservations that are tracked.
+	MaxReservations = 1 << 16
+)
+
+type key struct {
+	id  [16]byte
+	idx reservation.Index
+}
+
+type entry struct {
+	bucket     *tokenbucket.Bucket
+	expiration reservation.Tick
+	lastUsed   time.Time
+}
+
+// Policer holds the token buckets of all active reservations. It is safe for
+// concurrent use.
+type Policer struct {
+	mtx     sync.Mutex
+	buckets map[key]*entry
+	// lastGC is the tick at which expired reservations were last removed.
+	lastGC reservation.Tick
+}
+
+// NewPolicer creates a new policer without any reservations.
+func NewPolicer() *Policer {
+	return &Policer{buckets: make(map[key]*entry)}
+}
+
+// Allow returns whether a packet of the given size on reservation r conforms
+// to the reserved bandwidth.
+func (p *Policer) Allow(r *layers.ExtnCOLIBRI, size int, now time.Time) bool {
+	if p == nil {
+		return true
+	}
+	return p.bucket(r, now).Allow(now, float64(size))
+}
+
+// Len returns the number of reservations that are currently tracked.
+func (p *Policer) Len() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return len(p.buckets)
+}
+
+func (p *Policer) bucket(r *layers.ExtnCOLIBRI, now time.Time) *tokenbucket.Bucket {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if tick := reservation.TickFromTime(now); tick != p.lastGC {
+		p.gc(tick, now)
+	}
+	k := key{id: r.ID, idx: r.InfoField.Idx}
+	e, ok := p.buckets[k]
+	if !ok {
+		if len(p.buckets) >= MaxReservations {
+			p.evict()
+		}
+		e = &entry{
+			bucket:     newBucket(r.InfoField.BWCls),
+			expiration: r.InfoField.ExpirationTick,
+		}
+		p.buckets[k] = e
+	}
+	e.lastUsed = now
+	return e.bucket
+}
+
+// gc removes all expired and idle reservations. Must be called with the lock
+// held.
+func (p *Policer) gc(tick reservation.Tick, now time.Time) {
+	for k, e := range p.buckets {
+		if e.expiration < tick || now.Sub(e.lastUsed) > IdleTimeout {
+			delete(p.buckets, k)
+		}
+	}
+	p.lastGC = tick
+}
+
+// evict removes the least recently used reservation. Must be called with the
+// lock held.
+func (p *Policer) evict() {
+	var oldest key
+	var oldestUsed time.Time
+	first := true
+	for k, e := range p.buckets {
+		if first || e.lastUsed.Before(oldestUsed) {
+			oldest, oldestUsed, first = k, e.lastUsed, false
+		}
+	}
+	delete(p.buckets, oldest)
+}
+
+func newBucket(bw reservation.BWCls) *tokenbucket.Bucket {
+	// Rate in bytes per second.
+	rate := float64(bw.ToKbps()) * 1000 / 8
+	burst := rate * BurstDuration.Seconds()
+	if burst < MinBurst {
+		burst = MinBurst
+	}
+	return tokenbucket.New(rate, burst)
+}
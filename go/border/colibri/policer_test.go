@@ -0,0 +1,118 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/border/colibri"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/layers"
+)
+
+func newReservation(id byte, idx reservation.Index, bw reservation.BWCls,
+	exp time.Time) *layers.ExtnCOLIBRI {
+
+	r := &layers.ExtnCOLIBRI{
+		InfoField: reservation.InfoField{
+			ExpirationTick: reservation.TickFromTime(exp),
+			BWCls:          bw,
+			Idx:            idx,
+		},
+	}
+	r.ID[0] = id
+	return r
+}
+
+func TestPolicerAllow(t *testing.T) {
+	now := time.Now()
+	p := colibri.NewPolicer()
+	// Class 13 is 1024 kbps, i.e., 128000 bytes per second and a burst of
+	// 12800 bytes.
+	r := newReservation(1, 0, 13, now.Add(time.Minute))
+	assert.True(t, p.Allow(r, 12800, now))
+	assert.False(t, p.Allow(r, 1000, now), "burst exhausted")
+	assert.True(t, p.Allow(r, 1280, now.Add(20*time.Millisecond)), "refilled")
+	// Other reservations and other indices have their own buckets.
+	assert.True(t, p.Allow(newReservation(2, 0, 13, now.Add(time.Minute)), 12800, now))
+	assert.True(t, p.Allow(newReservation(1, 1, 13, now.Add(time.Minute)), 12800, now))
+	assert.Equal(t, 3, p.Len())
+}
+
+func TestPolicerMinBurst(t *testing.T) {
+	now := time.Now()
+	p := colibri.NewPolicer()
+	r := newReservation(1, 0, 1, now.Add(time.Minute))
+	assert.True(t, p.Allow(r, colibri.MinBurst, now))
+	assert.False(t, p.Allow(r, colibri.MinBurst, now))
+}
+
+func TestPolicerExpiry(t *testing.T) {
+	now := time.Now()
+	p := colibri.NewPolicer()
+	p.Allow(newReservation(1, 0, 13, now), 100, now)
+	p.Allow(newReservation(2, 0, 13, now.Add(time.Hour)), 100, now)
+	assert.Equal(t, 2, p.Len())
+	// The reservation expires within the next tick, which is within the idle
+	// timeout.
+	p.Allow(newReservation(2, 0, 13, now.Add(time.Hour)), 100, now.Add(8*time.Second))
+	assert.Equal(t, 1, p.Len())
+}
+
+func TestPolicerIdle(t *testing.T) {
+	now := time.Now()
+	p := colibri.NewPolicer()
+	p.Allow(newReservation(1, 0, 13, now.Add(time.Hour)), 100, now)
+	p.Allow(newReservation(2, 0, 13, now.Add(time.Hour)), 100, now)
+	// Every call is in a later tick, such that the idle reservations are
+	// removed.
+	p.Allow(newReservation(2, 0, 13, now.Add(time.Hour)), 100, now.Add(colibri.IdleTimeout/2))
+	assert.Equal(t, 2, p.Len())
+	p.Allow(newReservation(2, 0, 13, now.Add(time.Hour)), 100,
+		now.Add(colibri.IdleTimeout+colibri.IdleTimeout/2))
+	assert.Equal(t, 1, p.Len(), "idle reservation removed")
+}
+
+func TestPolicerMaxReservations(t *testing.T) {
+	now := time.Now()
+	exp := now.Add(time.Hour)
+	p := colibri.NewPolicer()
+	for i := 0; i < colibri.MaxReservations; i++ {
+		r := newReservation(0, 0, 13, exp)
+		r.ID[0], r.ID[1], r.ID[2] = byte(i), byte(i>>8), 1
+		// The first reservation is the least recently used one.
+		p.Allow(r, 100, now.Add(time.Duration(i)*time.Nanosecond))
+	}
+	assert.Equal(t, colibri.MaxReservations, p.Len())
+	// Exhaust the burst of the second reservation.
+	second := newReservation(1, 0, 13, exp)
+	second.ID[2] = 1
+	assert.True(t, p.Allow(second, 12700, now.Add(time.Millisecond)))
+	assert.True(t, p.Allow(newReservation(2, 0, 13, exp), 100, now.Add(time.Millisecond)))
+	assert.Equal(t, colibri.MaxReservations, p.Len())
+	// The second reservation is still tracked, as the first one was evicted.
+	assert.False(t, p.Allow(second, 1000, now.Add(time.Millisecond)))
+	first := newReservation(0, 0, 13, exp)
+	first.ID[2] = 1
+	assert.True(t, p.Allow(first, 12800, now.Add(time.Millisecond)), "new bucket")
+}
+
+func TestNilPolicer(t *testing.T) {
+	var p *colibri.Policer
+	assert.True(t, p.Allow(newReservation(1, 0, 0, time.Now()), 1<<20, time.Now()))
+}
@@ -0,0 +1,202 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file implements the drain mode for planned maintenance. Draining an
+// interface asks the beacon services to revoke it, while data traffic is still
+// forwarded for a grace period. Path-aware endpoints thus have time to switch
+// to other paths before the interface stops forwarding.
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/border/ifstate"
+	"github.com/scionproto/scion/go/border/rctrl"
+	"github.com/scionproto/scion/go/border/rctx"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// drainTimers holds the timers that end the grace period of draining
+// interfaces.
+type drainTimers struct {
+	mtx    sync.Mutex
+	timers map[common.IFIDType]*drainTimer
+}
+
+type drainTimer struct {
+	*time.Timer
+	deadline time.Time
+}
+
+// drainIntfs starts draining the interfaces. The beacon services are notified
+// immediately, and the interfaces stop forwarding after the grace period.
+// Interfaces that are already draining keep their deadline.
+func (r *Router) drainIntfs(ifids []common.IFIDType, grace time.Duration) {
+	r.drain.mtx.Lock()
+	defer r.drain.mtx.Unlock()
+	if r.drain.timers == nil {
+		r.drain.timers = make(map[common.IFIDType]*drainTimer)
+	}
+	for _, ifid := range ifids {
+		if _, ok := r.drain.timers[ifid]; ok || ifstate.DrainStateOf(ifid) == ifstate.Drained {
+			continue
+		}
+		ifid := ifid
+		ifstate.SetDrainState(ifid, ifstate.Draining)
+		r.drain.timers[ifid] = &drainTimer{
+			Timer: time.AfterFunc(grace, func() {
+				r.drain.mtx.Lock()
+				defer r.drain.mtx.Unlock()
+				if _, ok := r.drain.timers[ifid]; !ok {
+					// Cancelled in the meantime.
+					return
+				}
+				delete(r.drain.timers, ifid)
+				ifstate.SetDrainState(ifid, ifstate.Drained)
+				log.Info("Interface drained", "ifid", ifid)
+			}),
+			deadline: time.Now().Add(grace),
+		}
+		log.Info("Draining interface", "ifid", ifid, "grace", grace)
+		if err := rctrl.RequestRevocation(ifid); err != nil {
+			log.Error("Unable to request revocation of draining interface",
+				"ifid", ifid, "err", err)
+		}
+	}
+}
+
+// undrainIntfs puts the interfaces back into normal operation. The beacon
+// services activate them again once keepalives are received.
+func (r *Router) undrainIntfs(ifids []common.IFIDType) {
+	r.drain.mtx.Lock()
+	defer r.drain.mtx.Unlock()
+	for _, ifid := range ifids {
+		if t, ok := r.drain.timers[ifid]; ok {
+			t.Stop()
+			delete(r.drain.timers, ifid)
+		}
+		if ifstate.DrainStateOf(ifid) != ifstate.NotDraining {
+			ifstate.SetDrainState(ifid, ifstate.NotDraining)
+			log.Info("Interface no longer draining", "ifid", ifid)
+		}
+	}
+}
+
+// drainDeadline returns the end of the grace period of a draining interface.
+func (r *Router) drainDeadline(ifid common.IFIDType) (time.Time, bool) {
+	r.drain.mtx.Lock()
+	defer r.drain.mtx.Unlock()
+	t, ok := r.drain.timers[ifid]
+	if !ok {
+		return time.Time{}, false
+	}
+	return t.deadline, true
+}
+
+// parseDrainRequest parses the interfaces and the grace period of a drain
+// request. If no grace period is specified, the default is used.
+func parseDrainRequest(v url.Values, defGrace time.Duration) ([]common.IFIDType,
+	time.Duration, error) {
+
+	if len(v["ifid"]) == 0 {
+		return nil, 0, serrors.New("at least one ifid must be specified")
+	}
+	ifids := make([]common.IFIDType, 0, len(v["ifid"]))
+	for _, raw := range v["ifid"] {
+		ifid, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil || ifid == 0 {
+			return nil, 0, serrors.New("invalid ifid", "ifid", raw)
+		}
+		ifids = append(ifids, common.IFIDType(ifid))
+	}
+	grace := defGrace
+	if raw := v.Get("grace"); raw != "" {
+		var err error
+		if grace, err = time.ParseDuration(raw); err != nil || grace < 0 {
+			return nil, 0, serrors.New("invalid grace period", "grace", raw)
+		}
+	}
+	return ifids, grace, nil
+}
+
+// drainHandler is the HTTP handler of the drain mode. GET lists the drain
+// state of all external interfaces, POST starts draining the interfaces given
+// by the ifid parameters, optionally with a grace period given by the grace
+// parameter, and DELETE stops draining them, e.g.:
+//
+//   curl -X POST 'http://<br>/drain?ifid=1&ifid=2&grace=1m'
+func drainHandler(w http.ResponseWriter, req *http.Request) {
+	if r == nil {
+		http.Error(w, "router not started", http.StatusServiceUnavailable)
+		return
+	}
+	if req.Method == http.MethodGet {
+		writeDrainStates(w)
+		return
+	}
+	if req.Method != http.MethodPost && req.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := req.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ifids, grace, err := parseDrainRequest(req.Form, cfg.BR.DrainGracePeriod.Duration)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	intfs := rctx.Get().Conf.BR.IFs
+	for _, ifid := range ifids {
+		if _, ok := intfs[ifid]; !ok {
+			http.Error(w, fmt.Sprintf("unknown interface: %d", ifid), http.StatusBadRequest)
+			return
+		}
+	}
+	if req.Method == http.MethodPost {
+		r.drainIntfs(ifids, grace)
+	} else {
+		r.undrainIntfs(ifids)
+	}
+	writeDrainStates(w)
+}
+
+func writeDrainStates(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/plain")
+	var ifids []common.IFIDType
+	for ifid := range rctx.Get().Conf.BR.IFs {
+		ifids = append(ifids, ifid)
+	}
+	sort.Slice(ifids, func(i, j int) bool { return ifids[i] < ifids[j] })
+	out := "Interfaces:\n"
+	for _, ifid := range ifids {
+		state := ifstate.DrainStateOf(ifid)
+		out += fmt.Sprintf("  %d: %s", ifid, state)
+		if deadline, ok := r.drainDeadline(ifid); ok {
+			out += fmt.Sprintf(" (until %s)", deadline.Format(time.RFC3339))
+		}
+		out += "\n"
+	}
+	fmt.Fprint(w, out)
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/ifstate"
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+func TestParseDrainRequest(t *testing.T) {
+	tests := map[string]struct {
+		query  string
+		ifids  []common.IFIDType
+		grace  time.Duration
+		hasErr bool
+	}{
+		"default grace": {
+			query: "ifid=1&ifid=2",
+			ifids: []common.IFIDType{1, 2},
+			grace: 30 * time.Second,
+		},
+		"explicit grace": {
+			query: "ifid=3&grace=1m",
+			ifids: []common.IFIDType{3},
+			grace: time.Minute,
+		},
+		"no ifid":        {query: "grace=1m", hasErr: true},
+		"invalid ifid":   {query: "ifid=a", hasErr: true},
+		"zero ifid":      {query: "ifid=0", hasErr: true},
+		"invalid grace":  {query: "ifid=1&grace=soon", hasErr: true},
+		"negative grace": {query: "ifid=1&grace=-1s", hasErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := url.ParseQuery(test.query)
+			require.NoError(t, err)
+			ifids, grace, err := parseDrainRequest(v, 30*time.Second)
+			if test.hasErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.ifids, ifids)
+			assert.Equal(t, test.grace, grace)
+		})
+	}
+}
+
+func TestDrainIntfs(t *testing.T) {
+	r := &Router{}
+	const ifid = common.IFIDType(41)
+	defer ifstate.SetDrainState(ifid, ifstate.NotDraining)
+
+	r.drainIntfs([]common.IFIDType{ifid}, 50*time.Millisecond)
+	assert.Equal(t, ifstate.Draining, ifstate.DrainStateOf(ifid))
+	_, ok := r.drainDeadline(ifid)
+	assert.True(t, ok)
+	// After the grace period, the interface stops forwarding.
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, ifstate.Drained, ifstate.DrainStateOf(ifid))
+	_, ok = r.drainDeadline(ifid)
+	assert.False(t, ok)
+
+	r.undrainIntfs([]common.IFIDType{ifid})
+	assert.Equal(t, ifstate.NotDraining, ifstate.DrainStateOf(ifid))
+}
+
+func TestUndrainBeforeDeadline(t *testing.T) {
+	r := &Router{}
+	const ifid = common.IFIDType(42)
+	defer ifstate.SetDrainState(ifid, ifstate.NotDraining)
+
+	r.drainIntfs([]common.IFIDType{ifid}, 20*time.Millisecond)
+	r.undrainIntfs([]common.IFIDType{ifid})
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, ifstate.NotDraining, ifstate.DrainStateOf(ifid))
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the accounting of dropped packets.
+
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/border/capture"
+	"github.com/scionproto/scion/go/border/internal/metrics"
+	"github.com/scionproto/scion/go/border/rcmn"
+	"github.com/scionproto/scion/go/border/rpkt"
+)
+
+// dropLogInterval is the minimum interval between two log entries for
+// dropped packets with the same drop reason.
+const dropLogInterval = time.Second
+
+// dropPkt accounts for a packet that is dropped with the result in l. The
+// drop reason is derived from err, or is fallback if err does not indicate a
+// more specific reason.
+func (r *Router) dropPkt(rp *rpkt.RtrPkt, l metrics.ProcessLabels,
+	fallback rcmn.DropReason, err error) {
+
+	reason := rcmn.DropReasonOf(err, fallback)
+	metrics.Process.Pkts(l).Inc()
+	metrics.Process.Drops(metrics.DropLabels{Reason: reason.String(), IntfIn: l.IntfIn}).Inc()
+	if ok, suppressed := r.dropLog.sample(reason, time.Now()); ok {
+		// XXX(kormat): uncomment for debugging:
+		// err = common.NewBasicError("Raw packet", err, "raw", rp.Raw)
+		rp.Error("Dropping packet", "reason", reason, "result", l.Result, "err", err,
+			"suppressed", suppressed)
+	}
+	if capture.Enabled() {
+		capture.Capture(capture.Drop, rp.Ingress.IfID, rp.Raw, reason.String())
+	}
+}
+
+// dropSampler limits the rate of log entries for dropped packets to one per
+// drop reason and dropLogInterval.
+type dropSampler struct {
+	mtx        sync.Mutex
+	last       [rcmn.NumDropReasons]time.Time
+	suppressed [rcmn.NumDropReasons]int
+}
+
+// sample returns whether a drop with the given reason should be logged. If
+// so, it also returns the number of drops with the same reason that were not
+// logged since the last log entry.
+func (s *dropSampler) sample(reason rcmn.DropReason, now time.Time) (bool, int) {
+	if reason >= rcmn.NumDropReasons {
+		reason = rcmn.DropUnknown
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if now.Sub(s.last[reason]) < dropLogInterval {
+		s.suppressed[reason]++
+		return false, 0
+	}
+	suppressed := s.suppressed[reason]
+	s.last[reason] = now
+	s.suppressed[reason] = 0
+	return true, suppressed
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/border/rcmn"
+)
+
+func TestDropSampler(t *testing.T) {
+	var s dropSampler
+	now := time.Now()
+	ok, suppressed := s.sample(rcmn.DropBadMAC, now)
+	assert.True(t, ok)
+	assert.Equal(t, 0, suppressed)
+	// Drops with the same reason are suppressed within the interval.
+	for i := 0; i < 3; i++ {
+		ok, _ = s.sample(rcmn.DropBadMAC, now.Add(dropLogInterval/2))
+		assert.False(t, ok)
+	}
+	// Other reasons are sampled independently.
+	ok, _ = s.sample(rcmn.DropExpiredHopF, now.Add(dropLogInterval/2))
+	assert.True(t, ok)
+	// After the interval, the number of suppressed drops is reported.
+	ok, suppressed = s.sample(rcmn.DropBadMAC, now.Add(dropLogInterval))
+	assert.True(t, ok)
+	assert.Equal(t, 3, suppressed)
+	ok, _ = s.sample(rcmn.DropBadMAC, now.Add(dropLogInterval))
+	assert.False(t, ok)
+}
@@ -20,9 +20,14 @@ package main
 
 import (
 	"errors"
+	"time"
 
+	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/internal/metrics"
 	"github.com/scionproto/scion/go/border/rcmn"
 	"github.com/scionproto/scion/go/border/rpkt"
+	"github.com/scionproto/scion/go/border/scmplimit"
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/layers"
 	"github.com/scionproto/scion/go/lib/log"
@@ -35,11 +40,9 @@ type pktErrorArgs struct {
 }
 
 // handlePktError is called to enqueue packets with protocol-level errors
-// for handling by the PacketError goroutine.
-func (r *Router) handlePktError(rp *rpkt.RtrPkt, perr error, desc string) {
-	// XXX(kormat): uncomment for debugging:
-	// perr = common.NewBasicError("Raw packet", perr, "raw", rp.Raw)
-	rp.Error(desc, "err", perr)
+// for handling by the PacketError goroutine. Logging the error is left to
+// dropPkt.
+func (r *Router) handlePktError(rp *rpkt.RtrPkt, perr error) {
 	rp.RefInc(1)
 	args := pktErrorArgs{rp: rp, perr: perr}
 	select {
@@ -98,14 +101,43 @@ func (r *Router) doPktError(rp *rpkt.RtrPkt, perr error) {
 			}
 		}
 	}
+	if !r.allowSCMPError(rp, srcIA) {
+		return
+	}
 	reply, err := r.createSCMPErrorReply(rp, serr.CT, serr.Info)
 	if err != nil {
 		rp.Error("Error creating SCMP response", "err", err)
 		return
 	}
+	metrics.SCMP.Errors().Inc()
 	reply.Route()
 }
 
+// newSCMPLimiter creates the rate limiter for SCMP errors from the
+// configuration.
+func newSCMPLimiter(cfg brconf.SCMPRateLimit) *scmplimit.Limiter {
+	return scmplimit.New(scmplimit.Config{
+		GlobalRate:       cfg.GlobalRate,
+		GlobalBurst:      cfg.GlobalBurst,
+		DestinationRate:  cfg.DestinationRate,
+		DestinationBurst: cfg.DestinationBurst,
+	})
+}
+
+// allowSCMPError checks whether an SCMP error reply to the source of the
+// packet is within the rate limits. Suppressed replies are counted per limit.
+func (r *Router) allowSCMPError(rp *rpkt.RtrPkt, srcIA addr.IA) bool {
+	srcHost, err := rp.SrcHost()
+	if err != nil {
+		return false
+	}
+	ok, reason := r.scmpLimit.Allow(srcIA, srcHost, time.Now())
+	if !ok {
+		metrics.SCMP.Suppressed(metrics.SCMPSuppressedLabels{Reason: reason.String()}).Inc()
+	}
+	return ok
+}
+
 // createSCMPErrorReply generates an SCMP error reply to the supplied packet.
 func (r *Router) createSCMPErrorReply(rp *rpkt.RtrPkt, ct scmp.ClassType,
 	info scmp.Info) (*rpkt.RtrPkt, error) {
@@ -0,0 +1,74 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file keeps track of the drain state of external interfaces. Interfaces
+// are drained for maintenance on request of an operator.
+
+package ifstate
+
+import (
+	"sync"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// DrainState is the drain state of an interface.
+type DrainState int
+
+const (
+	// NotDraining is the state of interfaces in normal operation.
+	NotDraining DrainState = iota
+	// Draining is the state of interfaces that are being drained. Data traffic
+	// is still forwarded, but keepalives and beacons are dropped such that the
+	// beacon services revoke the interface.
+	Draining
+	// Drained is the state of interfaces whose grace period has expired. No
+	// traffic is forwarded anymore.
+	Drained
+)
+
+func (s DrainState) String() string {
+	switch s {
+	case NotDraining:
+		return "not_draining"
+	case Draining:
+		return "draining"
+	case Drained:
+		return "drained"
+	default:
+		return "unknown"
+	}
+}
+
+// drainStates maps interface IDs to their drain state. Interfaces that are not
+// present are not draining.
+var drainStates sync.Map
+
+// SetDrainState sets the drain state of the given interface.
+func SetDrainState(ifID common.IFIDType, s DrainState) {
+	if s == NotDraining {
+		drainStates.Delete(ifID)
+		return
+	}
+	drainStates.Store(ifID, s)
+}
+
+// DrainStateOf returns the drain state of the given interface.
+func DrainStateOf(ifID common.IFIDType) DrainState {
+	s, ok := drainStates.Load(ifID)
+	if !ok {
+		return NotDraining
+	}
+	return s.(DrainState)
+}
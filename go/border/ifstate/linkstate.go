@@ -0,0 +1,45 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file keeps track of the link state of external interfaces, as detected
+// by BFD. In contrast to the interface state, which is driven by the beacon
+// service, the link state is updated locally by the border router and reacts
+// within the BFD detection time.
+
+package ifstate
+
+import (
+	"sync"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// linkDown contains the IDs of interfaces whose link is down.
+var linkDown sync.Map
+
+// SetLinkState sets the link state of the given interface.
+func SetLinkState(ifID common.IFIDType, up bool) {
+	if up {
+		linkDown.Delete(ifID)
+		return
+	}
+	linkDown.Store(ifID, struct{}{})
+}
+
+// LinkUp returns whether the link of the given interface is up. Interfaces
+// without BFD session are always considered up.
+func LinkUp(ifID common.IFIDType) bool {
+	_, down := linkDown.Load(ifID)
+	return !down
+}
@@ -41,6 +41,8 @@ const (
 	ErrParsePayload = "err_parse_payload"
 	// ErrResolveSVC is an error resolving a SVC address.
 	ErrResolveSVC = "err_resolve_svc"
+	// ErrPoliced is a packet dropped by ingress policing.
+	ErrPoliced = "err_policed"
 )
 
 // Metrics initialization.
@@ -49,6 +51,7 @@ var (
 	Output  = newOutput()
 	Process = newProcess()
 	Control = newControl()
+	SCMP    = newSCMP()
 )
 
 type IntfLabels struct {
@@ -44,8 +44,27 @@ func (l ProcessLabels) Values() []string {
 	return []string{l.Result, l.IntfIn, l.IntfOut}
 }
 
+// DropLabels are the labels of the dropped packets counter.
+type DropLabels struct {
+	// Reason is the reason the packet was dropped.
+	Reason string
+	// IntfIn is the input SCION interface.
+	IntfIn string
+}
+
+// Labels returns the list of labels.
+func (l DropLabels) Labels() []string {
+	return []string{"reason", "intf_in"}
+}
+
+// Values returns the label values in the order defined by Labels.
+func (l DropLabels) Values() []string {
+	return []string{l.Reason, l.IntfIn}
+}
+
 type process struct {
 	pkts     *prometheus.CounterVec
+	drops    *prometheus.CounterVec
 	duration *prometheus.CounterVec
 }
 
@@ -54,6 +73,8 @@ func newProcess() process {
 	return process{
 		pkts: prom.NewCounterVecWithLabels(Namespace, sub,
 			"pkts_total", "Total number of processed packets.", ProcessLabels{}),
+		drops: prom.NewCounterVecWithLabels(Namespace, sub,
+			"drops_total", "Total number of dropped packets by reason.", DropLabels{}),
 		duration: prom.NewCounterVecWithLabels(Namespace, sub,
 			"duration_seconds_total", "Total packet processing duration.", IntfLabels{}),
 	}
@@ -64,6 +85,11 @@ func (p *process) Pkts(l ProcessLabels) prometheus.Counter {
 	return p.pkts.WithLabelValues(l.Values()...)
 }
 
+// Drops returns the counter for the given label set.
+func (p *process) Drops(l DropLabels) prometheus.Counter {
+	return p.drops.WithLabelValues(l.Values()...)
+}
+
 // Duration returns the counter for the given label set.
 func (p *process) Duration(l IntfLabels) prometheus.Counter {
 	return p.duration.WithLabelValues(l.Values()...)
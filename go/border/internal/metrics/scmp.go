@@ -0,0 +1,63 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/scionproto/scion/go/lib/prom"
+)
+
+// SCMPSuppressedLabels are the labels of the suppressed SCMP errors counter.
+type SCMPSuppressedLabels struct {
+	// Reason is the rate limit that suppressed the message.
+	Reason string
+}
+
+// Labels returns the list of labels.
+func (l SCMPSuppressedLabels) Labels() []string {
+	return []string{"reason"}
+}
+
+// Values returns the label values in the order defined by Labels.
+func (l SCMPSuppressedLabels) Values() []string {
+	return []string{l.Reason}
+}
+
+type scmp struct {
+	errors     prometheus.Counter
+	suppressed *prometheus.CounterVec
+}
+
+func newSCMP() scmp {
+	sub := "scmp"
+	return scmp{
+		errors: prom.NewCounter(Namespace, sub,
+			"errors_total", "Total number of SCMP errors originated by the router."),
+		suppressed: prom.NewCounterVecWithLabels(Namespace, sub,
+			"suppressed_total", "Total number of SCMP errors suppressed by rate limiting.",
+			SCMPSuppressedLabels{}),
+	}
+}
+
+// Errors returns the counter of originated SCMP errors.
+func (s *scmp) Errors() prometheus.Counter {
+	return s.errors
+}
+
+// Suppressed returns the counter for the given label set.
+func (s *scmp) Suppressed(l SCMPSuppressedLabels) prometheus.Counter {
+	return s.suppressed.WithLabelValues(l.Values()...)
+}
@@ -13,7 +13,9 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// This file handles IO using the POSIX(/BSD) socket API.
+// This file handles packet IO for router sockets. The input and output
+// routines read and write through the rctx.IOBackend of a socket, and are thus
+// independent of the I/O backend that is used.
 
 package main
 
@@ -50,15 +52,19 @@ const (
 	outputBatchCnt = 32 // Must be <= outputBufCnt
 )
 
-func (r *Router) posixInput(s *rctx.Sock, stop, stopped chan struct{}) {
+func (r *Router) ioInput(s *rctx.Sock, stop, stopped chan struct{}) {
 	defer log.HandlePanic()
 	defer close(stopped)
 	dst := s.Conn.LocalAddr()
-	log.Info("posixInput starting", "addr", dst)
-	defer log.Info("posixInput stopping", "addr", dst)
+	log.Info("ioInput starting", "addr", dst)
+	defer log.Info("ioInput stopping", "addr", dst)
 	pkts := make(ringbuf.EntryList, 0, inputBufCnt)
 	msgs := conn.NewReadMessages(inputBatchCnt)
 	readMetas := make([]conn.ReadMeta, inputBatchCnt)
+	var dispatcher *dispatcher
+	if r.workers != nil {
+		dispatcher = r.workers.newDispatcher()
+	}
 	var err error
 
 	l := metrics.IntfLabels{Intf: s.Label, NeighIA: s.NeighIA}
@@ -92,14 +98,14 @@ Top:
 		default:
 		}
 		var ok bool
-		if pkts, ok = r.posixPrepInput(pkts, msgs); !ok {
+		if pkts, ok = r.prepInput(pkts, msgs); !ok {
 			break
 		}
 		inputReads.Inc()
 		toRead := min(len(pkts), inputBatchCnt)
 		var pktsRead int
 		// Loop until a read succeeds, or a non-trivial error occurs
-		if pktsRead, err = r.posixInputRead(msgs[:toRead], readMetas[:toRead], s.Conn); err != nil {
+		if pktsRead, err = r.inputRead(msgs[:toRead], readMetas[:toRead], s); err != nil {
 			inputReadErrs.Inc()
 			log.Error("Error reading from socket", "socket", dst, "err", err)
 			// The most likely reason for errors is that the socket has
@@ -139,9 +145,13 @@ Top:
 			inputBytes.Add(float64(msg.N))
 			inputPktSize.Observe(float64(msg.N))
 		}
-		for written := 0; written < pktsRead; {
-			wn, _ := s.Ring.Write(pkts[written:pktsRead], true)
-			written += wn
+		if dispatcher != nil {
+			dispatcher.dispatch(pkts[:pktsRead])
+		} else {
+			for written := 0; written < pktsRead; {
+				wn, _ := s.Ring.Write(pkts[written:pktsRead], true)
+				written += wn
+			}
 		}
 		// Move unused pkts to the start.
 		copied := copy(pkts, pkts[pktsRead:])
@@ -151,9 +161,9 @@ Top:
 	r.freePkts.Write(pkts, true)
 }
 
-// posixPrepInput refills pkts if it's below inputLowBufCnt, and sets the msgs
+// prepInput refills pkts if it's below inputLowBufCnt, and sets the msgs
 // Buffers references to point to the corresponding buffers in pkts.
-func (r *Router) posixPrepInput(pkts ringbuf.EntryList,
+func (r *Router) prepInput(pkts ringbuf.EntryList,
 	msgs []ipv4.Message) (ringbuf.EntryList, bool) {
 
 	if len(pkts) < inputLowBufCnt {
@@ -178,11 +188,11 @@ func (r *Router) posixPrepInput(pkts ringbuf.EntryList,
 	return pkts, true
 }
 
-func (r *Router) posixInputRead(msgs []ipv4.Message, metas []conn.ReadMeta,
-	c conn.Conn) (int, error) {
+func (r *Router) inputRead(msgs []ipv4.Message, metas []conn.ReadMeta,
+	s *rctx.Sock) (int, error) {
 	// Loop until a read succeeds, or a non-trivial error occurs
 	for {
-		n, err := c.ReadBatch(msgs, metas)
+		n, err := s.Backend.ReadBatch(s, msgs, metas)
 		if err != nil && isSyscallErrno(err, syscall.ECONNREFUSED) {
 			// As we are using a connected UDP socket for interface sockets,
 			// any ECONNREFUSED errors that happen while sending to the
@@ -197,13 +207,13 @@ func (r *Router) posixInputRead(msgs []ipv4.Message, metas []conn.ReadMeta,
 	}
 }
 
-func (r *Router) posixOutput(s *rctx.Sock, _, stopped chan struct{}) {
+func (r *Router) ioOutput(s *rctx.Sock, _, stopped chan struct{}) {
 	defer log.HandlePanic()
 	defer close(stopped)
 	src := s.Conn.LocalAddr()
 	dst := s.Conn.RemoteAddr()
-	log.Info("posixOutput starting", "addr", src)
-	defer log.Info("posixOutput stopping", "addr", src)
+	log.Info("ioOutput starting", "addr", src)
+	defer log.Info("ioOutput stopping", "addr", src)
 	epkts := make(ringbuf.EntryList, 0, outputBufCnt)
 	msgs := conn.NewWriteMessages(outputBatchCnt)
 
@@ -223,14 +233,14 @@ func (r *Router) posixOutput(s *rctx.Sock, _, stopped chan struct{}) {
 		var bytes int // Needs to be declared before goto
 		var t float64 // Needs to be declared before goto
 		var ok bool
-		if epkts, ok = r.posixPrepOutput(epkts, msgs, s.Ring, dst != nil); !ok {
+		if epkts, ok = r.prepOutput(epkts, msgs, s.Ring, dst != nil); !ok {
 			break
 		}
 		toWrite := min(len(epkts), outputBatchCnt)
 		start := time.Now()
 		var err error
 		var pktsWritten int
-		if pktsWritten, err = s.Conn.WriteBatch(msgs[:toWrite]); err != nil {
+		if pktsWritten, err = s.Backend.WriteBatch(s, msgs[:toWrite]); err != nil {
 			outputWriteErrs.Inc()
 			log.Error("Error sending packet(s)", "src", src, "err", err)
 			// If some packets were still sent, continue processing. Otherwise:
@@ -276,10 +286,10 @@ func (r *Router) posixOutput(s *rctx.Sock, _, stopped chan struct{}) {
 	epkts = epkts[:0]
 }
 
-// posixPrepOutput fetches new packets if epkts is empty, and sets the msgs
+// prepOutput fetches new packets if epkts is empty, and sets the msgs
 // Buffers and Addr based on the corresponding entries in epkts. The second return
 // value is false, if the underlying ring is closed and drained.
-func (r *Router) posixPrepOutput(epkts ringbuf.EntryList, msgs []ipv4.Message,
+func (r *Router) prepOutput(epkts ringbuf.EntryList, msgs []ipv4.Message,
 	ring *ringbuf.Ring, connected bool) (ringbuf.EntryList, bool) {
 
 	if len(epkts) == 0 {
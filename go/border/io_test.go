@@ -24,8 +24,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/memio"
+	"github.com/scionproto/scion/go/border/rcmn"
 	"github.com/scionproto/scion/go/border/rctx"
 	"github.com/scionproto/scion/go/border/rpkt"
+	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/overlay/conn"
 	"github.com/scionproto/scion/go/lib/overlay/conn/mock_conn"
@@ -85,7 +89,7 @@ func TestIsSyscallErrno(t *testing.T) {
 	}
 }
 
-func TestPosixOutputNoLeakNoErrors(t *testing.T) {
+func TestIOOutputNoLeakNoErrors(t *testing.T) {
 	mctrl := gomock.NewController(t)
 	defer mctrl.Finish()
 	r := initTestRouter(1)
@@ -103,7 +107,7 @@ func TestPosixOutputNoLeakNoErrors(t *testing.T) {
 	sock.Stop()
 }
 
-func TestPosixOutputNoLeakTemporaryErrors(t *testing.T) {
+func TestIOOutputNoLeakTemporaryErrors(t *testing.T) {
 	mctrl := gomock.NewController(t)
 	defer mctrl.Finish()
 	r := initTestRouter(1)
@@ -122,7 +126,7 @@ func TestPosixOutputNoLeakTemporaryErrors(t *testing.T) {
 	sock.Stop()
 }
 
-func TestPosixOutputNoLeakRecoverableErrors(t *testing.T) {
+func TestIOOutputNoLeakRecoverableErrors(t *testing.T) {
 	mctrl := gomock.NewController(t)
 	defer mctrl.Finish()
 	r := initTestRouter(1)
@@ -141,6 +145,62 @@ func TestPosixOutputNoLeakRecoverableErrors(t *testing.T) {
 	sock.Stop()
 }
 
+func TestMemBackendOutput(t *testing.T) {
+	r := initTestRouter(4)
+	r.memNet = memio.NewNetwork()
+	ctx := rctx.New(loadConfig(t))
+	require.NoError(t, r.setupNet(ctx, nil, brconf.SockConf{Default: MemSock}))
+	startSocks(ctx)
+	intf := ctx.Conf.BR.IFs[11]
+	neigh, err := r.memNet.Listen(intf.Remote, intf.Local)
+	require.NoError(t, err)
+	defer neigh.Close()
+
+	pkts, checkAllReturned := newTestPktList(t, 2)
+	for i, epkt := range pkts {
+		epkt.(*rpkt.EgressRtrPkt).Rp.Raw = []byte{byte(i), 1, 2, 3}
+	}
+	ctx.ExtSockOut[11].Ring.Write(pkts, true)
+	buf := make([]byte, 64)
+	for i := range pkts {
+		n, meta, err := neigh.Read(buf)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{byte(i), 1, 2, 3}, buf[:n])
+		assert.Equal(t, intf.Local.String(), meta.Src.String())
+	}
+	closeAllSocks(ctx)
+	checkAllReturned(len(pkts))
+}
+
+func TestMemBackendInput(t *testing.T) {
+	r := initTestRouter(1)
+	r.memNet = memio.NewNetwork()
+	local := &net.UDPAddr{IP: net.IP{127, 0, 0, 11}, Port: 50011}
+	remote := &net.UDPAddr{IP: net.IP{127, 0, 0, 110}, Port: 50110}
+	c, err := newMemConn(r, local, remote)
+	require.NoError(t, err)
+	neigh, err := r.memNet.Listen(remote, local)
+	require.NoError(t, err)
+	defer neigh.Close()
+	sock := rctx.NewSock(ringbuf.New(inputBufCnt, nil, "ext_in_11"), c, connBackend{},
+		rcmn.DirExternal, 11, "1-ff00:0:110", r.ioInput, nil, MemSock)
+	sock.Start()
+	defer sock.Stop()
+
+	_, err = neigh.Write([]byte{1, 2, 3})
+	require.NoError(t, err)
+	pkts := make(ringbuf.EntryList, 1)
+	n, _ := sock.Ring.Read(pkts, true)
+	require.Equal(t, 1, n)
+	rp := pkts[0].(*rpkt.RtrPkt)
+	assert.Equal(t, []byte{1, 2, 3}, []byte(rp.Raw))
+	assert.Equal(t, rcmn.DirExternal, rp.DirFrom)
+	assert.Equal(t, common.IFIDType(11), rp.Ingress.IfID)
+	assert.Equal(t, remote.String(), rp.Ingress.Src.String())
+	assert.Equal(t, local.String(), rp.Ingress.Dst.String())
+	rp.Release()
+}
+
 func testSuccessfulWrite(done chan<- struct{}) func(conn.Messages) (int, error) {
 	return func(msgs conn.Messages) (int, error) {
 		for i, msg := range msgs {
@@ -184,8 +244,8 @@ func newTestDst(t *testing.T) *net.UDPAddr {
 }
 
 func newTestSock(r *Router, ringSize int, mconn conn.Conn) *rctx.Sock {
-	return rctx.NewSock(ringbuf.New(ringSize, nil, "loc_out"), mconn, 0, 12, prom.LabelNeighIA,
-		nil, r.posixOutput, PosixSock)
+	return rctx.NewSock(ringbuf.New(ringSize, nil, "loc_out"), mconn, connBackend{}, 0, 12,
+		prom.LabelNeighIA, nil, r.ioOutput, PosixSock)
 }
 
 type tempTestErr struct{}
@@ -31,6 +31,7 @@ import (
 	"github.com/BurntSushi/toml"
 
 	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/capture"
 	"github.com/scionproto/scion/go/border/ifstate"
 	"github.com/scionproto/scion/go/lib/assert"
 	"github.com/scionproto/scion/go/lib/common"
@@ -73,6 +74,8 @@ func realMain() int {
 	http.HandleFunc("/info", env.InfoHandler)
 	http.HandleFunc("/status", statusHandler)
 	http.HandleFunc("/topology", itopo.TopologyHandler)
+	http.HandleFunc("/capture", capture.Handler)
+	http.HandleFunc("/drain", drainHandler)
 	if err := setup(); err != nil {
 		log.Crit("Setup failed", "err", err)
 		return 1
@@ -0,0 +1,337 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package memio implements an in-memory packet I/O backend for the border
+// router.
+//
+// Connections are created on a Network and exchange packets through
+// in-process queues instead of UDP sockets. A connection delivers a packet to
+// the connection on the same network that is bound to the destination
+// address. Packets to unbound addresses are silently dropped, as is the case
+// for UDP. This allows driving a full router from Go tests without binding
+// real sockets.
+package memio
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/net/ipv4"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/overlay/conn"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// QueueSize is the number of packets a connection can buffer before it starts
+// dropping incoming packets.
+const QueueSize = 1024
+
+var (
+	// ErrClosed indicates that the connection is closed.
+	ErrClosed = serrors.New("connection closed")
+	// ErrAddrInUse indicates that the listen address is already bound.
+	ErrAddrInUse = serrors.New("address already in use")
+)
+
+var _ conn.Conn = (*Conn)(nil)
+
+// Network is an in-memory network that connections are bound to.
+type Network struct {
+	mtx   sync.Mutex
+	conns map[string]*Conn
+}
+
+// NewNetwork creates a new empty in-memory network.
+func NewNetwork() *Network {
+	return &Network{conns: make(map[string]*Conn)}
+}
+
+// Listen creates a connection bound to the listen address. If remote is not
+// nil, the connection is connected to the remote address, i.e., all written
+// packets are sent to remote and the source of all read packets is reported
+// as remote.
+func (n *Network) Listen(listen, remote *net.UDPAddr) (*Conn, error) {
+	if listen == nil {
+		return nil, serrors.New("listen address must be specified")
+	}
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	key := listen.String()
+	if _, ok := n.conns[key]; ok {
+		return nil, serrors.WithCtx(ErrAddrInUse, "listen", listen)
+	}
+	c := &Conn{
+		network: n,
+		local:   copyAddr(listen),
+		remote:  copyAddr(remote),
+		queue:   make(chan packet, QueueSize),
+		closed:  make(chan struct{}),
+		wake:    make(chan struct{}, 1),
+	}
+	n.conns[key] = c
+	return c, nil
+}
+
+func (n *Network) lookup(a *net.UDPAddr) *Conn {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	return n.conns[a.String()]
+}
+
+func (n *Network) remove(c *Conn) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	if n.conns[c.local.String()] == c {
+		delete(n.conns, c.local.String())
+	}
+}
+
+type packet struct {
+	raw   common.RawBytes
+	src   *net.UDPAddr
+	recvd time.Time
+}
+
+// Conn is an in-memory connection. It implements conn.Conn.
+type Conn struct {
+	network *Network
+	local   *net.UDPAddr
+	remote  *net.UDPAddr
+	queue   chan packet
+	// closed is closed when the connection is closed.
+	closed    chan struct{}
+	closeOnce sync.Once
+	// wake is signaled whenever the read deadline changes, such that blocked
+	// readers can reevaluate it.
+	wake chan struct{}
+
+	mtx          sync.Mutex
+	readDeadline time.Time
+	rcvOvfl      uint32
+}
+
+// Read reads a single packet.
+func (c *Conn) Read(b common.RawBytes) (int, *conn.ReadMeta, error) {
+	msgs := conn.NewReadMessages(1)
+	msgs[0].Buffers[0] = b
+	metas := make([]conn.ReadMeta, 1)
+	if _, err := c.ReadBatch(msgs, metas); err != nil {
+		return 0, nil, err
+	}
+	return msgs[0].N, &metas[0], nil
+}
+
+// ReadBatch blocks until at least one packet is available, and then reads up
+// to len(msgs) packets. The corresponding read metadata is stored in metas.
+func (c *Conn) ReadBatch(msgs conn.Messages, metas []conn.ReadMeta) (int, error) {
+	if len(msgs) == 0 {
+		return 0, nil
+	}
+	pkt, err := c.waitPacket()
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for {
+		c.fill(&msgs[n], &metas[n], pkt)
+		n++
+		if n == len(msgs) {
+			return n, nil
+		}
+		select {
+		case pkt = <-c.queue:
+		default:
+			return n, nil
+		}
+	}
+}
+
+func (c *Conn) waitPacket() (packet, error) {
+	for {
+		select {
+		case <-c.closed:
+			return packet{}, ErrClosed
+		default:
+		}
+		var timer *time.Timer
+		var timeout <-chan time.Time
+		if deadline := c.deadline(); !deadline.IsZero() {
+			d := time.Until(deadline)
+			if d <= 0 {
+				return packet{}, timeoutError{}
+			}
+			timer = time.NewTimer(d)
+			timeout = timer.C
+		}
+		pkt, done, err := c.selectPacket(timeout)
+		if timer != nil {
+			timer.Stop()
+		}
+		if done {
+			return pkt, err
+		}
+	}
+}
+
+// selectPacket blocks until a packet is available, the connection is closed,
+// the timeout fires or the deadline changes. In the latter case, the last
+// boolean return value is false.
+func (c *Conn) selectPacket(timeout <-chan time.Time) (packet, bool, error) {
+	select {
+	case pkt := <-c.queue:
+		return pkt, true, nil
+	case <-c.closed:
+		return packet{}, true, ErrClosed
+	case <-timeout:
+		return packet{}, true, timeoutError{}
+	case <-c.wake:
+		return packet{}, false, nil
+	}
+}
+
+func (c *Conn) fill(msg *ipv4.Message, meta *conn.ReadMeta, pkt packet) {
+	msg.N = copy(msg.Buffers[0], pkt.raw)
+	msg.Addr = pkt.src
+	now := time.Now()
+	c.mtx.Lock()
+	meta.RcvOvfl = c.rcvOvfl
+	c.mtx.Unlock()
+	meta.Src = pkt.src
+	if c.remote != nil {
+		meta.Src = c.remote
+	}
+	meta.Local = c.local
+	meta.Recvd = pkt.recvd
+	meta.ReadDelay = now.Sub(pkt.recvd)
+}
+
+// Write writes the packet to the remote address of a connected connection.
+func (c *Conn) Write(b common.RawBytes) (int, error) {
+	if c.remote == nil {
+		return 0, serrors.New("connection is not connected")
+	}
+	return c.WriteTo(b, c.remote)
+}
+
+// WriteTo writes the packet to dst.
+func (c *Conn) WriteTo(b common.RawBytes, dst *net.UDPAddr) (int, error) {
+	select {
+	case <-c.closed:
+		return 0, ErrClosed
+	default:
+	}
+	c.deliver(b, dst)
+	return len(b), nil
+}
+
+// WriteBatch writes all msgs. For connected connections, the destination
+// address of the messages is ignored.
+func (c *Conn) WriteBatch(msgs conn.Messages) (int, error) {
+	select {
+	case <-c.closed:
+		return 0, ErrClosed
+	default:
+	}
+	for i := range msgs {
+		dst := c.remote
+		if dst == nil {
+			dst = msgs[i].Addr.(*net.UDPAddr)
+		}
+		c.deliver(msgs[i].Buffers[0], dst)
+		msgs[i].N = len(msgs[i].Buffers[0])
+	}
+	return len(msgs), nil
+}
+
+func (c *Conn) deliver(b common.RawBytes, dst *net.UDPAddr) {
+	peer := c.network.lookup(dst)
+	if peer == nil {
+		return
+	}
+	pkt := packet{
+		raw:   append(common.RawBytes(nil), b...),
+		src:   c.local,
+		recvd: time.Now(),
+	}
+	select {
+	case peer.queue <- pkt:
+	default:
+		peer.mtx.Lock()
+		peer.rcvOvfl++
+		peer.mtx.Unlock()
+	}
+}
+
+func (c *Conn) LocalAddr() *net.UDPAddr {
+	return c.local
+}
+
+func (c *Conn) RemoteAddr() *net.UDPAddr {
+	return c.remote
+}
+
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	c.mtx.Lock()
+	c.readDeadline = t
+	c.mtx.Unlock()
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// SetWriteDeadline is a no-op, writes never block.
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+func (c *Conn) SetDeadline(t time.Time) error {
+	return c.SetReadDeadline(t)
+}
+
+// Close closes the connection and unbinds its listen address. Blocked reads
+// return with ErrClosed.
+func (c *Conn) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.network.remove(c)
+	})
+	return nil
+}
+
+func (c *Conn) deadline() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.readDeadline
+}
+
+func copyAddr(a *net.UDPAddr) *net.UDPAddr {
+	if a == nil {
+		return nil
+	}
+	return &net.UDPAddr{
+		IP:   append(net.IP(nil), a.IP...),
+		Port: a.Port,
+		Zone: a.Zone,
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
@@ -0,0 +1,161 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memio
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/overlay/conn"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var (
+	addrA = &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 40000}
+	addrB = &net.UDPAddr{IP: net.IP{127, 0, 0, 2}, Port: 40000}
+	addrC = &net.UDPAddr{IP: net.IP{127, 0, 0, 3}, Port: 40000}
+)
+
+func TestListen(t *testing.T) {
+	n := NewNetwork()
+	c, err := n.Listen(addrA, nil)
+	require.NoError(t, err)
+	_, err = n.Listen(addrA, nil)
+	assert.True(t, errors.Is(err, ErrAddrInUse))
+	require.NoError(t, c.Close())
+	_, err = n.Listen(addrA, nil)
+	assert.NoError(t, err, "address must be free after close")
+}
+
+func TestConnectedBatch(t *testing.T) {
+	n := NewNetwork()
+	a, err := n.Listen(addrA, addrB)
+	require.NoError(t, err)
+	b, err := n.Listen(addrB, addrA)
+	require.NoError(t, err)
+
+	out := conn.NewWriteMessages(3)
+	for i := range out {
+		out[i].Buffers[0] = []byte{byte(i), 1, 2, 3}
+	}
+	written, err := a.WriteBatch(out)
+	require.NoError(t, err)
+	assert.Equal(t, 3, written)
+
+	in := newReadMessages(4)
+	metas := make([]conn.ReadMeta, 4)
+	read, err := b.ReadBatch(in, metas)
+	require.NoError(t, err)
+	require.Equal(t, 3, read)
+	for i := 0; i < read; i++ {
+		assert.Equal(t, []byte{byte(i), 1, 2, 3}, in[i].Buffers[0][:in[i].N])
+		assert.Equal(t, addrA.String(), metas[i].Src.String())
+		assert.Equal(t, addrB.String(), metas[i].Local.String())
+	}
+}
+
+func TestUnconnectedWrite(t *testing.T) {
+	n := NewNetwork()
+	a, err := n.Listen(addrA, nil)
+	require.NoError(t, err)
+	b, err := n.Listen(addrB, nil)
+	require.NoError(t, err)
+
+	out := conn.NewWriteMessages(2)
+	out[0].Buffers[0] = []byte{1}
+	out[0].Addr.(*net.UDPAddr).IP = addrB.IP
+	out[0].Addr.(*net.UDPAddr).Port = addrB.Port
+	// Packets to unbound addresses are dropped.
+	out[1].Buffers[0] = []byte{2}
+	out[1].Addr.(*net.UDPAddr).IP = addrC.IP
+	out[1].Addr.(*net.UDPAddr).Port = addrC.Port
+	written, err := a.WriteBatch(out)
+	require.NoError(t, err)
+	assert.Equal(t, 2, written)
+
+	buf := make([]byte, 16)
+	read, meta, err := b.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1}, buf[:read])
+	assert.Equal(t, addrA.String(), meta.Src.String())
+}
+
+func TestReadDeadline(t *testing.T) {
+	n := NewNetwork()
+	c, err := n.Listen(addrA, nil)
+	require.NoError(t, err)
+	done := make(chan error)
+	go func() {
+		_, err := c.ReadBatch(newReadMessages(1), make([]conn.ReadMeta, 1))
+		done <- err
+	}()
+	// The reader blocks until the deadline is set.
+	require.NoError(t, c.SetReadDeadline(time.Now()))
+	select {
+	case err := <-done:
+		assert.True(t, serrors.IsTimeout(err))
+	case <-time.After(time.Second):
+		t.Fatal("Reader not unblocked by deadline")
+	}
+}
+
+func TestClose(t *testing.T) {
+	n := NewNetwork()
+	c, err := n.Listen(addrA, addrB)
+	require.NoError(t, err)
+	done := make(chan error)
+	go func() {
+		_, err := c.ReadBatch(newReadMessages(1), make([]conn.ReadMeta, 1))
+		done <- err
+	}()
+	require.NoError(t, c.Close())
+	select {
+	case err := <-done:
+		assert.True(t, errors.Is(err, ErrClosed))
+	case <-time.After(time.Second):
+		t.Fatal("Reader not unblocked by close")
+	}
+	_, err = c.Write([]byte{1})
+	assert.True(t, errors.Is(err, ErrClosed))
+}
+
+func TestQueueOverflow(t *testing.T) {
+	n := NewNetwork()
+	a, err := n.Listen(addrA, addrB)
+	require.NoError(t, err)
+	b, err := n.Listen(addrB, addrA)
+	require.NoError(t, err)
+	for i := 0; i < QueueSize+2; i++ {
+		_, err := a.Write([]byte{1})
+		require.NoError(t, err)
+	}
+	metas := make([]conn.ReadMeta, 1)
+	_, err = b.ReadBatch(newReadMessages(1), metas)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), metas[0].RcvOvfl)
+}
+
+func newReadMessages(n int) conn.Messages {
+	msgs := conn.NewReadMessages(n)
+	for i := range msgs {
+		msgs[i].Buffers[0] = make([]byte, 64)
+	}
+	return msgs
+}
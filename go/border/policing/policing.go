@@ -0,0 +1,212 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policing implements ingress policing for the border router.
+//
+// Traffic entering the router on an external interface can be limited per
+// interface, and optionally per source ISD-AS on that interface. Each limit is
+// enforced with a token bucket that is refilled at a configured rate (in bytes
+// per second) up to a configured burst size (in bytes). A packet is only
+// forwarded if it conforms to all the buckets that apply to it.
+//
+// The configuration is loaded from an optional JSON file, e.g.:
+//
+//   {
+//     "Interfaces": {
+//       "1": {
+//         "Rate": 12500000,
+//         "Burst": 125000,
+//         "SrcIAs": {
+//           "1-ff00:0:110": {"Rate": 1250000, "Burst": 12500}
+//         }
+//       }
+//     }
+//   }
+package policing
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/tokenbucket"
+)
+
+// Config is the ingress policing configuration.
+type Config struct {
+	// Interfaces maps external interfaces to their policing configuration.
+	// Interfaces that are not present are not policed.
+	Interfaces map[common.IFIDType]IntfConfig
+}
+
+// IntfConfig is the policing configuration of an external interface.
+type IntfConfig struct {
+	// Limit is the limit for all traffic entering on the interface. If unset,
+	// only the per source ISD-AS limits are enforced.
+	Limit
+	// SrcIAs holds limits for traffic with a given source ISD-AS entering on
+	// the interface.
+	SrcIAs map[addr.IA]Limit
+}
+
+// Limit is the configuration of a token bucket.
+type Limit struct {
+	// Rate is the refill rate in bytes per second.
+	Rate uint64
+	// Burst is the bucket size in bytes.
+	Burst uint64
+}
+
+// IsSet returns whether the limit is set.
+func (l Limit) IsSet() bool {
+	return l.Rate != 0 || l.Burst != 0
+}
+
+// Validate checks that the limit is well-formed.
+func (l Limit) Validate() error {
+	if l.IsSet() && (l.Rate == 0 || l.Burst == 0) {
+		return serrors.New("rate and burst must both be set", "rate", l.Rate, "burst", l.Burst)
+	}
+	return nil
+}
+
+// Validate checks that the configuration is well-formed.
+func (cfg *Config) Validate() error {
+	for ifid, intf := range cfg.Interfaces {
+		if err := intf.Limit.Validate(); err != nil {
+			return serrors.WrapStr("invalid interface limit", err, "ifid", ifid)
+		}
+		for ia, l := range intf.SrcIAs {
+			if err := l.Validate(); err != nil {
+				return serrors.WrapStr("invalid source IA limit", err, "ifid", ifid, "ia", ia)
+			}
+		}
+	}
+	return nil
+}
+
+// LoadConfig loads the configuration from the given file. A missing file
+// results in an empty configuration, i.e., no policing.
+func LoadConfig(file string) (Config, error) {
+	var cfg Config
+	raw, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, serrors.WrapStr("unable to read policing config", err, "file", file)
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return cfg, serrors.WrapStr("unable to parse policing config", err, "file", file)
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, serrors.WrapStr("invalid policing config", err, "file", file)
+	}
+	return cfg, nil
+}
+
+// Policers holds the token buckets for all policed interfaces. It is safe for
+// concurrent use.
+type Policers struct {
+	intfs map[common.IFIDType]*intfPolicer
+}
+
+type intfPolicer struct {
+	cfg    IntfConfig
+	intf   *tokenbucket.Bucket
+	srcIAs map[addr.IA]*tokenbucket.Bucket
+}
+
+// New creates the policers for the given configuration.
+func New(cfg Config) *Policers {
+	var p *Policers
+	return p.Update(cfg)
+}
+
+// Update creates the policers for the given configuration. Buckets whose limit
+// is unchanged are taken over from p, such that a reload does not reset their
+// state. The receiver is not modified and may be nil.
+func (p *Policers) Update(cfg Config) *Policers {
+	updated := &Policers{intfs: make(map[common.IFIDType]*intfPolicer, len(cfg.Interfaces))}
+	for ifid, intfCfg := range cfg.Interfaces {
+		var old *intfPolicer
+		if p != nil {
+			old = p.intfs[ifid]
+		}
+		ip := &intfPolicer{
+			cfg:    intfCfg,
+			srcIAs: make(map[addr.IA]*tokenbucket.Bucket, len(intfCfg.SrcIAs)),
+		}
+		if old != nil && old.cfg.Limit == intfCfg.Limit {
+			ip.intf = old.intf
+		} else {
+			ip.intf = newBucket(intfCfg.Limit)
+		}
+		for ia, l := range intfCfg.SrcIAs {
+			if old != nil && old.srcIAs[ia] != nil && old.cfg.SrcIAs[ia] == l {
+				ip.srcIAs[ia] = old.srcIAs[ia]
+				continue
+			}
+			ip.srcIAs[ia] = newBucket(l)
+		}
+		updated.intfs[ifid] = ip
+	}
+	return updated
+}
+
+// Allow returns whether a packet of the given size entering on interface
+// ifid from source srcIA conforms to the configured limits. Tokens are only
+// consumed if the packet conforms to all limits.
+func (p *Policers) Allow(ifid common.IFIDType, srcIA addr.IA, size int, now time.Time) bool {
+	if p == nil {
+		return true
+	}
+	ip, ok := p.intfs[ifid]
+	if !ok {
+		return true
+	}
+	n := float64(size)
+	srcBucket := ip.srcIAs[srcIA]
+	if srcBucket != nil && !srcBucket.Allow(now, n) {
+		return false
+	}
+	if ip.intf != nil && !ip.intf.Allow(now, n) {
+		// The packet is dropped, it must not be charged to the source IA.
+		if srcBucket != nil {
+			srcBucket.Refund(n)
+		}
+		return false
+	}
+	return true
+}
+
+// Policed returns whether traffic entering on interface ifid is policed.
+func (p *Policers) Policed(ifid common.IFIDType) bool {
+	if p == nil {
+		return false
+	}
+	_, ok := p.intfs[ifid]
+	return ok
+}
+
+func newBucket(l Limit) *tokenbucket.Bucket {
+	if !l.IsSet() {
+		return nil
+	}
+	return tokenbucket.New(float64(l.Rate), float64(l.Burst))
+}
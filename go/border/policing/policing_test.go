@@ -0,0 +1,165 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/policing"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var (
+	ia110 = xtest.MustParseIA("1-ff00:0:110")
+	ia120 = xtest.MustParseIA("1-ff00:0:120")
+	ia130 = xtest.MustParseIA("1-ff00:0:130")
+)
+
+func TestLoadConfig(t *testing.T) {
+	cfg, err := policing.LoadConfig("testdata/policing.json")
+	require.NoError(t, err)
+	expected := policing.Config{
+		Interfaces: map[common.IFIDType]policing.IntfConfig{
+			1: {
+				Limit: policing.Limit{Rate: 1000, Burst: 3000},
+				SrcIAs: map[addr.IA]policing.Limit{
+					ia110: {Rate: 100, Burst: 1000},
+				},
+			},
+			2: {
+				SrcIAs: map[addr.IA]policing.Limit{
+					ia120: {Rate: 100, Burst: 1000},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, cfg)
+
+	cfg, err = policing.LoadConfig("testdata/nonexisting.json")
+	assert.NoError(t, err)
+	assert.Empty(t, cfg.Interfaces)
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := policing.Config{
+		Interfaces: map[common.IFIDType]policing.IntfConfig{
+			1: {Limit: policing.Limit{Rate: 1000}},
+		},
+	}
+	assert.Error(t, cfg.Validate())
+	cfg = policing.Config{
+		Interfaces: map[common.IFIDType]policing.IntfConfig{
+			1: {SrcIAs: map[addr.IA]policing.Limit{ia110: {Burst: 1000}}},
+		},
+	}
+	assert.Error(t, cfg.Validate())
+}
+
+func TestPolicersAllow(t *testing.T) {
+	cfg, err := policing.LoadConfig("testdata/policing.json")
+	require.NoError(t, err)
+	p := policing.New(cfg)
+	now := time.Now()
+
+	t.Run("unpoliced interface", func(t *testing.T) {
+		assert.False(t, p.Policed(3))
+		assert.True(t, p.Allow(3, ia110, 1<<20, now))
+	})
+	t.Run("source IA limit", func(t *testing.T) {
+		assert.True(t, p.Allow(1, ia110, 1000, now))
+		assert.False(t, p.Allow(1, ia110, 1, now))
+		// Other source IAs are only limited by the interface.
+		assert.True(t, p.Allow(1, ia130, 2000, now))
+	})
+	t.Run("interface limit", func(t *testing.T) {
+		assert.False(t, p.Allow(1, ia130, 1, now))
+		assert.True(t, p.Allow(1, ia130, 1000, now.Add(time.Second)))
+	})
+	t.Run("only source IA limit", func(t *testing.T) {
+		assert.True(t, p.Policed(2))
+		assert.True(t, p.Allow(2, ia130, 1<<20, now))
+		assert.True(t, p.Allow(2, ia120, 1000, now))
+		assert.False(t, p.Allow(2, ia120, 1, now))
+	})
+	t.Run("nil policers", func(t *testing.T) {
+		var p *policing.Policers
+		assert.True(t, p.Allow(1, ia110, 1<<20, now))
+	})
+}
+
+func TestPolicersAllowNoChargeOnDrop(t *testing.T) {
+	cfg := policing.Config{
+		Interfaces: map[common.IFIDType]policing.IntfConfig{
+			1: {
+				Limit:  policing.Limit{Rate: 100, Burst: 1000},
+				SrcIAs: map[addr.IA]policing.Limit{ia110: {Rate: 1, Burst: 1000}},
+			},
+		},
+	}
+	p := policing.New(cfg)
+	now := time.Now()
+	// Exhaust the interface bucket with traffic from another source.
+	require.True(t, p.Allow(1, ia130, 1000, now))
+	// The packet is dropped by the interface bucket and must not be charged
+	// to the source IA.
+	assert.False(t, p.Allow(1, ia110, 500, now))
+	now = now.Add(10 * time.Second)
+	assert.True(t, p.Allow(1, ia110, 1000, now))
+}
+
+func TestPolicersUpdate(t *testing.T) {
+	cfg := policing.Config{
+		Interfaces: map[common.IFIDType]policing.IntfConfig{
+			1: {Limit: policing.Limit{Rate: 100, Burst: 1000}},
+			2: {Limit: policing.Limit{Rate: 100, Burst: 1000}},
+			3: {SrcIAs: map[addr.IA]policing.Limit{
+				ia110: {Rate: 100, Burst: 1000},
+				ia120: {Rate: 100, Burst: 1000},
+			}},
+		},
+	}
+	p := policing.New(cfg)
+	now := time.Now()
+	require.True(t, p.Allow(1, ia110, 1000, now))
+	require.True(t, p.Allow(2, ia110, 1000, now))
+	require.True(t, p.Allow(3, ia110, 1000, now))
+	require.True(t, p.Allow(3, ia120, 1000, now))
+
+	updated := p.Update(policing.Config{
+		Interfaces: map[common.IFIDType]policing.IntfConfig{
+			1: {Limit: policing.Limit{Rate: 100, Burst: 1000}},
+			2: {Limit: policing.Limit{Rate: 100, Burst: 2000}},
+			3: {SrcIAs: map[addr.IA]policing.Limit{
+				ia110: {Rate: 100, Burst: 1000},
+				ia120: {Rate: 200, Burst: 1000},
+			}},
+		},
+	})
+	// Unchanged limits keep their state.
+	assert.False(t, updated.Allow(1, ia110, 1, now))
+	assert.False(t, updated.Allow(3, ia110, 1, now))
+	// Changed limits start with a full bucket.
+	assert.True(t, updated.Allow(2, ia110, 2000, now))
+	assert.True(t, updated.Allow(3, ia120, 1000, now))
+	// Removed interfaces are no longer policed.
+	updated = updated.Update(policing.Config{})
+	assert.False(t, updated.Policed(1))
+}
@@ -0,0 +1,192 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rcmn
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/scionproto/scion/go/lib/scmp"
+)
+
+// DropReason is the reason a packet is dropped by the router. The set of
+// reasons is closed, such that it can be used as a metrics label.
+type DropReason uint8
+
+const (
+	// DropUnknown is used if no more specific reason is known.
+	DropUnknown DropReason = iota
+	// DropParse means the packet could not be parsed.
+	DropParse
+	// DropPoliced means the packet exceeded the ingress policing limits.
+	DropPoliced
+	// DropReservationExceeded means the packet exceeded the bandwidth of its
+	// COLIBRI reservation.
+	DropReservationExceeded
+	// DropBadVersion means the SCION version is not supported.
+	DropBadVersion
+	// DropBadAddrType means the source or destination address type is
+	// invalid.
+	DropBadAddrType
+	// DropMTUExceeded means the packet is larger than the MTU.
+	DropMTUExceeded
+	// DropBadPathOffset means the info or hop field offset is invalid.
+	DropBadPathOffset
+	// DropPathRequired means the packet has no path, but requires one.
+	DropPathRequired
+	// DropBadSegment means the path segment is invalid.
+	DropBadSegment
+	// DropBadHopField means the current hop field is invalid.
+	DropBadHopField
+	// DropNonRoutingHopF means the current hop field is verify-only.
+	DropNonRoutingHopF
+	// DropBadMAC means the MAC of the current hop field is invalid.
+	DropBadMAC
+	// DropExpiredHopF means the current hop field has expired.
+	DropExpiredHopF
+	// DropUnknownIF means the interface is not known to the local AS.
+	DropUnknownIF
+	// DropRevokedIF means the interface is revoked.
+	DropRevokedIF
+	// DropLinkDown means the link of the interface has been detected as down.
+	DropLinkDown
+	// DropDrained means the interface is drained for maintenance.
+	DropDrained
+	// DropBadExtension means an extension header is invalid.
+	DropBadExtension
+	// DropBadSVC means the SVC destination address can not be resolved.
+	DropBadSVC
+	// DropInvalid means the packet failed validation for other reasons.
+	DropInvalid
+	// DropBadPayload means the payload of a packet addressed to the router
+	// could not be parsed.
+	DropBadPayload
+	// DropProcess means processing of the packet failed for other reasons.
+	DropProcess
+	// DropNoRoute means no route for the packet was found.
+	DropNoRoute
+	// NumDropReasons is the number of drop reasons.
+	NumDropReasons
+)
+
+var dropReasonNames = [NumDropReasons]string{
+	DropUnknown:             "unknown",
+	DropParse:               "parse_error",
+	DropPoliced:             "policed",
+	DropReservationExceeded: "reservation_exceeded",
+	DropBadVersion:          "bad_version",
+	DropBadAddrType:         "bad_addr_type",
+	DropMTUExceeded:         "mtu_exceeded",
+	DropBadPathOffset:       "bad_path_offset",
+	DropPathRequired:        "path_required",
+	DropBadSegment:          "bad_segment",
+	DropBadHopField:         "bad_hop_field",
+	DropNonRoutingHopF:      "non_routing_hop_field",
+	DropBadMAC:              "bad_mac",
+	DropExpiredHopF:         "expired_hop_field",
+	DropUnknownIF:           "unknown_intf",
+	DropRevokedIF:           "revoked_intf",
+	DropLinkDown:            "link_down",
+	DropDrained:             "drained_intf",
+	DropBadExtension:        "bad_extension",
+	DropBadSVC:              "bad_svc",
+	DropInvalid:             "invalid",
+	DropBadPayload:          "bad_payload",
+	DropProcess:             "process_error",
+	DropNoRoute:             "no_route",
+}
+
+// String returns the metrics label of the drop reason.
+func (r DropReason) String() string {
+	if r < NumDropReasons {
+		return dropReasonNames[r]
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", uint8(r))
+}
+
+type dropError struct {
+	reason DropReason
+	err    error
+}
+
+func (e *dropError) Error() string {
+	return e.err.Error()
+}
+
+func (e *dropError) Unwrap() error {
+	return e.err
+}
+
+// WithDropReason annotates err with the reason for dropping the packet. This
+// is only necessary for errors that do not carry SCMP error information,
+// which is mapped to a drop reason automatically.
+func WithDropReason(err error, reason DropReason) error {
+	return &dropError{reason: reason, err: err}
+}
+
+// DropReasonOf returns the drop reason for err. An explicit annotation (see
+// WithDropReason) takes precedence over the reason derived from the SCMP
+// error information. If neither is present, fallback is returned.
+func DropReasonOf(err error, fallback DropReason) DropReason {
+	var derr *dropError
+	if errors.As(err, &derr) {
+		return derr.reason
+	}
+	var serr *scmp.Error
+	if errors.As(err, &serr) {
+		if reason, ok := scmpDropReason(serr.CT); ok {
+			return reason
+		}
+	}
+	return fallback
+}
+
+func scmpDropReason(ct scmp.ClassType) (DropReason, bool) {
+	switch ct.Class {
+	case scmp.C_CmnHdr:
+		switch ct.Type {
+		case scmp.T_C_BadVersion:
+			return DropBadVersion, true
+		case scmp.T_C_BadDstType, scmp.T_C_BadSrcType:
+			return DropBadAddrType, true
+		case scmp.T_C_BadPktLen:
+			return DropMTUExceeded, true
+		case scmp.T_C_BadInfoFOffset, scmp.T_C_BadHopFOffset:
+			return DropBadPathOffset, true
+		}
+	case scmp.C_Path:
+		switch ct.Type {
+		case scmp.T_P_PathRequired:
+			return DropPathRequired, true
+		case scmp.T_P_BadMac:
+			return DropBadMAC, true
+		case scmp.T_P_ExpiredHopF:
+			return DropExpiredHopF, true
+		case scmp.T_P_BadIF:
+			return DropUnknownIF, true
+		case scmp.T_P_RevokedIF:
+			return DropRevokedIF, true
+		case scmp.T_P_NonRoutingHopF:
+			return DropNonRoutingHopF, true
+		case scmp.T_P_BadSegment, scmp.T_P_BadInfoField:
+			return DropBadSegment, true
+		case scmp.T_P_BadHopField:
+			return DropBadHopField, true
+		}
+	case scmp.C_Ext:
+		return DropBadExtension, true
+	}
+	return DropUnknown, false
+}
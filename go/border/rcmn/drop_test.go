@@ -0,0 +1,81 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rcmn_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/border/rcmn"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+func TestDropReasonOf(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected rcmn.DropReason
+	}{
+		"plain error": {
+			err:      serrors.New("fail"),
+			expected: rcmn.DropProcess,
+		},
+		"scmp error": {
+			err: common.NewBasicError("Hop field expired",
+				scmp.NewError(scmp.C_Path, scmp.T_P_ExpiredHopF, nil, nil)),
+			expected: rcmn.DropExpiredHopF,
+		},
+		"wrapped scmp bad mac": {
+			err: serrors.WrapStr("verify", scmp.NewError(scmp.C_Path, scmp.T_P_BadMac, nil,
+				serrors.New("bad mac"))),
+			expected: rcmn.DropBadMAC,
+		},
+		"scmp extension error": {
+			err:      scmp.NewError(scmp.C_Ext, scmp.T_E_BadHopByHop, nil, nil),
+			expected: rcmn.DropBadExtension,
+		},
+		"unmapped scmp error": {
+			err:      scmp.NewError(scmp.C_Path, scmp.T_P_DeliveryNonLocal, nil, nil),
+			expected: rcmn.DropProcess,
+		},
+		"annotated error": {
+			err: common.NewBasicError("wrapped",
+				rcmn.WithDropReason(serrors.New("no svc"), rcmn.DropBadSVC)),
+			expected: rcmn.DropBadSVC,
+		},
+		"annotation takes precedence": {
+			err: rcmn.WithDropReason(scmp.NewError(scmp.C_Path, scmp.T_P_BadIF, nil, nil),
+				rcmn.DropLinkDown),
+			expected: rcmn.DropLinkDown,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, rcmn.DropReasonOf(test.err, rcmn.DropProcess))
+		})
+	}
+}
+
+func TestDropReasonString(t *testing.T) {
+	seen := make(map[string]bool)
+	for r := rcmn.DropUnknown; r < rcmn.NumDropReasons; r++ {
+		s := r.String()
+		assert.NotEmpty(t, s, "reason %d", r)
+		assert.False(t, seen[s], "duplicate label %s", s)
+		seen[s] = true
+	}
+}
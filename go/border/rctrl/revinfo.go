@@ -19,33 +19,101 @@ import (
 	"github.com/scionproto/scion/go/border/rctx"
 	"github.com/scionproto/scion/go/border/rpkt"
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
 )
 
+// revReqQ holds the interfaces for which the router requested a revocation.
+var revReqQ = make(chan common.IFIDType, 64)
+
+// RequestRevocation asks the local Beacon Services (BS) to issue a revocation
+// for the given interface, e.g., because its link was detected as down or the
+// interface is drained. The request is sent asynchronously by the revocation
+// forwarding goroutine. The BS issues the signed revocation and pushes it to
+// the border routers like any other revocation.
+func RequestRevocation(ifid common.IFIDType) error {
+	select {
+	case revReqQ <- ifid:
+		return nil
+	default:
+		return serrors.New("revocation request queue full", "ifid", ifid)
+	}
+}
+
 // RevInfoFwd takes RevInfos, and forwards them to the local Beacon Service
-// (BS) and Path Service (PS).
+// (BS) and Path Service (PS). It also sends the revocation requests of the
+// router to the BS.
 func revInfoFwd(revInfoQ chan rpkt.RawSRevCallbackArgs) {
-	cl := metrics.ControlLabels{}
 	// Run forever.
-	for args := range revInfoQ {
-		revInfo, err := args.SignedRevInfo.RevInfo()
-		if err != nil {
-			cl.Result = metrics.ErrParse
-			metrics.Control.ReadRevInfos(cl).Inc()
-			logger.Error("Error getting RevInfo from SignedRevInfo", "err", err)
-			continue
+	for {
+		select {
+		case args := <-revInfoQ:
+			fwdRevInfos(args)
+		case ifid := <-revReqQ:
+			if err := sendRevRequest(ifid); err != nil {
+				logger.Error("Unable to request revocation", "ifid", ifid, "err", err)
+			}
 		}
-		cl.Result = metrics.Success
+	}
+}
+
+// fwdRevInfos forwards the RevInfo to all designated local services.
+func fwdRevInfos(args rpkt.RawSRevCallbackArgs) {
+	cl := metrics.ControlLabels{}
+	revInfo, err := args.SignedRevInfo.RevInfo()
+	if err != nil {
+		cl.Result = metrics.ErrParse
 		metrics.Control.ReadRevInfos(cl).Inc()
-		logger.Debug("Forwarding revocation", "revInfo", revInfo.String(), "targets", args.Addrs)
-		for _, svcAddr := range args.Addrs {
-			fwdRevInfo(args.SignedRevInfo, svcAddr)
+		logger.Error("Error getting RevInfo from SignedRevInfo", "err", err)
+		return
+	}
+	cl.Result = metrics.Success
+	metrics.Control.ReadRevInfos(cl).Inc()
+	logger.Debug("Forwarding revocation", "revInfo", revInfo.String(), "targets", args.Addrs)
+	for _, svcAddr := range args.Addrs {
+		fwdRevInfo(args.SignedRevInfo, svcAddr)
+	}
+}
+
+// sendRevRequest asks the local Beacon Services to revoke the interface by
+// reporting it as inactive. The request is not signed, the Beacon Services
+// only accept it from the control address of a router in the topology.
+func sendRevRequest(ifid common.IFIDType) error {
+	infos := &path_mgmt.IFStateInfos{
+		Infos: []*path_mgmt.IFStateInfo{{IfID: ifid, Active: false}},
+	}
+	cpld, err := ctrl.NewPathMgmtPld(infos, nil, nil)
+	if err != nil {
+		return common.NewBasicError("Generating IFStateInfos Ctrl payload", err)
+	}
+	scpld, err := cpld.SignedPld(infra.NullSigner)
+	if err != nil {
+		return common.NewBasicError("Generating IFStateInfos signed Ctrl payload", err)
+	}
+	pld, err := scpld.PackPld()
+	if err != nil {
+		return common.NewBasicError("Writing IFStateInfos signed Ctrl payload", err)
+	}
+	bsAddrs, err := rctx.Get().ResolveSVCMulti(addr.SvcBS)
+	if err != nil {
+		return common.NewBasicError("Resolving SVC BS multicast", err)
+	}
+	var errors common.MultiError
+	for _, a := range bsAddrs {
+		dst := &snet.SVCAddr{IA: ia, NextHop: a, SVC: addr.SvcBS.Multicast()}
+		if _, err := snetConn.WriteTo(pld, dst); err != nil {
+			errors = append(errors, common.NewBasicError("Writing IFStateInfos", err,
+				"dst", dst))
+			continue
 		}
+		logger.Debug("Sent revocation request", "ifid", ifid, "dst", dst)
 	}
+	return errors.ToError()
 }
 
 // fwdRevInfo forwards RevInfo payloads to a designated local host.
@@ -27,6 +27,17 @@ import (
 	"github.com/scionproto/scion/go/lib/ringbuf"
 )
 
+// IOBackend performs the packet I/O of sockets. The batch operations read
+// from and write to the connection of the socket, Close releases it.
+type IOBackend interface {
+	// ReadBatch reads a batch of packets from the socket.
+	ReadBatch(s *Sock, msgs conn.Messages, metas []conn.ReadMeta) (int, error)
+	// WriteBatch writes a batch of packets to the socket.
+	WriteBatch(s *Sock, msgs conn.Messages) (int, error)
+	// Close closes the socket.
+	Close(s *Sock) error
+}
+
 // SockFunc is a function that is started as a goroutine by
 // Sock.Start()/Sock.Stop() to read/write to the underlying connection.
 type SockFunc func(sock *Sock, stop, stopped chan struct{})
@@ -39,6 +50,8 @@ type Sock struct {
 	Ring *ringbuf.Ring
 	// Conn is the underlying connection that this Sock represents.
 	Conn conn.Conn
+	// Backend is the I/O backend that reads from and writes to Conn.
+	Backend IOBackend
 	// Dir is the direction that a packet is being read from/written to.
 	Dir rcmn.Dir
 	// Ifid is the interface ID associated with a connection.
@@ -62,14 +75,16 @@ type Sock struct {
 	started       bool
 }
 
-func NewSock(ring *ringbuf.Ring, conn conn.Conn, dir rcmn.Dir, ifid common.IFIDType,
-	neighIA string, reader, writer SockFunc, sockType brconf.SockType) *Sock {
+func NewSock(ring *ringbuf.Ring, conn conn.Conn, backend IOBackend, dir rcmn.Dir,
+	ifid common.IFIDType, neighIA string, reader, writer SockFunc,
+	sockType brconf.SockType) *Sock {
 
 	log.Debug("New Socket", "dir", dir, "ifid", ifid, "neighIA", neighIA, "sockType", sockType)
 
 	s := &Sock{
 		Ring:    ring,
 		Conn:    conn,
+		Backend: backend,
 		Dir:     dir,
 		Ifid:    ifid,
 		Label:   metrics.IntfToLabel(ifid),
@@ -137,15 +152,15 @@ func (s *Sock) Stop() {
 		if s.Writer != nil {
 			<-s.writerStopped
 		}
-		// Close the posix sockets.
-		if err := s.Conn.Close(); err != nil {
+		// Close the underlying connection.
+		if err := s.Backend.Close(s); err != nil {
 			log.Error("Error stopping socket", "addr", s.Conn.LocalAddr(), "err", err)
 		}
 		s.running = false
 		log.Info("Sock routines stopped", "addr", s.Conn.LocalAddr())
 	} else if !s.started {
 		s.Ring.Close()
-		if err := s.Conn.Close(); err != nil {
+		if err := s.Backend.Close(s); err != nil {
 			log.Error("Error stopping socket", "addr", s.Conn.LocalAddr(), "err", err)
 		}
 		log.Info("Non-started sock stopped", "addr", s.Conn.LocalAddr())
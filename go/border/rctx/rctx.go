@@ -23,6 +23,7 @@ import (
 	"sync/atomic"
 
 	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/policing"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/scrypto"
@@ -34,6 +35,8 @@ type Ctx struct {
 	Conf *brconf.BRConf
 	// HFMacPool is the pool of Hop Field MAC generation instances.
 	HFMacPool *sync.Pool
+	// Policers enforce the ingress policing limits of the external interfaces.
+	Policers *policing.Policers
 	// LockSockIn is a Sock for receiving packets from the local AS,
 	LocSockIn *Sock
 	// LocSockOut is a Sock for sending packets to the local AS,
@@ -53,6 +56,7 @@ var ctx atomic.Value
 func New(conf *brconf.BRConf) *Ctx {
 	ctx := &Ctx{
 		Conf:       conf,
+		Policers:   policing.New(conf.Policing),
 		ExtSockOut: make(map[common.IFIDType]*Sock),
 		ExtSockIn:  make(map[common.IFIDType]*Sock),
 	}
@@ -19,13 +19,19 @@ package main
 
 import (
 	"sync"
+	"time"
 
+	"github.com/scionproto/scion/go/border/bfd"
 	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/capture"
+	"github.com/scionproto/scion/go/border/colibri"
 	"github.com/scionproto/scion/go/border/internal/metrics"
+	"github.com/scionproto/scion/go/border/memio"
 	"github.com/scionproto/scion/go/border/rcmn"
 	"github.com/scionproto/scion/go/border/rctrl"
 	"github.com/scionproto/scion/go/border/rctx"
 	"github.com/scionproto/scion/go/border/rpkt"
+	"github.com/scionproto/scion/go/border/scmplimit"
 	"github.com/scionproto/scion/go/lib/assert"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
@@ -49,10 +55,34 @@ type Router struct {
 	// setCtxMtx serializes modifications to the router context. Topology updates
 	// can be caused by a SIGHUP reload.
 	setCtxMtx sync.Mutex
+	// sockConf determines the I/O backend used for the router sockets.
+	sockConf brconf.SockConf
+	// memNet is the in-memory network that sockets of type MemSock are bound to.
+	memNet *memio.Network
+	// bfd holds the BFD sessions of the external interfaces.
+	bfd bfdSessions
+	// dropLog samples the log entries for dropped packets.
+	dropLog dropSampler
+	// workers is the pool of processing goroutines. If nil, packets are
+	// processed by one goroutine per input socket.
+	workers *workerPool
+	// scmpLimit limits the rate of SCMP errors originated by the router.
+	scmpLimit *scmplimit.Limiter
+	// drain holds the grace period timers of draining interfaces.
+	drain drainTimers
+	// colibri polices the traffic of COLIBRI reservations.
+	colibri *colibri.Policer
 }
 
 func NewRouter(id, confDir string) (*Router, error) {
-	r := &Router{Id: id, confDir: confDir}
+	r := &Router{Id: id, confDir: confDir, sockConf: brconf.SockConf{Default: cfg.BR.IOBackend}}
+	if _, ok := registeredLocSockOps[cfg.BR.IOBackend]; !ok {
+		return nil, common.NewBasicError("Unknown I/O backend", nil,
+			"io_backend", cfg.BR.IOBackend)
+	}
+	if cfg.BR.IOBackend == MemSock {
+		r.memNet = memio.NewNetwork()
+	}
 	if err := r.setup(); err != nil {
 		return nil, err
 	}
@@ -85,6 +115,20 @@ func (r *Router) ReloadConfig() error {
 	return nil
 }
 
+// sockProcessor returns the function that processes the packets in the ring
+// buffer of an input socket. If the router uses a worker pool, the input
+// routine hands the packets to the workers directly, and nil is returned for
+// external sockets. The ring of the local socket still receives the packets
+// that are reprocessed on their way from an external to another external
+// interface. They are processed by a dedicated goroutine, as the workers
+// enqueue them and must not wait for themselves.
+func (r *Router) sockProcessor(dir rcmn.Dir) rctx.SockFunc {
+	if r.workers != nil && dir != rcmn.DirLocal {
+		return nil
+	}
+	return r.handleSock
+}
+
 func (r *Router) handleSock(s *rctx.Sock, stop, stopped chan struct{}) {
 	defer log.HandlePanic()
 	defer close(stopped)
@@ -97,12 +141,18 @@ func (r *Router) handleSock(s *rctx.Sock, stop, stopped chan struct{}) {
 			log.Debug("handleSock stopping", "addr", dst)
 			return
 		}
-		for i := 0; i < n; i++ {
-			rp := pkts[i].(*rpkt.RtrPkt)
-			r.processPacket(rp)
-			rp.Release()
-			pkts[i] = nil
-		}
+		r.processBatch(pkts[:n])
+	}
+}
+
+// processBatch processes and releases the packets. The entries of pkts are
+// reset to nil.
+func (r *Router) processBatch(pkts ringbuf.EntryList) {
+	for i := range pkts {
+		rp := pkts[i].(*rpkt.RtrPkt)
+		r.processPacket(rp)
+		rp.Release()
+		pkts[i] = nil
 	}
 }
 
@@ -129,25 +179,55 @@ func (r *Router) processPacket(rp *rpkt.RtrPkt) {
 	rp.Logger = log.New("rpkt", rp.Id)
 	// XXX(kormat): uncomment for debugging:
 	//rp.Debug("processPacket", "raw", rp.Raw)
+	// BFD control packets share the underlay with SCION packets and are told
+	// apart by the version field.
+	if rp.DirFrom == rcmn.DirExternal && bfd.IsBFD(rp.Raw) {
+		r.handleBFD(rp)
+		return
+	}
+	if capture.Enabled() {
+		capture.Capture(capture.Input, rp.Ingress.IfID, rp.Raw, "")
+	}
 	if err := rp.Parse(); err != nil {
-		r.handlePktError(rp, err, "Error parsing packet")
+		r.handlePktError(rp, err)
 		l.Result = metrics.ErrParse
-		metrics.Process.Pkts(l).Inc()
+		r.dropPkt(rp, l, rcmn.DropParse, err)
+		return
+	}
+	// Enforce ingress policing before spending any more effort on the packet.
+	// Reservation traffic is exempt, but only once its reservation hop field
+	// is authenticated during validation.
+	reserved := rp.Reservation() != nil
+	if !reserved && !r.police(rp) {
+		l.Result = metrics.ErrPoliced
+		r.dropPkt(rp, l, rcmn.DropPoliced, nil)
 		return
 	}
 	// Validation looks for errors in the packet that didn't break basic
 	// parsing.
 	valid, err := rp.Validate()
+	// Invalid reservation traffic is charged to the regular ingress policing.
+	if reserved && (err != nil || !valid) && !r.police(rp) {
+		l.Result = metrics.ErrPoliced
+		r.dropPkt(rp, l, rcmn.DropPoliced, nil)
+		return
+	}
 	if err != nil {
-		r.handlePktError(rp, err, "Error validating packet")
+		r.handlePktError(rp, err)
 		l.Result = metrics.ErrValidate
-		metrics.Process.Pkts(l).Inc()
+		r.dropPkt(rp, l, rcmn.DropInvalid, err)
 		return
 	}
 	if !valid {
-		rp.Error("Error validating packet, no specific error")
 		l.Result = metrics.ErrValidate
-		metrics.Process.Pkts(l).Inc()
+		r.dropPkt(rp, l, rcmn.DropInvalid, nil)
+		return
+	}
+	// Reservation traffic is policed once the reservation hop field is
+	// authenticated.
+	if !r.policeReservation(rp) {
+		l.Result = metrics.ErrPoliced
+		r.dropPkt(rp, l, rcmn.DropReservationExceeded, nil)
 		return
 	}
 	// Check if the packet needs to be processed locally, and if so register hooks for doing so.
@@ -156,22 +236,58 @@ func (r *Router) processPacket(rp *rpkt.RtrPkt) {
 	if _, err := rp.Payload(true); err != nil {
 		// Any errors at this point are application-level, and hence not
 		// calling handlePktError, as no SCMP errors will be sent.
-		rp.Error("Error parsing payload", "err", err)
 		l.Result = metrics.ErrParsePayload
-		metrics.Process.Pkts(l).Inc()
+		r.dropPkt(rp, l, rcmn.DropBadPayload, err)
 		return
 	}
 	// Process the packet, if a previous step has registered a relevant hook for doing so.
 	if err := rp.Process(); err != nil {
-		r.handlePktError(rp, err, "Error processing packet")
+		r.handlePktError(rp, err)
 		l.Result = metrics.ErrProcess
-		metrics.Process.Pkts(l).Inc()
+		r.dropPkt(rp, l, rcmn.DropProcess, err)
 		return
 	}
 	// Forward the packet. Packets destined to self are forwarded to the local dispatcher.
 	if err := rp.Route(); err != nil {
-		r.handlePktError(rp, err, "Error routing packet")
+		r.handlePktError(rp, err)
 		l.Result = metrics.ErrRoute
-		metrics.Process.Pkts(l).Inc()
+		r.dropPkt(rp, l, rcmn.DropNoRoute, err)
+		return
+	}
+	if capture.Enabled() {
+		var ifid common.IFIDType
+		if len(rp.Egress) > 0 {
+			ifid = rp.Egress[0].S.Ifid
+		}
+		capture.Capture(capture.Output, ifid, rp.Raw, "")
+	}
+}
+
+// police returns whether a packet that entered on an external interface
+// conforms to the ingress policing limits of that interface. Packets without a
+// parsable source ISD-AS are left to validation.
+func (r *Router) police(rp *rpkt.RtrPkt) bool {
+	if rp.DirFrom != rcmn.DirExternal || !rp.Ctx.Policers.Policed(rp.Ingress.IfID) {
+		return true
+	}
+	srcIA, err := rp.SrcIA()
+	if err != nil {
+		return true
+	}
+	return rp.Ctx.Policers.Allow(rp.Ingress.IfID, srcIA, len(rp.Raw), time.Now())
+}
+
+// policeReservation returns whether a packet on a COLIBRI reservation conforms
+// to the reserved bandwidth. Packets are policed where they enter the AS,
+// i.e., on the ingress interface, or at the first hop if the reservation
+// starts in the local AS.
+func (r *Router) policeReservation(rp *rpkt.RtrPkt) bool {
+	res := rp.Reservation()
+	if res == nil {
+		return true
+	}
+	if rp.DirFrom != rcmn.DirExternal && res.CurrHF != 0 {
+		return true
 	}
+	return r.colibri.Allow(res, len(rp.Raw), time.Now())
 }
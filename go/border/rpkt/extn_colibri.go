@@ -0,0 +1,184 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file implements the router's handling of the COLIBRI hop-by-hop
+// extension. Packets carrying it are forwarded along a bandwidth reservation,
+// using the reservation hop fields instead of the SCION path header.
+
+package rpkt
+
+import (
+	"errors"
+	"hash"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/border/rcmn"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/layers"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+var _ rExtension = (*rColibri)(nil)
+
+// rColibri is the router's representation of the COLIBRI extension.
+type rColibri struct {
+	*layers.ExtnCOLIBRI
+	rp  *RtrPkt
+	raw common.RawBytes
+	log.Logger
+}
+
+func rColibriFromRaw(rp *RtrPkt, start, end, pos int) (*rColibri, error) {
+	c := &rColibri{rp: rp, raw: rp.Raw[start:end]}
+	var err error
+	if c.ExtnCOLIBRI, err = layers.ExtnCOLIBRIFromRaw(c.raw); err != nil {
+		return nil, common.NewBasicError("Unable to parse COLIBRI extension",
+			scmp.NewError(scmp.C_Ext, scmp.T_E_BadHopByHop,
+				&scmp.InfoExtIdx{Idx: uint8(pos)}, err))
+	}
+	c.Logger = rp.Logger.New("ext", "colibri")
+	return c, nil
+}
+
+func (c *rColibri) RegisterHooks(h *hooks) error {
+	// The reservation hop fields replace the path header.
+	h.ConsDirFlag = append(h.ConsDirFlag, c.ConsDirFlag)
+	h.IFCurr = append(h.IFCurr, c.IFCurr)
+	h.IFNext = append(h.IFNext, c.IFNext)
+	return nil
+}
+
+func (c *rColibri) GetExtn() (common.Extension, error) {
+	return c.ExtnCOLIBRI.Copy(), nil
+}
+
+// ConsDirFlag is always true, as the hop fields of a reservation are in the
+// direction of travel.
+func (c *rColibri) ConsDirFlag() (HookResult, bool, error) {
+	return HookFinish, true, nil
+}
+
+// IFCurr returns the interface the packet entered the AS on if it comes from
+// a neighboring AS, and the interface it leaves the AS on otherwise.
+func (c *rColibri) IFCurr(_ bool, dirFrom rcmn.Dir) (HookResult, common.IFIDType, error) {
+	hf := c.CurrHopField()
+	if dirFrom == rcmn.DirExternal {
+		return HookFinish, hf.Ingress, nil
+	}
+	return HookFinish, hf.Egress, nil
+}
+
+// IFNext returns the interface the packet leaves the AS on.
+func (c *rColibri) IFNext(_ bool, _ rcmn.Dir) (HookResult, common.IFIDType, error) {
+	return HookFinish, c.CurrHopField().Egress, nil
+}
+
+// validatePath validates the current reservation hop field in place of the
+// path header.
+func (c *rColibri) validatePath(dirFrom rcmn.Dir) error {
+	rp := c.rp
+	if reservation.TickFromTime(time.Now()) > c.InfoField.ExpirationTick {
+		return common.NewBasicError("Reservation expired",
+			scmp.NewError(scmp.C_Path, scmp.T_P_ExpiredHopF, nil, nil),
+			"expiration", c.InfoField.ExpirationTick)
+	}
+	if rp.ifCurr == nil {
+		return common.NewBasicError("Reservation hop field without interface",
+			scmp.NewError(scmp.C_Path, scmp.T_P_BadHopField, nil, nil))
+	}
+	if dirFrom == rcmn.DirExternal && *rp.ifCurr != rp.Ingress.IfID {
+		return common.NewBasicError("Reservation hop field ingress does not match",
+			scmp.NewError(scmp.C_Path, scmp.T_P_BadIF, nil, nil),
+			"expected", rp.Ingress.IfID, "actual", *rp.ifCurr)
+	}
+	if err := rp.validateLocalIF(rp.ifCurr); err != nil {
+		return err
+	}
+	hfmac := rp.Ctx.HFMacPool.Get().(hash.Hash)
+	err := c.CurrHopField().VerifyMac(hfmac, c.ID[:], &c.InfoField)
+	rp.Ctx.HFMacPool.Put(hfmac)
+	if err != nil && errors.Is(err, reservation.ErrBadMac) {
+		err = scmp.NewError(scmp.C_Path, scmp.T_P_BadMac, nil, err)
+	}
+	return err
+}
+
+// forward forwards the packet along the reservation.
+func (c *rColibri) forward() (HookResult, error) {
+	switch c.rp.DirFrom {
+	case rcmn.DirExternal:
+		return c.forwardFromExternal()
+	case rcmn.DirLocal:
+		return c.forwardFromLocal()
+	default:
+		return HookError, common.NewBasicError("Unsupported forwarding DirFrom", nil,
+			"dirFrom", c.rp.DirFrom)
+	}
+}
+
+// forwardFromExternal forwards a packet that entered the AS to the destination
+// host, if this is the last AS of the reservation, or to the border router of
+// the egress interface.
+func (c *rColibri) forwardFromExternal() (HookResult, error) {
+	rp := c.rp
+	if c.IsLastHop() {
+		if !rp.dstIA.Equal(rp.Ctx.Conf.IA) {
+			return HookError, common.NewBasicError("Reservation ends before destination",
+				scmp.NewError(scmp.C_Path, scmp.T_P_BadHopField, nil, nil),
+				"dstIA", rp.dstIA)
+		}
+		if rp.dstHost.IP() == nil {
+			// Not an IP address, cannot build an overlay with this
+			return HookError, common.NewBasicError("invalid overlay L3 address", nil,
+				"addr", rp.dstHost)
+		}
+		dst := &net.UDPAddr{
+			IP:   rp.dstHost.IP(),
+			Port: int(topology.EndhostPort),
+		}
+		rp.Egress = append(rp.Egress, EgressPair{S: rp.Ctx.LocSockOut, Dst: dst})
+		return HookContinue, nil
+	}
+	if err := rp.validateLocalIF(rp.ifNext); err != nil {
+		return HookError, err
+	}
+	if _, ok := rp.Ctx.Conf.BR.IFs[*rp.ifNext]; ok {
+		// Egress interface is local so re-inject the packet
+		// and make it look like it arrived in the internal interface
+		rp.RefInc(1)
+		return rp.reprocess()
+	}
+	nextBR := rp.Ctx.Conf.Topo.IFInfoMap()[*rp.ifNext]
+	rp.Egress = append(rp.Egress, EgressPair{S: rp.Ctx.LocSockOut, Dst: nextBR.InternalAddr})
+	return HookContinue, nil
+}
+
+// forwardFromLocal advances the reservation to the next AS, and sends the
+// packet on the egress interface.
+func (c *rColibri) forwardFromLocal() (HookResult, error) {
+	rp := c.rp
+	if c.IsLastHop() {
+		return HookError, common.NewBasicError("No next hop in reservation",
+			scmp.NewError(scmp.C_Path, scmp.T_P_BadHopField, nil, nil))
+	}
+	c.CurrHF++
+	c.raw[layers.ExtnCOLIBRICurrHFOff] = c.CurrHF
+	rp.IncrementedPath = true
+	rp.Egress = append(rp.Egress, EgressPair{S: rp.Ctx.ExtSockOut[*rp.ifCurr]})
+	return HookContinue, nil
+}
@@ -0,0 +1,259 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpkt
+
+import (
+	"hash"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/rcmn"
+	"github.com/scionproto/scion/go/border/rctx"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/layers"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/spkt"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+// colibriHdrLen is the length of the common and address headers of the
+// COLIBRI test packets. They do not carry a path header.
+const colibriHdrLen = spkt.CmnHdrLen + 2*addr.IABytes + 2*addr.HostLenIPv4
+
+var colibriBR2Addr = &net.UDPAddr{IP: net.IP{127, 0, 0, 2}, Port: 30002}
+
+func TestColibriForwarding(t *testing.T) {
+	ctx := colibriCtx(t)
+	now := time.Now()
+	valid := reservation.InfoField{
+		ExpirationTick: reservation.TickFromTime(now.Add(time.Minute)),
+		BWCls:          13,
+		Idx:            2,
+		PathType:       reservation.E2EPath,
+	}
+	expired := valid
+	expired.ExpirationTick = reservation.TickFromTime(now) - 1
+	// Packets from external enter on interface 1 and are forwarded to the
+	// router of interface 2. Packets from local come from the router of
+	// interface 2 and leave on interface 3.
+	extHopFields := []reservation.HopField{
+		{Ingress: 9, Egress: 8},
+		{Ingress: 1, Egress: 2},
+		{Ingress: 7, Egress: 6},
+	}
+	locHopFields := []reservation.HopField{
+		{Ingress: 7, Egress: 1},
+		{Ingress: 2, Egress: 3},
+		{Ingress: 9, Egress: 8},
+	}
+	tests := map[string]struct {
+		DirFrom rcmn.Dir
+		Ingress common.IFIDType
+		Info    reservation.InfoField
+		// MacInfo is the info field the MAC of the local hop field is
+		// computed over.
+		MacInfo  reservation.InfoField
+		CurrHF   uint8
+		ErrMsg   string
+		Egress   *rctx.Sock
+		EgressTo *net.UDPAddr
+		NextHF   uint8
+	}{
+		"external valid": {
+			DirFrom:  rcmn.DirExternal,
+			Ingress:  1,
+			Info:     valid,
+			MacInfo:  valid,
+			CurrHF:   1,
+			Egress:   ctx.LocSockOut,
+			EgressTo: colibriBR2Addr,
+			NextHF:   1,
+		},
+		"external expired": {
+			DirFrom: rcmn.DirExternal,
+			Ingress: 1,
+			Info:    expired,
+			MacInfo: expired,
+			CurrHF:  1,
+			ErrMsg:  "Reservation expired",
+		},
+		"external wrong hop field index": {
+			DirFrom: rcmn.DirExternal,
+			Ingress: 1,
+			Info:    valid,
+			MacInfo: valid,
+			CurrHF:  0,
+			ErrMsg:  "Unknown IF",
+		},
+		"external wrong reservation index": {
+			DirFrom: rcmn.DirExternal,
+			Ingress: 1,
+			Info:    withIdx(valid, 3),
+			MacInfo: valid,
+			CurrHF:  1,
+			ErrMsg:  reservation.ErrBadMac.Error(),
+		},
+		"local valid": {
+			DirFrom: rcmn.DirLocal,
+			Info:    valid,
+			MacInfo: valid,
+			CurrHF:  1,
+			Egress:  ctx.ExtSockOut[3],
+			NextHF:  2,
+		},
+		"local expired": {
+			DirFrom: rcmn.DirLocal,
+			Info:    expired,
+			MacInfo: expired,
+			CurrHF:  1,
+			ErrMsg:  "Reservation expired",
+		},
+		"local wrong hop field index": {
+			DirFrom: rcmn.DirLocal,
+			Info:    valid,
+			MacInfo: valid,
+			CurrHF:  0,
+			ErrMsg:  reservation.ErrBadMac.Error(),
+		},
+		"local wrong reservation index": {
+			DirFrom: rcmn.DirLocal,
+			Info:    withIdx(valid, 3),
+			MacInfo: valid,
+			CurrHF:  1,
+			ErrMsg:  reservation.ErrBadMac.Error(),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hfs := append([]reservation.HopField(nil), extHopFields...)
+			if test.DirFrom == rcmn.DirLocal {
+				hfs = append(hfs[:0], locHopFields...)
+			}
+			setColibriMac(t, ctx, &hfs[1], &test.MacInfo)
+			rp := NewRtrPkt()
+			rp.Raw = colibriPkt(t, test.CurrHF, test.Info, hfs)
+			rp.Ctx = ctx
+			rp.DirFrom = test.DirFrom
+			rp.Ingress.IfID = test.Ingress
+			rp.Logger = log.Root()
+			err := rp.Parse()
+			if err == nil {
+				err = rp.validatePath(rp.DirFrom)
+			}
+			if test.ErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.ErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, rp.Reservation())
+			res, err := rp.colibri.forward()
+			require.NoError(t, err)
+			assert.Equal(t, HookContinue, res)
+			require.Len(t, rp.Egress, 1)
+			assert.Equal(t, test.Egress, rp.Egress[0].S)
+			assert.Equal(t, test.EgressTo, rp.Egress[0].Dst)
+			assert.Equal(t, test.NextHF, rp.Reservation().CurrHF)
+			currHFOff := colibriHdrLen + common.ExtnSubHdrLen + layers.ExtnCOLIBRICurrHFOff
+			assert.Equal(t, test.NextHF, rp.Raw[currHFOff])
+		})
+	}
+}
+
+// colibriCtx returns a router context for a router with the interfaces 1 and
+// 3 in 1-ff00:0:111. Interface 2 is on another router of the AS.
+func colibriCtx(t *testing.T) *rctx.Ctx {
+	ia := addr.IA{I: 1, A: 0xff0000000111}
+	ifInfos := topology.IfInfoMap{
+		1: {ID: 1, BRName: "br1", IA: addr.IA{I: 1, A: 0xff0000000110}},
+		2: {ID: 2, BRName: "br2", InternalAddr: colibriBR2Addr,
+			IA: addr.IA{I: 1, A: 0xff0000000120}},
+		3: {ID: 3, BRName: "br1", IA: addr.IA{I: 1, A: 0xff0000000130}},
+	}
+	topo := topology.NewRWTopology()
+	topo.IA = ia
+	topo.IFInfoMap = ifInfos
+	if1, if3 := ifInfos[1], ifInfos[3]
+	conf := &brconf.BRConf{
+		IA:   ia,
+		Topo: topology.FromRWTopology(topo),
+		BR: &topology.BRInfo{
+			Name: "br1",
+			IFs:  map[common.IFIDType]*topology.IFInfo{1: &if1, 3: &if3},
+		},
+		MasterKeys: keyconf.Master{Key0: make([]byte, 16)},
+	}
+	ctx := rctx.New(conf)
+	require.NoError(t, ctx.InitMacPool())
+	ctx.LocSockOut = &rctx.Sock{Label: "loc"}
+	ctx.ExtSockOut[1] = &rctx.Sock{Ifid: 1}
+	ctx.ExtSockOut[3] = &rctx.Sock{Ifid: 3}
+	return ctx
+}
+
+func setColibriMac(t *testing.T, ctx *rctx.Ctx, hf *reservation.HopField,
+	info *reservation.InfoField) {
+
+	var id [reservation.E2EIDLen]byte
+	hfmac := ctx.HFMacPool.Get().(hash.Hash)
+	defer ctx.HFMacPool.Put(hfmac)
+	mac, err := hf.CalcMac(hfmac, id[:], info)
+	require.NoError(t, err)
+	copy(hf.Mac[:], mac)
+}
+
+// colibriPkt builds a raw SCION/UDP packet from 1-ff00:0:110 to 1-ff00:0:140
+// that is forwarded along a reservation with a zero ID.
+func colibriPkt(t *testing.T, currHF uint8, info reservation.InfoField,
+	hfs []reservation.HopField) common.RawBytes {
+
+	extn := &layers.ExtnCOLIBRI{CurrHF: currHF, InfoField: info, HopFields: hfs}
+	extnLen := common.ExtnSubHdrLen + extn.Len()
+	raw := make(common.RawBytes, colibriHdrLen+extnLen+8)
+	cmn := spkt.CmnHdr{
+		DstType:   addr.HostTypeIPv4,
+		SrcType:   addr.HostTypeIPv4,
+		TotalLen:  uint16(len(raw)),
+		HdrLen:    colibriHdrLen / common.LineLen,
+		CurrInfoF: colibriHdrLen / common.LineLen,
+		CurrHopF:  colibriHdrLen / common.LineLen,
+		NextHdr:   common.HopByHopClass,
+	}
+	cmn.Write(raw)
+	addrHdr := raw[spkt.CmnHdrLen:]
+	addr.IA{I: 1, A: 0xff0000000140}.Write(addrHdr)
+	addr.IA{I: 1, A: 0xff0000000110}.Write(addrHdr[addr.IABytes:])
+	copy(addrHdr[2*addr.IABytes:], []byte{192, 0, 2, 1, 192, 0, 2, 2})
+	b := raw[colibriHdrLen:]
+	b[0] = byte(common.L4UDP)
+	b[1] = byte(extnLen / common.LineLen)
+	b[2] = common.ExtnCOLIBRIType.Type
+	require.NoError(t, extn.Write(b[common.ExtnSubHdrLen:]))
+	common.Order.PutUint16(raw[len(raw)-4:], 8)
+	return raw
+}
+
+func withIdx(info reservation.InfoField, idx reservation.Index) reservation.InfoField {
+	info.Idx = idx
+	return info
+}
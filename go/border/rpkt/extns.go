@@ -52,6 +52,15 @@ func (rp *RtrPkt) extnParseHBH(extType common.ExtnType,
 		return rSCMPExtFromRaw(rp, start, end)
 	case extType == common.ExtnOneHopPathType:
 		return rOneHopPathFromRaw(rp)
+	case extType == common.ExtnCOLIBRIType:
+		if rp.colibri != nil {
+			return nil, common.NewBasicError("Duplicate COLIBRI extension",
+				scmp.NewError(scmp.C_Ext, scmp.T_E_BadHopByHop,
+					&scmp.InfoExtIdx{Idx: uint8(pos)}, nil))
+		}
+		var err error
+		rp.colibri, err = rColibriFromRaw(rp, start, end, pos)
+		return rp.colibri, err
 	default:
 		// HBH not supported, so send an SCMP error in response.
 		return nil, common.NewBasicError(
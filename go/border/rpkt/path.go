@@ -34,6 +34,9 @@ import (
 
 // validatePath validates the path header.
 func (rp *RtrPkt) validatePath(dirFrom rcmn.Dir) error {
+	if rp.colibri != nil {
+		return rp.colibri.validatePath(dirFrom)
+	}
 	// First check if there is a path
 	if rp.infoF == nil || rp.hopF == nil {
 		return common.NewBasicError("Path required",
@@ -78,8 +81,13 @@ func (rp *RtrPkt) validatePath(dirFrom rcmn.Dir) error {
 }
 
 // validateLocalIF makes sure a given interface ID exists in the local AS, and
-// that it isn't revoked. Note that revocations are ignored if the packet's
-// destination is this router.
+// that it isn't revoked, detected as down by BFD, or drained. Note that
+// revocations are ignored if the packet's destination is this router.
+//
+// While an interface is draining, packets with a OneHopPath extension, i.e.,
+// keepalives and beacons, are dropped such that the beacon services on both
+// sides revoke the interface. Other packets are forwarded regardless of
+// revocations until the interface is drained.
 func (rp *RtrPkt) validateLocalIF(ifid *common.IFIDType) error {
 	if ifid == nil {
 		return serrors.New("validateLocalIF: Interface is nil")
@@ -94,15 +102,30 @@ func (rp *RtrPkt) validateLocalIF(ifid *common.IFIDType) error {
 			"ifid", *ifid,
 		)
 	}
+	drain := ifstate.DrainStateOf(*ifid)
+	if drain == ifstate.Drained {
+		return rcmn.WithDropReason(
+			common.NewBasicError(errIntfDrained, nil, "ifid", *ifid), rcmn.DropDrained)
+	}
 	for _, e := range rp.HBHExt {
 		if e.Type() == common.ExtnOneHopPathType {
+			if drain == ifstate.Draining {
+				return rcmn.WithDropReason(
+					common.NewBasicError(errIntfDrained, nil, "ifid", *ifid),
+					rcmn.DropDrained)
+			}
 			// Ignore revocations if OneHopExtension is present
 			return nil
 		}
 	}
 	state, ok := ifstate.LoadState(*ifid)
-	if !ok || state.Active {
-		// Interface is not revoked
+	if !ok || state.Active || drain == ifstate.Draining {
+		// Interface is not revoked, or the revocation is expected because it
+		// is draining. The link might have failed nonetheless.
+		if !ifstate.LinkUp(*ifid) {
+			return rcmn.WithDropReason(
+				common.NewBasicError(errIntfLinkDown, nil, "ifid", *ifid), rcmn.DropLinkDown)
+		}
 		return nil
 	}
 	// Interface is revoked.
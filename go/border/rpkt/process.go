@@ -34,6 +34,9 @@ func (rp *RtrPkt) NeedsLocalProcessing() {
 	if rp.dstIA.Equal(rp.Ctx.Conf.IA) && rp.CmnHdr.DstType == addr.HostTypeSVC {
 		// SVC address needs to be resolved for delivery.
 		rp.hooks.Route = append(rp.hooks.Route, rp.RouteResolveSVC)
+	} else if rp.colibri != nil {
+		// Packet is forwarded along a reservation.
+		rp.hooks.Route = append(rp.hooks.Route, rp.colibri.forward)
 	} else {
 		// Packet not destined to local AS, just forward.
 		// Non-SVC packet to local AS, just forward.
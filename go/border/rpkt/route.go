@@ -62,7 +62,13 @@ func (rp *RtrPkt) Route() error {
 	rp.RefInc(len(rp.Egress))
 	// Call all egress functions.
 	for _, epair := range rp.Egress {
-		epair.S.Ring.Write(ringbuf.EntryList{&EgressRtrPkt{rp, epair.Dst}}, true)
+		entry := ringbuf.EntryList{&EgressRtrPkt{rp, epair.Dst}}
+		if rp.colibri != nil {
+			// Reservation traffic has priority over best-effort traffic.
+			epair.S.Ring.WritePrio(entry, true)
+		} else {
+			epair.S.Ring.Write(entry, true)
+		}
 		l.IntfOut = epair.S.Label
 		metrics.Process.Pkts(l).Inc()
 	}
@@ -73,12 +79,14 @@ func (rp *RtrPkt) Route() error {
 func (rp *RtrPkt) RouteResolveSVC() (HookResult, error) {
 	svc, ok := rp.dstHost.(addr.HostSVC)
 	if !ok {
-		return HookError, common.NewBasicError("Destination host is NOT an SVC address", nil,
-			"actual", rp.dstHost, "type", fmt.Sprintf("%T", rp.dstHost))
+		return HookError, rcmn.WithDropReason(
+			common.NewBasicError("Destination host is NOT an SVC address", nil,
+				"actual", rp.dstHost, "type", fmt.Sprintf("%T", rp.dstHost)),
+			rcmn.DropBadSVC)
 	}
 	addrs, err := rp.Ctx.ResolveSVC(svc)
 	if err != nil {
-		return HookError, err
+		return HookError, rcmn.WithDropReason(err, rcmn.DropBadSVC)
 	}
 	for _, dst := range addrs {
 		// FIXME(sgmonroy) Choose LocSock based on overlay type for dual-stack support
@@ -267,7 +275,7 @@ func (rp *RtrPkt) reprocess() (HookResult, error) {
 	// XXX This hook is meant to be called only when processing packets from external to external
 	// interface. Thus, the goroutine writing to the LocIn ringbuffer should always be the ones
 	// NOT reading from it to avoid deadlock, ie. goroutines handling packets from external
-	// interfaces.
+	// interfaces, or the workers of the pool, which never read from the LocIn ringbuffer.
 	s.Ring.Write(ringbuf.EntryList{rp}, true)
 	// Stop routing the packet after enqueuing it back into the ringbuffer.
 	return HookFinish, nil
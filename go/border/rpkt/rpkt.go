@@ -33,6 +33,7 @@ import (
 	"github.com/scionproto/scion/go/lib/assert"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/l4"
+	"github.com/scionproto/scion/go/lib/layers"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/scmp"
 	"github.com/scionproto/scion/go/lib/spath"
@@ -103,6 +104,9 @@ type RtrPkt struct {
 	consDirFlag *bool
 	// HBHExt is the list of Hop-by-hop extensions, if any. (PARSE)
 	HBHExt []rExtension
+	// colibri is the COLIBRI extension, if the packet is forwarded along a
+	// reservation. (PARSE)
+	colibri *rColibri
 	// E2EExt is the list of end2end extensions, if any. (PARSE, only if needed)
 	// TODO(kormat): The router currently ignores these.
 	E2EExt []rExtension
@@ -232,6 +236,7 @@ func (rp *RtrPkt) Reset() {
 	rp.ifNext = nil
 	rp.consDirFlag = nil
 	rp.HBHExt = rp.HBHExt[:0]
+	rp.colibri = nil
 	rp.E2EExt = rp.E2EExt[:0]
 	rp.L4Type = common.L4None
 	rp.l4 = nil
@@ -382,3 +387,12 @@ func (rp *RtrPkt) ErrStrf(desc string) func() string {
 		return rp.ErrStr(desc)
 	}
 }
+
+// Reservation returns the COLIBRI extension if the packet is forwarded along a
+// reservation, and nil otherwise.
+func (rp *RtrPkt) Reservation() *layers.ExtnCOLIBRI {
+	if rp.colibri == nil {
+		return nil
+	}
+	return rp.colibri.ExtnCOLIBRI
+}
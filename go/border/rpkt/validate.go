@@ -25,6 +25,8 @@ import (
 const (
 	errCurrIntfInvalid common.ErrMsg = "Invalid current interface"
 	errIntfRevoked     common.ErrMsg = "Interface revoked"
+	errIntfLinkDown    common.ErrMsg = "Interface link down"
+	errIntfDrained     common.ErrMsg = "Interface drained"
 	errHookResponse    common.ErrMsg = "Extension hook return value unrecognised"
 )
 
@@ -0,0 +1,162 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scmplimit rate limits the SCMP error messages originated by the
+// border router.
+//
+// Without a limit, every offending packet results in an SCMP error reply, and
+// the router can be abused as a reflector. A Limiter enforces a global limit
+// on all SCMP errors, and a limit per destination, i.e., per source of the
+// offending packets. Each limit is a token bucket that is refilled at a
+// configured rate (in messages per second) up to a configured burst size.
+package scmplimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/tokenbucket"
+)
+
+// Reason indicates why an SCMP message was suppressed.
+type Reason int
+
+const (
+	// Allowed indicates that the message was not suppressed.
+	Allowed Reason = iota
+	// Global indicates that the global limit was exceeded.
+	Global
+	// Destination indicates that the per-destination limit was exceeded.
+	Destination
+)
+
+func (r Reason) String() string {
+	switch r {
+	case Allowed:
+		return "allowed"
+	case Global:
+		return "global"
+	case Destination:
+		return "destination"
+	default:
+		return "unknown"
+	}
+}
+
+// DefaultMaxDestinations is the default number of destinations that are
+// tracked by a limiter.
+const DefaultMaxDestinations = 10000
+
+// Config is the configuration of a limiter.
+type Config struct {
+	// GlobalRate is the rate of all SCMP messages in messages per second.
+	GlobalRate float64
+	// GlobalBurst is the burst size of all SCMP messages.
+	GlobalBurst float64
+	// DestinationRate is the rate of SCMP messages to a single destination in
+	// messages per second.
+	DestinationRate float64
+	// DestinationBurst is the burst size of SCMP messages to a single
+	// destination.
+	DestinationBurst float64
+	// MaxDestinations is the maximum number of destinations that are tracked.
+	// If zero, DefaultMaxDestinations is used.
+	MaxDestinations int
+}
+
+type dstKey struct {
+	ia    addr.IA
+	htype addr.HostAddrType
+	host  string
+}
+
+// Limiter limits the rate of SCMP messages. It is safe for concurrent use.
+type Limiter struct {
+	cfg    Config
+	global *tokenbucket.Bucket
+
+	mtx  sync.Mutex
+	dsts map[dstKey]*tokenbucket.Bucket
+}
+
+// New creates a new limiter.
+func New(cfg Config) *Limiter {
+	if cfg.MaxDestinations == 0 {
+		cfg.MaxDestinations = DefaultMaxDestinations
+	}
+	return &Limiter{
+		cfg:    cfg,
+		global: tokenbucket.New(cfg.GlobalRate, cfg.GlobalBurst),
+		dsts:   make(map[dstKey]*tokenbucket.Bucket),
+	}
+}
+
+// Allow checks whether an SCMP message to the destination can be sent at
+// time now. If not, the reason for the suppression is returned. A suppressed
+// message does not consume a token of either limit.
+func (l *Limiter) Allow(ia addr.IA, host addr.HostAddr, now time.Time) (bool, Reason) {
+	dst := l.allowDst(newDstKey(ia, host), now)
+	if dst == nil {
+		return false, Destination
+	}
+	if !l.global.Allow(now, 1) {
+		dst.Refund(1)
+		return false, Global
+	}
+	return true, Allowed
+}
+
+// allowDst returns the bucket of the destination if the message conforms to
+// it, nil otherwise.
+func (l *Limiter) allowDst(key dstKey, now time.Time) *tokenbucket.Bucket {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	b, ok := l.dsts[key]
+	if !ok {
+		if len(l.dsts) >= l.cfg.MaxDestinations {
+			l.evict(now)
+		}
+		if len(l.dsts) >= l.cfg.MaxDestinations {
+			// All tracked destinations are active. Suppress the message to new
+			// destinations instead of losing track of the active ones.
+			return nil
+		}
+		b = tokenbucket.New(l.cfg.DestinationRate, l.cfg.DestinationBurst)
+		l.dsts[key] = b
+	}
+	if !b.Allow(now, 1) {
+		return nil
+	}
+	return b
+}
+
+// evict removes the destinations whose bucket is full again. Tracking them
+// makes no difference, because a new bucket is full as well.
+func (l *Limiter) evict(now time.Time) {
+	for key, b := range l.dsts {
+		if b.Tokens(now) >= l.cfg.DestinationBurst {
+			delete(l.dsts, key)
+		}
+	}
+}
+
+func newDstKey(ia addr.IA, host addr.HostAddr) dstKey {
+	key := dstKey{ia: ia}
+	if host != nil {
+		key.htype = host.Type()
+		key.host = string(host.Pack())
+	}
+	return key
+}
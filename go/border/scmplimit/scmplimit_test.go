@@ -0,0 +1,122 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scmplimit_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/border/scmplimit"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestLimiterDestination(t *testing.T) {
+	l := scmplimit.New(scmplimit.Config{
+		GlobalRate:       100,
+		GlobalBurst:      100,
+		DestinationRate:  1,
+		DestinationBurst: 2,
+	})
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	host := addr.HostFromIP(net.IPv4(192, 0, 2, 1))
+	other := addr.HostFromIP(net.IPv4(192, 0, 2, 2))
+	now := time.Now()
+	for i := 0; i < 2; i++ {
+		ok, reason := l.Allow(ia, host, now)
+		assert.True(t, ok)
+		assert.Equal(t, scmplimit.Allowed, reason)
+	}
+	ok, reason := l.Allow(ia, host, now)
+	assert.False(t, ok)
+	assert.Equal(t, scmplimit.Destination, reason)
+	// Other destinations are limited independently.
+	ok, _ = l.Allow(ia, other, now)
+	assert.True(t, ok)
+	ok, _ = l.Allow(xtest.MustParseIA("1-ff00:0:111"), host, now)
+	assert.True(t, ok)
+	// The bucket is refilled over time.
+	ok, _ = l.Allow(ia, host, now.Add(time.Second))
+	assert.True(t, ok)
+}
+
+func TestLimiterGlobal(t *testing.T) {
+	l := scmplimit.New(scmplimit.Config{
+		GlobalRate:       1,
+		GlobalBurst:      3,
+		DestinationRate:  10,
+		DestinationBurst: 10,
+	})
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		ok, _ := l.Allow(ia, addr.HostFromIP(net.IPv4(192, 0, 2, byte(i))), now)
+		assert.True(t, ok)
+	}
+	ok, reason := l.Allow(ia, addr.HostFromIP(net.IPv4(192, 0, 2, 10)), now)
+	assert.False(t, ok)
+	assert.Equal(t, scmplimit.Global, reason)
+}
+
+func TestLimiterGlobalDoesNotChargeDestination(t *testing.T) {
+	l := scmplimit.New(scmplimit.Config{
+		GlobalRate:       1,
+		GlobalBurst:      1,
+		DestinationRate:  0.1,
+		DestinationBurst: 1,
+	})
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	host := addr.HostFromIP(net.IPv4(192, 0, 2, 1))
+	other := addr.HostFromIP(net.IPv4(192, 0, 2, 2))
+	now := time.Now()
+	ok, _ := l.Allow(ia, other, now)
+	assert.True(t, ok)
+	ok, reason := l.Allow(ia, host, now)
+	assert.False(t, ok)
+	assert.Equal(t, scmplimit.Global, reason)
+	// The message suppressed by the global limit did not consume the token of
+	// the destination. Otherwise, the destination bucket would still be empty
+	// after the global bucket is refilled.
+	ok, reason = l.Allow(ia, host, now.Add(time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, scmplimit.Allowed, reason)
+}
+
+func TestLimiterMaxDestinations(t *testing.T) {
+	l := scmplimit.New(scmplimit.Config{
+		GlobalRate:       100,
+		GlobalBurst:      100,
+		DestinationRate:  1,
+		DestinationBurst: 1,
+		MaxDestinations:  2,
+	})
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	host := func(i byte) addr.HostAddr { return addr.HostFromIP(net.IPv4(192, 0, 2, i)) }
+	now := time.Now()
+	ok, _ := l.Allow(ia, host(1), now)
+	assert.True(t, ok)
+	ok, _ = l.Allow(ia, host(2), now)
+	assert.True(t, ok)
+	// Both tracked destinations are active, new destinations are suppressed.
+	ok, reason := l.Allow(ia, host(3), now)
+	assert.False(t, ok)
+	assert.Equal(t, scmplimit.Destination, reason)
+	// Once the tracked destinations are idle, they are evicted.
+	ok, _ = l.Allow(ia, host(3), now.Add(time.Second))
+	assert.True(t, ok)
+}
@@ -0,0 +1,42 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"net"
+
+	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/lib/overlay/conn"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// MemSock is the in-memory I/O backend. Sockets of this type are bound on the
+// memio.Network of the router instead of the network stack of the host. The
+// router creates the network if the backend is selected with io_backend.
+const MemSock brconf.SockType = "mem"
+
+func init() {
+	registeredLocSockOps[MemSock] = connLoc{sockType: MemSock, newConn: newMemConn,
+		backend: connBackend{}}
+	registeredExtSockOps[MemSock] = connExt{sockType: MemSock, newConn: newMemConn,
+		backend: connBackend{}}
+}
+
+func newMemConn(r *Router, listen, remote *net.UDPAddr) (conn.Conn, error) {
+	if r.memNet == nil {
+		return nil, serrors.New("in-memory network not set", "listen", listen)
+	}
+	return r.memNet.Listen(listen, remote)
+}
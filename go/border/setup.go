@@ -23,6 +23,7 @@ import (
 	"github.com/syndtr/gocapability/capability"
 
 	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/colibri"
 	"github.com/scionproto/scion/go/border/rctx"
 	"github.com/scionproto/scion/go/border/rpkt"
 	"github.com/scionproto/scion/go/lib/common"
@@ -61,6 +62,13 @@ func (r *Router) setup() error {
 
 	// Configure the rpkt package with the callbacks it needs.
 	rpkt.Init(r.RawSRevCallback)
+	r.scmpLimit = newSCMPLimiter(cfg.BR.SCMPRateLimit)
+	r.colibri = colibri.NewPolicer()
+	// Start the processing goroutines before any input socket is set up.
+	if cfg.BR.NumWorkers > 0 {
+		r.workers = newWorkerPool(cfg.BR.NumWorkers)
+		r.workers.start(r)
+	}
 
 	// Load config.
 	var err error
@@ -167,12 +175,16 @@ func (r *Router) setupCtxFromTopoUpdate(tx itopo.Transaction, err error) (bool,
 // setupNewContext sets up a new router context.
 func (r *Router) setupNewContext(ctx *rctx.Ctx, tx *itopo.Transaction) error {
 	oldCtx := rctx.Get()
+	// Keep the rate state of the policers whose limits did not change.
+	if oldCtx != nil {
+		ctx.Policers = oldCtx.Policers.Update(ctx.Conf.Policing)
+	}
 	// Initialize Hop Field Mac Pool
 	if err := ctx.InitMacPool(); err != nil {
 		return err
 	}
 	// TODO(roosd): Eventually, this will be configurable through brconfig.toml.
-	sockConf := brconf.SockConf{Default: PosixSock}
+	sockConf := r.sockConf
 	if err := r.setupNetAndTopo(ctx, oldCtx, sockConf, tx); err != nil {
 		r.rollbackNet(ctx, oldCtx, sockConf, handleRollbackErr)
 		return err
@@ -181,6 +193,7 @@ func (r *Router) setupNewContext(ctx *rctx.Ctx, tx *itopo.Transaction) error {
 	startSocks(ctx)
 	// Tear down sockets for removed interfaces
 	r.teardownNet(ctx, oldCtx, sockConf)
+	r.updateBFD(ctx, cfg.BR.BFD)
 	return nil
 }
 
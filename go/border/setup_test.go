@@ -295,18 +295,18 @@ func setupTestRouter(t *testing.T) (*Router, *rctx.Ctx) {
 	return r, oldCtx
 }
 
-func initTestRouter(maxNumPosixInput int) *Router {
+func initTestRouter(maxNumInput int) *Router {
 	// Init metrics.
 	testInitOnce.Do(func() {
 		// Reduce output displayed in goconvey.
 		log.Discard()
 	})
-	// The number of free packets has to be at least the number of posix
+	// The number of free packets has to be at least the number of
 	// input routines times inputBufCnt. Otherwise they might get stuck
 	// trying to prepare for reading from the connection.
 	// See: https://github.com/scionproto/scion/issues/1981
 	r := &Router{
-		freePkts: ringbuf.New(maxNumPosixInput*inputBufCnt, func() interface{} {
+		freePkts: ringbuf.New(maxNumInput*inputBufCnt, func() interface{} {
 			return rpkt.NewRtrPkt()
 		}, "free_pkts"),
 	}
@@ -0,0 +1,164 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the sharded packet processing. Input routines hash
+// packets by flow onto a fixed set of processing goroutines, each with its
+// own ring buffer. As all packets of a flow are processed by the same
+// goroutine, the order of packets within a flow is preserved.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/scionproto/scion/go/border/rpkt"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/ringbuf"
+	"github.com/scionproto/scion/go/lib/spkt"
+)
+
+// workerRingSize is the number of packets that can be queued per worker.
+const workerRingSize = 256
+
+// workerPool is a set of packet processing goroutines.
+type workerPool struct {
+	rings []*ringbuf.Ring
+}
+
+// newWorkerPool creates the ring buffers for n workers. The workers are
+// started with start.
+func newWorkerPool(n int) *workerPool {
+	p := &workerPool{rings: make([]*ringbuf.Ring, n)}
+	for i := range p.rings {
+		p.rings[i] = ringbuf.New(workerRingSize, nil, fmt.Sprintf("worker_%d", i))
+	}
+	return p
+}
+
+// start starts the processing goroutines. They run for the lifetime of the
+// router.
+func (p *workerPool) start(r *Router) {
+	for i, ring := range p.rings {
+		go func(id int, ring *ringbuf.Ring) {
+			defer log.HandlePanic()
+			r.processWorker(id, ring)
+		}(i, ring)
+	}
+}
+
+// newDispatcher creates a dispatcher. Each input goroutine must use its own
+// dispatcher.
+func (p *workerPool) newDispatcher() *dispatcher {
+	return &dispatcher{
+		pool:    p,
+		batches: make([]ringbuf.EntryList, len(p.rings)),
+	}
+}
+
+// dispatcher distributes packets onto the workers of a pool. It reuses the
+// per-worker batches between calls to avoid allocations.
+type dispatcher struct {
+	pool    *workerPool
+	batches []ringbuf.EntryList
+}
+
+// dispatch hands the packets to the workers. It blocks until all packets have
+// been queued.
+func (d *dispatcher) dispatch(pkts ringbuf.EntryList) {
+	n := uint32(len(d.batches))
+	for _, entry := range pkts {
+		i := flowHash(entry.(*rpkt.RtrPkt).Raw) % n
+		d.batches[i] = append(d.batches[i], entry)
+	}
+	for i, batch := range d.batches {
+		for written := 0; written < len(batch); {
+			wn, _ := d.pool.rings[i].Write(batch[written:], true)
+			if wn < 0 {
+				break
+			}
+			written += wn
+		}
+		for j := range batch {
+			batch[j] = nil
+		}
+		d.batches[i] = batch[:0]
+	}
+}
+
+// processWorker processes the packets queued on ring.
+func (r *Router) processWorker(id int, ring *ringbuf.Ring) {
+	log.Debug("processWorker starting", "id", id)
+	pkts := make(ringbuf.EntryList, processBufCnt)
+	for {
+		n, _ := ring.Read(pkts, true)
+		if n < 0 {
+			log.Debug("processWorker stopping", "id", id)
+			return
+		}
+		r.processBatch(pkts[:n])
+	}
+}
+
+const (
+	fnvOffset32 = 2166136261
+	fnvPrime32  = 16777619
+)
+
+// flowHash returns the FNV-1a hash of the flow identifier of a raw SCION
+// packet, i.e., the address header and, for UDP and TCP, the L4 ports. It does
+// not allocate and is robust against malformed packets, which are hashed on
+// the bytes available.
+func flowHash(raw common.RawBytes) uint32 {
+	h := uint32(fnvOffset32)
+	hash := func(b []byte) {
+		for _, c := range b {
+			h ^= uint32(c)
+			h *= fnvPrime32
+		}
+	}
+	if len(raw) < spkt.CmnHdrLen || raw[0]>>4 != spkt.SCIONVersion {
+		return h
+	}
+	verDstSrc := common.Order.Uint16(raw)
+	dstLen, err := addr.HostLen(addr.HostAddrType(verDstSrc>>6) & 0x3F)
+	if err != nil {
+		dstLen = 0
+	}
+	srcLen, err := addr.HostLen(addr.HostAddrType(verDstSrc) & 0x3F)
+	if err != nil {
+		srcLen = 0
+	}
+	addrEnd := spkt.CmnHdrLen + 2*addr.IABytes + int(dstLen) + int(srcLen)
+	if addrEnd > len(raw) {
+		hash(raw[spkt.CmnHdrLen:])
+		return h
+	}
+	hash(raw[spkt.CmnHdrLen:addrEnd])
+	// Skip the path and the extensions to find the L4 header.
+	nextHdr := common.L4ProtocolType(raw[7])
+	offset := int(raw[4]) * common.LineLen
+	for nextHdr == common.HopByHopClass || nextHdr == common.End2EndClass {
+		if offset+2 > len(raw) || raw[offset+1] == 0 {
+			return h
+		}
+		nextHdr = common.L4ProtocolType(raw[offset])
+		offset += int(raw[offset+1]) * common.LineLen
+	}
+	if (nextHdr == common.L4UDP || nextHdr == common.L4TCP) && offset+4 <= len(raw) {
+		hash(raw[offset : offset+4])
+	}
+	return h
+}
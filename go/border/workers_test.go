@@ -0,0 +1,204 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/memio"
+	"github.com/scionproto/scion/go/border/rctx"
+	"github.com/scionproto/scion/go/border/rpkt"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/ringbuf"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/spkt"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// flowPkt builds a raw SCION/UDP packet between IPv4 hosts without a path.
+func flowPkt(srcPort, dstPort uint16, payload byte) common.RawBytes {
+	const hdrLen = spkt.CmnHdrLen + 2*addr.IABytes + 2*addr.HostLenIPv4
+	raw := make(common.RawBytes, hdrLen+8+1)
+	cmn := spkt.CmnHdr{
+		DstType:  addr.HostTypeIPv4,
+		SrcType:  addr.HostTypeIPv4,
+		TotalLen: uint16(len(raw)),
+		HdrLen:   hdrLen / common.LineLen,
+		NextHdr:  common.L4UDP,
+	}
+	cmn.Write(raw)
+	addrHdr := raw[spkt.CmnHdrLen:]
+	addr.IA{I: 1, A: 0xff0000000110}.Write(addrHdr)
+	addr.IA{I: 1, A: 0xff0000000111}.Write(addrHdr[addr.IABytes:])
+	copy(addrHdr[2*addr.IABytes:], []byte{192, 0, 2, 1, 192, 0, 2, 2})
+	common.Order.PutUint16(raw[hdrLen:], srcPort)
+	common.Order.PutUint16(raw[hdrLen+2:], dstPort)
+	raw[len(raw)-1] = payload
+	return raw
+}
+
+func TestFlowHash(t *testing.T) {
+	t.Run("same flow, different payload", func(t *testing.T) {
+		assert.Equal(t, flowHash(flowPkt(40000, 30041, 1)), flowHash(flowPkt(40000, 30041, 2)))
+	})
+	t.Run("different ports", func(t *testing.T) {
+		assert.NotEqual(t, flowHash(flowPkt(40000, 30041, 1)), flowHash(flowPkt(40001, 30041, 1)))
+	})
+	t.Run("malformed packets", func(t *testing.T) {
+		raw := flowPkt(40000, 30041, 1)
+		for i := 0; i < len(raw); i++ {
+			assert.NotPanics(t, func() { flowHash(raw[:i]) })
+		}
+		raw[4] = 0xff
+		assert.NotPanics(t, func() { flowHash(raw) })
+	})
+}
+
+func TestDispatcherKeepsFlowOrder(t *testing.T) {
+	pool := newWorkerPool(4)
+	d := pool.newDispatcher()
+	var pkts ringbuf.EntryList
+	for i := 0; i < 32; i++ {
+		rp := &rpkt.RtrPkt{Raw: flowPkt(uint16(40000+i%3), 30041, byte(i))}
+		pkts = append(pkts, rp)
+	}
+	d.dispatch(pkts)
+	// All packets of a flow are queued in order on the same ring.
+	total := 0
+	for _, ring := range pool.rings {
+		out := make(ringbuf.EntryList, workerRingSize)
+		n, _ := ring.Read(out, false)
+		total += n
+		last := map[uint32]int{}
+		for _, entry := range out[:n] {
+			raw := entry.(*rpkt.RtrPkt).Raw
+			port := uint32(common.Order.Uint16(raw[len(raw)-9:]))
+			seq := int(raw[len(raw)-1])
+			if prev, ok := last[port]; ok {
+				assert.True(t, prev < seq, "flow %d reordered", port)
+			}
+			last[port] = seq
+		}
+	}
+	require.Equal(t, len(pkts), total)
+	for _, batch := range d.batches {
+		assert.Empty(t, batch)
+	}
+}
+
+func TestWorkersReprocessExtToExt(t *testing.T) {
+	r := initTestRouter(8)
+	r.memNet = memio.NewNetwork()
+	r.workers = newWorkerPool(2)
+	r.workers.start(r)
+	conf := loadConfig(t)
+	conf.MasterKeys = keyconf.Master{Key0: make([]byte, 16)}
+	ctx := rctx.New(conf)
+	require.NoError(t, ctx.InitMacPool())
+	require.NoError(t, r.setupNet(ctx, nil, brconf.SockConf{Default: MemSock}))
+	rctx.Set(ctx)
+	startSocks(ctx)
+	defer closeAllSocks(ctx)
+	in, out := conf.BR.IFs[11], conf.BR.IFs[12]
+	src, err := r.memNet.Listen(in.Remote, in.Local)
+	require.NoError(t, err)
+	defer src.Close()
+	dst, err := r.memNet.Listen(out.Remote, out.Local)
+	require.NoError(t, err)
+	defer dst.Close()
+
+	// The packets enter on interface 11 and leave on interface 12 of the same
+	// router, i.e., all of them are reprocessed. Send more packets than fit
+	// into the ring of the local socket.
+	const numPkts = 200
+	for i := 0; i < numPkts; i++ {
+		_, err := src.Write(transitPkt(t, conf.MasterKeys.Key0, byte(i)))
+		require.NoError(t, err)
+	}
+	buf := make(common.RawBytes, 1500)
+	for i := 0; i < numPkts; i++ {
+		require.NoError(t, dst.SetReadDeadline(time.Now().Add(time.Second)))
+		n, _, err := dst.Read(buf)
+		require.NoError(t, err, "packet %d", i)
+		// The hop field of the next AS is the current one.
+		assert.Equal(t, uint8(transitHopOff+1), buf[6])
+		// The packets of a flow stay in order.
+		assert.Equal(t, byte(i), buf[n-1])
+	}
+}
+
+const (
+	// transitHdrLen is the length of the common, the address and the path
+	// header of a transit packet in bytes.
+	transitHdrLen = spkt.CmnHdrLen + 2*addr.IABytes + 2*addr.HostLenIPv4 +
+		spath.InfoFieldLength + 3*spath.HopFieldLength
+	// transitInfoOff is the offset of the info field in lines.
+	transitInfoOff = (spkt.CmnHdrLen + 2*addr.IABytes + 2*addr.HostLenIPv4) / common.LineLen
+	// transitHopOff is the offset of the hop field of the local AS in lines.
+	transitHopOff = transitInfoOff + 2
+)
+
+// transitPkt builds a raw SCION/UDP packet from 1-ff00:0:110 to 1-ff00:0:120
+// that transits the local AS from interface 11 to interface 12 on a down
+// segment. Only the hop field of the local AS carries a valid MAC.
+func transitPkt(t *testing.T, key []byte, payload byte) common.RawBytes {
+	raw := make(common.RawBytes, transitHdrLen+8+1)
+	cmn := spkt.CmnHdr{
+		DstType:   addr.HostTypeIPv4,
+		SrcType:   addr.HostTypeIPv4,
+		TotalLen:  uint16(len(raw)),
+		HdrLen:    transitHdrLen / common.LineLen,
+		CurrInfoF: transitInfoOff,
+		CurrHopF:  transitHopOff,
+		NextHdr:   common.L4UDP,
+	}
+	cmn.Write(raw)
+	addrHdr := raw[spkt.CmnHdrLen:]
+	addr.IA{I: 1, A: 0xff0000000120}.Write(addrHdr)
+	addr.IA{I: 1, A: 0xff0000000110}.Write(addrHdr[addr.IABytes:])
+	copy(addrHdr[2*addr.IABytes:], []byte{192, 0, 2, 1, 192, 0, 2, 2})
+
+	info := spath.InfoField{ConsDir: true, TsInt: util.TimeToSecs(time.Now()), ISD: 1, Hops: 3}
+	info.Write(raw[transitInfoOff*common.LineLen:])
+	hops := []*spath.HopField{
+		{ConsEgress: 1},
+		{ConsIngress: 11, ConsEgress: 12},
+		{ConsIngress: 2},
+	}
+	macFactory, err := scrypto.HFMacFactory(key)
+	require.NoError(t, err)
+	for i, hop := range hops {
+		off := (transitInfoOff + 1 + i) * common.LineLen
+		hop.Mac = make(common.RawBytes, spath.MacLen)
+		if i == 1 {
+			prev := raw[off-common.LineLen+1 : off]
+			hop.Mac = hop.CalcMac(macFactory(), info.TsInt, prev)
+		}
+		hop.Write(raw[off:])
+	}
+	common.Order.PutUint16(raw[transitHdrLen:], 40000)
+	common.Order.PutUint16(raw[transitHdrLen+2:], 30041)
+	common.Order.PutUint16(raw[transitHdrLen+4:], 9)
+	raw[len(raw)-1] = payload
+	return raw
+}
@@ -0,0 +1,523 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package beacondbmem implements the beacon.DB interface with an in-memory
+// backend. The semantics mirror the sqlite backend in beacondbsqlite. Beacons
+// and revocations are stored in packed form and parsed on every read, such
+// that callers never share state with the database.
+//
+// BeginTransaction snapshots the beacons and revocations. The beacon store
+// can thus insert a batch of beacons and publish them at once on commit. All
+// other writers block while the transaction is open.
+package beacondbmem
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/infra/modules/db"
+	"github.com/scionproto/scion/go/lib/log"
+)
+
+var _ beacon.DB = (*Backend)(nil)
+
+// Backend is an in-memory beacon database.
+type Backend struct {
+	*executor
+	// writeLock serializes writers. It is held for the whole lifetime of a
+	// transaction.
+	writeLock *db.WriteLock
+}
+
+// New returns a new empty in-memory backend.
+func New(ia addr.IA) *Backend {
+	return &Backend{
+		executor: &executor{
+			ia:          ia,
+			beacons:     make(map[string]*beaconEntry),
+			revocations: make(map[intfKey]*revEntry),
+		},
+		writeLock: db.NewWriteLock(),
+	}
+}
+
+// SetMaxOpenConns is a no-op for the in-memory backend.
+func (b *Backend) SetMaxOpenConns(_ int) {}
+
+// SetMaxIdleConns is a no-op for the in-memory backend.
+func (b *Backend) SetMaxIdleConns(_ int) {}
+
+// BeginTransaction begins a transaction on the database. The call blocks
+// until all other transactions are finished or the context is done.
+func (b *Backend) BeginTransaction(ctx context.Context,
+	_ *sql.TxOptions) (beacon.Transaction, error) {
+
+	tx, err := b.writeLock.Begin(ctx)
+	if err != nil {
+		return nil, db.NewTxError("create tx", err)
+	}
+	return &transaction{
+		executor: b.executor.clone(),
+		backend:  b,
+		tx:       tx,
+	}, nil
+}
+
+// InsertBeacon inserts the beacon if it is new or updates the changed
+// information.
+func (b *Backend) InsertBeacon(ctx context.Context, bcn beacon.Beacon,
+	usage beacon.Usage) (beacon.InsertStats, error) {
+
+	if err := b.writeLock.Acquire(ctx); err != nil {
+		return beacon.InsertStats{}, db.NewWriteError("insert beacon", err)
+	}
+	defer b.writeLock.Release()
+	return b.executor.InsertBeacon(ctx, bcn, usage)
+}
+
+// DeleteExpiredBeacons deletes all beacons that expire before now.
+func (b *Backend) DeleteExpiredBeacons(ctx context.Context, now time.Time) (int, error) {
+	if err := b.writeLock.Acquire(ctx); err != nil {
+		return 0, db.NewWriteError("delete expired beacons", err)
+	}
+	defer b.writeLock.Release()
+	return b.executor.DeleteExpiredBeacons(ctx, now)
+}
+
+// DeleteRevokedBeacons deletes all beacons that traverse a revoked interface.
+func (b *Backend) DeleteRevokedBeacons(ctx context.Context, now time.Time) (int, error) {
+	if err := b.writeLock.Acquire(ctx); err != nil {
+		return 0, db.NewWriteError("delete revoked beacons", err)
+	}
+	defer b.writeLock.Release()
+	return b.executor.DeleteRevokedBeacons(ctx, now)
+}
+
+// InsertRevocation inserts the revocation, unless a newer revocation for the
+// same interface is already present.
+func (b *Backend) InsertRevocation(ctx context.Context,
+	revocation *path_mgmt.SignedRevInfo) error {
+
+	if err := b.writeLock.Acquire(ctx); err != nil {
+		return db.NewWriteError("insert revocation", err)
+	}
+	defer b.writeLock.Release()
+	return b.executor.InsertRevocation(ctx, revocation)
+}
+
+// DeleteRevocation deletes the revocation for the given interface.
+func (b *Backend) DeleteRevocation(ctx context.Context, ia addr.IA,
+	ifid common.IFIDType) error {
+
+	if err := b.writeLock.Acquire(ctx); err != nil {
+		return db.NewWriteError("delete revocation", err)
+	}
+	defer b.writeLock.Release()
+	return b.executor.DeleteRevocation(ctx, ia, ifid)
+}
+
+// DeleteExpiredRevocations deletes all revocations that expire before now.
+func (b *Backend) DeleteExpiredRevocations(ctx context.Context, now time.Time) (int, error) {
+	if err := b.writeLock.Acquire(ctx); err != nil {
+		return 0, db.NewWriteError("delete expired revocations", err)
+	}
+	defer b.writeLock.Release()
+	return b.executor.DeleteExpiredRevocations(ctx, now)
+}
+
+// Close closes the database.
+func (b *Backend) Close() error {
+	return nil
+}
+
+var _ (beacon.Transaction) = (*transaction)(nil)
+
+type transaction struct {
+	*executor
+	backend *Backend
+	tx      *db.WriteTx
+}
+
+func (tx *transaction) Commit() error {
+	return tx.tx.Commit(func() {
+		tx.RLock()
+		defer tx.RUnlock()
+		tx.backend.Lock()
+		defer tx.backend.Unlock()
+		tx.backend.nextRowID = tx.nextRowID
+		tx.backend.beacons = tx.beacons
+		tx.backend.revocations = tx.revocations
+	})
+}
+
+func (tx *transaction) Rollback() error {
+	return tx.tx.Rollback()
+}
+
+type intfKey struct {
+	ia   addr.IA
+	ifid common.IFIDType
+}
+
+type beaconEntry struct {
+	// rowID is assigned on insertion and kept on updates. It breaks ties
+	// between beacons of equal length.
+	rowID       int64
+	start       addr.IA
+	inIfID      common.IFIDType
+	hopsLength  int
+	infoTime    time.Time
+	expiration  time.Time
+	lastUpdated time.Time
+	usage       beacon.Usage
+	packed      common.RawBytes
+	intfs       []intfKey
+}
+
+type revEntry struct {
+	issuingTime uint32
+	expiration  time.Time
+	packed      common.RawBytes
+}
+
+var _ (beacon.DBReadWrite) = (*executor)(nil)
+
+type executor struct {
+	sync.RWMutex
+	ia          addr.IA
+	nextRowID   int64
+	beacons     map[string]*beaconEntry
+	revocations map[intfKey]*revEntry
+}
+
+// clone returns a copy of the executor. Entries are never modified in place,
+// thus copying the maps is sufficient.
+func (e *executor) clone() *executor {
+	e.RLock()
+	defer e.RUnlock()
+	c := &executor{
+		ia:          e.ia,
+		nextRowID:   e.nextRowID,
+		beacons:     make(map[string]*beaconEntry, len(e.beacons)),
+		revocations: make(map[intfKey]*revEntry, len(e.revocations)),
+	}
+	for k, v := range e.beacons {
+		c.beacons[k] = v
+	}
+	for k, v := range e.revocations {
+		c.revocations[k] = v
+	}
+	return c
+}
+
+func (e *executor) AllRevocations(_ context.Context) (<-chan beacon.RevocationOrErr, error) {
+	e.RLock()
+	defer e.RUnlock()
+	// Revocations are few, buffer them all instead of streaming.
+	res := make(chan beacon.RevocationOrErr, len(e.revocations))
+	for _, r := range e.revocations {
+		srev, err := path_mgmt.NewSignedRevInfoFromRaw(r.packed)
+		if err != nil {
+			err = db.NewDataError(beacon.ErrParse, err)
+		}
+		res <- beacon.RevocationOrErr{
+			Rev: srev,
+			Err: err,
+		}
+	}
+	close(res)
+	return res, nil
+}
+
+func (e *executor) AllBeacons(_ context.Context) (<-chan beacon.StoredBeaconOrErr, error) {
+	e.RLock()
+	defer e.RUnlock()
+	entries := make([]*beaconEntry, 0, len(e.beacons))
+	for _, b := range e.beacons {
+		entries = append(entries, b)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		switch {
+		case a.start.I != b.start.I:
+			return a.start.I < b.start.I
+		case a.start.A != b.start.A:
+			return a.start.A < b.start.A
+		case a.inIfID != b.inIfID:
+			return a.inIfID < b.inIfID
+		case a.hopsLength != b.hopsLength:
+			return a.hopsLength < b.hopsLength
+		}
+		return a.rowID < b.rowID
+	})
+	// The entries are already sorted in memory, no need for a producer
+	// goroutine.
+	res := make(chan beacon.StoredBeaconOrErr, len(entries))
+	for _, entry := range entries {
+		s, err := seg.NewBeaconFromRaw(entry.packed)
+		if err != nil {
+			res <- beacon.StoredBeaconOrErr{Err: db.NewDataError(beacon.ErrParse, err)}
+			continue
+		}
+		res <- beacon.StoredBeaconOrErr{
+			Beacon: beacon.StoredBeacon{
+				Beacon:      beacon.Beacon{Segment: s, InIfId: entry.inIfID},
+				Usage:       entry.usage,
+				LastUpdated: entry.lastUpdated,
+			},
+		}
+	}
+	close(res)
+	return res, nil
+}
+
+func (e *executor) BeaconSources(_ context.Context) ([]addr.IA, error) {
+	e.RLock()
+	defer e.RUnlock()
+	seen := make(map[addr.IA]struct{})
+	var ias []addr.IA
+	for _, b := range e.beacons {
+		if _, ok := seen[b.start]; ok {
+			continue
+		}
+		seen[b.start] = struct{}{}
+		ias = append(ias, b.start)
+	}
+	return ias, nil
+}
+
+func (e *executor) CandidateBeacons(_ context.Context, setSize int, usage beacon.Usage,
+	src addr.IA) (<-chan beacon.BeaconOrErr, error) {
+
+	e.RLock()
+	defer e.RUnlock()
+	now := time.Now().Unix()
+	var entries []*beaconEntry
+	for _, b := range e.beacons {
+		if b.usage&usage != usage {
+			continue
+		}
+		if !src.IsZero() && !src.Equal(b.start) {
+			continue
+		}
+		if e.isRevoked(b, now) {
+			continue
+		}
+		entries = append(entries, b)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].hopsLength != entries[j].hopsLength {
+			return entries[i].hopsLength < entries[j].hopsLength
+		}
+		return entries[i].rowID < entries[j].rowID
+	})
+	if len(entries) > setSize {
+		entries = entries[:setSize]
+	}
+	beacons := make([]beacon.Beacon, 0, len(entries))
+	var errors []error
+	for _, entry := range entries {
+		s, err := seg.NewBeaconFromRaw(entry.packed)
+		if err != nil {
+			errors = append(errors, db.NewDataError(beacon.ErrParse, err))
+			continue
+		}
+		beacons = append(beacons, beacon.Beacon{Segment: s, InIfId: entry.inIfID})
+	}
+	results := make(chan beacon.BeaconOrErr)
+	go func() {
+		defer log.HandlePanic()
+		defer close(results)
+		for _, b := range beacons {
+			results <- beacon.BeaconOrErr{Beacon: b}
+		}
+		for _, e := range errors {
+			results <- beacon.BeaconOrErr{Err: e}
+			return
+		}
+	}()
+	return results, nil
+}
+
+// isRevoked indicates whether any interface on the beacon is covered by a
+// revocation that has not expired at now (in unix seconds). The caller must
+// hold the lock.
+func (e *executor) isRevoked(b *beaconEntry, now int64) bool {
+	for _, intf := range b.intfs {
+		if r, ok := e.revocations[intf]; ok && r.expiration.Unix() >= now {
+			return true
+		}
+	}
+	return false
+}
+
+// InsertBeacon inserts the beacon if it is new or updates the changed
+// information.
+func (e *executor) InsertBeacon(_ context.Context, b beacon.Beacon,
+	usage beacon.Usage) (beacon.InsertStats, error) {
+
+	ret := beacon.InsertStats{}
+	segID, err := b.Segment.ID()
+	if err != nil {
+		return ret, db.NewInputDataError("extract id", err)
+	}
+	if _, err := b.Segment.FullId(); err != nil {
+		return ret, db.NewInputDataError("extract full id", err)
+	}
+	info, err := b.Segment.InfoF()
+	if err != nil {
+		return ret, db.NewInputDataError("extract infof", err)
+	}
+	packed, err := b.Segment.Pack()
+	if err != nil {
+		return ret, db.NewInputDataError("pack segment", err)
+	}
+	intfs, err := interfaces(b, e.ia)
+	if err != nil {
+		return ret, err
+	}
+	entry := &beaconEntry{
+		start:       b.Segment.FirstIA(),
+		inIfID:      b.InIfId,
+		hopsLength:  len(b.Segment.ASEntries),
+		infoTime:    info.Timestamp(),
+		expiration:  b.Segment.MaxExpiry(),
+		lastUpdated: time.Now(),
+		usage:       usage,
+		packed:      packed,
+		intfs:       intfs,
+	}
+
+	e.Lock()
+	defer e.Unlock()
+	key := string(segID)
+	if existing, ok := e.beacons[key]; ok {
+		// Update the beacon data if it is newer.
+		if entry.infoTime.After(existing.infoTime) {
+			// The indexed interfaces are only written on insertion.
+			entry.rowID, entry.intfs = existing.rowID, existing.intfs
+			e.beacons[key] = entry
+			ret.Updated = 1
+		}
+		return ret, nil
+	}
+	e.nextRowID++
+	entry.rowID = e.nextRowID
+	e.beacons[key] = entry
+	ret.Inserted = 1
+	return ret, nil
+}
+
+// interfaces returns all non-peering interfaces the beacon traverses,
+// including the ingress interface in the local AS.
+func interfaces(b beacon.Beacon, localIA addr.IA) ([]intfKey, error) {
+	var intfs []intfKey
+	for _, as := range b.Segment.ASEntries {
+		ia := as.IA()
+		// Do not insert peering interfaces.
+		hof, err := as.HopEntries[0].HopField()
+		if err != nil {
+			return nil, db.NewInputDataError("extract hop field", err)
+		}
+		// Ignore the null interface of the first and last hop.
+		if hof.ConsIngress != 0 {
+			intfs = append(intfs, intfKey{ia: ia, ifid: hof.ConsIngress})
+		}
+		if hof.ConsEgress != 0 {
+			intfs = append(intfs, intfKey{ia: ia, ifid: hof.ConsEgress})
+		}
+	}
+	return append(intfs, intfKey{ia: localIA, ifid: b.InIfId}), nil
+}
+
+func (e *executor) DeleteExpiredBeacons(_ context.Context, now time.Time) (int, error) {
+	e.Lock()
+	defer e.Unlock()
+	var deleted int
+	for k, b := range e.beacons {
+		if b.expiration.Unix() < now.Unix() {
+			delete(e.beacons, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (e *executor) DeleteRevokedBeacons(_ context.Context, now time.Time) (int, error) {
+	e.Lock()
+	defer e.Unlock()
+	var deleted int
+	for k, b := range e.beacons {
+		if e.isRevoked(b, now.Unix()) {
+			delete(e.beacons, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (e *executor) InsertRevocation(_ context.Context,
+	revocation *path_mgmt.SignedRevInfo) error {
+
+	revInfo, err := revocation.RevInfo()
+	if err != nil {
+		return db.NewInputDataError("extract revocation", err)
+	}
+	packed, err := revocation.Pack()
+	if err != nil {
+		return db.NewInputDataError("pack revocation", err)
+	}
+	e.Lock()
+	defer e.Unlock()
+	key := intfKey{ia: revInfo.IA(), ifid: revInfo.IfID}
+	if existing, ok := e.revocations[key]; ok && existing.issuingTime > revInfo.RawTimestamp {
+		return nil
+	}
+	e.revocations[key] = &revEntry{
+		issuingTime: revInfo.RawTimestamp,
+		expiration:  revInfo.Expiration(),
+		packed:      packed,
+	}
+	return nil
+}
+
+func (e *executor) DeleteRevocation(_ context.Context, ia addr.IA,
+	ifid common.IFIDType) error {
+
+	e.Lock()
+	defer e.Unlock()
+	delete(e.revocations, intfKey{ia: ia, ifid: ifid})
+	return nil
+}
+
+func (e *executor) DeleteExpiredRevocations(_ context.Context, now time.Time) (int, error) {
+	e.Lock()
+	defer e.Unlock()
+	var deleted int
+	for k, r := range e.revocations {
+		if r.expiration.Unix() < now.Unix() {
+			delete(e.revocations, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacondbmem
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/beacon/beacondbtest"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var testIA = xtest.MustParseIA("1-ff00:0:333")
+
+var _ beacondbtest.Testable = (*TestBackend)(nil)
+
+type TestBackend struct {
+	*Backend
+}
+
+func (b *TestBackend) Prepare(t *testing.T, _ context.Context) {
+	b.Backend = New(testIA)
+}
+
+func TestBeaconDBSuite(t *testing.T) {
+	tdb := &TestBackend{}
+	beacondbtest.Test(t, tdb)
+}
+
+// TestReturnedBeaconsAreCopies tests that modifying a returned beacon does
+// not alter the stored beacon.
+func TestReturnedBeaconsAreCopies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	db := New(testIA)
+	b := beacondbtest.InsertBeacon(t, ctrl, db, beacondbtest.Info1, 2, 10, beacon.UsageProp)
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	res, err := db.CandidateBeacons(ctx, 10, beacon.UsageProp, addr.IA{})
+	require.NoError(t, err)
+	r := <-res
+	require.NoError(t, r.Err)
+	r.Beacon.Segment.ASEntries = nil
+	res, err = db.CandidateBeacons(ctx, 10, beacon.UsageProp, addr.IA{})
+	require.NoError(t, err)
+	beacondbtest.CheckResult(t, res, b)
+}
+
+// TestTransactionBlocksWriters tests that writes outside of an open
+// transaction block until it is finished.
+func TestTransactionBlocksWriters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	db := New(testIA)
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	tx, err := db.BeginTransaction(ctx, nil)
+	require.NoError(t, err)
+	b, _ := beacondbtest.AllocBeacon(t, ctrl, beacondbtest.Info1, 2, 10)
+	shortCtx, shortCancelF := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer shortCancelF()
+	_, err = db.InsertBeacon(shortCtx, b, beacon.UsageProp)
+	assert.Error(t, err)
+	require.NoError(t, tx.Rollback())
+	assert.Error(t, tx.Commit())
+	stats, err := db.InsertBeacon(ctx, b, beacon.UsageProp)
+	require.NoError(t, err)
+	assert.Equal(t, beacon.InsertStats{Inserted: 1}, stats)
+}
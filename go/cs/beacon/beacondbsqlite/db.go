@@ -160,6 +160,53 @@ func (e *executor) AllRevocations(ctx context.Context) (<-chan beacon.Revocation
 	return res, nil
 }
 
+func (e *executor) AllBeacons(ctx context.Context) (<-chan beacon.StoredBeaconOrErr, error) {
+	e.RLock()
+	defer e.RUnlock()
+	query := `
+		SELECT Beacon, InIntfID, Usage, LastUpdated
+		FROM Beacons
+		ORDER BY StartIsd, StartAs, InIntfID, HopsLength, RowID
+	`
+	rows, err := e.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, db.NewReadError("Error selecting beacons", err)
+	}
+	res := make(chan beacon.StoredBeaconOrErr)
+	go func() {
+		defer log.HandlePanic()
+		defer close(res)
+		defer rows.Close()
+		for rows.Next() {
+			var rawBeacon sql.RawBytes
+			var inIntfId common.IFIDType
+			var usage beacon.Usage
+			var lastUpdated int64
+			err = rows.Scan(&rawBeacon, &inIntfId, &usage, &lastUpdated)
+			if err != nil {
+				res <- beacon.StoredBeaconOrErr{Err: db.NewReadError(beacon.ErrReadingRows, err)}
+				return
+			}
+			s, err := seg.NewBeaconFromRaw(common.RawBytes(rawBeacon))
+			if err != nil {
+				res <- beacon.StoredBeaconOrErr{Err: db.NewDataError(beacon.ErrParse, err)}
+				continue
+			}
+			res <- beacon.StoredBeaconOrErr{
+				Beacon: beacon.StoredBeacon{
+					Beacon:      beacon.Beacon{Segment: s, InIfId: inIntfId},
+					Usage:       usage,
+					LastUpdated: time.Unix(0, lastUpdated),
+				},
+			}
+		}
+		if err := rows.Err(); err != nil {
+			res <- beacon.StoredBeaconOrErr{Err: db.NewReadError(beacon.ErrReadingRows, err)}
+		}
+	}()
+	return res, nil
+}
+
 func (e *executor) BeaconSources(ctx context.Context) ([]addr.IA, error) {
 	e.RLock()
 	defer e.RUnlock()
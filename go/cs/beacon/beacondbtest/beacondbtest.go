@@ -129,6 +129,8 @@ func Test(t *testing.T, db Testable) {
 		tableWrapper(false, testDeleteRevokedBeacons))
 	t.Run("AllRevocations",
 		tableWrapper(false, testAllRevocations))
+	t.Run("AllBeacons should return all beacons in order",
+		testWrapper(testAllBeacons))
 	t.Run("InsertRevocation updates existing rev",
 		testWrapper(testInsertUpdateRevocation))
 	t.Run("DeleteRevocations should delete revocations",
@@ -168,6 +170,8 @@ func Test(t *testing.T, db Testable) {
 			tableWrapper(true, testDeleteRevokedBeacons))
 		t.Run("AllRevocations",
 			tableWrapper(true, testAllRevocations))
+		t.Run("AllBeacons should return all beacons in order",
+			txTestWrapper(testAllBeacons))
 		t.Run("InsertRevocation updates existing rev",
 			txTestWrapper(testInsertUpdateRevocation))
 		t.Run("DeleteRevocations should delete revocations",
@@ -196,6 +200,39 @@ func testBeaconSources(t *testing.T, ctrl *gomock.Controller, db beacon.DBReadWr
 	assert.ElementsMatch(t, []addr.IA{ia311, ia330}, ias)
 }
 
+func testAllBeacons(t *testing.T, ctrl *gomock.Controller, db beacon.DBReadWrite) {
+	b3 := InsertBeacon(t, ctrl, db, Info3, 13, 1, beacon.UsageProp)
+	b2 := InsertBeacon(t, ctrl, db, Info2, 12, 2, beacon.UsageProp|beacon.UsageUpReg)
+	b1 := InsertBeacon(t, ctrl, db, Info1, 14, 3, beacon.UsageDownReg)
+	ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+	defer cancelF()
+	results, err := db.AllBeacons(ctx)
+	require.NoError(t, err)
+	expected := []struct {
+		Beacon beacon.Beacon
+		Usage  beacon.Usage
+	}{
+		{Beacon: b1, Usage: beacon.UsageDownReg},
+		{Beacon: b2, Usage: beacon.UsageProp | beacon.UsageUpReg},
+		{Beacon: b3, Usage: beacon.UsageProp},
+	}
+	var i int
+	for res := range results {
+		require.NoError(t, res.Err)
+		require.True(t, i < len(expected), "unexpected beacon")
+		expectedID, err := expected[i].Beacon.Segment.ID()
+		require.NoError(t, err)
+		id, err := res.Beacon.Beacon.Segment.ID()
+		require.NoError(t, err)
+		assert.Equal(t, expectedID, id)
+		assert.Equal(t, expected[i].Beacon.InIfId, res.Beacon.Beacon.InIfId)
+		assert.Equal(t, expected[i].Usage, res.Beacon.Usage)
+		assert.False(t, res.Beacon.LastUpdated.IsZero())
+		i++
+	}
+	assert.Equal(t, len(expected), i)
+}
+
 func testInsertBeacon(t *testing.T, ctrl *gomock.Controller, db beacon.DBReadWrite) {
 	TS := uint32(10)
 	b, _ := AllocBeacon(t, ctrl, Info3, 12, TS)
@@ -54,6 +54,26 @@ type DBRead interface {
 	// be drained, since the implementation might spawn go routines to fill the
 	// channel.
 	AllRevocations(ctx context.Context) (<-chan RevocationOrErr, error)
+	// AllBeacons returns all beacons in the database together with their
+	// usage, ordered by start ISD-AS and ingress interface. The result
+	// channel either carries beacons or errors. The channel must be drained,
+	// since the db might spawn go routines to fill the channel.
+	AllBeacons(ctx context.Context) (<-chan StoredBeaconOrErr, error)
+}
+
+// StoredBeacon is a beacon with the metadata kept in the database.
+type StoredBeacon struct {
+	Beacon Beacon
+	// Usage is the allowed usage of the beacon.
+	Usage Usage
+	// LastUpdated is the time the beacon was inserted or last updated.
+	LastUpdated time.Time
+}
+
+// StoredBeaconOrErr contains a stored beacon or an error.
+type StoredBeaconOrErr struct {
+	Beacon StoredBeacon
+	Err    error
 }
 
 // InsertStats provides statistics about an insertion.
@@ -128,6 +148,11 @@ func (u Usage) None() bool {
 }
 
 func (u Usage) String() string {
+	return fmt.Sprintf("Usage: [%s]", strings.Join(u.Names(), ","))
+}
+
+// Names returns the names of the set usage flags.
+func (u Usage) Names() []string {
 	names := []string{}
 	if u&UsageUpReg != 0 {
 		names = append(names, "UpRegistration")
@@ -141,5 +166,5 @@ func (u Usage) String() string {
 	if u&UsageProp != 0 {
 		names = append(names, "Propagation")
 	}
-	return fmt.Sprintf("Usage: [%s]", strings.Join(names, ","))
+	return names
 }
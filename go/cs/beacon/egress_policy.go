@@ -0,0 +1,154 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"sync"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// EgressPolicy restricts the propagation of beacons on specific egress
+// interfaces or towards specific neighbor ASes. Egress policies are part of
+// the propagation policy and are applied in addition to its filter.
+type EgressPolicy struct {
+	// Interfaces are the egress interfaces the policy applies to.
+	Interfaces []common.IFIDType `yaml:"Interfaces"`
+	// Neighbors are the neighbor ASes the policy applies to.
+	Neighbors []addr.IA `yaml:"Neighbors"`
+	// Origins restricts propagation to beacons that originate in one of the
+	// listed ASes. A wildcard AS matches all ASes of the ISD. If empty, beacons
+	// of all origins are propagated.
+	Origins []addr.IA `yaml:"Origins"`
+	// MaxBeacons is the maximum number of beacons propagated to each neighbor
+	// AS the policy applies to in one propagation run. Zero means no limit.
+	MaxBeacons int `yaml:"MaxBeacons"`
+	// Filter is the filter applied to the beacons. Unset values are inherited
+	// from the filter of the propagation policy.
+	Filter Filter `yaml:"Filter"`
+}
+
+func (p *EgressPolicy) initDefaults(parent Filter) {
+	if p.Filter.MaxHopsLength == 0 {
+		p.Filter.MaxHopsLength = parent.MaxHopsLength
+	}
+	if p.Filter.AllowIsdLoop == nil {
+		p.Filter.AllowIsdLoop = parent.AllowIsdLoop
+	}
+	p.Filter.InitDefaults()
+}
+
+// Validate checks that the policy is scoped and the limits are valid.
+func (p *EgressPolicy) Validate() error {
+	if len(p.Interfaces) == 0 && len(p.Neighbors) == 0 {
+		return common.NewBasicError("Egress policy without interfaces or neighbors", nil)
+	}
+	if p.MaxBeacons < 0 {
+		return common.NewBasicError("MaxBeacons must not be negative", nil,
+			"max_beacons", p.MaxBeacons)
+	}
+	return nil
+}
+
+// appliesTo indicates whether the policy applies to the egress interface or
+// the neighbor AS.
+func (p *EgressPolicy) appliesTo(egIfid common.IFIDType, neighbor addr.IA) bool {
+	for _, ifid := range p.Interfaces {
+		if ifid == egIfid {
+			return true
+		}
+	}
+	for _, ia := range p.Neighbors {
+		if ia.Equal(neighbor) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply returns an error if the beacon is filtered by the policy.
+func (p *EgressPolicy) apply(beacon Beacon) error {
+	if len(p.Origins) > 0 {
+		origin := beacon.Segment.FirstIA()
+		if !matchesAny(origin, p.Origins) {
+			return common.NewBasicError("Origin not allowed", nil, "origin", origin)
+		}
+	}
+	return p.Filter.Apply(beacon)
+}
+
+func matchesAny(ia addr.IA, patterns []addr.IA) bool {
+	for _, pattern := range patterns {
+		if pattern.I == ia.I && (pattern.A == 0 || pattern.A == ia.A) {
+			return true
+		}
+	}
+	return false
+}
+
+// EgressFilter applies the egress policies during one propagation run. It
+// keeps track of the number of beacons propagated to each neighbor AS to
+// enforce the beacon limits. It is safe for concurrent use.
+type EgressFilter struct {
+	policies []EgressPolicy
+	mu       sync.Mutex
+	counts   map[egressKey]int
+}
+
+type egressKey struct {
+	policy   int
+	neighbor addr.IA
+}
+
+// NewEgressFilter creates a filter for one propagation run. The policies must
+// be initialized, i.e., they are part of an initialized propagation policy.
+func NewEgressFilter(policies []EgressPolicy) *EgressFilter {
+	return &EgressFilter{
+		policies: policies,
+		counts:   make(map[egressKey]int),
+	}
+}
+
+// Apply returns an error if the beacon must not be propagated on the egress
+// interface towards the neighbor AS. An accepted beacon counts against the
+// beacon limits of all policies that apply. A nil filter accepts all beacons.
+func (f *EgressFilter) Apply(beacon Beacon, egIfid common.IFIDType, neighbor addr.IA) error {
+	if f == nil || len(f.policies) == 0 {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var applied []egressKey
+	for i := range f.policies {
+		policy := &f.policies[i]
+		if !policy.appliesTo(egIfid, neighbor) {
+			continue
+		}
+		if err := policy.apply(beacon); err != nil {
+			return err
+		}
+		key := egressKey{policy: i, neighbor: neighbor}
+		if policy.MaxBeacons != 0 && f.counts[key] >= policy.MaxBeacons {
+			return common.NewBasicError("MaxBeacons reached", nil, "neighbor", neighbor,
+				"max", policy.MaxBeacons)
+		}
+		applied = append(applied, key)
+	}
+	for _, key := range applied {
+		f.counts[key]++
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestLoadEgressPolicy(t *testing.T) {
+	p, err := beacon.LoadPolicyFromYaml("testdata/egressPolicy.yml", beacon.PropPolicy)
+	require.NoError(t, err)
+	require.Len(t, p.Egress, 2)
+
+	assert.Equal(t, []common.IFIDType{5}, p.Egress[0].Interfaces)
+	assert.Equal(t, []addr.IA{xtest.MustParseIA("1-0")}, p.Egress[0].Origins)
+	// Unset filter values are inherited from the propagation policy.
+	assert.Equal(t, 8, p.Egress[0].Filter.MaxHopsLength)
+	assert.True(t, *p.Egress[0].Filter.AllowIsdLoop)
+
+	assert.Equal(t, []addr.IA{ia210}, p.Egress[1].Neighbors)
+	assert.Equal(t, 2, p.Egress[1].MaxBeacons)
+	assert.Equal(t, 4, p.Egress[1].Filter.MaxHopsLength)
+	assert.Equal(t, []addr.ISD{3}, p.Egress[1].Filter.IsdBlackList)
+
+	_, err = beacon.LoadPolicyFromYaml("testdata/egressPolicy.yml", beacon.UpRegPolicy)
+	assert.Error(t, err)
+}
+
+func TestEgressPolicyValidate(t *testing.T) {
+	tests := map[string]struct {
+		Policy    beacon.EgressPolicy
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"interfaces": {
+			Policy:    beacon.EgressPolicy{Interfaces: []common.IFIDType{1}},
+			Assertion: assert.NoError,
+		},
+		"neighbors": {
+			Policy:    beacon.EgressPolicy{Neighbors: []addr.IA{ia110}, MaxBeacons: 1},
+			Assertion: assert.NoError,
+		},
+		"unscoped": {
+			Policy:    beacon.EgressPolicy{MaxBeacons: 1},
+			Assertion: assert.Error,
+		},
+		"negative max beacons": {
+			Policy:    beacon.EgressPolicy{Neighbors: []addr.IA{ia110}, MaxBeacons: -1},
+			Assertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.Assertion(t, test.Policy.Validate())
+		})
+	}
+}
+
+func TestEgressFilterApply(t *testing.T) {
+	p := beacon.Policy{
+		Type: beacon.PropPolicy,
+		Egress: []beacon.EgressPolicy{
+			{
+				Interfaces: []common.IFIDType{5},
+				Origins:    []addr.IA{xtest.MustParseIA("1-0")},
+			},
+			{
+				Neighbors:  []addr.IA{ia210},
+				MaxBeacons: 2,
+				Filter:     beacon.Filter{AsBlackList: []addr.AS{ia112.A}},
+			},
+		},
+	}
+	p.InitDefaults()
+	f := beacon.NewEgressFilter(p.Egress)
+
+	// The first policy only allows beacons from ISD 1 on interface 5.
+	assert.NoError(t, f.Apply(newTestBeacon(ia110, ia111), 5, ia113))
+	assert.Error(t, f.Apply(newTestBeacon(ia310, ia111), 5, ia113))
+	// Other interfaces are not restricted.
+	assert.NoError(t, f.Apply(newTestBeacon(ia310, ia111), 6, ia113))
+
+	// The second policy filters the blacklisted AS and limits the number of
+	// beacons towards the neighbor.
+	assert.Error(t, f.Apply(newTestBeacon(ia112, ia111), 7, ia210))
+	assert.NoError(t, f.Apply(newTestBeacon(ia110, ia111), 7, ia210))
+	assert.NoError(t, f.Apply(newTestBeacon(ia113, ia111), 8, ia210))
+	assert.Error(t, f.Apply(newTestBeacon(ia311, ia111), 7, ia210))
+
+	// Both policies apply, the filtered beacon does not count against the
+	// limit.
+	f = beacon.NewEgressFilter(p.Egress)
+	assert.Error(t, f.Apply(newTestBeacon(ia310, ia111), 5, ia210))
+	assert.NoError(t, f.Apply(newTestBeacon(ia110, ia111), 5, ia210))
+	assert.NoError(t, f.Apply(newTestBeacon(ia111), 5, ia210))
+	assert.Error(t, f.Apply(newTestBeacon(ia113), 5, ia210))
+
+	// A nil filter accepts all beacons.
+	var nilFilter *beacon.EgressFilter
+	assert.NoError(t, nilFilter.Apply(newTestBeacon(ia310), 5, ia210))
+}
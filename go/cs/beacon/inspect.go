@@ -0,0 +1,124 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/log"
+)
+
+// maxFilteredBeacons is the number of filtered beacons kept for inspection.
+const maxFilteredBeacons = 128
+
+// SelectionResult is the result of a beacon selection for a policy.
+type SelectionResult struct {
+	// Policy is the type of the policy the beacons were selected for.
+	Policy PolicyType
+	// Time is the time the selection was made.
+	Time time.Time
+	// Beacons are the selected beacons.
+	Beacons []Beacon
+	// Errors are the errors that occurred during the selection.
+	Errors []error
+}
+
+// FilteredBeacon is a beacon that was rejected by the policies.
+type FilteredBeacon struct {
+	// Time is the time the beacon was filtered.
+	Time time.Time
+	// Beacon is the filtered beacon.
+	Beacon Beacon
+	// Reason is the reason the beacon was filtered.
+	Reason error
+}
+
+// inspector keeps track of the last selection per policy and the most
+// recently filtered beacons. The zero value is ready to use.
+type inspector struct {
+	mu         sync.Mutex
+	selections map[PolicyType]SelectionResult
+	// filtered is a ring buffer of the last filtered beacons. next is the
+	// index the next filtered beacon is written to.
+	filtered []FilteredBeacon
+	next     int
+}
+
+// record forwards the beacons selected for the policy from in and records
+// them as the last selection of the policy once in is closed. The selection is
+// recorded before the returned channel is closed.
+func (i *inspector) record(policy PolicyType, in <-chan BeaconOrErr) <-chan BeaconOrErr {
+	out := make(chan BeaconOrErr, cap(in))
+	go func() {
+		defer log.HandlePanic()
+		defer close(out)
+		sel := SelectionResult{Policy: policy, Time: time.Now()}
+		for res := range in {
+			if res.Err != nil {
+				sel.Errors = append(sel.Errors, res.Err)
+			} else {
+				sel.Beacons = append(sel.Beacons, res.Beacon)
+			}
+			out <- res
+		}
+		i.mu.Lock()
+		defer i.mu.Unlock()
+		if i.selections == nil {
+			i.selections = make(map[PolicyType]SelectionResult)
+		}
+		i.selections[policy] = sel
+	}()
+	return out
+}
+
+// filter records the beacon as filtered for the given reason.
+func (i *inspector) filter(beacon Beacon, reason error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	f := FilteredBeacon{Time: time.Now(), Beacon: beacon, Reason: reason}
+	if len(i.filtered) < maxFilteredBeacons {
+		i.filtered = append(i.filtered, f)
+		return
+	}
+	i.filtered[i.next] = f
+	i.next = (i.next + 1) % maxFilteredBeacons
+}
+
+// LastSelections returns the last selection for each policy, ordered by
+// policy type.
+func (i *inspector) LastSelections() []SelectionResult {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	sels := make([]SelectionResult, 0, len(i.selections))
+	for _, sel := range i.selections {
+		sels = append(sels, sel)
+	}
+	sort.Slice(sels, func(a, b int) bool { return sels[a].Policy < sels[b].Policy })
+	return sels
+}
+
+// FilteredBeacons returns the most recently filtered beacons, newest first.
+func (i *inspector) FilteredBeacons() []FilteredBeacon {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	res := make([]FilteredBeacon, 0, len(i.filtered))
+	for k := 0; k < len(i.filtered); k++ {
+		idx := (i.next - 1 - k + 2*len(i.filtered)) % len(i.filtered)
+		res = append(res, i.filtered[idx])
+	}
+	return res
+}
@@ -0,0 +1,58 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+func TestInspectorRecord(t *testing.T) {
+	var i inspector
+	in := make(chan BeaconOrErr, 3)
+	in <- BeaconOrErr{Beacon: Beacon{InIfId: 1}}
+	in <- BeaconOrErr{Err: errors.New("fail")}
+	in <- BeaconOrErr{Beacon: Beacon{InIfId: 2}}
+	close(in)
+	var forwarded int
+	for range i.record(PropPolicy, in) {
+		forwarded++
+	}
+	assert.Equal(t, 3, forwarded)
+	// The selection is recorded before the output channel is closed.
+	sels := i.LastSelections()
+	assert.Len(t, sels, 1)
+	assert.Equal(t, PropPolicy, sels[0].Policy)
+	assert.Equal(t, []Beacon{{InIfId: 1}, {InIfId: 2}}, sels[0].Beacons)
+	assert.Len(t, sels[0].Errors, 1)
+}
+
+func TestInspectorFilteredBeacons(t *testing.T) {
+	var i inspector
+	assert.Empty(t, i.FilteredBeacons())
+	total := maxFilteredBeacons + 10
+	for k := 1; k <= total; k++ {
+		i.filter(Beacon{InIfId: common.IFIDType(k)}, errors.New("filtered"))
+	}
+	filtered := i.FilteredBeacons()
+	assert.Len(t, filtered, maxFilteredBeacons)
+	for k, f := range filtered {
+		assert.Equal(t, common.IFIDType(total-k), f.Beacon.InIfId)
+	}
+}
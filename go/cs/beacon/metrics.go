@@ -216,6 +216,16 @@ func (e *executor) AllRevocations(ctx context.Context) (<-chan RevocationOrErr,
 	return ret, err
 }
 
+func (e *executor) AllBeacons(ctx context.Context) (<-chan StoredBeaconOrErr, error) {
+	var ret <-chan StoredBeaconOrErr
+	var err error
+	e.metrics.Observe(ctx, "all_beacons", func(ctx context.Context) error {
+		ret, err = e.db.AllBeacons(ctx)
+		return err
+	})
+	return ret, err
+}
+
 func (e *executor) InsertBeacon(ctx context.Context, beacon Beacon,
 	usage Usage) (InsertStats, error) {
 	var ret InsertStats
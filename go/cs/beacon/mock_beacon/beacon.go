@@ -39,6 +39,21 @@ func (m *MockDB) EXPECT() *MockDBMockRecorder {
 	return m.recorder
 }
 
+// AllBeacons mocks base method
+func (m *MockDB) AllBeacons(arg0 context.Context) (<-chan beacon.StoredBeaconOrErr, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllBeacons", arg0)
+	ret0, _ := ret[0].(<-chan beacon.StoredBeaconOrErr)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllBeacons indicates an expected call of AllBeacons
+func (mr *MockDBMockRecorder) AllBeacons(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllBeacons", reflect.TypeOf((*MockDB)(nil).AllBeacons), arg0)
+}
+
 // AllRevocations mocks base method
 func (m *MockDB) AllRevocations(arg0 context.Context) (<-chan beacon.RevocationOrErr, error) {
 	m.ctrl.T.Helper()
@@ -248,6 +263,21 @@ func (m *MockTransaction) EXPECT() *MockTransactionMockRecorder {
 	return m.recorder
 }
 
+// AllBeacons mocks base method
+func (m *MockTransaction) AllBeacons(arg0 context.Context) (<-chan beacon.StoredBeaconOrErr, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllBeacons", arg0)
+	ret0, _ := ret[0].(<-chan beacon.StoredBeaconOrErr)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllBeacons indicates an expected call of AllBeacons
+func (mr *MockTransactionMockRecorder) AllBeacons(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllBeacons", reflect.TypeOf((*MockTransaction)(nil).AllBeacons), arg0)
+}
+
 // AllRevocations mocks base method
 func (m *MockTransaction) AllRevocations(arg0 context.Context) (<-chan beacon.RevocationOrErr, error) {
 	m.ctrl.T.Helper()
@@ -16,6 +16,7 @@ package beacon
 
 import (
 	"io/ioutil"
+	"time"
 
 	yaml "gopkg.in/yaml.v2"
 
@@ -80,6 +81,11 @@ func (p *Policies) Validate() error {
 		return common.NewBasicError("Invalid policy type", nil,
 			"expected", DownRegPolicy, "actual", p.DownReg.Type)
 	}
+	for _, policy := range []*Policy{&p.Prop, &p.UpReg, &p.DownReg} {
+		if err := policy.validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -142,6 +148,11 @@ func (p *CorePolicies) Validate() error {
 		return common.NewBasicError("Invalid policy type", nil,
 			"expected", CoreRegPolicy, "actual", p.CoreReg.Type)
 	}
+	for _, policy := range []*Policy{&p.Prop, &p.CoreReg} {
+		if err := policy.validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -188,6 +199,13 @@ type Policy struct {
 	Filter Filter `yaml:"Filter"`
 	// Type is the policy type.
 	Type PolicyType `yaml:"Type"`
+	// Selection configures how the best beacons are selected from the
+	// candidate beacons.
+	Selection Selection `yaml:"Selection"`
+	// Egress contains the policies that restrict propagation on specific
+	// egress interfaces or towards specific neighbor ASes. Only supported by
+	// the propagation policy.
+	Egress []EgressPolicy `yaml:"Egress"`
 }
 
 // InitDefaults initializes the default values for unset fields.
@@ -203,6 +221,10 @@ func (p *Policy) InitDefaults() {
 		p.MaxExpTime = &m
 	}
 	p.Filter.InitDefaults()
+	p.Selection.InitDefaults()
+	for i := range p.Egress {
+		p.Egress[i].initDefaults(p.Filter)
+	}
 }
 
 func (p *Policy) initDefaults(t PolicyType) error {
@@ -212,6 +234,23 @@ func (p *Policy) initDefaults(t PolicyType) error {
 			"expected", t, "actual", p.Type)
 	}
 	p.Type = t
+	return p.validate()
+}
+
+// validate checks the selection and the egress policies.
+func (p *Policy) validate() error {
+	if err := p.Selection.Validate(); err != nil {
+		return common.NewBasicError("Invalid selection", err, "type", p.Type)
+	}
+	if len(p.Egress) > 0 && p.Type != PropPolicy {
+		return common.NewBasicError("Egress policies only supported for propagation", nil,
+			"type", p.Type)
+	}
+	for i := range p.Egress {
+		if err := p.Egress[i].Validate(); err != nil {
+			return common.NewBasicError("Invalid egress policy", err, "index", i)
+		}
+	}
 	return nil
 }
 
@@ -237,6 +276,93 @@ func LoadPolicyFromYaml(path string, t PolicyType) (*Policy, error) {
 	return ParsePolicyYaml(b, t)
 }
 
+// SelectionAlgorithm is the name of a beacon selection algorithm.
+type SelectionAlgorithm string
+
+const (
+	// SelectShortest selects the shortest beacons, but also tries to achieve
+	// some path diversity.
+	SelectShortest SelectionAlgorithm = "Shortest"
+	// SelectLowestLatency selects the beacons with the lowest aggregate
+	// latency.
+	SelectLowestLatency SelectionAlgorithm = "LowestLatency"
+	// SelectHighestBandwidth selects the beacons with the widest bottleneck
+	// bandwidth.
+	SelectHighestBandwidth SelectionAlgorithm = "HighestBandwidth"
+	// SelectGeoDiversity selects the beacons that span the most geographic
+	// locations.
+	SelectGeoDiversity SelectionAlgorithm = "GeoDiversity"
+)
+
+// Selection configures the beacon selection. All algorithms except Shortest
+// use the metadata in the static info extensions of the beacons.
+type Selection struct {
+	// Algorithm is the selection algorithm.
+	Algorithm SelectionAlgorithm `yaml:"Algorithm"`
+	// MaxLatency is the maximum aggregate latency of a selected beacon.
+	// Beacons without complete latency information are rejected, since their
+	// latency cannot be bounded. Zero means no limit. Not supported by the
+	// Shortest algorithm.
+	MaxLatency time.Duration `yaml:"MaxLatency"`
+	// MinBandwidth is the minimum bottleneck bandwidth in Kbit/s of a
+	// selected beacon. Beacons without bandwidth information are not
+	// affected. Zero means no limit. Not supported by the Shortest algorithm.
+	MinBandwidth uint64 `yaml:"MinBandwidth"`
+}
+
+// InitDefaults initializes the default values for unset fields.
+func (s *Selection) InitDefaults() {
+	if s.Algorithm == "" {
+		s.Algorithm = SelectShortest
+	}
+}
+
+// Validate checks that the algorithm is known and supports the configured
+// criteria.
+func (s *Selection) Validate() error {
+	switch s.Algorithm {
+	case SelectShortest:
+		if s.MaxLatency != 0 || s.MinBandwidth != 0 {
+			return common.NewBasicError("Selection criteria not supported by algorithm", nil,
+				"algorithm", s.Algorithm)
+		}
+	case SelectLowestLatency, SelectHighestBandwidth, SelectGeoDiversity:
+	default:
+		return common.NewBasicError("Unknown selection algorithm", nil,
+			"algorithm", s.Algorithm)
+	}
+	if s.MaxLatency < 0 {
+		return common.NewBasicError("MaxLatency must not be negative", nil,
+			"max_latency", s.MaxLatency)
+	}
+	return nil
+}
+
+// accept indicates whether a beacon with the given metrics satisfies the
+// selection criteria.
+func (s *Selection) accept(m staticMetrics) bool {
+	if s.MaxLatency != 0 && (!m.latencyComplete || m.latency > s.MaxLatency) {
+		return false
+	}
+	if s.MinBandwidth != 0 && m.bandwidth != 0 && m.bandwidth < s.MinBandwidth {
+		return false
+	}
+	return true
+}
+
+// algorithm returns the configured selection algorithm.
+func (s *Selection) algorithm() selectionAlgorithm {
+	switch s.Algorithm {
+	case SelectLowestLatency:
+		return rankingAlgo{criteria: *s, less: lessLatency}
+	case SelectHighestBandwidth:
+		return rankingAlgo{criteria: *s, less: lessBandwidth}
+	case SelectGeoDiversity:
+		return geoAlgo{criteria: *s}
+	}
+	return baseAlgo{}
+}
+
 // Filter filters beacons.
 type Filter struct {
 	// MaxHopsLength is the maximum number of hops a segment can have.
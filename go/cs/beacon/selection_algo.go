@@ -14,7 +14,10 @@
 
 package beacon
 
-import "math"
+import (
+	"math"
+	"sort"
+)
 
 type selectionAlgorithm interface {
 	// SelectAndServe selects the n best beacons from the beacons channel and
@@ -110,6 +113,108 @@ func (baseAlgo) serveMostDiverse(beacons <-chan BeaconOrErr, results chan<- Beac
 	results <- BeaconOrErr{Beacon: first}
 }
 
+// rankingAlgo selects the best beacons according to a ranking based on the
+// static metrics of the beacons. Beacons without the relevant metrics are
+// ranked last. On equal rank, the shorter beacon is preferred.
+type rankingAlgo struct {
+	criteria Selection
+	// less reports whether the beacon with metrics a is ranked before the one
+	// with metrics b.
+	less func(a, b staticMetrics) bool
+}
+
+func (a rankingAlgo) SelectAndServe(beacons <-chan BeaconOrErr, results chan<- BeaconOrErr,
+	resultSize int) {
+
+	candidates := collectCandidates(beacons, results, a.criteria)
+	// The candidate beacons are sorted by length, the stable sort keeps the
+	// shorter beacons first on equal rank.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return a.less(candidates[i].metrics, candidates[j].metrics)
+	})
+	for i := 0; i < len(candidates) && i < resultSize; i++ {
+		results <- BeaconOrErr{Beacon: candidates[i].beacon}
+	}
+}
+
+// lessLatency ranks beacons with complete latency information first, and then
+// by lowest latency.
+func lessLatency(a, b staticMetrics) bool {
+	if a.latencyComplete != b.latencyComplete {
+		return a.latencyComplete
+	}
+	return a.latency < b.latency
+}
+
+// lessBandwidth ranks beacons by the widest bottleneck bandwidth. Beacons
+// without bandwidth information are ranked last.
+func lessBandwidth(a, b staticMetrics) bool {
+	if (a.bandwidth == 0) != (b.bandwidth == 0) {
+		return b.bandwidth == 0
+	}
+	return a.bandwidth > b.bandwidth
+}
+
+// geoAlgo selects the beacons that span the most geographic locations. The
+// beacons are selected greedily, each selected beacon is the one that adds the
+// most locations that are not yet covered by already selected beacons. On
+// equal number of added locations, the shorter beacon is preferred.
+type geoAlgo struct {
+	criteria Selection
+}
+
+func (a geoAlgo) SelectAndServe(beacons <-chan BeaconOrErr, results chan<- BeaconOrErr,
+	resultSize int) {
+
+	candidates := collectCandidates(beacons, results, a.criteria)
+	covered := make(map[geoCell]struct{})
+	for served := 0; served < resultSize && len(candidates) > 0; served++ {
+		best, maxAdded := 0, -1
+		for i, c := range candidates {
+			added := 0
+			for cell := range c.metrics.cells {
+				if _, ok := covered[cell]; !ok {
+					added++
+				}
+			}
+			if added > maxAdded {
+				best, maxAdded = i, added
+			}
+		}
+		for cell := range candidates[best].metrics.cells {
+			covered[cell] = struct{}{}
+		}
+		results <- BeaconOrErr{Beacon: candidates[best].beacon}
+		candidates = append(candidates[:best], candidates[best+1:]...)
+	}
+}
+
+type candidate struct {
+	beacon  Beacon
+	metrics staticMetrics
+}
+
+// collectCandidates drains the beacons channel and returns all beacons that
+// satisfy the selection criteria together with their static metrics. Errors
+// are directly served on the results channel.
+func collectCandidates(beacons <-chan BeaconOrErr, results chan<- BeaconOrErr,
+	criteria Selection) []candidate {
+
+	var candidates []candidate
+	for res := range beacons {
+		if res.Err != nil {
+			results <- res
+			continue
+		}
+		m := computeStaticMetrics(res.Beacon)
+		if !criteria.accept(m) {
+			continue
+		}
+		candidates = append(candidates, candidate{beacon: res.Beacon, metrics: m})
+	}
+	return candidates
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -0,0 +1,234 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/beacon/mock_beacon"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/xtest/graph"
+)
+
+func TestStoreSelectionAlgorithms(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+
+	stub := graph.If_111_A_112_X
+	// The beacons are ordered by length, as they are returned by the DB.
+	short := withStaticInfo(t, testBeaconOrErr(g, graph.If_120_X_111_B, stub),
+		staticInfo{latency: 5000, bandwidth: 100, lat: 47.4, long: 8.5})
+	long := withStaticInfo(t,
+		testBeaconOrErr(g, graph.If_130_B_120_A, graph.If_120_X_111_B, stub),
+		staticInfo{latency: 100, bandwidth: 1000, lat: 47.1, long: 8.9})
+	noInfo := testBeaconOrErr(g, graph.If_130_B_120_A, graph.If_120_X_111_B, stub)
+	farAway := withStaticInfo(t,
+		testBeaconOrErr(g, graph.If_130_B_111_A, graph.If_111_B_120_X, graph.If_120_X_111_B,
+			stub),
+		staticInfo{latency: 20000, lat: 40.7, long: -74.0})
+	// partialInfo has a lower latency than long, but lacks the latency
+	// information of one AS entry.
+	partialInfo := withStaticInfo(t,
+		testBeaconOrErr(g, graph.If_130_B_120_A, graph.If_120_X_111_B, stub),
+		staticInfo{latency: 10, bandwidth: 1000})
+	partialInfo.Beacon.Segment.ASEntries[0].Exts.StaticInfo = nil
+	candidates := []beacon.BeaconOrErr{short, long, noInfo, farAway}
+
+	tests := map[string]struct {
+		Selection  beacon.Selection
+		BestSize   int
+		Candidates []beacon.BeaconOrErr
+		Expected   []beacon.BeaconOrErr
+	}{
+		"lowest latency": {
+			Selection: beacon.Selection{Algorithm: beacon.SelectLowestLatency},
+			BestSize:  3,
+			Expected:  []beacon.BeaconOrErr{long, short, farAway},
+		},
+		"lowest latency with max latency": {
+			Selection: beacon.Selection{
+				Algorithm:  beacon.SelectLowestLatency,
+				MaxLatency: 5 * time.Millisecond,
+			},
+			BestSize: 3,
+			Expected: []beacon.BeaconOrErr{long},
+		},
+		"max latency rejects partial latency information": {
+			Selection: beacon.Selection{
+				Algorithm:  beacon.SelectLowestLatency,
+				MaxLatency: 5 * time.Millisecond,
+			},
+			BestSize:   3,
+			Candidates: []beacon.BeaconOrErr{long, partialInfo},
+			Expected:   []beacon.BeaconOrErr{long},
+		},
+		"highest bandwidth": {
+			Selection: beacon.Selection{Algorithm: beacon.SelectHighestBandwidth},
+			BestSize:  3,
+			Expected:  []beacon.BeaconOrErr{long, short, noInfo},
+		},
+		"highest bandwidth with min bandwidth": {
+			Selection: beacon.Selection{
+				Algorithm:    beacon.SelectHighestBandwidth,
+				MinBandwidth: 500,
+			},
+			BestSize: 2,
+			Expected: []beacon.BeaconOrErr{long, noInfo},
+		},
+		"geo diversity": {
+			Selection: beacon.Selection{Algorithm: beacon.SelectGeoDiversity},
+			BestSize:  2,
+			Expected:  []beacon.BeaconOrErr{short, farAway},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+			db := mock_beacon.NewMockDB(mctrl)
+			policy := beacon.Policy{BestSetSize: test.BestSize, Selection: test.Selection}
+			store, err := beacon.NewBeaconStore(
+				beacon.Policies{Prop: policy, UpReg: policy, DownReg: policy}, db)
+			require.NoError(t, err)
+			candidates := candidates
+			if test.Candidates != nil {
+				candidates = test.Candidates
+			}
+			beaconErr := beacon.BeaconOrErr{Err: errors.New("fail")}
+			db.EXPECT().CandidateBeacons(gomock.Any(), gomock.Any(), gomock.Any(),
+				addr.IA{}).DoAndReturn(
+				func(_ ...interface{}) (<-chan beacon.BeaconOrErr, error) {
+					results := make(chan beacon.BeaconOrErr, len(candidates)+1)
+					defer close(results)
+					for _, res := range append(candidates, beaconErr) {
+						results <- res
+					}
+					return results, nil
+				},
+			)
+			res, err := store.BeaconsToPropagate(context.Background())
+			require.NoError(t, err)
+			var served []beacon.BeaconOrErr
+			var errs int
+			for bOrErr := range res {
+				if bOrErr.Err != nil {
+					errs++
+					continue
+				}
+				served = append(served, bOrErr)
+			}
+			assert.Equal(t, test.Expected, served)
+			assert.Equal(t, 1, errs)
+		})
+	}
+}
+
+func TestSelectionValidate(t *testing.T) {
+	tests := map[string]struct {
+		Selection beacon.Selection
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"shortest": {
+			Selection: beacon.Selection{Algorithm: beacon.SelectShortest},
+			Assertion: assert.NoError,
+		},
+		"shortest with criteria": {
+			Selection: beacon.Selection{
+				Algorithm:  beacon.SelectShortest,
+				MaxLatency: time.Second,
+			},
+			Assertion: assert.Error,
+		},
+		"latency with criteria": {
+			Selection: beacon.Selection{
+				Algorithm:    beacon.SelectLowestLatency,
+				MaxLatency:   time.Second,
+				MinBandwidth: 1000,
+			},
+			Assertion: assert.NoError,
+		},
+		"negative latency": {
+			Selection: beacon.Selection{
+				Algorithm:  beacon.SelectLowestLatency,
+				MaxLatency: -time.Second,
+			},
+			Assertion: assert.Error,
+		},
+		"unknown": {
+			Selection: beacon.Selection{Algorithm: "Random"},
+			Assertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.Assertion(t, test.Selection.Validate())
+		})
+	}
+}
+
+func TestLoadSelectionPolicy(t *testing.T) {
+	p, err := beacon.LoadPolicyFromYaml("testdata/selectionPolicy.yml", beacon.PropPolicy)
+	require.NoError(t, err)
+	expected := beacon.Selection{
+		Algorithm:    beacon.SelectLowestLatency,
+		MaxLatency:   150 * time.Millisecond,
+		MinBandwidth: 10000,
+	}
+	assert.Equal(t, expected, p.Selection)
+
+	p, err = beacon.LoadPolicyFromYaml("testdata/policy.yml", beacon.PropPolicy)
+	require.NoError(t, err)
+	assert.Equal(t, beacon.SelectShortest, p.Selection.Algorithm)
+}
+
+type staticInfo struct {
+	latency   uint32
+	bandwidth uint64
+	lat, long float32
+}
+
+// withStaticInfo adds a static info extension to all AS entries of the beacon.
+// Every link and every AS traversal has the provided latency.
+func withStaticInfo(t *testing.T, b beacon.BeaconOrErr, info staticInfo) beacon.BeaconOrErr {
+	for _, entry := range b.Beacon.Segment.ASEntries {
+		hopF, err := entry.HopEntries[0].HopField()
+		require.NoError(t, err)
+		entry.Exts.StaticInfo = &seg.StaticInfoExtn{
+			Interfaces: []*seg.StaticInfoInterface{
+				{
+					IfID:        hopF.ConsEgress,
+					LinkLatency: info.latency,
+					Bandwidth:   info.bandwidth,
+					Latitude:    info.lat,
+					Longitude:   info.long,
+				},
+			},
+			IntraLatencies: []*seg.StaticInfoLatency{
+				{IfID: hopF.ConsIngress, OtherIfID: hopF.ConsEgress, Latency: info.latency},
+			},
+		}
+	}
+	return b
+}
@@ -0,0 +1,124 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"math"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+)
+
+// staticMetrics contains the metrics of a beacon that are derived from the
+// static info extensions of its AS entries.
+type staticMetrics struct {
+	// latency is the sum of all known latencies along the beacon.
+	latency time.Duration
+	// latencyComplete indicates whether the latencies of all links and all
+	// AS traversals are known.
+	latencyComplete bool
+	// bandwidth is the bottleneck bandwidth in Kbit/s of all links with known
+	// bandwidth. Zero indicates that no bandwidth is known.
+	bandwidth uint64
+	// cells contains the geographic grid cells the interfaces of the beacon
+	// are located in.
+	cells map[geoCell]struct{}
+}
+
+// geoCell is a cell of one by one degree in the geographic grid.
+type geoCell struct {
+	lat, long int
+}
+
+// computeStaticMetrics computes the static metrics of the beacon. The last
+// link, i.e., the one the beacon was received on, is described by the
+// information the last AS entry contains about its egress interface.
+func computeStaticMetrics(b Beacon) staticMetrics {
+	m := staticMetrics{
+		latencyComplete: true,
+		cells:           make(map[geoCell]struct{}),
+	}
+	entries := b.Segment.ASEntries
+	for i, entry := range entries {
+		ingress, egress, ok := interfaces(entry)
+		if !ok {
+			m.latencyComplete = false
+			continue
+		}
+		info := entry.Exts.StaticInfo
+		m.addLocation(info.Interface(ingress))
+		m.addLocation(info.Interface(egress))
+		if ingress != 0 {
+			l, ok := info.IntraLatency(ingress, egress)
+			m.addLatency(l, ok)
+		}
+		// Description of the link between this AS entry and the next AS.
+		intf := info.Interface(egress)
+		var next *seg.StaticInfoInterface
+		if i+1 < len(entries) {
+			if nextIngress, _, ok := interfaces(entries[i+1]); ok {
+				next = entries[i+1].Exts.StaticInfo.Interface(nextIngress)
+			}
+		}
+		switch {
+		case intf != nil && intf.LinkLatency != 0:
+			m.addLatency(intf.LinkDuration(), true)
+		case next != nil && next.LinkLatency != 0:
+			m.addLatency(next.LinkDuration(), true)
+		default:
+			m.addLatency(0, false)
+		}
+		switch {
+		case intf != nil && intf.Bandwidth != 0:
+			m.addBandwidth(intf.Bandwidth)
+		case next != nil && next.Bandwidth != 0:
+			m.addBandwidth(next.Bandwidth)
+		}
+	}
+	return m
+}
+
+func (m *staticMetrics) addLatency(l time.Duration, known bool) {
+	m.latency += l
+	m.latencyComplete = m.latencyComplete && known
+}
+
+func (m *staticMetrics) addBandwidth(bw uint64) {
+	if m.bandwidth == 0 || bw < m.bandwidth {
+		m.bandwidth = bw
+	}
+}
+
+func (m *staticMetrics) addLocation(intf *seg.StaticInfoInterface) {
+	if intf == nil || (intf.Latitude == 0 && intf.Longitude == 0) {
+		return
+	}
+	cell := geoCell{
+		lat:  int(math.Floor(float64(intf.Latitude))),
+		long: int(math.Floor(float64(intf.Longitude))),
+	}
+	m.cells[cell] = struct{}{}
+}
+
+// interfaces returns the ingress and egress interface of the AS entry. The
+// last return value is false if the hop field cannot be parsed.
+func interfaces(entry *seg.ASEntry) (common.IFIDType, common.IFIDType, bool) {
+	hopF, err := entry.HopEntries[0].HopField()
+	if err != nil {
+		return 0, 0, false
+	}
+	return hopF.ConsIngress, hopF.ConsEgress, true
+}
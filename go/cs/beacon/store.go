@@ -23,7 +23,6 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/log"
-	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/spath"
 	"github.com/scionproto/scion/go/proto"
 )
@@ -41,7 +40,10 @@ type usager interface {
 // core AS.
 type Store struct {
 	baseStore
-	policies Policies
+	// policies is replaced on policy updates, the policies it points to are
+	// never modified.
+	policies *Policies
+	mtx      sync.RWMutex
 }
 
 // NewBeaconStore creates a new beacon store for a non-core AS.
@@ -52,20 +54,60 @@ func NewBeaconStore(policies Policies, db DB) (*Store, error) {
 	}
 	s := &Store{
 		baseStore: baseStore{
-			db:   db,
-			algo: baseAlgo{},
+			db: db,
 		},
-		policies: policies,
+		policies: &policies,
 	}
-	s.baseStore.usager = &s.policies
+	s.baseStore.usager = func() usager { return s.currentPolicies() }
 	return s, nil
 }
 
+func (s *Store) currentPolicies() *Policies {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.policies
+}
+
+// UpdatePolicy atomically replaces the policy of the same type. It is a
+// shorthand for UpdatePolicies with a single policy.
+func (s *Store) UpdatePolicy(ctx context.Context, policy Policy) error {
+	return s.UpdatePolicies(ctx, policy)
+}
+
+// UpdatePolicies atomically replaces the policies of the same types. The
+// resulting set of policies is validated before it is applied, either all
+// policies are replaced or none. Stored beacons keep the usage they were
+// inserted with, but candidates are filtered with the current policy when
+// they are selected.
+func (s *Store) UpdatePolicies(ctx context.Context, policies ...Policy) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	updated := *s.policies
+	for _, policy := range policies {
+		policy.InitDefaults()
+		switch policy.Type {
+		case PropPolicy:
+			updated.Prop = policy
+		case UpRegPolicy:
+			updated.UpReg = policy
+		case DownRegPolicy:
+			updated.DownReg = policy
+		default:
+			return common.NewBasicError("Unsupported policy type", nil, "type", policy.Type)
+		}
+	}
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+	s.policies = &updated
+	return nil
+}
+
 // BeaconsToPropagate returns a channel that provides all beacons to propagate
 // at the time of the call. The selection is based on the configured propagation
 // policy.
 func (s *Store) BeaconsToPropagate(ctx context.Context) (<-chan BeaconOrErr, error) {
-	return s.getBeacons(ctx, &s.policies.Prop)
+	return s.getBeacons(ctx, &s.currentPolicies().Prop)
 }
 
 // SegmentsToRegister returns a channel that provides all beacons to register at
@@ -74,11 +116,12 @@ func (s *Store) BeaconsToPropagate(ctx context.Context) (<-chan BeaconOrErr, err
 func (s *Store) SegmentsToRegister(ctx context.Context, segType proto.PathSegType) (
 	<-chan BeaconOrErr, error) {
 
+	policies := s.currentPolicies()
 	switch segType {
 	case proto.PathSegType_down:
-		return s.getBeacons(ctx, &s.policies.DownReg)
+		return s.getBeacons(ctx, &policies.DownReg)
 	case proto.PathSegType_up:
-		return s.getBeacons(ctx, &s.policies.UpReg)
+		return s.getBeacons(ctx, &policies.UpReg)
 	default:
 		return nil, common.NewBasicError("Unsupported segment type", nil, "type", segType)
 	}
@@ -92,24 +135,31 @@ func (s *Store) getBeacons(ctx context.Context, policy *Policy) (<-chan BeaconOr
 	if err != nil {
 		return nil, err
 	}
+	beacons = s.filterCandidates(policy, beacons)
 	results := make(chan BeaconOrErr, min(maxResultChanSize, policy.BestSetSize))
 	go func() {
 		defer log.HandlePanic()
 		defer close(results)
-		s.algo.SelectAndServe(beacons, results, policy.BestSetSize)
+		policy.Selection.algorithm().SelectAndServe(beacons, results, policy.BestSetSize)
 	}()
-	return results, nil
+	return s.inspector.record(policy.Type, results), nil
+}
+
+// EgressPolicies returns the egress policies of the propagation policy.
+func (s *Store) EgressPolicies() []EgressPolicy {
+	return s.currentPolicies().Prop.Egress
 }
 
 // MaxExpTime returns the segment maximum expiration time for the given policy.
 func (s *Store) MaxExpTime(policyType PolicyType) spath.ExpTimeType {
+	policies := s.currentPolicies()
 	switch policyType {
 	case UpRegPolicy:
-		return *s.policies.UpReg.MaxExpTime
+		return *policies.UpReg.MaxExpTime
 	case DownRegPolicy:
-		return *s.policies.DownReg.MaxExpTime
+		return *policies.DownReg.MaxExpTime
 	case PropPolicy:
-		return *s.policies.Prop.MaxExpTime
+		return *policies.Prop.MaxExpTime
 	}
 	return DefaultMaxExpTime
 }
@@ -120,7 +170,10 @@ func (s *Store) MaxExpTime(policyType PolicyType) spath.ExpTimeType {
 // a non-core AS.
 type CoreStore struct {
 	baseStore
-	policies CorePolicies
+	// policies is replaced on policy updates, the policies it points to are
+	// never modified.
+	policies *CorePolicies
+	mtx      sync.RWMutex
 }
 
 // NewCoreBeaconStore creates a new beacon store for a non-core AS.
@@ -131,20 +184,58 @@ func NewCoreBeaconStore(policies CorePolicies, db DB) (*CoreStore, error) {
 	}
 	s := &CoreStore{
 		baseStore: baseStore{
-			db:   db,
-			algo: baseAlgo{},
+			db: db,
 		},
-		policies: policies,
+		policies: &policies,
 	}
-	s.usager = &s.policies
+	s.usager = func() usager { return s.currentPolicies() }
 	return s, nil
 }
 
+func (s *CoreStore) currentPolicies() *CorePolicies {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.policies
+}
+
+// UpdatePolicy atomically replaces the policy of the same type. It is a
+// shorthand for UpdatePolicies with a single policy.
+func (s *CoreStore) UpdatePolicy(ctx context.Context, policy Policy) error {
+	return s.UpdatePolicies(ctx, policy)
+}
+
+// UpdatePolicies atomically replaces the policies of the same types. The
+// resulting set of policies is validated before it is applied, either all
+// policies are replaced or none. Stored beacons keep the usage they were
+// inserted with, but candidates are filtered with the current policy when
+// they are selected.
+func (s *CoreStore) UpdatePolicies(ctx context.Context, policies ...Policy) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	updated := *s.policies
+	for _, policy := range policies {
+		policy.InitDefaults()
+		switch policy.Type {
+		case PropPolicy:
+			updated.Prop = policy
+		case CoreRegPolicy:
+			updated.CoreReg = policy
+		default:
+			return common.NewBasicError("Unsupported policy type", nil, "type", policy.Type)
+		}
+	}
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+	s.policies = &updated
+	return nil
+}
+
 // BeaconsToPropagate returns a channel that provides all beacons to propagate
 // at the time of the call. The selection is based on the configured propagation
 // policy.
 func (s *CoreStore) BeaconsToPropagate(ctx context.Context) (<-chan BeaconOrErr, error) {
-	return s.getBeacons(ctx, &s.policies.Prop)
+	return s.getBeacons(ctx, &s.currentPolicies().Prop)
 }
 
 // SegmentsToRegister returns a channel that provides all beacons to register at
@@ -156,7 +247,7 @@ func (s *CoreStore) SegmentsToRegister(ctx context.Context, segType proto.PathSe
 	if segType != proto.PathSegType_core {
 		return nil, common.NewBasicError("Unsupported segment type", nil, "type", segType)
 	}
-	return s.getBeacons(ctx, &s.policies.CoreReg)
+	return s.getBeacons(ctx, &s.currentPolicies().CoreReg)
 }
 
 // getBeacons fetches the candidate beacons from the database and serves the
@@ -179,11 +270,12 @@ func (s *CoreStore) getBeacons(ctx context.Context, policy *Policy) (<-chan Beac
 			errs = append(errs, src)
 			continue
 		}
+		beacons = s.filterCandidates(policy, beacons)
 		wg.Add(1)
 		go func() {
 			defer log.HandlePanic()
 			defer wg.Done()
-			s.algo.SelectAndServe(beacons, results, policy.BestSetSize)
+			policy.Selection.algorithm().SelectAndServe(beacons, results, policy.BestSetSize)
 		}()
 	}
 	go func() {
@@ -196,40 +288,53 @@ func (s *CoreStore) getBeacons(ctx context.Context, policy *Policy) (<-chan Beac
 			}
 		}
 	}()
-	return results, nil
+	return s.inspector.record(policy.Type, results), nil
+}
+
+// EgressPolicies returns the egress policies of the propagation policy.
+func (s *CoreStore) EgressPolicies() []EgressPolicy {
+	return s.currentPolicies().Prop.Egress
 }
 
 // MaxExpTime returns the segment maximum expiration time for the given policy.
 func (s *CoreStore) MaxExpTime(policyType PolicyType) spath.ExpTimeType {
+	policies := s.currentPolicies()
 	switch policyType {
 	case CoreRegPolicy:
-		return *s.policies.CoreReg.MaxExpTime
+		return *policies.CoreReg.MaxExpTime
 	case PropPolicy:
-		return *s.policies.Prop.MaxExpTime
+		return *policies.Prop.MaxExpTime
 	}
 	return DefaultMaxExpTime
 }
 
 // baseStore is the basis for the beacon store.
 type baseStore struct {
-	db     DB
-	usager usager
-	algo   selectionAlgorithm
+	db DB
+	// usager returns the current policies.
+	usager func() usager
+	inspector
 }
 
 // PreFilter indicates whether the beacon will be filtered on insert by
 // returning an error with the reason. This allows the caller to drop
 // ignored beacons.
 func (s *baseStore) PreFilter(beacon Beacon) error {
-	return s.usager.Filter(beacon)
+	err := s.usager().Filter(beacon)
+	if err != nil {
+		s.filter(beacon, err)
+	}
+	return err
 }
 
 // InsertBeacon adds a verified beacon to the store.
 // Beacon that contains revoked interfaces is inserted and does not cause an error.
 // If the beacon does not match any policy, it is not inserted, but does not cause an error.
 func (s *baseStore) InsertBeacon(ctx context.Context, beacon Beacon) (InsertStats, error) {
-	usage := s.usager.Usage(beacon)
+	policies := s.usager()
+	usage := policies.Usage(beacon)
 	if usage.None() {
+		s.filter(beacon, policies.Filter(beacon))
 		return InsertStats{Filtered: 1}, nil
 	}
 	return s.db.InsertBeacon(ctx, beacon, usage)
@@ -256,6 +361,11 @@ func (s *baseStore) InsertRevocations(ctx context.Context,
 	return tx.Commit()
 }
 
+// Beacons returns all beacons in the store together with their usage.
+func (s *baseStore) Beacons(ctx context.Context) (<-chan StoredBeaconOrErr, error) {
+	return s.db.AllBeacons(ctx)
+}
+
 // DeleteRevocation deletes the revocation from the BeaconDB.
 func (s *baseStore) DeleteRevocation(ctx context.Context, ia addr.IA, ifid common.IFIDType) error {
 	return s.db.DeleteRevocation(ctx, ia, ifid)
@@ -271,10 +381,29 @@ func (s *baseStore) DeleteExpiredRevocations(ctx context.Context) (int, error) {
 	return s.db.DeleteExpiredRevocations(ctx, time.Now())
 }
 
-// UpdatePolicy updates the policy. Beacons that are filtered by all
-// policies after the update are removed.
-func (s *baseStore) UpdatePolicy(ctx context.Context, policy Policy) error {
-	return serrors.New("policy update not supported")
+// filterCandidates drops the candidate beacons that are filtered by the
+// policy. The usage of a stored beacon is computed with the policies at
+// insertion time. Filtering the candidates ensures that a policy update also
+// applies to the beacons that are already stored. The input channel is always
+// drained.
+func (s *baseStore) filterCandidates(policy *Policy,
+	in <-chan BeaconOrErr) <-chan BeaconOrErr {
+
+	out := make(chan BeaconOrErr, cap(in))
+	go func() {
+		defer log.HandlePanic()
+		defer close(out)
+		for res := range in {
+			if res.Err == nil {
+				if err := policy.Filter.Apply(res.Beacon); err != nil {
+					s.filter(res.Beacon, err)
+					continue
+				}
+			}
+			out <- res
+		}
+	}()
+	return out
 }
 
 // Close closes the store and the underlying database connection.
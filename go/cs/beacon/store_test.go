@@ -20,12 +20,15 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/cs/beacon"
 	"github.com/scionproto/scion/go/cs/beacon/mock_beacon"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/spath"
 	"github.com/scionproto/scion/go/lib/xtest"
 	"github.com/scionproto/scion/go/lib/xtest/graph"
 	"github.com/scionproto/scion/go/proto"
@@ -385,3 +388,117 @@ func testBeacon(g *graph.Graph, ifids []common.IFIDType) *seg.PathSegment {
 	pseg.ASEntries = pseg.ASEntries[:len(pseg.ASEntries)-1]
 	return pseg
 }
+
+func TestStoreUpdatePolicy(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	store, err := beacon.NewBeaconStore(beacon.Policies{}, mock_beacon.NewMockDB(mctrl))
+	require.NoError(t, err)
+
+	exp := spath.ExpTimeType(42)
+	err = store.UpdatePolicy(context.Background(),
+		beacon.Policy{Type: beacon.UpRegPolicy, MaxExpTime: &exp})
+	require.NoError(t, err)
+	assert.Equal(t, exp, store.MaxExpTime(beacon.UpRegPolicy))
+	assert.Equal(t, beacon.DefaultMaxExpTime, store.MaxExpTime(beacon.DownRegPolicy))
+
+	// Invalid policies are not applied.
+	err = store.UpdatePolicy(context.Background(),
+		beacon.Policy{Type: beacon.CoreRegPolicy, MaxExpTime: &exp})
+	assert.Error(t, err)
+	err = store.UpdatePolicy(context.Background(), beacon.Policy{
+		Type:       beacon.PropPolicy,
+		MaxExpTime: &exp,
+		Selection:  beacon.Selection{Algorithm: "Random"},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, beacon.DefaultMaxExpTime, store.MaxExpTime(beacon.PropPolicy))
+}
+
+func TestCoreStoreUpdatePolicy(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	store, err := beacon.NewCoreBeaconStore(beacon.CorePolicies{}, mock_beacon.NewMockDB(mctrl))
+	require.NoError(t, err)
+
+	exp := spath.ExpTimeType(42)
+	err = store.UpdatePolicy(context.Background(),
+		beacon.Policy{Type: beacon.CoreRegPolicy, MaxExpTime: &exp})
+	require.NoError(t, err)
+	assert.Equal(t, exp, store.MaxExpTime(beacon.CoreRegPolicy))
+	assert.Equal(t, beacon.DefaultMaxExpTime, store.MaxExpTime(beacon.PropPolicy))
+
+	err = store.UpdatePolicy(context.Background(),
+		beacon.Policy{Type: beacon.UpRegPolicy, MaxExpTime: &exp})
+	assert.Error(t, err)
+}
+
+func TestStoreUpdatePolicies(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	store, err := beacon.NewBeaconStore(beacon.Policies{}, mock_beacon.NewMockDB(mctrl))
+	require.NoError(t, err)
+
+	// A single invalid policy rejects the whole set.
+	exp := spath.ExpTimeType(42)
+	err = store.UpdatePolicies(context.Background(),
+		beacon.Policy{Type: beacon.UpRegPolicy, MaxExpTime: &exp},
+		beacon.Policy{
+			Type:       beacon.PropPolicy,
+			MaxExpTime: &exp,
+			Selection:  beacon.Selection{Algorithm: "Random"},
+		},
+	)
+	assert.Error(t, err)
+	assert.Equal(t, beacon.DefaultMaxExpTime, store.MaxExpTime(beacon.UpRegPolicy))
+	assert.Equal(t, beacon.DefaultMaxExpTime, store.MaxExpTime(beacon.PropPolicy))
+
+	err = store.UpdatePolicies(context.Background(),
+		beacon.Policy{Type: beacon.UpRegPolicy, MaxExpTime: &exp},
+		beacon.Policy{Type: beacon.PropPolicy, MaxExpTime: &exp},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, exp, store.MaxExpTime(beacon.UpRegPolicy))
+	assert.Equal(t, exp, store.MaxExpTime(beacon.PropPolicy))
+	assert.Equal(t, beacon.DefaultMaxExpTime, store.MaxExpTime(beacon.DownRegPolicy))
+}
+
+func TestStoreUpdatePolicyFiltersCandidates(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+	db := mock_beacon.NewMockDB(mctrl)
+	store, err := beacon.NewBeaconStore(beacon.Policies{}, db)
+	require.NoError(t, err)
+
+	// The candidates have been inserted with the initial policy.
+	stub := graph.If_111_A_112_X
+	via120 := testBeaconOrErr(g, graph.If_120_X_111_B, stub)
+	via130 := testBeaconOrErr(g, graph.If_130_B_111_A, stub)
+	db.EXPECT().CandidateBeacons(gomock.Any(), gomock.Any(), gomock.Any(),
+		addr.IA{}).DoAndReturn(
+		func(_ ...interface{}) (<-chan beacon.BeaconOrErr, error) {
+			results := make(chan beacon.BeaconOrErr, 2)
+			defer close(results)
+			results <- via120
+			results <- via130
+			return results, nil
+		},
+	)
+
+	err = store.UpdatePolicy(context.Background(), beacon.Policy{
+		Type:   beacon.PropPolicy,
+		Filter: beacon.Filter{AsBlackList: []addr.AS{xtest.MustParseAS("ff00:0:120")}},
+	})
+	require.NoError(t, err)
+	res, err := store.BeaconsToPropagate(context.Background())
+	require.NoError(t, err)
+	var selected []beacon.BeaconOrErr
+	for bOrErr := range res {
+		selected = append(selected, bOrErr)
+	}
+	assert.Equal(t, []beacon.BeaconOrErr{via130}, selected)
+	filtered := store.FilteredBeacons()
+	require.Len(t, filtered, 1)
+	assert.Equal(t, via120.Beacon, filtered[0].Beacon)
+}
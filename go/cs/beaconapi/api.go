@@ -0,0 +1,269 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package beaconapi implements the admin HTTP API to inspect the beacon store
+// and to control the beaconing tasks of the control service.
+//
+// The API provides the following endpoints:
+//
+//	GET  /beacons            Lists the beacons in the store with their usage.
+//	                         The result can be restricted with the start_ia
+//	                         and ingress_interface query parameters.
+//	GET  /beacons/selections Lists the last beacon selection per policy.
+//	GET  /beacons/filtered   Lists the most recently filtered beacons together
+//	                         with the reason they were filtered.
+//	POST /beacons/trigger    Forces an immediate run of the task given by the
+//	                         task query parameter. The task is one of
+//	                         origination, propagation or registration.
+//
+// The API is not authenticated. It must be served on a listener that is only
+// reachable by the operator, and not on the metrics and status pages.
+package beaconapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Names of the tasks that can be triggered.
+const (
+	TaskOrigination  = "origination"
+	TaskPropagation  = "propagation"
+	TaskRegistration = "registration"
+)
+
+// DefaultTimeout is the default timeout for reading the beacons from the
+// store.
+const DefaultTimeout = 10 * time.Second
+
+// Store is the beacon store that is inspected.
+type Store interface {
+	// Beacons returns all beacons in the store together with their usage.
+	Beacons(ctx context.Context) (<-chan beacon.StoredBeaconOrErr, error)
+	// LastSelections returns the last beacon selection for each policy.
+	LastSelections() []beacon.SelectionResult
+	// FilteredBeacons returns the most recently filtered beacons, newest
+	// first.
+	FilteredBeacons() []beacon.FilteredBeacon
+}
+
+// Trigger forces an immediate run of a task.
+type Trigger func()
+
+// API serves the beacon store admin API.
+type API struct {
+	// Store is the inspected beacon store.
+	Store Store
+	// Triggers maps the task names to their trigger. Tasks that do not run
+	// in this control service have no trigger.
+	Triggers map[string]Trigger
+	// Timeout bounds reading the beacons from the store. If zero,
+	// DefaultTimeout is used.
+	Timeout time.Duration
+}
+
+// Register registers the endpoints of the API with the mux.
+func (a *API) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/beacons", a.beacons)
+	mux.HandleFunc("/beacons/selections", a.selections)
+	mux.HandleFunc("/beacons/filtered", a.filtered)
+	mux.HandleFunc("/beacons/trigger", a.trigger)
+}
+
+func (a *API) beacons(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	startIA, ingress, err := parseBeaconsQuery(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	timeout := a.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancelF := context.WithTimeout(req.Context(), timeout)
+	defer cancelF()
+	results, err := a.Store.Beacons(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rep := []beaconJSON{}
+	var errs []error
+	// The channel must be drained, even if an error occurs.
+	for res := range results {
+		if res.Err != nil {
+			errs = append(errs, res.Err)
+			continue
+		}
+		b := res.Beacon
+		if !startIA.IsZero() && !startIA.Equal(b.Beacon.Segment.FirstIA()) {
+			continue
+		}
+		if ingress != 0 && ingress != b.Beacon.InIfId {
+			continue
+		}
+		entry := newBeaconJSON(b.Beacon)
+		entry.Usage = b.Usage.Names()
+		entry.LastUpdated = &b.LastUpdated
+		rep = append(rep, entry)
+	}
+	if len(errs) > 0 {
+		http.Error(w, serrors.New("unable to read beacons", "errs", errs).Error(),
+			http.StatusInternalServerError)
+		return
+	}
+	env.WriteJSON(w, rep)
+}
+
+func parseBeaconsQuery(req *http.Request) (addr.IA, common.IFIDType, error) {
+	var startIA addr.IA
+	if raw := req.URL.Query().Get("start_ia"); raw != "" {
+		ia, err := addr.IAFromString(raw)
+		if err != nil {
+			return addr.IA{}, 0, serrors.WrapStr("invalid start_ia", err)
+		}
+		startIA = ia
+	}
+	var ingress common.IFIDType
+	if raw := req.URL.Query().Get("ingress_interface"); raw != "" {
+		ifid, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return addr.IA{}, 0, serrors.WrapStr("invalid ingress_interface", err)
+		}
+		ingress = common.IFIDType(ifid)
+	}
+	return startIA, ingress, nil
+}
+
+func (a *API) selections(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	rep := []selectionJSON{}
+	for _, sel := range a.Store.LastSelections() {
+		entry := selectionJSON{
+			Policy:  string(sel.Policy),
+			Time:    sel.Time,
+			Beacons: make([]beaconJSON, 0, len(sel.Beacons)),
+		}
+		for _, b := range sel.Beacons {
+			entry.Beacons = append(entry.Beacons, newBeaconJSON(b))
+		}
+		for _, err := range sel.Errors {
+			entry.Errors = append(entry.Errors, err.Error())
+		}
+		rep = append(rep, entry)
+	}
+	env.WriteJSON(w, rep)
+}
+
+func (a *API) filtered(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	rep := []filteredJSON{}
+	for _, f := range a.Store.FilteredBeacons() {
+		entry := filteredJSON{
+			Time:   f.Time,
+			Beacon: newBeaconJSON(f.Beacon),
+		}
+		if f.Reason != nil {
+			entry.Reason = f.Reason.Error()
+		}
+		rep = append(rep, entry)
+	}
+	env.WriteJSON(w, rep)
+}
+
+func (a *API) trigger(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	task := req.URL.Query().Get("task")
+	switch task {
+	case TaskOrigination, TaskPropagation, TaskRegistration:
+	default:
+		http.Error(w, fmt.Sprintf("unknown task: %q", task), http.StatusBadRequest)
+		return
+	}
+	trigger, ok := a.Triggers[task]
+	if !ok {
+		http.Error(w, fmt.Sprintf("task not running: %s", task), http.StatusNotFound)
+		return
+	}
+	trigger()
+	w.Header().Set("Content-Type", "text/plain")
+	fmt.Fprintf(w, "triggered %s\n", task)
+}
+
+type beaconJSON struct {
+	ID          string          `json:"id"`
+	StartIA     addr.IA         `json:"start_ia"`
+	Ingress     common.IFIDType `json:"ingress_interface"`
+	Hops        []addr.IA       `json:"hops"`
+	Timestamp   time.Time       `json:"timestamp"`
+	Expiration  time.Time       `json:"expiration"`
+	Usage       []string        `json:"usage,omitempty"`
+	LastUpdated *time.Time      `json:"last_updated,omitempty"`
+}
+
+func newBeaconJSON(b beacon.Beacon) beaconJSON {
+	entry := beaconJSON{
+		Ingress: b.InIfId,
+	}
+	if b.Segment == nil || len(b.Segment.ASEntries) == 0 {
+		return entry
+	}
+	if id, err := b.Segment.ID(); err == nil {
+		entry.ID = id.String()
+	}
+	entry.StartIA = b.Segment.FirstIA()
+	for _, as := range b.Segment.ASEntries {
+		entry.Hops = append(entry.Hops, as.IA())
+	}
+	if info, err := b.Segment.InfoF(); err == nil {
+		entry.Timestamp = info.Timestamp()
+	}
+	entry.Expiration = b.Segment.MaxExpiry()
+	return entry
+}
+
+type selectionJSON struct {
+	Policy  string       `json:"policy"`
+	Time    time.Time    `json:"time"`
+	Beacons []beaconJSON `json:"beacons"`
+	Errors  []string     `json:"errors,omitempty"`
+}
+
+type filteredJSON struct {
+	Time   time.Time  `json:"time"`
+	Beacon beaconJSON `json:"beacon"`
+	Reason string     `json:"reason"`
+}
@@ -0,0 +1,201 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/beaconapi"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/xtest/graph"
+)
+
+type testStore struct {
+	beacons    []beacon.StoredBeacon
+	selections []beacon.SelectionResult
+	filtered   []beacon.FilteredBeacon
+}
+
+func (s *testStore) Beacons(_ context.Context) (<-chan beacon.StoredBeaconOrErr, error) {
+	res := make(chan beacon.StoredBeaconOrErr, len(s.beacons))
+	for _, b := range s.beacons {
+		res <- beacon.StoredBeaconOrErr{Beacon: b}
+	}
+	close(res)
+	return res, nil
+}
+
+func (s *testStore) LastSelections() []beacon.SelectionResult {
+	return s.selections
+}
+
+func (s *testStore) FilteredBeacons() []beacon.FilteredBeacon {
+	return s.filtered
+}
+
+func TestBeacons(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+	b120 := beacon.Beacon{
+		Segment: g.Beacon([]common.IFIDType{graph.If_120_X_111_B}),
+		InIfId:  graph.If_111_B_120_X,
+	}
+	b130 := beacon.Beacon{
+		Segment: g.Beacon([]common.IFIDType{graph.If_130_B_111_A}),
+		InIfId:  graph.If_111_A_130_B,
+	}
+	store := &testStore{
+		beacons: []beacon.StoredBeacon{
+			{Beacon: b120, Usage: beacon.UsageProp | beacon.UsageUpReg, LastUpdated: time.Now()},
+			{Beacon: b130, Usage: beacon.UsageDownReg, LastUpdated: time.Now()},
+		},
+	}
+	api := &beaconapi.API{Store: store}
+	mux := http.NewServeMux()
+	api.Register(mux)
+
+	tests := map[string]struct {
+		Query        string
+		ExpectedCode int
+		ExpectedIAs  []string
+	}{
+		"all": {
+			ExpectedCode: http.StatusOK,
+			ExpectedIAs:  []string{"1-ff00:0:120", "1-ff00:0:130"},
+		},
+		"start IA": {
+			Query:        "?start_ia=1-ff00:0:130",
+			ExpectedCode: http.StatusOK,
+			ExpectedIAs:  []string{"1-ff00:0:130"},
+		},
+		"ingress interface": {
+			Query:        "?ingress_interface=" + graph.If_111_B_120_X.String(),
+			ExpectedCode: http.StatusOK,
+			ExpectedIAs:  []string{"1-ff00:0:120"},
+		},
+		"invalid start IA": {
+			Query:        "?start_ia=invalid",
+			ExpectedCode: http.StatusBadRequest,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/beacons"+test.Query, nil))
+			require.Equal(t, test.ExpectedCode, rec.Code)
+			if test.ExpectedCode != http.StatusOK {
+				return
+			}
+			var rep []struct {
+				StartIA string   `json:"start_ia"`
+				Usage   []string `json:"usage"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rep))
+			var ias []string
+			for _, b := range rep {
+				ias = append(ias, b.StartIA)
+				assert.NotEmpty(t, b.Usage)
+			}
+			assert.Equal(t, test.ExpectedIAs, ias)
+		})
+	}
+}
+
+func TestFiltered(t *testing.T) {
+	store := &testStore{
+		filtered: []beacon.FilteredBeacon{
+			{Beacon: beacon.Beacon{InIfId: 1}, Reason: errors.New("MaxHopsLength exceeded")},
+		},
+	}
+	api := &beaconapi.API{Store: store}
+	mux := http.NewServeMux()
+	api.Register(mux)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/beacons/filtered", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var rep []struct {
+		Reason string `json:"reason"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rep))
+	require.Len(t, rep, 1)
+	assert.Equal(t, "MaxHopsLength exceeded", rep[0].Reason)
+}
+
+func TestTrigger(t *testing.T) {
+	var triggered []string
+	api := &beaconapi.API{
+		Store: &testStore{},
+		Triggers: map[string]beaconapi.Trigger{
+			beaconapi.TaskPropagation: func() {
+				triggered = append(triggered, beaconapi.TaskPropagation)
+			},
+		},
+	}
+	mux := http.NewServeMux()
+	api.Register(mux)
+
+	tests := map[string]struct {
+		Method       string
+		Task         string
+		ExpectedCode int
+	}{
+		"triggered": {
+			Method:       http.MethodPost,
+			Task:         beaconapi.TaskPropagation,
+			ExpectedCode: http.StatusOK,
+		},
+		"not running": {
+			Method:       http.MethodPost,
+			Task:         beaconapi.TaskOrigination,
+			ExpectedCode: http.StatusNotFound,
+		},
+		"unknown task": {
+			Method:       http.MethodPost,
+			Task:         "unknown",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		"wrong method": {
+			Method:       http.MethodGet,
+			Task:         beaconapi.TaskPropagation,
+			ExpectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			triggered = nil
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(test.Method,
+				"/beacons/trigger?task="+test.Task, nil))
+			assert.Equal(t, test.ExpectedCode, rec.Code)
+			if test.ExpectedCode == http.StatusOK {
+				assert.Equal(t, []string{test.Task}, triggered)
+			} else {
+				assert.Empty(t, triggered)
+			}
+		})
+	}
+}
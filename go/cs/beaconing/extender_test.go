@@ -204,6 +204,36 @@ func TestExtenderExtend(t *testing.T) {
 		SoMsg("exp", hopF.ExpTime, ShouldEqual, 1)
 
 	})
+	Convey("The static info extension is added", t, func() {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		g := graph.NewDefaultGraph(mctrl)
+		intfs := ifstate.NewInterfaces(topoProvider.Get().IFInfoMap(), ifstate.Config{})
+		intfs.Get(graph.If_111_B_120_X).Activate(graph.If_120_X_111_B)
+		intfs.Get(graph.If_111_A_112_X).Activate(graph.If_112_X_111_A)
+		staticInfo, err := LoadStaticInfoCfg("testdata/staticinfo.json")
+		SoMsg("err", err, ShouldBeNil)
+		ext, err := ExtenderConf{
+			MTU:           1337,
+			Signer:        testSigner(t, priv, topoProvider.Get().IA()),
+			Mac:           mac,
+			GetMaxExpTime: maxExpTimeFactory(beacon.DefaultMaxExpTime),
+			Intfs:         intfs,
+			StaticInfo:    staticInfo,
+		}.new()
+		SoMsg("err", err, ShouldBeNil)
+		pseg := testBeacon(g, segDesc).Segment
+		err = ext.extend(pseg, graph.If_111_B_120_X, graph.If_111_A_112_X, nil)
+		SoMsg("err", err, ShouldBeNil)
+		err = pseg.VerifyASEntry(context.Background(), segVerifier(pub), pseg.MaxAEIdx())
+		SoMsg("verify err", err, ShouldBeNil)
+		extn := pseg.ASEntries[pseg.MaxAEIdx()].Exts.StaticInfo
+		SoMsg("extn", extn, ShouldNotBeNil)
+		SoMsg("interfaces", len(extn.Interfaces), ShouldEqual, 2)
+		l, ok := extn.IntraLatency(graph.If_111_B_120_X, graph.If_111_A_112_X)
+		SoMsg("intra ok", ok, ShouldBeTrue)
+		SoMsg("intra", l, ShouldEqual, 500*time.Microsecond)
+	})
 	Convey("Segment is not extended on error", t, func() {
 		mctrl := gomock.NewController(t)
 		defer mctrl.Finish()
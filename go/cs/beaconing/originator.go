@@ -64,6 +64,18 @@ func (cfg OriginatorConf) New() (*Originator, error) {
 	return o, nil
 }
 
+// Force forces the next run to originate beacons on all active interfaces,
+// regardless of the configured period. It is safe for concurrent use.
+func (o *Originator) Force() {
+	o.tick.force()
+}
+
+// SetPeriod changes the period from the next run on. It is safe for
+// concurrent use.
+func (o *Originator) SetPeriod(period time.Duration) {
+	o.tick.setPeriod(period)
+}
+
 // Name returns the tasks name.
 func (o *Originator) Name() string {
 	return "bs_beaconing_originator"
@@ -72,6 +84,7 @@ func (o *Originator) Name() string {
 // Run originates core and downstream beacons.
 func (o *Originator) Run(ctx context.Context) {
 	o.tick.now = time.Now()
+	o.tick.applyPending()
 	o.originateBeacons(ctx, topology.Core)
 	o.originateBeacons(ctx, topology.Child)
 	metrics.Originator.Runtime().Add(time.Since(o.tick.now).Seconds())
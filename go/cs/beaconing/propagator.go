@@ -35,12 +35,21 @@ type BeaconProvider interface {
 	BeaconsToPropagate(ctx context.Context) (<-chan beacon.BeaconOrErr, error)
 }
 
+// EgressPolicyProvider provides the egress policies that restrict propagation
+// on specific egress interfaces or towards specific neighbor ASes.
+type EgressPolicyProvider interface {
+	EgressPolicies() []beacon.EgressPolicy
+}
+
 var _ periodic.Task = (*Propagator)(nil)
 
 // PropagatorConf is the configuration to create a new propagator.
 type PropagatorConf struct {
 	Config         ExtenderConf
 	BeaconProvider BeaconProvider
+	// EgressPolicies provides the egress policies. If nil, no egress policies
+	// are applied.
+	EgressPolicies EgressPolicyProvider
 	BeaconSender   *onehop.BeaconSender
 	Period         time.Duration
 	Core           bool
@@ -50,11 +59,13 @@ type PropagatorConf struct {
 // Propagator forwards beacons to neighboring ASes. In a core AS, the beacons
 // are propagated to neighbors on core links. In a non-core AS, the beacons are
 // forwarded on child links. Selection of the beacons is handled by the beacon
-// provider, the propagator only filters AS loops.
+// provider, the propagator only filters AS loops and applies the egress
+// policies.
 type Propagator struct {
 	*segExtender
 	beaconSender *onehop.BeaconSender
 	provider     BeaconProvider
+	egress       EgressPolicyProvider
 	allowIsdLoop bool
 	core         bool
 
@@ -71,6 +82,7 @@ func (cfg PropagatorConf) New() (*Propagator, error) {
 	}
 	p := &Propagator{
 		provider:     cfg.BeaconProvider,
+		egress:       cfg.EgressPolicies,
 		beaconSender: cfg.BeaconSender,
 		core:         cfg.Core,
 		allowIsdLoop: cfg.AllowIsdLoop,
@@ -80,6 +92,18 @@ func (cfg PropagatorConf) New() (*Propagator, error) {
 	return p, nil
 }
 
+// Force forces the next run to propagate beacons on all active interfaces,
+// regardless of the configured period. It is safe for concurrent use.
+func (p *Propagator) Force() {
+	p.tick.force()
+}
+
+// SetPeriod changes the period from the next run on. It is safe for
+// concurrent use.
+func (p *Propagator) SetPeriod(period time.Duration) {
+	p.tick.setPeriod(period)
+}
+
 // Name returns the tasks name.
 func (p *Propagator) Name() string {
 	return "bs_beaconing_propagator"
@@ -91,6 +115,7 @@ func (p *Propagator) Name() string {
 // interfaces.
 func (p *Propagator) Run(ctx context.Context) {
 	p.tick.now = time.Now()
+	p.tick.applyPending()
 	if err := p.run(ctx); err != nil {
 		log.FromCtx(ctx).Error("[beaconing.Propagator] Unable to propagate beacons", "err", err)
 	}
@@ -114,6 +139,10 @@ func (p *Propagator) run(ctx context.Context) error {
 		metrics.Propagator.InternalErrors().Inc()
 		return err
 	}
+	var egress *beacon.EgressFilter
+	if p.egress != nil {
+		egress = beacon.NewEgressFilter(p.egress.EgressPolicies())
+	}
 	s := newSummary()
 	var wg sync.WaitGroup
 	for bOrErr := range beacons {
@@ -125,10 +154,13 @@ func (p *Propagator) run(ctx context.Context) error {
 		if !p.IntfActive(bOrErr.Beacon.InIfId) {
 			continue
 		}
+		// The egress interfaces are determined in order of the beacons provided
+		// by the beacon provider, such that the egress policy limits are
+		// consumed by the best beacons.
 		b := beaconPropagator{
 			Propagator:  p,
 			beacon:      bOrErr.Beacon,
-			activeIntfs: intfs,
+			activeIntfs: p.egressIntfs(logger, bOrErr.Beacon, intfs, egress),
 			peers:       peers,
 			summary:     s,
 			logger:      logger,
@@ -216,9 +248,6 @@ func (p *beaconPropagator) propagate(ctx context.Context) error {
 	}
 	var expected int
 	for _, egIfid := range p.activeIntfs {
-		if p.shouldIgnore(p.beacon, egIfid) {
-			continue
-		}
 		expected++
 		bseg := p.beacon
 		if bseg.Segment, err = seg.NewBeaconFromRaw(raw); err != nil {
@@ -298,18 +327,32 @@ func (p *beaconPropagator) extendAndSend(ctx context.Context, bseg beacon.Beacon
 	}()
 }
 
-// shouldIgnore indicates whether a beacon should not be sent on the egress
-// interface because it creates a loop.
-func (p *beaconPropagator) shouldIgnore(bseg beacon.Beacon, egIfid common.IFIDType) bool {
-	intf := p.cfg.Intfs.Get(egIfid)
-	if intf == nil {
-		return true
-	}
-	if err := beacon.FilterLoop(bseg, intf.TopoInfo().IA, p.allowIsdLoop); err != nil {
-		p.logger.Trace("[beaconing.Propagator] Ignoring beacon on loop", "ifid", egIfid, "err", err)
-		return true
+// egressIntfs returns the active interfaces the beacon should be sent on. A
+// beacon is not sent on an interface if it creates a loop or if it is filtered
+// by the egress policies.
+func (p *Propagator) egressIntfs(logger log.Logger, bseg beacon.Beacon,
+	activeIntfs []common.IFIDType, egress *beacon.EgressFilter) []common.IFIDType {
+
+	var egIfids []common.IFIDType
+	for _, egIfid := range activeIntfs {
+		intf := p.cfg.Intfs.Get(egIfid)
+		if intf == nil {
+			continue
+		}
+		neighbor := intf.TopoInfo().IA
+		if err := beacon.FilterLoop(bseg, neighbor, p.allowIsdLoop); err != nil {
+			logger.Trace("[beaconing.Propagator] Ignoring beacon on loop",
+				"ifid", egIfid, "err", err)
+			continue
+		}
+		if err := egress.Apply(bseg, egIfid, neighbor); err != nil {
+			logger.Trace("[beaconing.Propagator] Ignoring beacon due to egress policy",
+				"ifid", egIfid, "err", err)
+			continue
+		}
+		egIfids = append(egIfids, egIfid)
 	}
-	return false
+	return egIfids
 }
 
 func (p *beaconPropagator) onSuccess(intf *ifstate.Interface, egIfid common.IFIDType) {
@@ -29,6 +29,7 @@ import (
 	"github.com/scionproto/scion/go/cs/beaconing/mock_beaconing"
 	"github.com/scionproto/scion/go/cs/ifstate"
 	"github.com/scionproto/scion/go/cs/onehop"
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/infra/modules/itopo/itopotest"
 	"github.com/scionproto/scion/go/lib/scrypto"
@@ -51,6 +52,7 @@ func TestPropagatorRun(t *testing.T) {
 		inactive map[common.IFIDType]bool
 		expected int
 		core     bool
+		egress   []beacon.EgressPolicy
 	}
 	topoFile := map[bool]string{false: topoNonCore, true: topoCore}
 	// The beacons to propagate for the non-core and core tests.
@@ -127,6 +129,28 @@ func TestPropagatorRun(t *testing.T) {
 			expected: 1,
 			core:     true,
 		},
+		{
+			name: "Core: Egress policy restricts origins",
+			egress: []beacon.EgressPolicy{
+				{
+					Interfaces: []common.IFIDType{graph.If_110_X_210_X},
+					Origins:    []addr.IA{xtest.MustParseIA("1-ff00:0:130")},
+				},
+			},
+			expected: 2,
+			core:     true,
+		},
+		{
+			name: "Core: Egress policy limits beacons per neighbor",
+			egress: []beacon.EgressPolicy{
+				{
+					Neighbors:  []addr.IA{xtest.MustParseIA("2-ff00:0:210")},
+					MaxBeacons: 1,
+				},
+			},
+			expected: 2,
+			core:     true,
+		},
 		{
 			name: "Core: All inactive",
 			inactive: map[common.IFIDType]bool{
@@ -155,6 +179,7 @@ func TestPropagatorRun(t *testing.T) {
 				},
 				Period:         time.Hour,
 				BeaconProvider: provider,
+				EgressPolicies: newEgressPolicies(test.egress),
 				Core:           test.core,
 				BeaconSender: &onehop.BeaconSender{
 					Sender: onehop.Sender{
@@ -275,3 +300,15 @@ func TestPropagatorRun(t *testing.T) {
 		p.Run(nil)
 	})
 }
+
+type egressPolicies []beacon.EgressPolicy
+
+func newEgressPolicies(egress []beacon.EgressPolicy) egressPolicies {
+	p := beacon.Policy{Egress: egress}
+	p.InitDefaults()
+	return p.Egress
+}
+
+func (p egressPolicies) EgressPolicies() []beacon.EgressPolicy {
+	return p
+}
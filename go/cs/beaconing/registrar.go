@@ -95,6 +95,18 @@ func (cfg RegistrarConf) New() (*Registrar, error) {
 	return r, nil
 }
 
+// Force forces the next run to register segments, regardless of the
+// configured period. It is safe for concurrent use.
+func (r *Registrar) Force() {
+	r.tick.force()
+}
+
+// SetPeriod changes the period from the next run on. It is safe for
+// concurrent use.
+func (r *Registrar) SetPeriod(period time.Duration) {
+	r.tick.setPeriod(period)
+}
+
 // Name returns the tasks name.
 func (r *Registrar) Name() string {
 	return "bs_beaconing_registrar"
@@ -103,6 +115,7 @@ func (r *Registrar) Name() string {
 // Run registers path segments for the specified type to path servers.
 func (r *Registrar) Run(ctx context.Context) {
 	r.tick.now = time.Now()
+	r.tick.applyPending()
 	if err := r.run(ctx); err != nil {
 		log.FromCtx(ctx).Error("[beaconing.Registrar] Unable to register",
 			"type", r.segType, "err", err)
@@ -0,0 +1,174 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+var linkTypes = map[string]seg.LinkType{
+	"":         seg.LinkTypeUnset,
+	"direct":   seg.LinkTypeDirect,
+	"multihop": seg.LinkTypeMultihop,
+	"opennet":  seg.LinkTypeOpennet,
+}
+
+// StaticInfoCfg is the operator-provided static information about the
+// interfaces of the AS. It is used to create the static info extension that is
+// added to the AS entries.
+type StaticInfoCfg struct {
+	// Interfaces maps the interface ID to its static information.
+	Interfaces map[common.IFIDType]InterfaceInfo
+}
+
+// InterfaceInfo is the static information of a single interface.
+type InterfaceInfo struct {
+	// Latency is the latency of the inter-AS link.
+	Latency util.DurWrap
+	// Bandwidth is the bandwidth of the inter-AS link in Kbit/s.
+	Bandwidth uint64
+	// Geo is the location of the interface.
+	Geo GeoInfo
+	// LinkType is the type of the inter-AS link. One of direct, multihop or
+	// opennet. If empty, the link type is unset.
+	LinkType string
+	// IntraLatency maps the interface ID of other interfaces in the AS to the
+	// latency between this and the other interface.
+	IntraLatency map[common.IFIDType]util.DurWrap
+}
+
+// GeoInfo is the location of an interface.
+type GeoInfo struct {
+	Latitude  float32
+	Longitude float32
+	Address   string
+}
+
+// LoadStaticInfoCfg loads the static info configuration from a json file and
+// validates it.
+func LoadStaticInfoCfg(path string) (*StaticInfoCfg, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to read static info config", err, "path", path)
+	}
+	cfg := &StaticInfoCfg{}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, serrors.WrapStr("unable to parse static info config", err, "path", path)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, serrors.WrapStr("invalid static info config", err, "path", path)
+	}
+	return cfg, nil
+}
+
+// Validate checks that all durations are non-negative and fit the extension,
+// and that all link types are known.
+func (cfg *StaticInfoCfg) Validate() error {
+	for ifid, info := range cfg.Interfaces {
+		if _, ok := linkTypes[info.LinkType]; !ok {
+			return serrors.New("unknown link type", "ifid", ifid, "link_type", info.LinkType)
+		}
+		if err := validateLatency(info.Latency.Duration); err != nil {
+			return serrors.WrapStr("invalid latency", err, "ifid", ifid)
+		}
+		for other, l := range info.IntraLatency {
+			if err := validateLatency(l.Duration); err != nil {
+				return serrors.WrapStr("invalid intra latency", err, "ifid", ifid,
+					"other", other)
+			}
+		}
+	}
+	return nil
+}
+
+// Generate creates the static info extension for an AS entry with the provided
+// ingress, egress and peer interfaces. Zero interface IDs are ignored. The
+// extension contains the information of all configured interfaces and the
+// intra-AS latencies that can be traversed by a path through the AS entry. If
+// cfg is nil or none of the interfaces is configured, nil is returned.
+func (cfg *StaticInfoCfg) Generate(inIfid, egIfid common.IFIDType,
+	peers []common.IFIDType) *seg.StaticInfoExtn {
+
+	if cfg == nil {
+		return nil
+	}
+	extn := &seg.StaticInfoExtn{}
+	ifids := append([]common.IFIDType{inIfid, egIfid}, peers...)
+	for _, ifid := range ifids {
+		if info, ok := cfg.Interfaces[ifid]; ok && ifid != 0 {
+			extn.Interfaces = append(extn.Interfaces, &seg.StaticInfoInterface{
+				IfID:        ifid,
+				LinkLatency: toMicros(info.Latency.Duration),
+				Bandwidth:   info.Bandwidth,
+				Latitude:    info.Geo.Latitude,
+				Longitude:   info.Geo.Longitude,
+				Address:     info.Geo.Address,
+				LinkType:    linkTypes[info.LinkType],
+			})
+		}
+	}
+	// Paths through the AS entry either traverse from ingress to egress, or
+	// from a peering interface to egress.
+	ingresses := append([]common.IFIDType{inIfid}, peers...)
+	for _, ifid := range ingresses {
+		if l, ok := cfg.intraLatency(ifid, egIfid); ok {
+			extn.IntraLatencies = append(extn.IntraLatencies, &seg.StaticInfoLatency{
+				IfID:      ifid,
+				OtherIfID: egIfid,
+				Latency:   toMicros(l),
+			})
+		}
+	}
+	if len(extn.Interfaces) == 0 && len(extn.IntraLatencies) == 0 {
+		return nil
+	}
+	return extn
+}
+
+// intraLatency returns the latency between the two interfaces. The latency
+// can be configured on either of the two interfaces.
+func (cfg *StaticInfoCfg) intraLatency(a, b common.IFIDType) (time.Duration, bool) {
+	if a == 0 || b == 0 {
+		return 0, false
+	}
+	if l, ok := cfg.Interfaces[a].IntraLatency[b]; ok {
+		return l.Duration, true
+	}
+	if l, ok := cfg.Interfaces[b].IntraLatency[a]; ok {
+		return l.Duration, true
+	}
+	return 0, false
+}
+
+func validateLatency(d time.Duration) error {
+	if d < 0 {
+		return serrors.New("latency must not be negative", "latency", d)
+	}
+	if d/time.Microsecond > 1<<32-1 {
+		return serrors.New("latency too large", "latency", d)
+	}
+	return nil
+}
+
+func toMicros(d time.Duration) uint32 {
+	return uint32(d / time.Microsecond)
+}
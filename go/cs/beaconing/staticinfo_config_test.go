@@ -0,0 +1,141 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest/graph"
+)
+
+func TestLoadStaticInfoCfg(t *testing.T) {
+	cfg, err := LoadStaticInfoCfg("testdata/staticinfo.json")
+	require.NoError(t, err)
+	require.Len(t, cfg.Interfaces, 3)
+	info := cfg.Interfaces[graph.If_111_B_120_X]
+	assert.Equal(t, 10*time.Millisecond, info.Latency.Duration)
+	assert.Equal(t, uint64(1000000), info.Bandwidth)
+	assert.Equal(t, "Zurich", info.Geo.Address)
+	assert.Equal(t, "direct", info.LinkType)
+	assert.Equal(t, 500*time.Microsecond,
+		info.IntraLatency[graph.If_111_A_112_X].Duration)
+
+	_, err = LoadStaticInfoCfg("testdata/doesnotexist.json")
+	assert.Error(t, err)
+}
+
+func TestStaticInfoCfgValidate(t *testing.T) {
+	tests := map[string]struct {
+		Info      InterfaceInfo
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			Info:      InterfaceInfo{LinkType: "multihop"},
+			Assertion: assert.NoError,
+		},
+		"unknown link type": {
+			Info:      InterfaceInfo{LinkType: "carrier-pigeon"},
+			Assertion: assert.Error,
+		},
+		"negative latency": {
+			Info:      InterfaceInfo{Latency: util.DurWrap{Duration: -time.Second}},
+			Assertion: assert.Error,
+		},
+		"intra latency too large": {
+			Info: InterfaceInfo{
+				IntraLatency: map[common.IFIDType]util.DurWrap{
+					2: {Duration: 2 * time.Hour},
+				},
+			},
+			Assertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := StaticInfoCfg{
+				Interfaces: map[common.IFIDType]InterfaceInfo{1: test.Info},
+			}
+			test.Assertion(t, cfg.Validate())
+		})
+	}
+}
+
+func TestStaticInfoCfgGenerate(t *testing.T) {
+	cfg, err := LoadStaticInfoCfg("testdata/staticinfo.json")
+	require.NoError(t, err)
+
+	t.Run("nil config", func(t *testing.T) {
+		var nilCfg *StaticInfoCfg
+		assert.Nil(t, nilCfg.Generate(graph.If_111_B_120_X, graph.If_111_A_112_X, nil))
+	})
+	t.Run("unknown interfaces", func(t *testing.T) {
+		assert.Nil(t, cfg.Generate(1, 2, nil))
+	})
+	t.Run("ingress, egress and peer", func(t *testing.T) {
+		extn := cfg.Generate(graph.If_111_B_120_X, graph.If_111_A_112_X,
+			[]common.IFIDType{graph.If_111_C_121_X})
+		require.NotNil(t, extn)
+		expected := &seg.StaticInfoExtn{
+			Interfaces: []*seg.StaticInfoInterface{
+				{
+					IfID:        graph.If_111_B_120_X,
+					LinkLatency: 10000,
+					Bandwidth:   1000000,
+					Latitude:    47.3769,
+					Longitude:   8.5417,
+					Address:     "Zurich",
+					LinkType:    seg.LinkTypeDirect,
+				},
+				{
+					IfID:        graph.If_111_A_112_X,
+					LinkLatency: 2000,
+					Bandwidth:   400000,
+					LinkType:    seg.LinkTypeOpennet,
+				},
+				{
+					IfID:        graph.If_111_C_121_X,
+					LinkLatency: 15000,
+					LinkType:    seg.LinkTypeMultihop,
+				},
+			},
+			IntraLatencies: []*seg.StaticInfoLatency{
+				{
+					IfID:      graph.If_111_B_120_X,
+					OtherIfID: graph.If_111_A_112_X,
+					Latency:   500,
+				},
+				{
+					IfID:      graph.If_111_C_121_X,
+					OtherIfID: graph.If_111_A_112_X,
+					Latency:   1000,
+				},
+			},
+		}
+		assert.Equal(t, expected, extn)
+	})
+	t.Run("terminated segment", func(t *testing.T) {
+		extn := cfg.Generate(graph.If_111_B_120_X, 0, nil)
+		require.NotNil(t, extn)
+		assert.Len(t, extn.Interfaces, 1)
+		assert.Empty(t, extn.IntraLatencies)
+	})
+}
@@ -15,6 +15,7 @@
 package beaconing
 
 import (
+	"sync/atomic"
 	"time"
 )
 
@@ -23,6 +24,36 @@ type tick struct {
 	now    time.Time
 	last   time.Time
 	period time.Duration
+	// forced is set if the period should be considered passed in the next
+	// run. It is set concurrently and must only be accessed atomically.
+	forced int32
+	// pendingPeriod is the period in nanoseconds that applies from the next
+	// run on. Zero means no change. It is set concurrently and must only be
+	// accessed atomically.
+	pendingPeriod int64
+}
+
+// force makes the period pass in the next run, regardless of the last time.
+// It is safe for concurrent use.
+func (t *tick) force() {
+	atomic.StoreInt32(&t.forced, 1)
+}
+
+// setPeriod changes the period from the next run on. It is safe for
+// concurrent use.
+func (t *tick) setPeriod(period time.Duration) {
+	atomic.StoreInt64(&t.pendingPeriod, int64(period))
+}
+
+// applyPending applies the pending period change, and resets the last time if
+// the next run was forced. It must be called at the start of a run.
+func (t *tick) applyPending() {
+	if period := atomic.SwapInt64(&t.pendingPeriod, 0); period != 0 {
+		t.period = time.Duration(period)
+	}
+	if atomic.SwapInt32(&t.forced, 0) == 1 {
+		t.last = time.Time{}
+	}
 }
 
 // updateLast updates the last time to the current time, if the period has
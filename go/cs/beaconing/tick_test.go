@@ -0,0 +1,54 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beaconing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTickForce(t *testing.T) {
+	now := time.Now()
+	tk := tick{now: now, last: now.Add(-time.Second), period: time.Minute}
+	tk.applyPending()
+	assert.False(t, tk.passed())
+
+	tk.force()
+	tk.applyPending()
+	assert.True(t, tk.passed())
+	tk.updateLast()
+	assert.Equal(t, now, tk.last)
+
+	// The force only applies to a single run.
+	tk.applyPending()
+	assert.False(t, tk.passed())
+}
+
+func TestTickSetPeriod(t *testing.T) {
+	now := time.Now()
+	tk := tick{now: now, last: now.Add(-time.Second), period: time.Minute}
+	tk.setPeriod(time.Millisecond)
+	// The period only changes at the start of the next run.
+	assert.False(t, tk.passed())
+	tk.applyPending()
+	assert.Equal(t, time.Millisecond, tk.period)
+	assert.True(t, tk.passed())
+
+	// Without a pending change, the period is kept.
+	tk.applyPending()
+	assert.Equal(t, time.Millisecond, tk.period)
+}
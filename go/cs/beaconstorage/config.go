@@ -22,6 +22,7 @@ import (
 	"io"
 
 	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/beacon/beacondbmem"
 	"github.com/scionproto/scion/go/cs/beacon/beacondbsqlite"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
@@ -40,6 +41,8 @@ const (
 	backendNone Backend = ""
 	// BackendSqlite indicates an sqlite backend.
 	BackendSqlite Backend = "sqlite"
+	// BackendMem indicates an in-memory backend.
+	BackendMem Backend = "mem"
 )
 
 const (
@@ -100,7 +103,7 @@ func (cfg *BeaconDBConf) Validate() error {
 
 func (cfg *BeaconDBConf) validateBackend() error {
 	switch cfg.Backend() {
-	case BackendSqlite:
+	case BackendSqlite, BackendMem:
 		return nil
 	case backendNone:
 		return serrors.New("No backend set")
@@ -109,7 +112,7 @@ func (cfg *BeaconDBConf) validateBackend() error {
 }
 
 func (cfg *BeaconDBConf) validateConnection() error {
-	if cfg.Connection() == "" {
+	if cfg.Backend() != BackendMem && cfg.Connection() == "" {
 		return serrors.New("empty connection not allowed")
 	}
 	return nil
@@ -134,6 +137,8 @@ func (cfg *BeaconDBConf) New(ia addr.IA) (beacon.DB, error) {
 	switch cfg.Backend() {
 	case BackendSqlite:
 		bdb, err = beacondbsqlite.New(cfg.Connection(), ia)
+	case BackendMem:
+		bdb = beacondbmem.New(ia)
 	default:
 		return nil, common.NewBasicError("Unsupported backend", nil, "backend", cfg.Backend())
 	}
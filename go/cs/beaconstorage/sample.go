@@ -15,10 +15,11 @@
 package beaconstorage
 
 const beaconDbSample = `
-# The type of trustdb backend. (default sqlite)
+# The type of beacondb backend. The in-memory backend (mem) does not persist
+# beacons across restarts. (sqlite|mem, default sqlite)
 backend = "sqlite"
 
-# Connection for the trust database.
+# Connection for the beacon database. Ignored by the in-memory backend.
 connection = "/var/lib/scion/beacondb/%s.beacon.db"
 
 # The maximum number of open connections to the database. In case of the
@@ -48,15 +48,27 @@ type Store interface {
 	InsertRevocations(ctx context.Context, revocations ...*path_mgmt.SignedRevInfo) error
 	// DeleteRevocation deletes the revocation from the BeaconDB.
 	DeleteRevocation(ctx context.Context, ia addr.IA, ifid common.IFIDType) error
-	// UpdatePolicy updates the policy. Beacons that are filtered by all
-	// policies after the update are removed.
+	// UpdatePolicy atomically replaces the policy of the same type.
 	UpdatePolicy(ctx context.Context, policy beacon.Policy) error
+	// UpdatePolicies atomically replaces the policies of the same types.
+	// Either all policies are applied or none. Stored beacons are filtered
+	// with the current policies when they are selected.
+	UpdatePolicies(ctx context.Context, policies ...beacon.Policy) error
+	// EgressPolicies returns the egress policies of the propagation policy.
+	EgressPolicies() []beacon.EgressPolicy
 	// MaxExpTime returns the segment maximum expiration time for the given policy.
 	MaxExpTime(policyType beacon.PolicyType) spath.ExpTimeType
 	// DeleteExpired deletes expired Beacons from the store.
 	DeleteExpiredBeacons(ctx context.Context) (int, error)
 	// DeleteExpiredRevocations deletes expired Revocations from the store.
 	DeleteExpiredRevocations(ctx context.Context) (int, error)
+	// Beacons returns all beacons in the store together with their usage.
+	Beacons(ctx context.Context) (<-chan beacon.StoredBeaconOrErr, error)
+	// LastSelections returns the last beacon selection for each policy.
+	LastSelections() []beacon.SelectionResult
+	// FilteredBeacons returns the most recently filtered beacons, newest
+	// first.
+	FilteredBeacons() []beacon.FilteredBeacon
 	// Close closes the store.
 	Close() error
 }
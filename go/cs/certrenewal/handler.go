@@ -0,0 +1,111 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certrenewal
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/scrypto/cert/renewal"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// ChainIssuer issues certificate chains for signed renewal requests.
+type ChainIssuer interface {
+	Issue(ctx context.Context, signed renewal.SignedRequest) (cert.Chain, error)
+}
+
+// ChainInserter verifies and inserts raw certificate chains.
+type ChainInserter interface {
+	InsertChain(ctx context.Context, raw []byte) error
+}
+
+type handler struct {
+	issuer   ChainIssuer
+	inserter ChainInserter
+	timeout  time.Duration
+}
+
+// NewHandler returns an infra.Handler for chain issuance requests. Issued
+// chains are inserted before the reply is sent.
+func NewHandler(issuer ChainIssuer, inserter ChainInserter,
+	timeout time.Duration) infra.Handler {
+
+	return &handler{
+		issuer:   issuer,
+		inserter: inserter,
+		timeout:  timeout,
+	}
+}
+
+// Handle handles chain issuance requests.
+func (h *handler) Handle(request *infra.Request) *infra.HandlerResult {
+	logger := log.FromCtx(request.Context())
+	req, ok := request.Message.(*cert_mgmt.ChainIssReq)
+	if !ok {
+		logger.Error("[ChainIssHandler] wrong message type, expected cert_mgmt.ChainIssReq",
+			"msg", request.Message, "type", common.TypeOf(request.Message))
+		return infra.MetricsErrInternal
+	}
+	rw, ok := infra.ResponseWriterFromContext(request.Context())
+	if !ok {
+		logger.Error("[ChainIssHandler] Unable to service request, no ResponseWriter found",
+			"msg", request.Message)
+		return infra.MetricsErrInternal
+	}
+	subCtx, cancelF := context.WithTimeout(request.Context(), h.timeout)
+	defer cancelF()
+
+	sendAck := messenger.SendAckHelper(subCtx, rw)
+	signed, err := renewal.ParseSignedRequest(req.Raw)
+	if err != nil {
+		logger.Warn("[ChainIssHandler] Unable to parse request", "peer", request.Peer,
+			"err", err)
+		sendAck(proto.Ack_ErrCode_reject, messenger.AckRejectFailedToParse)
+		return infra.MetricsErrInvalid
+	}
+	chain, err := h.issuer.Issue(subCtx, signed)
+	if err != nil {
+		logger.Warn("[ChainIssHandler] Unable to issue certificate chain", "peer", request.Peer,
+			"req", req, "err", err)
+		sendAck(proto.Ack_ErrCode_reject, messenger.AckRejectFailedToVerify)
+		return infra.MetricsErrInvalid
+	}
+	raw, err := json.Marshal(chain)
+	if err != nil {
+		logger.Error("[ChainIssHandler] Unable to marshal certificate chain", "err", err)
+		return infra.MetricsErrInternal
+	}
+	if err := h.inserter.InsertChain(subCtx, raw); err != nil {
+		logger.Error("[ChainIssHandler] Unable to insert issued certificate chain", "err", err)
+		sendAck(proto.Ack_ErrCode_retry, messenger.AckRetryDBError)
+		return infra.MetricsErrTrustDB(err)
+	}
+	rep := &cert_mgmt.ChainIssRep{RawChain: raw}
+	if err := rw.SendChainIssueReply(subCtx, rep); err != nil {
+		logger.Error("[ChainIssHandler] Messenger error", "err", err)
+		return infra.MetricsErrMsger(err)
+	}
+	logger.Info("[ChainIssHandler] Issued certificate chain", "peer", request.Peer,
+		"chain", rep)
+	return infra.MetricsResultOk
+}
@@ -0,0 +1,172 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certrenewal_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/certrenewal"
+	"github.com/scionproto/scion/go/cs/certrenewal/mock_certrenewal"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/ack"
+	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/infra/mock_infra"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/scrypto/cert/renewal"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest/matchers"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestHandlerHandle(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	issPub, _ := genKey(t)
+	curPub, curPriv := genKey(t)
+	newPub, newPriv := genKey(t)
+	rawChain := encodeChain(t, newIssuerCert(now, issPub), newASCert(now, curPub))
+	var chain cert.Chain
+	require.NoError(t, json.Unmarshal(rawChain, &chain))
+
+	info := &renewal.RequestInfo{
+		Subject:                    ia111,
+		Version:                    2,
+		FormatVersion:              1,
+		Description:                "renewed",
+		OptionalDistributionPoints: []addr.IA{},
+		Validity: &scrypto.Validity{
+			NotBefore: util.UnixTime{Time: now},
+			NotAfter:  util.UnixTime{Time: now.Add(24 * time.Hour)},
+		},
+		Keys:        renewal.Keys{Signing: renewal.KeyMeta{Key: newPub}},
+		Issuer:      ia110,
+		RequestTime: util.UnixTime{Time: now},
+	}
+	signed, err := renewal.NewSignedRequest(info,
+		renewal.PrivateKey{Type: renewal.SigningKey, Version: 1,
+			Algorithm: scrypto.Ed25519, Key: curPriv},
+		renewal.PrivateKey{Type: renewal.SigningKey, Version: 2,
+			Algorithm: scrypto.Ed25519, Key: newPriv},
+	)
+	require.NoError(t, err)
+	rawReq, err := json.Marshal(signed)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		Message  proto.Cerealizable
+		NoWriter bool
+		Prepare  func(issuer *mock_certrenewal.MockChainIssuer,
+			inserter *mock_certrenewal.MockChainInserter, rw *mock_infra.MockResponseWriter)
+		Result *infra.HandlerResult
+	}{
+		"wrong message type": {
+			Message: &cert_mgmt.ChainIssRep{},
+			Prepare: func(_ *mock_certrenewal.MockChainIssuer,
+				_ *mock_certrenewal.MockChainInserter, _ *mock_infra.MockResponseWriter) {
+			},
+			Result: infra.MetricsErrInternal,
+		},
+		"no response writer": {
+			Message:  &cert_mgmt.ChainIssReq{Raw: rawReq},
+			NoWriter: true,
+			Prepare: func(_ *mock_certrenewal.MockChainIssuer,
+				_ *mock_certrenewal.MockChainInserter, _ *mock_infra.MockResponseWriter) {
+			},
+			Result: infra.MetricsErrInternal,
+		},
+		"malformed request": {
+			Message: &cert_mgmt.ChainIssReq{Raw: []byte("garbage")},
+			Prepare: func(_ *mock_certrenewal.MockChainIssuer,
+				_ *mock_certrenewal.MockChainInserter, rw *mock_infra.MockResponseWriter) {
+
+				rw.EXPECT().SendAckReply(gomock.Any(), &matchers.AckMsg{Ack: ack.Ack{
+					Err:     proto.Ack_ErrCode_reject,
+					ErrDesc: messenger.AckRejectFailedToParse,
+				}})
+			},
+			Result: infra.MetricsErrInvalid,
+		},
+		"issuance fails": {
+			Message: &cert_mgmt.ChainIssReq{Raw: rawReq},
+			Prepare: func(issuer *mock_certrenewal.MockChainIssuer,
+				_ *mock_certrenewal.MockChainInserter, rw *mock_infra.MockResponseWriter) {
+
+				issuer.EXPECT().Issue(gomock.Any(), signed).Return(cert.Chain{},
+					certrenewal.ErrInvalidRequest)
+				rw.EXPECT().SendAckReply(gomock.Any(), &matchers.AckMsg{Ack: ack.Ack{
+					Err:     proto.Ack_ErrCode_reject,
+					ErrDesc: messenger.AckRejectFailedToVerify,
+				}})
+			},
+			Result: infra.MetricsErrInvalid,
+		},
+		"insertion fails": {
+			Message: &cert_mgmt.ChainIssReq{Raw: rawReq},
+			Prepare: func(issuer *mock_certrenewal.MockChainIssuer,
+				inserter *mock_certrenewal.MockChainInserter, rw *mock_infra.MockResponseWriter) {
+
+				issuer.EXPECT().Issue(gomock.Any(), signed).Return(chain, nil)
+				inserter.EXPECT().InsertChain(gomock.Any(), rawChain).Return(
+					errors.New("db failure"))
+				rw.EXPECT().SendAckReply(gomock.Any(), &matchers.AckMsg{Ack: ack.Ack{
+					Err:     proto.Ack_ErrCode_retry,
+					ErrDesc: messenger.AckRetryDBError,
+				}})
+			},
+			Result: infra.MetricsErrTrustDB(errors.New("db failure")),
+		},
+		"issued": {
+			Message: &cert_mgmt.ChainIssReq{Raw: rawReq},
+			Prepare: func(issuer *mock_certrenewal.MockChainIssuer,
+				inserter *mock_certrenewal.MockChainInserter, rw *mock_infra.MockResponseWriter) {
+
+				issuer.EXPECT().Issue(gomock.Any(), signed).Return(chain, nil)
+				inserter.EXPECT().InsertChain(gomock.Any(), rawChain)
+				rw.EXPECT().SendChainIssueReply(gomock.Any(),
+					&cert_mgmt.ChainIssRep{RawChain: rawChain})
+			},
+			Result: infra.MetricsResultOk,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+
+			issuer := mock_certrenewal.NewMockChainIssuer(mctrl)
+			inserter := mock_certrenewal.NewMockChainInserter(mctrl)
+			rw := mock_infra.NewMockResponseWriter(mctrl)
+			test.Prepare(issuer, inserter, rw)
+
+			ctx := context.Background()
+			if !test.NoWriter {
+				ctx = infra.NewContextWithResponseWriter(ctx, rw)
+			}
+			req := infra.NewRequest(ctx, test.Message, nil, nil, 0)
+			h := certrenewal.NewHandler(issuer, inserter, time.Second)
+			assert.Equal(t, test.Result, h.Handle(req))
+		})
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package certrenewal implements AS certificate renewal in the control
+// service.
+//
+// Issuing ASes run the Issuer behind the chain issuance handler. It verifies
+// signed renewal requests against the requester's current certificate chain
+// and issues a new AS certificate signed with the local issuer key.
+//
+// Non-issuing ASes run the Requester, which requests a new certificate from
+// the issuer of the current AS certificate before it expires.
+package certrenewal
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/scrypto/cert/renewal"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// DefaultMaxValidity is the default maximum validity period of issued AS
+// certificates.
+const DefaultMaxValidity = 3 * 24 * time.Hour
+
+var (
+	// ErrNotIssuing indicates that the local AS is not an issuing AS.
+	ErrNotIssuing = serrors.New("not an issuing AS")
+	// ErrUnexpectedIssuer indicates that the request is addressed to another
+	// issuer.
+	ErrUnexpectedIssuer = serrors.New("request addressed to other issuer")
+	// ErrInvalidRequest indicates that the request is malformed or does not
+	// match the current certificate of the requester.
+	ErrInvalidRequest = serrors.New("invalid request")
+)
+
+// Issuer issues AS certificates for verified renewal requests.
+type Issuer struct {
+	// IA is the ISD-AS of the local issuing AS.
+	IA addr.IA
+	// Provider provides the certificate chains of the issuer and requester.
+	Provider trust.CryptoProvider
+	// KeyRing provides the issuing private key.
+	KeyRing trust.KeyRing
+	// MaxValidity is the maximum validity period of an issued certificate.
+	MaxValidity time.Duration
+}
+
+// Issue verifies the signed request and issues a new certificate chain. If
+// the requested certificate version has already been issued for the same
+// keys, the existing certificate chain is returned. This allows requesters
+// to retry lost replies.
+func (i Issuer) Issue(ctx context.Context, signed renewal.SignedRequest) (cert.Chain, error) {
+	req, err := signed.Encoded.Decode()
+	if err != nil {
+		return cert.Chain{}, serrors.Wrap(ErrInvalidRequest, err)
+	}
+	// The request info is not authenticated yet. It is only used to look up
+	// the crypto material required for verification.
+	unverified, err := req.Encoded.Decode()
+	if err != nil {
+		return cert.Chain{}, serrors.Wrap(ErrInvalidRequest, err)
+	}
+	if !unverified.Issuer.Equal(i.IA) {
+		return cert.Chain{}, serrors.WithCtx(ErrUnexpectedIssuer,
+			"expected", i.IA, "actual", unverified.Issuer)
+	}
+	if unverified.Subject.I != i.IA.I || unverified.Subject.IsWildcard() {
+		return cert.Chain{}, serrors.WithCtx(ErrInvalidRequest,
+			"reason", "subject not in local ISD", "subject", unverified.Subject)
+	}
+	issChain, iss, err := i.issuerCert(ctx)
+	if err != nil {
+		return cert.Chain{}, err
+	}
+	curChain, cur, err := i.chain(ctx, unverified.Subject)
+	if err != nil {
+		return cert.Chain{}, serrors.WrapStr("unable to get current chain of requester", err,
+			"subject", unverified.Subject)
+	}
+	// Only certificates issued by the local AS can be renewed. The current
+	// certificate must still be valid, otherwise a compromised key of an
+	// expired certificate could be used to obtain a new one.
+	if !cur.Issuer.IA.Equal(i.IA) {
+		return cert.Chain{}, serrors.WithCtx(ErrInvalidRequest,
+			"reason", "current certificate issued by other issuer", "issuer", cur.Issuer.IA)
+	}
+	now := time.Now()
+	if !cur.Validity.Contains(now) {
+		return cert.Chain{}, serrors.WithCtx(ErrInvalidRequest,
+			"reason", "current certificate not valid", "validity", cur.Validity)
+	}
+	if cur.Version >= unverified.Version {
+		return i.reissued(req, curChain, cur)
+	}
+	info, keys, err := renewal.RequestVerifier{AS: cur}.Verify(signed)
+	if err != nil {
+		return cert.Chain{}, serrors.Wrap(ErrInvalidRequest, err)
+	}
+	validity, err := i.validity(info.Validity, *iss.Validity, now)
+	if err != nil {
+		return cert.Chain{}, err
+	}
+	enc, ok := cur.Keys[cert.EncryptionKey]
+	if !ok {
+		return cert.Chain{}, serrors.WithCtx(ErrInvalidRequest,
+			"reason", "current certificate without encryption key")
+	}
+	keys[cert.EncryptionKey] = enc
+	as := &cert.AS{
+		Base: cert.Base{
+			Subject:                    info.Subject,
+			Version:                    info.Version,
+			FormatVersion:              info.FormatVersion,
+			Description:                info.Description,
+			OptionalDistributionPoints: info.OptionalDistributionPoints,
+			Validity:                   &validity,
+			Keys:                       keys,
+		},
+		Issuer: cert.IssuerCertID{
+			IA:                 iss.Subject,
+			CertificateVersion: iss.Version,
+		},
+	}
+	if err := as.Validate(); err != nil {
+		return cert.Chain{}, serrors.Wrap(ErrInvalidRequest, err)
+	}
+	signedAS, err := i.sign(as, iss)
+	if err != nil {
+		return cert.Chain{}, err
+	}
+	return cert.Chain{Issuer: issChain.Issuer, AS: signedAS}, nil
+}
+
+// reissued returns the current chain of the requester, if it has the
+// requested version and authenticates the keys the request proves possession
+// of.
+func (i Issuer) reissued(req renewal.Request, chain cert.Chain,
+	cur *cert.AS) (cert.Chain, error) {
+
+	info, keys, err := renewal.VerifyPOPs(req)
+	if err != nil {
+		return cert.Chain{}, serrors.Wrap(ErrInvalidRequest, err)
+	}
+	if cur.Version != info.Version {
+		return cert.Chain{}, serrors.WithCtx(ErrInvalidRequest,
+			"reason", "version already issued", "current", cur.Version, "requested", info.Version)
+	}
+	for keyType, meta := range keys {
+		if !bytes.Equal(cur.Keys[keyType].Key, meta.Key) {
+			return cert.Chain{}, serrors.WithCtx(ErrInvalidRequest,
+				"reason", "version already issued with different keys", "key_type", keyType)
+		}
+	}
+	return chain, nil
+}
+
+// issuerCert returns the local certificate chain and the issuer certificate
+// that is used to issue new AS certificates.
+func (i Issuer) issuerCert(ctx context.Context) (cert.Chain, *cert.Issuer, error) {
+	chain, err := i.rawChain(ctx, i.IA)
+	if err != nil {
+		return cert.Chain{}, nil, serrors.WrapStr("unable to get local chain", err)
+	}
+	iss, err := chain.Issuer.Encoded.Decode()
+	if err != nil {
+		return cert.Chain{}, nil, serrors.WrapStr("unable to decode issuer certificate", err)
+	}
+	if !iss.Subject.Equal(i.IA) {
+		return cert.Chain{}, nil, serrors.WithCtx(ErrNotIssuing, "issuer", iss.Subject)
+	}
+	if !iss.Validity.Contains(time.Now()) {
+		return cert.Chain{}, nil, serrors.New("issuer certificate not valid",
+			"validity", iss.Validity)
+	}
+	return chain, iss, nil
+}
+
+// chain returns the latest active certificate chain of the AS.
+func (i Issuer) chain(ctx context.Context, ia addr.IA) (cert.Chain, *cert.AS, error) {
+	chain, err := i.rawChain(ctx, ia)
+	if err != nil {
+		return cert.Chain{}, nil, err
+	}
+	as, err := chain.AS.Encoded.Decode()
+	if err != nil {
+		return cert.Chain{}, nil, serrors.WrapStr("unable to decode AS certificate", err)
+	}
+	return chain, as, nil
+}
+
+func (i Issuer) rawChain(ctx context.Context, ia addr.IA) (cert.Chain, error) {
+	raw, err := i.Provider.GetRawChain(ctx, trust.ChainID{IA: ia, Version: scrypto.LatestVer},
+		infra.ChainOpts{})
+	if err != nil {
+		return cert.Chain{}, err
+	}
+	return cert.ParseChain(raw)
+}
+
+// validity computes the validity period of the new certificate. The
+// requested period is cropped to the issuer certificate validity and the
+// maximum validity period.
+func (i Issuer) validity(requested *scrypto.Validity, issuer scrypto.Validity,
+	now time.Time) (scrypto.Validity, error) {
+
+	if requested == nil {
+		return scrypto.Validity{}, serrors.WithCtx(ErrInvalidRequest, "reason", "no validity")
+	}
+	notBefore, notAfter := requested.NotBefore.Time, requested.NotAfter.Time
+	if notBefore.Before(issuer.NotBefore.Time) {
+		notBefore = issuer.NotBefore.Time
+	}
+	if notAfter.After(issuer.NotAfter.Time) {
+		notAfter = issuer.NotAfter.Time
+	}
+	maxValidity := i.MaxValidity
+	if maxValidity == 0 {
+		maxValidity = DefaultMaxValidity
+	}
+	if max := notBefore.Add(maxValidity); notAfter.After(max) {
+		notAfter = max
+	}
+	if !notAfter.After(now) || !notAfter.After(notBefore) {
+		return scrypto.Validity{}, serrors.WithCtx(ErrInvalidRequest,
+			"reason", "validity not covered by issuer", "requested", requested,
+			"issuer", issuer)
+	}
+	return scrypto.Validity{
+		NotBefore: util.UnixTime{Time: notBefore},
+		NotAfter:  util.UnixTime{Time: notAfter},
+	}, nil
+}
+
+func (i Issuer) sign(as *cert.AS, iss *cert.Issuer) (cert.SignedAS, error) {
+	meta := iss.Keys[cert.IssuingKey]
+	key, err := i.KeyRing.PrivateKey(keyconf.IssCertSigningKey, meta.KeyVersion)
+	if err != nil {
+		return cert.SignedAS{}, serrors.WrapStr("unable to load issuing key", err,
+			"key_version", meta.KeyVersion)
+	}
+	enc, err := cert.EncodeAS(as)
+	if err != nil {
+		return cert.SignedAS{}, serrors.WrapStr("unable to encode AS certificate", err)
+	}
+	protected, err := cert.EncodeProtectedAS(cert.ProtectedAS{
+		Algorithm:          key.Algorithm,
+		IA:                 iss.Subject,
+		CertificateVersion: iss.Version,
+	})
+	if err != nil {
+		return cert.SignedAS{}, serrors.WrapStr("unable to encode protected", err)
+	}
+	signed := cert.SignedAS{
+		Encoded:          enc,
+		EncodedProtected: protected,
+	}
+	if signed.Signature, err = scrypto.Sign(signed.SigInput(), key.Bytes,
+		key.Algorithm); err != nil {
+		return cert.SignedAS{}, serrors.WrapStr("unable to sign AS certificate", err)
+	}
+	return signed, nil
+}
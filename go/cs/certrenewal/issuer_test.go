@@ -0,0 +1,355 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certrenewal_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/certrenewal"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/mock_trust"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/scrypto/cert/renewal"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var (
+	ia110 = xtest.MustParseIA("1-ff00:0:110")
+	ia111 = xtest.MustParseIA("1-ff00:0:111")
+	ia112 = xtest.MustParseIA("1-ff00:0:112")
+	ia210 = xtest.MustParseIA("2-ff00:0:210")
+)
+
+func TestIssuerIssue(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	issPub, issPriv := genKey(t)
+	curPub, curPriv := genKey(t)
+	newPub, newPriv := genKey(t)
+	_, otherPriv := genKey(t)
+
+	issCert := newIssuerCert(now, issPub)
+	curCert := newASCert(now, curPub)
+	signingKey := renewal.PrivateKey{Type: renewal.SigningKey, Version: 1,
+		Algorithm: scrypto.Ed25519, Key: curPriv}
+	newKey := renewal.PrivateKey{Type: renewal.SigningKey, Version: 2,
+		Algorithm: scrypto.Ed25519, Key: newPriv}
+
+	newInfo := func() *renewal.RequestInfo {
+		return &renewal.RequestInfo{
+			Subject:                    ia111,
+			Version:                    2,
+			FormatVersion:              1,
+			Description:                "renewed",
+			OptionalDistributionPoints: []addr.IA{},
+			Validity: &scrypto.Validity{
+				NotBefore: util.UnixTime{Time: now},
+				NotAfter:  util.UnixTime{Time: now.Add(30 * 24 * time.Hour)},
+			},
+			Keys:        renewal.Keys{Signing: renewal.KeyMeta{Key: newPub}},
+			Issuer:      ia110,
+			RequestTime: util.UnixTime{Time: now},
+		}
+	}
+	sign := func(t *testing.T, info *renewal.RequestInfo,
+		key renewal.PrivateKey) renewal.SignedRequest {
+
+		signed, err := renewal.NewSignedRequest(info, key, newKey)
+		require.NoError(t, err)
+		return signed
+	}
+
+	tests := map[string]struct {
+		Request     func(t *testing.T) renewal.SignedRequest
+		LocalIssuer addr.IA
+		// Current modifies the current certificate of the requester, if set.
+		Current     func(as *cert.AS)
+		ExpectedErr error
+		Check       func(t *testing.T, chain cert.Chain)
+	}{
+		"valid": {
+			Request: func(t *testing.T) renewal.SignedRequest {
+				return sign(t, newInfo(), signingKey)
+			},
+			LocalIssuer: ia110,
+			Check: func(t *testing.T, chain cert.Chain) {
+				as, err := chain.AS.Encoded.Decode()
+				require.NoError(t, err)
+				assert.Equal(t, ia111, as.Subject)
+				assert.Equal(t, scrypto.Version(2), as.Version)
+				assert.Equal(t, "renewed", as.Description)
+				assert.Equal(t, newPub, as.Keys[cert.SigningKey].Key)
+				assert.Equal(t, scrypto.KeyVersion(2), as.Keys[cert.SigningKey].KeyVersion)
+				assert.Equal(t, curCert.Keys[cert.EncryptionKey], as.Keys[cert.EncryptionKey])
+				// The validity is cropped to the maximum validity period.
+				assert.Equal(t, now, as.Validity.NotBefore.Time)
+				assert.Equal(t, now.Add(48*time.Hour), as.Validity.NotAfter.Time)
+				v := cert.ASVerifier{Issuer: issCert, AS: as, SignedAS: &chain.AS}
+				assert.NoError(t, v.Verify())
+			},
+		},
+		"already issued": {
+			Request: func(t *testing.T) renewal.SignedRequest {
+				info := newInfo()
+				info.Version = 1
+				return sign(t, info, signingKey)
+			},
+			LocalIssuer: ia110,
+			ExpectedErr: certrenewal.ErrInvalidRequest,
+		},
+		"wrong issuer": {
+			Request: func(t *testing.T) renewal.SignedRequest {
+				info := newInfo()
+				info.Issuer = ia111
+				return sign(t, info, signingKey)
+			},
+			LocalIssuer: ia110,
+			ExpectedErr: certrenewal.ErrUnexpectedIssuer,
+		},
+		"remote ISD": {
+			Request: func(t *testing.T) renewal.SignedRequest {
+				info := newInfo()
+				info.Subject = ia210
+				return sign(t, info, signingKey)
+			},
+			LocalIssuer: ia110,
+			ExpectedErr: certrenewal.ErrInvalidRequest,
+		},
+		"not issuing": {
+			Request: func(t *testing.T) renewal.SignedRequest {
+				return sign(t, newInfo(), signingKey)
+			},
+			LocalIssuer: ia111,
+			ExpectedErr: certrenewal.ErrNotIssuing,
+		},
+		"expired current certificate": {
+			Request: func(t *testing.T) renewal.SignedRequest {
+				return sign(t, newInfo(), signingKey)
+			},
+			LocalIssuer: ia110,
+			Current: func(as *cert.AS) {
+				as.Validity = &scrypto.Validity{
+					NotBefore: util.UnixTime{Time: now.Add(-2 * time.Hour)},
+					NotAfter:  util.UnixTime{Time: now.Add(-time.Hour)},
+				}
+			},
+			ExpectedErr: certrenewal.ErrInvalidRequest,
+		},
+		"current certificate of other issuer": {
+			Request: func(t *testing.T) renewal.SignedRequest {
+				return sign(t, newInfo(), signingKey)
+			},
+			LocalIssuer: ia110,
+			Current: func(as *cert.AS) {
+				as.Issuer.IA = ia112
+			},
+			ExpectedErr: certrenewal.ErrInvalidRequest,
+		},
+		"invalid signature": {
+			Request: func(t *testing.T) renewal.SignedRequest {
+				key := signingKey
+				key.Key = otherPriv
+				return sign(t, newInfo(), key)
+			},
+			LocalIssuer: ia110,
+			ExpectedErr: certrenewal.ErrInvalidRequest,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+
+			iss := *issCert
+			iss.Subject = test.LocalIssuer
+			cur := *curCert
+			if test.Current != nil {
+				test.Current(&cur)
+			}
+			provider := mock_trust.NewMockCryptoProvider(mctrl)
+			provider.EXPECT().GetRawChain(gomock.Any(),
+				trust.ChainID{IA: ia110, Version: scrypto.LatestVer}, gomock.Any(),
+			).Return(encodeChain(t, &iss, curCert), nil).AnyTimes()
+			provider.EXPECT().GetRawChain(gomock.Any(),
+				trust.ChainID{IA: ia111, Version: scrypto.LatestVer}, gomock.Any(),
+			).Return(encodeChain(t, issCert, &cur), nil).AnyTimes()
+			keyRing := mock_trust.NewMockKeyRing(mctrl)
+			keyRing.EXPECT().PrivateKey(keyconf.IssCertSigningKey, scrypto.KeyVersion(1)).Return(
+				keyconf.Key{Algorithm: scrypto.Ed25519, Bytes: issPriv}, nil,
+			).AnyTimes()
+
+			issuer := certrenewal.Issuer{
+				IA:          ia110,
+				Provider:    provider,
+				KeyRing:     keyRing,
+				MaxValidity: 48 * time.Hour,
+			}
+			chain, err := issuer.Issue(context.Background(), test.Request(t))
+			xtest.AssertErrorsIs(t, err, test.ExpectedErr)
+			if test.Check != nil {
+				test.Check(t, chain)
+			}
+		})
+	}
+}
+
+func TestIssuerIssueResend(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	now := time.Now().Truncate(time.Second)
+	issPub, issPriv := genKey(t)
+	curPub, curPriv := genKey(t)
+	newPub, newPriv := genKey(t)
+
+	issCert := newIssuerCert(now, issPub)
+	curCert := newASCert(now, curPub)
+
+	var stored []byte
+	provider := mock_trust.NewMockCryptoProvider(mctrl)
+	provider.EXPECT().GetRawChain(gomock.Any(),
+		trust.ChainID{IA: ia110, Version: scrypto.LatestVer}, gomock.Any(),
+	).Return(encodeChain(t, issCert, curCert), nil).AnyTimes()
+	provider.EXPECT().GetRawChain(gomock.Any(),
+		trust.ChainID{IA: ia111, Version: scrypto.LatestVer}, gomock.Any(),
+	).DoAndReturn(func(context.Context, trust.ChainID, infra.ChainOpts) ([]byte, error) {
+		if stored != nil {
+			return stored, nil
+		}
+		return encodeChain(t, issCert, curCert), nil
+	}).AnyTimes()
+	keyRing := mock_trust.NewMockKeyRing(mctrl)
+	keyRing.EXPECT().PrivateKey(keyconf.IssCertSigningKey, scrypto.KeyVersion(1)).Return(
+		keyconf.Key{Algorithm: scrypto.Ed25519, Bytes: issPriv}, nil,
+	).AnyTimes()
+	issuer := certrenewal.Issuer{
+		IA:       ia110,
+		Provider: provider,
+		KeyRing:  keyRing,
+	}
+
+	info := &renewal.RequestInfo{
+		Subject:                    ia111,
+		Version:                    2,
+		FormatVersion:              1,
+		Description:                "renewed",
+		OptionalDistributionPoints: []addr.IA{},
+		Validity: &scrypto.Validity{
+			NotBefore: util.UnixTime{Time: now},
+			NotAfter:  util.UnixTime{Time: now.Add(24 * time.Hour)},
+		},
+		Keys:        renewal.Keys{Signing: renewal.KeyMeta{Key: newPub}},
+		Issuer:      ia110,
+		RequestTime: util.UnixTime{Time: now},
+	}
+	signed, err := renewal.NewSignedRequest(info,
+		renewal.PrivateKey{Type: renewal.SigningKey, Version: 1,
+			Algorithm: scrypto.Ed25519, Key: curPriv},
+		renewal.PrivateKey{Type: renewal.SigningKey, Version: 2,
+			Algorithm: scrypto.Ed25519, Key: newPriv},
+	)
+	require.NoError(t, err)
+
+	chain, err := issuer.Issue(context.Background(), signed)
+	require.NoError(t, err)
+	stored, err = json.Marshal(chain)
+	require.NoError(t, err)
+
+	// Resending the same request returns the already issued chain.
+	resent, err := issuer.Issue(context.Background(), signed)
+	require.NoError(t, err)
+	raw, err := json.Marshal(resent)
+	require.NoError(t, err)
+	assert.Equal(t, stored, raw)
+}
+
+func newIssuerCert(now time.Time, pub []byte) *cert.Issuer {
+	return &cert.Issuer{
+		Base: cert.Base{
+			Subject:                    ia110,
+			Version:                    1,
+			FormatVersion:              1,
+			Description:                "issuer",
+			OptionalDistributionPoints: []addr.IA{},
+			Validity: &scrypto.Validity{
+				NotBefore: util.UnixTime{Time: now.Add(-time.Hour)},
+				NotAfter:  util.UnixTime{Time: now.Add(7 * 24 * time.Hour)},
+			},
+			Keys: map[cert.KeyType]scrypto.KeyMeta{
+				cert.IssuingKey: {KeyVersion: 1, Algorithm: scrypto.Ed25519, Key: pub},
+			},
+		},
+		Issuer: cert.IssuerTRC{TRCVersion: 1},
+	}
+}
+
+func newASCert(now time.Time, pub []byte) *cert.AS {
+	return &cert.AS{
+		Base: cert.Base{
+			Subject:                    ia111,
+			Version:                    1,
+			FormatVersion:              1,
+			Description:                "current",
+			OptionalDistributionPoints: []addr.IA{},
+			Validity: &scrypto.Validity{
+				NotBefore: util.UnixTime{Time: now.Add(-time.Hour)},
+				NotAfter:  util.UnixTime{Time: now.Add(time.Hour)},
+			},
+			Keys: map[cert.KeyType]scrypto.KeyMeta{
+				cert.SigningKey: {
+					KeyVersion: 1,
+					Algorithm:  scrypto.Ed25519,
+					Key:        pub,
+				},
+				cert.EncryptionKey: {
+					KeyVersion: 1,
+					Algorithm:  scrypto.Curve25519xSalsa20Poly1305,
+					Key:        []byte{1},
+				},
+			},
+		},
+		Issuer: cert.IssuerCertID{IA: ia110, CertificateVersion: 1},
+	}
+}
+
+func encodeChain(t *testing.T, iss *cert.Issuer, as *cert.AS) []byte {
+	encIss, err := cert.EncodeIssuer(iss)
+	require.NoError(t, err)
+	encAS, err := cert.EncodeAS(as)
+	require.NoError(t, err)
+	raw, err := json.Marshal(cert.Chain{
+		Issuer: cert.SignedIssuer{Encoded: encIss},
+		AS:     cert.SignedAS{Encoded: encAS},
+	})
+	require.NoError(t, err)
+	return raw
+}
+
+func genKey(t *testing.T) ([]byte, []byte) {
+	pub, priv, err := scrypto.GenKeyPair(scrypto.Ed25519)
+	require.NoError(t, err)
+	return pub, priv
+}
@@ -0,0 +1,251 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certrenewal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/scrypto/cert/renewal"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// DefaultLeadTime is the default time before expiration of the AS certificate
+// at which renewal is requested.
+const DefaultLeadTime = 24 * time.Hour
+
+// RPC requests a certificate chain issuance.
+type RPC interface {
+	RequestChainIssue(ctx context.Context, msg *cert_mgmt.ChainIssReq, a net.Addr,
+		id uint64) (*cert_mgmt.ChainIssRep, error)
+}
+
+// SignerUpdater regenerates the signers of the control service, such that
+// they sign with the renewed certificate chain.
+type SignerUpdater interface {
+	UpdateSigners(ctx context.Context) error
+}
+
+var _ periodic.Task = (*Requester)(nil)
+
+// Requester requests a new AS certificate from the issuer of the current AS
+// certificate, once the current certificate is about to expire. The request
+// authenticates the currently active keys; key rollover requires manual
+// renewal.
+type Requester struct {
+	// IA is the ISD-AS of the local AS.
+	IA addr.IA
+	// Provider provides the local certificate chain.
+	Provider trust.CryptoProvider
+	// KeyRing provides the private keys of the local AS.
+	KeyRing trust.KeyRing
+	// Router is used to find a path to the issuing AS.
+	Router snet.Router
+	// RPC is used to send the issuance request.
+	RPC RPC
+	// Inserter inserts the issued certificate chain.
+	Inserter ChainInserter
+	// LeadTime is the time before expiration at which renewal is requested.
+	LeadTime time.Duration
+	// SignerUpdater is notified after a successful renewal. It is optional.
+	SignerUpdater SignerUpdater
+
+	// signersStale indicates that the signers have not been updated since
+	// the last renewal.
+	signersStale bool
+}
+
+// Name returns the tasks name.
+func (r *Requester) Name() string {
+	return "cs_certrenewal_requester"
+}
+
+// Run requests a new certificate chain, if the current one expires within
+// the lead time. After a successful renewal, the signers are updated. If the
+// update fails, it is retried on the next run.
+func (r *Requester) Run(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	renewed, err := r.run(ctx, time.Now())
+	switch {
+	case err != nil:
+		logger.Error("[certrenewal.Requester] Unable to renew certificate chain", "err", err)
+	case renewed != nil:
+		logger.Info("[certrenewal.Requester] Renewed certificate chain",
+			"version", renewed.Version, "validity", renewed.Validity)
+		r.signersStale = true
+	}
+	if !r.signersStale || r.SignerUpdater == nil {
+		return
+	}
+	if err := r.SignerUpdater.UpdateSigners(ctx); err != nil {
+		logger.Error("[certrenewal.Requester] Unable to update signers", "err", err)
+		return
+	}
+	r.signersStale = false
+}
+
+func (r *Requester) run(ctx context.Context, now time.Time) (*cert.AS, error) {
+	raw, err := r.Provider.GetRawChain(ctx, trust.ChainID{IA: r.IA, Version: scrypto.LatestVer},
+		infra.ChainOpts{})
+	if err != nil {
+		return nil, serrors.WrapStr("unable to get local chain", err)
+	}
+	chain, err := cert.ParseChain(raw)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to parse local chain", err)
+	}
+	cur, err := chain.AS.Encoded.Decode()
+	if err != nil {
+		return nil, serrors.WrapStr("unable to decode AS certificate", err)
+	}
+	leadTime := r.LeadTime
+	if leadTime == 0 {
+		leadTime = DefaultLeadTime
+	}
+	if cur.Validity.NotAfter.Add(-leadTime).After(now) {
+		return nil, nil
+	}
+	signed, err := r.request(cur, now)
+	if err != nil {
+		return nil, err
+	}
+	rawReq, err := json.Marshal(signed)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to marshal request", err)
+	}
+	dst, err := r.issuerAddr(ctx, cur.Issuer.IA)
+	if err != nil {
+		return nil, err
+	}
+	rep, err := r.RPC.RequestChainIssue(ctx, &cert_mgmt.ChainIssReq{Raw: rawReq}, dst,
+		messenger.NextId())
+	if err != nil {
+		return nil, serrors.WrapStr("unable to request chain issuance", err,
+			"issuer", cur.Issuer.IA)
+	}
+	renewed, err := checkReply(rep, cur)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Inserter.InsertChain(ctx, rep.RawChain); err != nil {
+		return nil, serrors.WrapStr("unable to insert renewed chain", err)
+	}
+	return renewed, nil
+}
+
+// request creates a signed renewal request for the next certificate version.
+// The validity period of the current certificate is kept.
+func (r *Requester) request(cur *cert.AS, now time.Time) (renewal.SignedRequest, error) {
+	period := cur.Validity.NotAfter.Sub(cur.Validity.NotBefore.Time)
+	info := &renewal.RequestInfo{
+		Subject:                    cur.Subject,
+		Version:                    cur.Version + 1,
+		FormatVersion:              cur.FormatVersion,
+		Description:                cur.Description,
+		OptionalDistributionPoints: cur.OptionalDistributionPoints,
+		Validity: &scrypto.Validity{
+			NotBefore: util.UnixTime{Time: now},
+			NotAfter:  util.UnixTime{Time: now.Add(period)},
+		},
+		Issuer:      cur.Issuer.IA,
+		RequestTime: util.UnixTime{Time: now},
+	}
+	signing, err := r.privateKey(cur, cert.SigningKey)
+	if err != nil {
+		return renewal.SignedRequest{}, err
+	}
+	info.Keys.Signing = renewal.KeyMeta{Key: cur.Keys[cert.SigningKey].Key}
+	pops := []renewal.PrivateKey{signing}
+	if _, ok := cur.Keys[cert.RevocationKey]; ok {
+		revocation, err := r.privateKey(cur, cert.RevocationKey)
+		if err != nil {
+			return renewal.SignedRequest{}, err
+		}
+		info.Keys.Revocation = renewal.KeyMeta{Key: cur.Keys[cert.RevocationKey].Key}
+		pops = append(pops, revocation)
+	}
+	return renewal.NewSignedRequest(info, signing, pops...)
+}
+
+func (r *Requester) privateKey(cur *cert.AS, keyType cert.KeyType) (renewal.PrivateKey, error) {
+	usage, renewalType := keyconf.ASSigningKey, renewal.SigningKey
+	if keyType == cert.RevocationKey {
+		usage, renewalType = keyconf.ASRevocationKey, renewal.RevocationKey
+	}
+	meta := cur.Keys[keyType]
+	key, err := r.KeyRing.PrivateKey(usage, meta.KeyVersion)
+	if err != nil {
+		return renewal.PrivateKey{}, serrors.WrapStr("unable to load private key", err,
+			"usage", usage, "key_version", meta.KeyVersion)
+	}
+	return renewal.PrivateKey{
+		Type:      renewalType,
+		Version:   meta.KeyVersion,
+		Algorithm: key.Algorithm,
+		Key:       key.Bytes,
+	}, nil
+}
+
+func (r *Requester) issuerAddr(ctx context.Context, issuer addr.IA) (net.Addr, error) {
+	path, err := r.Router.Route(ctx, issuer)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to find path to issuer", err, "issuer", issuer)
+	}
+	return &snet.SVCAddr{
+		IA:      path.Destination(),
+		Path:    path.Path(),
+		NextHop: path.OverlayNextHop(),
+		SVC:     addr.SvcCS,
+	}, nil
+}
+
+// checkReply checks that the issued certificate is the requested one. The
+// chain itself is verified on insertion.
+func checkReply(rep *cert_mgmt.ChainIssRep, cur *cert.AS) (*cert.AS, error) {
+	chain, err := cert.ParseChain(rep.RawChain)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to parse issued chain", err)
+	}
+	as, err := chain.AS.Encoded.Decode()
+	if err != nil {
+		return nil, serrors.WrapStr("unable to decode issued AS certificate", err)
+	}
+	if !as.Subject.Equal(cur.Subject) || as.Version != cur.Version+1 {
+		return nil, serrors.New("issued certificate does not match request",
+			"subject", as.Subject, "version", as.Version)
+	}
+	for _, keyType := range []cert.KeyType{cert.SigningKey, cert.RevocationKey} {
+		if !bytes.Equal(as.Keys[keyType].Key, cur.Keys[keyType].Key) {
+			return nil, serrors.New("issued certificate authenticates unexpected key",
+				"key_type", keyType)
+		}
+	}
+	return as, nil
+}
@@ -0,0 +1,205 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certrenewal_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/certrenewal"
+	"github.com/scionproto/scion/go/cs/certrenewal/mock_certrenewal"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/cert_mgmt"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/mock_trust"
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert/renewal"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+func TestRequesterRun(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	issPub, _ := genKey(t)
+	curPub, curPriv := genKey(t)
+	issCert := newIssuerCert(now, issPub)
+	curCert := newASCert(now, curPub)
+	renewed := newASCert(now, curPub)
+	renewed.Version = 2
+	renewed.Validity = &scrypto.Validity{
+		NotBefore: util.UnixTime{Time: now},
+		NotAfter:  util.UnixTime{Time: now.Add(48 * time.Hour)},
+	}
+	renewedRaw := encodeChain(t, issCert, renewed)
+
+	tests := map[string]struct {
+		LeadTime time.Duration
+		Reply    *cert_mgmt.ChainIssRep
+		ReplyErr error
+		Renewed  bool
+	}{
+		"not due": {
+			LeadTime: 30 * time.Minute,
+		},
+		"renewed": {
+			Reply:   &cert_mgmt.ChainIssRep{RawChain: renewedRaw},
+			Renewed: true,
+		},
+		"unexpected version": {
+			Reply: &cert_mgmt.ChainIssRep{RawChain: encodeChain(t, issCert, curCert)},
+		},
+		"request fails": {
+			ReplyErr: errors.New("test error"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+
+			provider := mock_trust.NewMockCryptoProvider(mctrl)
+			provider.EXPECT().GetRawChain(gomock.Any(),
+				trust.ChainID{IA: ia111, Version: scrypto.LatestVer}, gomock.Any(),
+			).Return(encodeChain(t, issCert, curCert), nil)
+			rpc := mock_certrenewal.NewMockRPC(mctrl)
+			inserter := mock_certrenewal.NewMockChainInserter(mctrl)
+			updater := mock_certrenewal.NewMockSignerUpdater(mctrl)
+			if test.Reply != nil || test.ReplyErr != nil {
+				rpc.EXPECT().RequestChainIssue(gomock.Any(), gomock.Any(), gomock.Any(),
+					gomock.Any()).DoAndReturn(
+					func(_ context.Context, msg *cert_mgmt.ChainIssReq, a net.Addr,
+						_ uint64) (*cert_mgmt.ChainIssRep, error) {
+
+						checkRequest(t, msg, curPub, now)
+						assert.Equal(t, addr.SvcCS, a.(*snet.SVCAddr).SVC)
+						return test.Reply, test.ReplyErr
+					},
+				)
+			}
+			if test.Renewed {
+				inserter.EXPECT().InsertChain(gomock.Any(), renewedRaw)
+				updater.EXPECT().UpdateSigners(gomock.Any())
+			}
+			r := &certrenewal.Requester{
+				IA:            ia111,
+				Provider:      provider,
+				KeyRing:       signingKeyRing(mctrl, curPriv),
+				Router:        issuerRouter(mctrl),
+				RPC:           rpc,
+				Inserter:      inserter,
+				LeadTime:      test.LeadTime,
+				SignerUpdater: updater,
+			}
+			r.Run(context.Background())
+		})
+	}
+}
+
+func TestRequesterRunRetriesSignerUpdate(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	now := time.Now().Truncate(time.Second)
+	issPub, _ := genKey(t)
+	curPub, curPriv := genKey(t)
+	issCert := newIssuerCert(now, issPub)
+	renewed := newASCert(now, curPub)
+	renewed.Version = 2
+	renewed.Validity = &scrypto.Validity{
+		NotBefore: util.UnixTime{Time: now},
+		NotAfter:  util.UnixTime{Time: now.Add(48 * time.Hour)},
+	}
+	renewedRaw := encodeChain(t, issCert, renewed)
+
+	provider := mock_trust.NewMockCryptoProvider(mctrl)
+	gomock.InOrder(
+		provider.EXPECT().GetRawChain(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+			encodeChain(t, issCert, newASCert(now, curPub)), nil),
+		provider.EXPECT().GetRawChain(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+			renewedRaw, nil).Times(2),
+	)
+	rpc := mock_certrenewal.NewMockRPC(mctrl)
+	rpc.EXPECT().RequestChainIssue(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any()).Return(&cert_mgmt.ChainIssRep{RawChain: renewedRaw}, nil)
+	inserter := mock_certrenewal.NewMockChainInserter(mctrl)
+	inserter.EXPECT().InsertChain(gomock.Any(), renewedRaw)
+	updater := mock_certrenewal.NewMockSignerUpdater(mctrl)
+	gomock.InOrder(
+		updater.EXPECT().UpdateSigners(gomock.Any()).Return(errors.New("test error")),
+		updater.EXPECT().UpdateSigners(gomock.Any()),
+	)
+	r := &certrenewal.Requester{
+		IA:            ia111,
+		Provider:      provider,
+		KeyRing:       signingKeyRing(mctrl, curPriv),
+		Router:        issuerRouter(mctrl),
+		RPC:           rpc,
+		Inserter:      inserter,
+		SignerUpdater: updater,
+	}
+	// The first run renews the chain, but fails to update the signers. The
+	// second run only retries the update, the third run has nothing to do.
+	r.Run(context.Background())
+	r.Run(context.Background())
+	r.Run(context.Background())
+}
+
+// checkRequest checks that the request is addressed to the issuer of the
+// current certificate and asks for the next version with the current keys.
+func checkRequest(t *testing.T, msg *cert_mgmt.ChainIssReq, pub []byte, now time.Time) {
+	signed, err := renewal.ParseSignedRequest(msg.Raw)
+	require.NoError(t, err)
+	req, err := signed.Encoded.Decode()
+	require.NoError(t, err)
+	info, err := req.Encoded.Decode()
+	require.NoError(t, err)
+	assert.Equal(t, ia111, info.Subject)
+	assert.Equal(t, ia110, info.Issuer)
+	assert.Equal(t, scrypto.Version(2), info.Version)
+	assert.Equal(t, pub, info.Keys.Signing.Key)
+	assert.False(t, info.Validity.NotBefore.Before(now))
+	raw, err := json.Marshal(signed)
+	require.NoError(t, err)
+	assert.Equal(t, raw, msg.Raw)
+}
+
+func signingKeyRing(mctrl *gomock.Controller, priv []byte) trust.KeyRing {
+	keyRing := mock_trust.NewMockKeyRing(mctrl)
+	keyRing.EXPECT().PrivateKey(keyconf.ASSigningKey, scrypto.KeyVersion(1)).Return(
+		keyconf.Key{Algorithm: scrypto.Ed25519, Bytes: priv}, nil,
+	).AnyTimes()
+	return keyRing
+}
+
+func issuerRouter(mctrl *gomock.Controller) snet.Router {
+	path := mock_snet.NewMockPath(mctrl)
+	path.EXPECT().Destination().Return(ia110).AnyTimes()
+	path.EXPECT().Path().Return(&spath.Path{}).AnyTimes()
+	path.EXPECT().OverlayNextHop().Return(&net.UDPAddr{}).AnyTimes()
+	router := mock_snet.NewMockRouter(mctrl)
+	router.EXPECT().Route(gomock.Any(), ia110).Return(path, nil).AnyTimes()
+	return router
+}
@@ -0,0 +1,59 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certrenewal
+
+import (
+	"sync"
+
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ infra.Signer = (*RenewableSigner)(nil)
+
+// RenewableSigner is a signer that can be replaced at runtime. It is shared
+// by the long running tasks, such that they sign with the renewed certificate
+// chain once the signer has been updated.
+type RenewableSigner struct {
+	mtx    sync.RWMutex
+	signer infra.Signer
+}
+
+// NewRenewableSigner creates a renewable signer that initially signs with the
+// given signer.
+func NewRenewableSigner(signer infra.Signer) *RenewableSigner {
+	return &RenewableSigner{signer: signer}
+}
+
+// Sign signs the message with the current signer.
+func (s *RenewableSigner) Sign(msg []byte) (*proto.SignS, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.signer.Sign(msg)
+}
+
+// Meta returns the meta data of the current signer.
+func (s *RenewableSigner) Meta() infra.SignerMeta {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.signer.Meta()
+}
+
+// Update replaces the current signer.
+func (s *RenewableSigner) Update(signer infra.Signer) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.signer = signer
+}
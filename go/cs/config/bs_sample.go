@@ -22,6 +22,12 @@ keepalive_interval = "1s"
 # is considered expired. (default 3s)
 keepalive_timeout = "3s"
 
+# The verification of the signatures of received interface keepalives. Sent
+# keepalives are always signed. With "none", the signatures are not verified.
+# With "warn", keepalives that fail verification are logged, but still
+# processed. With "enforce", they are dropped. (default "none")
+keepalive_auth = "none"
+
 # The interval between originating beacons. (default 5s)
 origination_interval = "5s"
 
@@ -40,6 +46,18 @@ rev_ttl = "10s"
 # The amount of time before the expiry of an existing revocation where the revoker can reissue a
 # new revocation. (default 5s)
 rev_overlap = "5s"
+
+# The file path for the static info configuration. The static info describes
+# latency, bandwidth, geographic location and link type of the interfaces of
+# this AS. In case of the empty string, no static info extension is added to
+# the beacons. (default "")
+static_info = ""
+
+# The address to serve the beacon store admin API on. The API allows to
+# inspect the beacon store and to trigger the beaconing tasks. It must not be
+# reachable by untrusted parties. In case of the empty string, the admin API is
+# disabled. (default "")
+admin_address = ""
 `
 
 const policiesSample = `
@@ -17,9 +17,11 @@ package config
 
 import (
 	"io"
+	"net"
 	"time"
 
 	"github.com/scionproto/scion/go/cs/beaconstorage"
+	"github.com/scionproto/scion/go/cs/certrenewal"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
@@ -56,6 +58,9 @@ const (
 	// DefaultQueryInterval is the default interval after which the segment
 	// cache expires.
 	DefaultQueryInterval = 5 * time.Minute
+	// DefaultRegQuotaInterval is the default interval to which the maximum
+	// number of registrations per origin applies.
+	DefaultRegQuotaInterval = time.Minute
 )
 
 // Error values
@@ -79,6 +84,7 @@ type Config struct {
 	PathDB   pathstorage.PathDBConf     `toml:"path_db,omitempty"`
 	BS       BSConfig                   `toml:"beaconing,omitempty"`
 	PS       PSConfig                   `toml:"path,omitempty"`
+	Renewal  RenewalConfig              `toml:"renewal,omitempty"`
 }
 
 // InitDefaults initializes the default values for all parts of the config.
@@ -94,6 +100,7 @@ func (cfg *Config) InitDefaults() {
 		&cfg.PathDB,
 		&cfg.BS,
 		&cfg.PS,
+		&cfg.Renewal,
 	)
 }
 
@@ -109,6 +116,7 @@ func (cfg *Config) Validate() error {
 		&cfg.PathDB,
 		&cfg.BS,
 		&cfg.PS,
+		&cfg.Renewal,
 	)
 }
 
@@ -126,6 +134,7 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.PathDB,
 		&cfg.BS,
 		&cfg.PS,
+		&cfg.Renewal,
 	)
 }
 
@@ -136,6 +145,20 @@ func (cfg *Config) ConfigName() string {
 
 var _ config.Config = (*BSConfig)(nil)
 
+// KeepaliveAuthMode is the mode of the interface keepalive authentication.
+type KeepaliveAuthMode string
+
+const (
+	// KeepaliveAuthNone does not verify the keepalive signatures.
+	KeepaliveAuthNone KeepaliveAuthMode = "none"
+	// KeepaliveAuthWarn verifies the keepalive signatures, but only logs
+	// verification failures.
+	KeepaliveAuthWarn KeepaliveAuthMode = "warn"
+	// KeepaliveAuthEnforce verifies the keepalive signatures and drops
+	// keepalives that fail verification.
+	KeepaliveAuthEnforce KeepaliveAuthMode = "enforce"
+)
+
 // BSConfig holds the configuration specific to the beacon server.
 type BSConfig struct {
 	// KeepaliveInterval is the interval between sending interface keepalives.
@@ -143,6 +166,9 @@ type BSConfig struct {
 	// KeepaliveTimeout is the timeout indicating how long an interface can
 	// receive no keepalive until it is considered expired.
 	KeepaliveTimeout util.DurWrap `toml:"keepalive_timeout,omitempty"`
+	// KeepaliveAuth specifies how the signatures of received interface
+	// keepalives are verified. Sent keepalives are always signed.
+	KeepaliveAuth KeepaliveAuthMode `toml:"keepalive_auth,omitempty"`
 	// OriginationInterval is the interval between originating beacons in a core BS.
 	OriginationInterval util.DurWrap `toml:"origination_interval,omitempty"`
 	// PropagationInterval is the interval between propagating beacons.
@@ -157,8 +183,16 @@ type BSConfig struct {
 	// RevOverlap specifies for how long before the expiry of an existing revocation the revoker
 	// can reissue a new revocation. (default 5s)
 	RevOverlap util.DurWrap `toml:"rev_overlap,omitempty"`
+	// StaticInfo contains the file path for the static info configuration.
+	// If this is the empty string, no static info extension is added to the
+	// AS entries.
+	StaticInfo string `toml:"static_info,omitempty"`
 	// Policies contains the policy files.
 	Policies Policies `toml:"policies,omitempty"`
+	// AdminAddress is the address to serve the beacon store admin API on. The
+	// API allows triggering the beaconing tasks, thus it is served on a
+	// separate listener. If empty, the admin API is disabled.
+	AdminAddress string `toml:"admin_address,omitempty"`
 }
 
 // InitDefaults the default values for the durations that are equal to zero.
@@ -173,6 +207,14 @@ func (cfg *BSConfig) Validate() error {
 	if cfg.KeepaliveTimeout.Duration == 0 {
 		initDurWrap(&cfg.KeepaliveTimeout, DefaultKeepaliveTimeout)
 	}
+	if cfg.KeepaliveAuth == "" {
+		cfg.KeepaliveAuth = KeepaliveAuthNone
+	}
+	switch cfg.KeepaliveAuth {
+	case KeepaliveAuthNone, KeepaliveAuthWarn, KeepaliveAuthEnforce:
+	default:
+		return serrors.New("invalid keepalive_auth", "mode", cfg.KeepaliveAuth)
+	}
 	if cfg.OriginationInterval.Duration == 0 {
 		initDurWrap(&cfg.OriginationInterval, DefaultOriginationInterval)
 	}
@@ -198,6 +240,11 @@ func (cfg *BSConfig) Validate() error {
 	if cfg.RevOverlap.Duration > cfg.RevTTL.Duration {
 		return serrors.New("rev_overlap cannot be greater than rev_ttl")
 	}
+	if cfg.AdminAddress != "" {
+		if _, _, err := net.SplitHostPort(cfg.AdminAddress); err != nil {
+			return serrors.WrapStr("invalid admin_address", err, "addr", cfg.AdminAddress)
+		}
+	}
 	return nil
 }
 
@@ -220,22 +267,64 @@ func initDurWrap(w *util.DurWrap, def time.Duration) {
 
 var _ config.Config = (*PSConfig)(nil)
 
+// SegSyncMode is the mode of the down segment synchronization between core
+// ASes.
+type SegSyncMode string
+
+const (
+	// SegSyncIncremental fetches only the changed segments from the remote
+	// core ASes. The local down segments are no longer pushed, so all core
+	// ASes must run in this mode.
+	SegSyncIncremental SegSyncMode = "incremental"
+	// SegSyncPush pushes all down segments to the remote core ASes.
+	SegSyncPush SegSyncMode = "push"
+)
+
 type PSConfig struct {
 	// QueryInterval specifies after how much time segments
 	// for a destination should be refetched.
 	QueryInterval util.DurWrap `toml:"query_interval,omitempty"`
+	// SegSyncMode specifies how down segments are synchronized between core
+	// ASes. Defaults to push, incremental synchronization is opt-in.
+	SegSyncMode SegSyncMode `toml:"seg_sync_mode,omitempty"`
+	// MaxSegsPerOrigin is the maximum number of up and down segments stored
+	// per registering AS. Zero means unlimited.
+	MaxSegsPerOrigin int `toml:"max_segs_per_origin,omitempty"`
+	// MaxRegsPerOrigin is the maximum number of registrations accepted from
+	// an AS in every RegQuotaInterval. Zero means unlimited.
+	MaxRegsPerOrigin int `toml:"max_regs_per_origin,omitempty"`
+	// RegQuotaInterval is the interval to which MaxRegsPerOrigin applies.
+	RegQuotaInterval util.DurWrap `toml:"reg_quota_interval,omitempty"`
 }
 
 func (cfg *PSConfig) InitDefaults() {
 	if cfg.QueryInterval.Duration == 0 {
 		cfg.QueryInterval.Duration = DefaultQueryInterval
 	}
+	if cfg.SegSyncMode == "" {
+		cfg.SegSyncMode = SegSyncPush
+	}
+	initDurWrap(&cfg.RegQuotaInterval, DefaultRegQuotaInterval)
 }
 
 func (cfg *PSConfig) Validate() error {
 	if cfg.QueryInterval.Duration == 0 {
 		return serrors.New("query_interval must not be zero")
 	}
+	switch cfg.SegSyncMode {
+	case SegSyncIncremental, SegSyncPush:
+	default:
+		return serrors.New("invalid seg_sync_mode", "mode", cfg.SegSyncMode)
+	}
+	if cfg.MaxSegsPerOrigin < 0 {
+		return serrors.New("max_segs_per_origin must not be negative")
+	}
+	if cfg.MaxRegsPerOrigin < 0 {
+		return serrors.New("max_regs_per_origin must not be negative")
+	}
+	if cfg.RegQuotaInterval.Duration <= 0 {
+		return serrors.New("reg_quota_interval must be positive")
+	}
 	return nil
 }
 
@@ -247,6 +336,41 @@ func (cfg *PSConfig) ConfigName() string {
 	return "path"
 }
 
+var _ config.Config = (*RenewalConfig)(nil)
+
+// RenewalConfig holds the configuration for AS certificate renewal.
+type RenewalConfig struct {
+	// MaxValidity is the maximum validity period of AS certificates issued by
+	// this control service. Only used in issuing ASes.
+	MaxValidity util.DurWrap `toml:"max_validity,omitempty"`
+	// LeadTime specifies how long before the expiration of the AS certificate
+	// renewal is requested. Only used in non-issuing ASes.
+	LeadTime util.DurWrap `toml:"lead_time,omitempty"`
+}
+
+func (cfg *RenewalConfig) InitDefaults() {
+	initDurWrap(&cfg.MaxValidity, certrenewal.DefaultMaxValidity)
+	initDurWrap(&cfg.LeadTime, certrenewal.DefaultLeadTime)
+}
+
+func (cfg *RenewalConfig) Validate() error {
+	if cfg.MaxValidity.Duration <= 0 {
+		return serrors.New("max_validity must be positive")
+	}
+	if cfg.LeadTime.Duration <= 0 {
+		return serrors.New("lead_time must be positive")
+	}
+	return nil
+}
+
+func (cfg *RenewalConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, renewalSample)
+}
+
+func (cfg *RenewalConfig) ConfigName() string {
+	return "renewal"
+}
+
 var _ config.Config = (*Policies)(nil)
 
 // Policies contains the file paths of the policies.
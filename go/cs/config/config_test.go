@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/scionproto/scion/go/cs/beaconstorage/beaconstoragetest"
+	"github.com/scionproto/scion/go/cs/certrenewal"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/env/envtest"
 	"github.com/scionproto/scion/go/lib/log/logtest"
@@ -67,6 +68,7 @@ func InitTestConfig(cfg *Config) {
 }
 
 func InitTestBSConfig(cfg *BSConfig) {
+	cfg.StaticInfo = "test"
 	InitTestPolicies(&cfg.Policies)
 }
 
@@ -85,17 +87,21 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	pathstoragetest.CheckTestPathDBConf(t, &cfg.PathDB, id)
 	CheckTestBSConfig(t, &cfg.BS)
 	CheckTestPSConfig(t, &cfg.PS, id)
+	CheckTestRenewalConfig(t, &cfg.Renewal)
 }
 
 func CheckTestBSConfig(t *testing.T, cfg *BSConfig) {
 	assert.Equal(t, DefaultKeepaliveTimeout, cfg.KeepaliveTimeout.Duration)
 	assert.Equal(t, DefaultKeepaliveInterval, cfg.KeepaliveInterval.Duration)
+	assert.Equal(t, KeepaliveAuthNone, cfg.KeepaliveAuth)
 	assert.Equal(t, DefaultOriginationInterval, cfg.OriginationInterval.Duration)
 	assert.Equal(t, DefaultPropagationInterval, cfg.PropagationInterval.Duration)
 	assert.Equal(t, DefaultRegistrationInterval, cfg.RegistrationInterval.Duration)
 	assert.Equal(t, DefaultExpiredCheckInterval, cfg.ExpiredCheckInterval.Duration)
 	assert.Equal(t, DefaultRevTTL, cfg.RevTTL.Duration)
 	assert.Equal(t, DefaultRevOverlap, cfg.RevOverlap.Duration)
+	assert.Empty(t, cfg.StaticInfo)
+	assert.Empty(t, cfg.AdminAddress)
 	CheckTestPolicies(t, &cfg.Policies)
 }
 
@@ -111,4 +117,13 @@ func InitTestPSConfig(cfg *PSConfig) {
 
 func CheckTestPSConfig(t *testing.T, cfg *PSConfig, id string) {
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
+	assert.Equal(t, SegSyncPush, cfg.SegSyncMode)
+	assert.Equal(t, 0, cfg.MaxSegsPerOrigin)
+	assert.Equal(t, 0, cfg.MaxRegsPerOrigin)
+	assert.Equal(t, DefaultRegQuotaInterval, cfg.RegQuotaInterval.Duration)
+}
+
+func CheckTestRenewalConfig(t *testing.T, cfg *RenewalConfig) {
+	assert.Equal(t, certrenewal.DefaultMaxValidity, cfg.MaxValidity.Duration)
+	assert.Equal(t, certrenewal.DefaultLeadTime, cfg.LeadTime.Duration)
 }
@@ -19,4 +19,35 @@ const idSample = "cs-1"
 const psSample = `
 # The time after which segments for a destination are refetched. (default 5m)
 query_interval = "5m"
+
+# The mode of the down segment synchronization between core ASes. With
+# "incremental", only changed segments are fetched from the remote core ASes.
+# With "push", all down segments are pushed to the remote core ASes. An AS in
+# "incremental" mode no longer pushes its down segments, so remote core ASes
+# in "push" mode do not receive them. Only switch to "incremental" once every
+# core AS runs in "incremental" mode. (default "push")
+seg_sync_mode = "push"
+
+# The maximum number of up and down segments stored per registering AS. If a
+# registration exceeds the quota, the least recently updated segments of the
+# AS are evicted. 0 means unlimited. (default 0)
+max_segs_per_origin = 0
+
+# The maximum number of registrations accepted from an AS per
+# reg_quota_interval. Registrations exceeding the limit are rejected. 0 means
+# unlimited. (default 0)
+max_regs_per_origin = 0
+
+# The interval to which max_regs_per_origin applies. (default 1m)
+reg_quota_interval = "1m"
+`
+
+const renewalSample = `
+# The maximum validity period of issued AS certificates. Only used in issuing
+# ASes. (default 72h)
+max_validity = "72h"
+
+# How long before the expiration of the AS certificate renewal is requested.
+# Only used in non-issuing ASes. (default 24h)
+lead_time = "24h"
 `
@@ -79,9 +79,15 @@ func (h *baseHandler) fetchSegsFromDB(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
-	segs := query.Results(res).Segs()
+	return h.filterSegs(ctx, query.Results(res).Segs())
+}
+
+// filterSegs removes revoked and expired segments.
+func (h *baseHandler) filterSegs(ctx context.Context,
+	segs seg.Segments) (seg.Segments, error) {
+
 	// XXX(lukedirtwalker): Consider cases where segment with revoked interfaces should be returned.
-	_, err = segs.FilterSegsErr(func(s *seg.PathSegment) (bool, error) {
+	_, err := segs.FilterSegsErr(func(s *seg.PathSegment) (bool, error) {
 		noRevoked, err := revcache.NoRevokedHopIntf(ctx, h.revCache, s)
 		if err != nil {
 			return false, err
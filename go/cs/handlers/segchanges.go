@@ -0,0 +1,239 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/proto"
+)
+
+const (
+	// MaxSegChanges is the maximum number of segments that are served in a
+	// single seg changes reply.
+	MaxSegChanges = 64
+	// MaxSegChangesIds is the maximum number of segment IDs that are served in
+	// a single seg changes ID reply. The remaining IDs are paged.
+	MaxSegChangesIds = 1024
+)
+
+type segChangesIdHandler struct {
+	*baseHandler
+	localIA addr.IA
+}
+
+// NewSegChangesIdHandler returns a handler for seg changes ID requests. The
+// handler replies with the IDs of all down segments starting at the local AS
+// that were updated since the last check of the requester. At most
+// MaxSegChangesIds IDs are served per request, the reply carries a cursor to
+// request the next page with.
+func NewSegChangesIdHandler(args HandlerArgs) infra.Handler {
+	f := func(r *infra.Request) *infra.HandlerResult {
+		handler := &segChangesIdHandler{
+			baseHandler: newBaseHandler(r, args),
+			localIA:     args.IA,
+		}
+		return handler.Handle()
+	}
+	return infra.HandlerFunc(f)
+}
+
+func (h *segChangesIdHandler) Handle() *infra.HandlerResult {
+	ctx := h.request.Context()
+	logger := log.FromCtx(ctx).New("from", h.request.Peer)
+	req, ok := h.request.Message.(*path_mgmt.SegChangesIdReq)
+	if !ok {
+		logger.Error("[segChangesIdHandler] wrong message type, expected "+
+			"path_mgmt.SegChangesIdReq",
+			"msg", h.request.Message, "type", common.TypeOf(h.request.Message))
+		return infra.MetricsErrInternal
+	}
+	rw, ok := infra.ResponseWriterFromContext(ctx)
+	if !ok {
+		logger.Error("[segChangesIdHandler] Unable to service request, no Messenger found")
+		return infra.MetricsErrInternal
+	}
+	params := &query.Params{
+		SegTypes: []proto.PathSegType{proto.PathSegType_down},
+		StartsAt: []addr.IA{h.localIA},
+	}
+	switch {
+	case req.Cursor != 0:
+		// The cursor is more recent than the last check of the first page.
+		cursor := time.Unix(0, int64(req.Cursor))
+		params.MinLastUpdate = &cursor
+	case req.LastCheck != 0:
+		lastCheck := time.Unix(int64(req.LastCheck), 0)
+		params.MinLastUpdate = &lastCheck
+	}
+	segs, cursor, err := h.fetchPage(ctx, params)
+	if err != nil {
+		logger.Error("[segChangesIdHandler] Failed to get segments from DB", "err", err)
+		messenger.SendAckHelper(ctx, rw)(proto.Ack_ErrCode_retry, messenger.AckRetryDBError)
+		return infra.MetricsErrPathDB(err)
+	}
+	reply := &path_mgmt.SegChangesIdReply{
+		Ids:    make([]*path_mgmt.SegIds, 0, len(segs)),
+		Cursor: cursor,
+	}
+	for _, s := range segs {
+		id, err := s.ID()
+		if err != nil {
+			logger.Error("[segChangesIdHandler] Failed to compute segment ID", "err", err)
+			return infra.MetricsErrInternal
+		}
+		fullId, err := s.FullId()
+		if err != nil {
+			logger.Error("[segChangesIdHandler] Failed to compute segment full ID", "err", err)
+			return infra.MetricsErrInternal
+		}
+		reply.Ids = append(reply.Ids, &path_mgmt.SegIds{SegId: id, FullId: fullId})
+	}
+	if err := rw.SendSegChangesIdReply(ctx, reply); err != nil {
+		logger.Error("[segChangesIdHandler] Messenger error", "err", err)
+		return infra.MetricsErrMsger(err)
+	}
+	logger.Debug("[segChangesIdHandler] Replied with segment IDs", "cnt", len(reply.Ids),
+		"cursor", reply.Cursor)
+	return infra.MetricsResultOk
+}
+
+// fetchPage returns the first page of the segments matching the params, and
+// the cursor to fetch the next page with. The cursor is 0 if there are no
+// more segments.
+func (h *segChangesIdHandler) fetchPage(ctx context.Context,
+	params *query.Params) (seg.Segments, uint64, error) {
+
+	res, err := h.pathDB.Get(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	res, cursor := pageByLastUpdate(res, MaxSegChangesIds)
+	segs, err := h.filterSegs(ctx, query.Results(res).Segs())
+	if err != nil {
+		return nil, 0, err
+	}
+	return segs, cursor, nil
+}
+
+// pageByLastUpdate returns at most max of the results, ordered by their last
+// update, and the last update of the last returned result in nanoseconds
+// since the Unix epoch as the cursor. The next page contains the results
+// updated after the cursor, thus results with the same last update are never
+// split across pages. If there are no more results, the cursor is 0.
+func pageByLastUpdate(res []*query.Result, max int) ([]*query.Result, uint64) {
+	if len(res) <= max {
+		return res, 0
+	}
+	sort.Stable(query.ByLastUpdate(res))
+	n := max
+	for n > 0 && res[n-1].LastUpdate.Equal(res[n].LastUpdate) {
+		n--
+	}
+	if n == 0 {
+		// More than max results have the same last update, they are all
+		// served in this page.
+		n = max
+		for n < len(res) && res[n].LastUpdate.Equal(res[max-1].LastUpdate) {
+			n++
+		}
+		if n == len(res) {
+			return res, 0
+		}
+	}
+	return res[:n], uint64(res[n-1].LastUpdate.UnixNano())
+}
+
+type segChangesHandler struct {
+	*baseHandler
+	localIA addr.IA
+}
+
+// NewSegChangesHandler returns a handler for seg changes requests. The handler
+// replies with the requested down segments starting at the local AS. At most
+// MaxSegChanges segments are served per request.
+func NewSegChangesHandler(args HandlerArgs) infra.Handler {
+	f := func(r *infra.Request) *infra.HandlerResult {
+		handler := &segChangesHandler{
+			baseHandler: newBaseHandler(r, args),
+			localIA:     args.IA,
+		}
+		return handler.Handle()
+	}
+	return infra.HandlerFunc(f)
+}
+
+func (h *segChangesHandler) Handle() *infra.HandlerResult {
+	ctx := h.request.Context()
+	logger := log.FromCtx(ctx).New("from", h.request.Peer)
+	req, ok := h.request.Message.(*path_mgmt.SegChangesReq)
+	if !ok {
+		logger.Error("[segChangesHandler] wrong message type, expected path_mgmt.SegChangesReq",
+			"msg", h.request.Message, "type", common.TypeOf(h.request.Message))
+		return infra.MetricsErrInternal
+	}
+	rw, ok := infra.ResponseWriterFromContext(ctx)
+	if !ok {
+		logger.Error("[segChangesHandler] Unable to service request, no Messenger found")
+		return infra.MetricsErrInternal
+	}
+	sendAck := messenger.SendAckHelper(ctx, rw)
+	if len(req.SegIds) > MaxSegChanges {
+		logger.Warn("[segChangesHandler] Too many segments requested",
+			"cnt", len(req.SegIds), "max", MaxSegChanges)
+		sendAck(proto.Ack_ErrCode_reject, messenger.AckRejectPolicyError)
+		return infra.MetricsErrInvalid
+	}
+	reply := &path_mgmt.SegChangesReply{SegRecs: &path_mgmt.SegRecs{}}
+	if len(req.SegIds) > 0 {
+		segs, err := h.fetchSegsFromDB(ctx, &query.Params{
+			SegIDs:   req.SegIds,
+			SegTypes: []proto.PathSegType{proto.PathSegType_down},
+			StartsAt: []addr.IA{h.localIA},
+		})
+		if err != nil {
+			logger.Error("[segChangesHandler] Failed to get segments from DB", "err", err)
+			sendAck(proto.Ack_ErrCode_retry, messenger.AckRetryDBError)
+			return infra.MetricsErrPathDB(err)
+		}
+		revs, err := revcache.RelevantRevInfos(ctx, h.revCache, segs)
+		if err != nil {
+			logger.Error("[segChangesHandler] Failed to get revocations", "err", err)
+			sendAck(proto.Ack_ErrCode_retry, messenger.AckRetryDBError)
+			return infra.MetricsErrRevCache(err)
+		}
+		for _, s := range segs {
+			reply.Recs = append(reply.Recs, seg.NewMeta(s, proto.PathSegType_down))
+		}
+		reply.SRevInfos = revs
+	}
+	if err := rw.SendSegChangesReply(ctx, reply); err != nil {
+		logger.Error("[segChangesHandler] Messenger error", "err", err)
+		return infra.MetricsErrMsger(err)
+	}
+	logger.Debug("[segChangesHandler] Replied with segments", "cnt", len(reply.Recs))
+	return infra.MetricsResultOk
+}
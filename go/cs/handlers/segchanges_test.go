@@ -0,0 +1,382 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/ack"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/infra/mock_infra"
+	"github.com/scionproto/scion/go/lib/pathdb/mock_pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/revcache/mock_revcache"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/lib/xtest/matchers"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestSegChangesIdHandler(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	lastCheck := time.Unix(time.Now().Add(-time.Minute).Unix(), 0)
+	cursor := time.Unix(0, lastCheck.Add(time.Second).UnixNano())
+
+	tests := map[string]struct {
+		Request     *path_mgmt.SegChangesIdReq
+		Params      *query.Params
+		DBErr       error
+		ExpectedIds func(g *testGraph) []*seg.PathSegment
+		Ack         *ack.Ack
+		Result      *infra.HandlerResult
+	}{
+		"initial sync": {
+			Request: &path_mgmt.SegChangesIdReq{},
+			Params: &query.Params{
+				SegTypes: []proto.PathSegType{proto.PathSegType_down},
+				StartsAt: []addr.IA{ia110},
+			},
+			ExpectedIds: func(g *testGraph) []*seg.PathSegment {
+				return []*seg.PathSegment{g.seg130_132, g.seg110_130}
+			},
+			Result: infra.MetricsResultOk,
+		},
+		"delta since last check": {
+			Request: &path_mgmt.SegChangesIdReq{LastCheck: uint32(lastCheck.Unix())},
+			Params: &query.Params{
+				SegTypes:      []proto.PathSegType{proto.PathSegType_down},
+				StartsAt:      []addr.IA{ia110},
+				MinLastUpdate: &lastCheck,
+			},
+			ExpectedIds: func(g *testGraph) []*seg.PathSegment {
+				return []*seg.PathSegment{g.seg110_130}
+			},
+			Result: infra.MetricsResultOk,
+		},
+		"next page": {
+			Request: &path_mgmt.SegChangesIdReq{
+				LastCheck: uint32(lastCheck.Unix()),
+				Cursor:    uint64(cursor.UnixNano()),
+			},
+			Params: &query.Params{
+				SegTypes:      []proto.PathSegType{proto.PathSegType_down},
+				StartsAt:      []addr.IA{ia110},
+				MinLastUpdate: &cursor,
+			},
+			ExpectedIds: func(g *testGraph) []*seg.PathSegment {
+				return []*seg.PathSegment{g.seg110_130}
+			},
+			Result: infra.MetricsResultOk,
+		},
+		"db error": {
+			Request: &path_mgmt.SegChangesIdReq{},
+			Params: &query.Params{
+				SegTypes: []proto.PathSegType{proto.PathSegType_down},
+				StartsAt: []addr.IA{ia110},
+			},
+			DBErr: serrors.New("test error"),
+			Ack: &ack.Ack{
+				Err:     proto.Ack_ErrCode_retry,
+				ErrDesc: messenger.AckRetryDBError,
+			},
+			Result: infra.MetricsErrPathDB(serrors.New("test error")),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			g := newTestGraph(ctrl)
+			pathDB := mock_pathdb.NewMockPathDB(ctrl)
+			revCache := mock_revcache.NewMockRevCache(ctrl)
+			revCache.EXPECT().Get(gomock.Any(), gomock.Any()).AnyTimes()
+			rw := mock_infra.NewMockResponseWriter(ctrl)
+
+			var segs []*seg.PathSegment
+			if test.ExpectedIds != nil {
+				segs = test.ExpectedIds(g)
+			}
+			pathDB.EXPECT().Get(gomock.Any(), test.Params).Return(results(segs), test.DBErr)
+			if test.Ack != nil {
+				rw.EXPECT().SendAckReply(gomock.Any(), &matchers.AckMsg{Ack: *test.Ack})
+			} else {
+				rw.EXPECT().SendSegChangesIdReply(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, reply *path_mgmt.SegChangesIdReply) error {
+						require.Len(t, reply.Ids, len(segs))
+						assert.Zero(t, reply.Cursor)
+						for i, s := range segs {
+							id, err := s.ID()
+							require.NoError(t, err)
+							fullId, err := s.FullId()
+							require.NoError(t, err)
+							assert.Equal(t, id, reply.Ids[i].SegId)
+							assert.Equal(t, fullId, reply.Ids[i].FullId)
+						}
+						return nil
+					},
+				)
+			}
+
+			args := HandlerArgs{PathDB: pathDB, RevCache: revCache, IA: ia110}
+			ctx := infra.NewContextWithResponseWriter(context.Background(), rw)
+			req := infra.NewRequest(ctx, test.Request, nil, nil, 0)
+			assert.Equal(t, test.Result, NewSegChangesIdHandler(args).Handle(req))
+		})
+	}
+}
+
+func TestSegChangesIdHandlerPaged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := newTestGraph(ctrl)
+	pathDB := mock_pathdb.NewMockPathDB(ctrl)
+	revCache := mock_revcache.NewMockRevCache(ctrl)
+	revCache.EXPECT().Get(gomock.Any(), gomock.Any()).AnyTimes()
+	rw := mock_infra.NewMockResponseWriter(ctrl)
+
+	// The results are returned in reverse order of their last update.
+	var res []*query.Result
+	for i := MaxSegChangesIds + 1; i > 0; i-- {
+		res = append(res, &query.Result{
+			Seg:        g.seg110_130,
+			Type:       proto.PathSegType_down,
+			LastUpdate: time.Unix(0, int64(i)),
+		})
+	}
+	pathDB.EXPECT().Get(gomock.Any(), gomock.Any()).Return(res, nil)
+	rw.EXPECT().SendSegChangesIdReply(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, reply *path_mgmt.SegChangesIdReply) error {
+			assert.Len(t, reply.Ids, MaxSegChangesIds)
+			assert.Equal(t, uint64(MaxSegChangesIds), reply.Cursor)
+			return nil
+		},
+	)
+	args := HandlerArgs{PathDB: pathDB, RevCache: revCache, IA: xtest.MustParseIA("1-ff00:0:110")}
+	ctx := infra.NewContextWithResponseWriter(context.Background(), rw)
+	req := infra.NewRequest(ctx, &path_mgmt.SegChangesIdReq{}, nil, nil, 0)
+	assert.Equal(t, infra.MetricsResultOk, NewSegChangesIdHandler(args).Handle(req))
+}
+
+func TestPageByLastUpdate(t *testing.T) {
+	tests := map[string]struct {
+		LastUpdates []int64
+		Max         int
+		Page        []int64
+		Cursor      uint64
+	}{
+		"single page": {
+			LastUpdates: []int64{3, 1, 2},
+			Max:         3,
+			Page:        []int64{3, 1, 2},
+		},
+		"first page in order": {
+			LastUpdates: []int64{4, 2, 3, 1},
+			Max:         2,
+			Page:        []int64{1, 2},
+			Cursor:      2,
+		},
+		"same last update is not split": {
+			LastUpdates: []int64{3, 2, 2, 1},
+			Max:         2,
+			Page:        []int64{1},
+			Cursor:      1,
+		},
+		"page with same last update is extended": {
+			LastUpdates: []int64{3, 2, 2, 2},
+			Max:         2,
+			Page:        []int64{2, 2, 2},
+			Cursor:      2,
+		},
+		"all with same last update": {
+			LastUpdates: []int64{2, 2, 2},
+			Max:         2,
+			Page:        []int64{2, 2, 2},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var res []*query.Result
+			for _, u := range test.LastUpdates {
+				res = append(res, &query.Result{LastUpdate: time.Unix(0, u)})
+			}
+			page, cursor := pageByLastUpdate(res, test.Max)
+			var lastUpdates []int64
+			for _, r := range page {
+				lastUpdates = append(lastUpdates, r.LastUpdate.UnixNano())
+			}
+			assert.Equal(t, test.Page, lastUpdates)
+			assert.Equal(t, test.Cursor, cursor)
+		})
+	}
+}
+
+func TestSegChangesHandler(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+
+	tests := map[string]struct {
+		// Requested returns the segments of which the IDs are requested.
+		Requested func(g *testGraph) []*seg.PathSegment
+		// IDs are requested in addition to the IDs of the requested segments.
+		IDs      []common.RawBytes
+		NoDB     bool
+		DBErr    error
+		RevErr   error
+		Ack      *ack.Ack
+		Expected func(g *testGraph) []*seg.PathSegment
+		Result   *infra.HandlerResult
+	}{
+		"requested segments": {
+			Requested: func(g *testGraph) []*seg.PathSegment {
+				return []*seg.PathSegment{g.seg130_132, g.seg110_130}
+			},
+			Expected: func(g *testGraph) []*seg.PathSegment {
+				return []*seg.PathSegment{g.seg130_132, g.seg110_130}
+			},
+			Result: infra.MetricsResultOk,
+		},
+		"no segments requested": {
+			Requested: func(g *testGraph) []*seg.PathSegment { return nil },
+			NoDB:      true,
+			Expected:  func(g *testGraph) []*seg.PathSegment { return nil },
+			Result:    infra.MetricsResultOk,
+		},
+		"too many segments requested": {
+			IDs:  make([]common.RawBytes, MaxSegChanges+1),
+			NoDB: true,
+			Ack: &ack.Ack{
+				Err:     proto.Ack_ErrCode_reject,
+				ErrDesc: messenger.AckRejectPolicyError,
+			},
+			Result: infra.MetricsErrInvalid,
+		},
+		"db error": {
+			Requested: func(g *testGraph) []*seg.PathSegment {
+				return []*seg.PathSegment{g.seg110_130}
+			},
+			DBErr: serrors.New("test error"),
+			Ack: &ack.Ack{
+				Err:     proto.Ack_ErrCode_retry,
+				ErrDesc: messenger.AckRetryDBError,
+			},
+			Result: infra.MetricsErrPathDB(serrors.New("test error")),
+		},
+		"revocation cache error": {
+			Requested: func(g *testGraph) []*seg.PathSegment {
+				return []*seg.PathSegment{g.seg110_130}
+			},
+			RevErr: serrors.New("test error"),
+			Ack: &ack.Ack{
+				Err:     proto.Ack_ErrCode_retry,
+				ErrDesc: messenger.AckRetryDBError,
+			},
+			Result: infra.MetricsErrRevCache(serrors.New("test error")),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			g := newTestGraph(ctrl)
+			pathDB := mock_pathdb.NewMockPathDB(ctrl)
+			revCache := mock_revcache.NewMockRevCache(ctrl)
+			rw := mock_infra.NewMockResponseWriter(ctrl)
+
+			ids := test.IDs
+			var requested []*seg.PathSegment
+			if test.Requested != nil {
+				requested = test.Requested(g)
+				for _, s := range requested {
+					id, err := s.ID()
+					require.NoError(t, err)
+					ids = append(ids, id)
+				}
+			}
+			if !test.NoDB {
+				pathDB.EXPECT().Get(gomock.Any(), &query.Params{
+					SegIDs:   ids,
+					SegTypes: []proto.PathSegType{proto.PathSegType_down},
+					StartsAt: []addr.IA{ia110},
+				}).Return(results(requested), test.DBErr)
+			}
+			if test.DBErr == nil && test.RevErr != nil {
+				// The first lookups filter revoked segments, the last one
+				// collects the relevant revocations.
+				gomock.InOrder(
+					revCache.EXPECT().Get(gomock.Any(), gomock.Any()).Times(len(requested)),
+					revCache.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, test.RevErr),
+				)
+			} else {
+				revCache.EXPECT().Get(gomock.Any(), gomock.Any()).AnyTimes()
+			}
+			if test.Ack != nil {
+				rw.EXPECT().SendAckReply(gomock.Any(), &matchers.AckMsg{Ack: *test.Ack})
+			} else {
+				expected := test.Expected(g)
+				rw.EXPECT().SendSegChangesReply(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, reply *path_mgmt.SegChangesReply) error {
+						require.NotNil(t, reply.SegRecs)
+						require.Len(t, reply.Recs, len(expected))
+						for i, s := range expected {
+							assert.Equal(t, seg.NewMeta(s, proto.PathSegType_down),
+								reply.Recs[i])
+						}
+						assert.Empty(t, reply.SRevInfos)
+						return nil
+					},
+				)
+			}
+
+			args := HandlerArgs{PathDB: pathDB, RevCache: revCache, IA: ia110}
+			ctx := infra.NewContextWithResponseWriter(context.Background(), rw)
+			req := infra.NewRequest(ctx, &path_mgmt.SegChangesReq{SegIds: ids}, nil, nil, 0)
+			assert.Equal(t, test.Result, NewSegChangesHandler(args).Handle(req))
+		})
+	}
+}
+
+func TestSegChangesHandlerWrongMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	args := HandlerArgs{
+		PathDB:   mock_pathdb.NewMockPathDB(ctrl),
+		RevCache: mock_revcache.NewMockRevCache(ctrl),
+		IA:       xtest.MustParseIA("1-ff00:0:110"),
+	}
+	ctx := infra.NewContextWithResponseWriter(context.Background(),
+		mock_infra.NewMockResponseWriter(ctrl))
+	idReq := infra.NewRequest(ctx, &path_mgmt.SegChangesReq{}, nil, nil, 0)
+	assert.Equal(t, infra.MetricsErrInternal, NewSegChangesIdHandler(args).Handle(idReq))
+	req := infra.NewRequest(ctx, &path_mgmt.SegChangesIdReq{}, nil, nil, 0)
+	assert.Equal(t, infra.MetricsErrInternal, NewSegChangesHandler(args).Handle(req))
+}
+
+func results(segs []*seg.PathSegment) []*query.Result {
+	res := make([]*query.Result, 0, len(segs))
+	for _, s := range segs {
+		res = append(res, &query.Result{Seg: s, Type: proto.PathSegType_down})
+	}
+	return res
+}
@@ -15,6 +15,9 @@
 package handlers
 
 import (
+	"sync"
+	"time"
+
 	"github.com/scionproto/scion/go/cs/metrics"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
@@ -31,9 +34,14 @@ type segRegHandler struct {
 	*baseHandler
 	localIA addr.IA
 	handler seghandler.Handler
+	limiter *regLimiter
 }
 
-func NewSegRegHandler(args HandlerArgs) infra.Handler {
+// NewSegRegHandler returns a handler for segment registrations. The
+// registrations are subject to the per-origin quota.
+func NewSegRegHandler(args HandlerArgs, quota RegQuota) infra.Handler {
+	limiter := newRegLimiter(quota.MaxRegs, quota.Interval)
+	storageMtx := &sync.Mutex{}
 	f := func(r *infra.Request) *infra.HandlerResult {
 		handler := &segRegHandler{
 			baseHandler: newBaseHandler(r, args),
@@ -42,11 +50,17 @@ func NewSegRegHandler(args HandlerArgs) infra.Handler {
 				Verifier: &seghandler.DefaultVerifier{
 					Verifier: args.VerifierFactory.NewVerifier(),
 				},
-				Storage: &seghandler.DefaultStorage{
-					PathDB:   args.PathDB,
-					RevCache: args.RevCache,
+				Storage: &quotaStorage{
+					Storage: &seghandler.DefaultStorage{
+						PathDB:   args.PathDB,
+						RevCache: args.RevCache,
+					},
+					pathDB:  args.PathDB,
+					maxSegs: quota.MaxSegs,
+					mu:      storageMtx,
 				},
 			},
+			limiter: limiter,
 		}
 		return handler.Handle()
 	}
@@ -84,6 +98,13 @@ func (h *segRegHandler) Handle() *infra.HandlerResult {
 		return infra.MetricsErrInvalid
 	}
 	logSegRecs(logger, "[segRegHandler]", h.request.Peer, segReg.SegRecs)
+	if !h.limiter.Allow(snetPeer.IA, time.Now()) {
+		logger.Info("[segRegHandler] Registration rate limit exceeded", "origin", snetPeer.IA)
+		labels.Result = metrics.ErrQuota
+		metrics.Registrations.ResultsTotal(labels).Inc()
+		sendAck(proto.Ack_ErrCode_reject, messenger.AckRejectPolicyError)
+		return infra.MetricsErrInvalid
+	}
 
 	peerPath, err := snetPeer.GetPath()
 	if err != nil {
@@ -0,0 +1,231 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/cs/metrics"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// RegQuota limits the segment registrations per origin AS. The origin of a
+// registration is the AS that sent it, the origin of a stored segment is the
+// last AS on the segment. A zero value disables the respective limit.
+type RegQuota struct {
+	// MaxSegs is the maximum number of up and down segments stored per
+	// origin. If a registration exceeds the quota, the least recently updated
+	// segments of the origin are evicted.
+	MaxSegs int
+	// MaxRegs is the maximum number of registrations accepted per origin in
+	// every Interval.
+	MaxRegs int
+	// Interval is the interval to which MaxRegs applies.
+	Interval time.Duration
+}
+
+// regLimiter limits the number of registrations per origin in a fixed window.
+type regLimiter struct {
+	maxRegs  int
+	interval time.Duration
+
+	mu        sync.Mutex
+	windows   map[addr.IA]*regWindow
+	lastPrune time.Time
+}
+
+type regWindow struct {
+	start time.Time
+	cnt   int
+}
+
+func newRegLimiter(maxRegs int, interval time.Duration) *regLimiter {
+	return &regLimiter{
+		maxRegs:  maxRegs,
+		interval: interval,
+		windows:  make(map[addr.IA]*regWindow),
+	}
+}
+
+// Allow returns whether a registration from the origin is accepted at the
+// given time. Accepted registrations are accounted.
+func (l *regLimiter) Allow(origin addr.IA, now time.Time) bool {
+	if l == nil || l.maxRegs <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prune(now)
+	w, ok := l.windows[origin]
+	if !ok || now.Sub(w.start) >= l.interval {
+		w = &regWindow{start: now}
+		l.windows[origin] = w
+	}
+	if w.cnt >= l.maxRegs {
+		return false
+	}
+	w.cnt++
+	return true
+}
+
+// prune removes expired windows, such that the limiter does not grow with
+// the number of origins that ever registered. Must be called with the lock
+// held.
+func (l *regLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < l.interval {
+		return
+	}
+	for origin, w := range l.windows {
+		if now.Sub(w.start) >= l.interval {
+			delete(l.windows, origin)
+		}
+	}
+	l.lastPrune = now
+}
+
+// quotaStorage enforces the per-origin segment quota before the segments are
+// inserted with the wrapped storage.
+type quotaStorage struct {
+	seghandler.Storage
+	pathDB  pathdb.PathDB
+	maxSegs int
+	// mu serializes quota enforcement and insertion, otherwise concurrent
+	// registrations of the same origin could exceed the quota.
+	mu *sync.Mutex
+}
+
+func (s *quotaStorage) StoreSegs(ctx context.Context,
+	segs []*seghandler.SegWithHP) (seghandler.SegStats, error) {
+
+	if s.maxSegs <= 0 {
+		return s.Storage.StoreSegs(ctx, segs)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	byOrigin := make(map[addr.IA][]*seghandler.SegWithHP)
+	for _, sh := range segs {
+		origin := sh.Seg.Segment.LastIA()
+		byOrigin[origin] = append(byOrigin[origin], sh)
+	}
+	accepted := make([]*seghandler.SegWithHP, 0, len(segs))
+	for origin, originSegs := range byOrigin {
+		originSegs, err := s.makeRoom(ctx, origin, originSegs)
+		if err != nil {
+			return seghandler.SegStats{}, err
+		}
+		accepted = append(accepted, originSegs...)
+	}
+	return s.Storage.StoreSegs(ctx, accepted)
+}
+
+// makeRoom evicts the least recently updated segments of the origin, such
+// that the new segments fit in the quota. Segments that do not fit even after
+// evicting all other segments of the origin are dropped. The remaining
+// segments are returned.
+func (s *quotaStorage) makeRoom(ctx context.Context, origin addr.IA,
+	segs []*seghandler.SegWithHP) ([]*seghandler.SegWithHP, error) {
+
+	logger := log.FromCtx(ctx)
+	labels := metrics.QuotaLabels{Src: origin}
+	res, err := s.pathDB.Get(ctx, &query.Params{
+		SegTypes: []proto.PathSegType{proto.PathSegType_up, proto.PathSegType_down},
+		EndsAt:   []addr.IA{origin},
+	})
+	if err != nil {
+		return nil, serrors.WrapStr("unable to count segments of origin", err,
+			"origin", origin)
+	}
+	stored := make(map[string]struct{}, len(res))
+	for _, r := range res {
+		id, err := r.Seg.ID()
+		if err != nil {
+			return nil, err
+		}
+		stored[string(id)] = struct{}{}
+	}
+	// Updates of stored segments do not count against the quota.
+	var updated, added []*seghandler.SegWithHP
+	for _, sh := range segs {
+		id, err := sh.Seg.Segment.ID()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := stored[string(id)]; ok {
+			updated = append(updated, sh)
+		} else {
+			added = append(added, sh)
+		}
+	}
+	if len(added) > s.maxSegs {
+		logger.Info("[segRegHandler] Dropping segments exceeding quota", "origin", origin,
+			"cnt", len(added)-s.maxSegs, "max", s.maxSegs)
+		metrics.Registrations.QuotaDropped(labels).Add(float64(len(added) - s.maxSegs))
+		added = added[:s.maxSegs]
+	}
+	excess := len(res) + len(added) - s.maxSegs
+	if excess <= 0 {
+		return append(updated, added...), nil
+	}
+	// Evict the least recently updated segments, but never the ones that are
+	// updated by this registration.
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].LastUpdate.Before(res[j].LastUpdate)
+	})
+	updatedIDs := make(map[string]struct{}, len(updated))
+	for _, sh := range updated {
+		id, err := sh.Seg.Segment.ID()
+		if err != nil {
+			return nil, err
+		}
+		updatedIDs[string(id)] = struct{}{}
+	}
+	var evict []common.RawBytes
+	for _, r := range res {
+		if len(evict) == excess {
+			break
+		}
+		id, err := r.Seg.ID()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := updatedIDs[string(id)]; ok {
+			continue
+		}
+		evict = append(evict, id)
+	}
+	// An empty query would match all segments.
+	if len(evict) == 0 {
+		return append(updated, added...), nil
+	}
+	n, err := s.pathDB.Delete(ctx, &query.Params{SegIDs: evict})
+	if err != nil {
+		return nil, serrors.WrapStr("unable to evict segments of origin", err,
+			"origin", origin)
+	}
+	logger.Info("[segRegHandler] Evicted segments exceeding quota", "origin", origin,
+		"cnt", n, "max", s.maxSegs)
+	metrics.Registrations.QuotaEvicted(labels).Add(float64(n))
+	return append(updated, added...), nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/metrics"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler/mock_seghandler"
+	"github.com/scionproto/scion/go/lib/pathdb/mock_pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestRegLimiterAllow(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	now := time.Now()
+
+	t.Run("unlimited", func(t *testing.T) {
+		l := newRegLimiter(0, time.Minute)
+		for i := 0; i < 10; i++ {
+			assert.True(t, l.Allow(ia110, now))
+		}
+	})
+	t.Run("limited per origin", func(t *testing.T) {
+		l := newRegLimiter(2, time.Minute)
+		assert.True(t, l.Allow(ia110, now))
+		assert.True(t, l.Allow(ia110, now.Add(time.Second)))
+		assert.False(t, l.Allow(ia110, now.Add(2*time.Second)))
+		assert.True(t, l.Allow(ia111, now.Add(2*time.Second)))
+	})
+	t.Run("new window", func(t *testing.T) {
+		l := newRegLimiter(1, time.Minute)
+		assert.True(t, l.Allow(ia110, now))
+		assert.False(t, l.Allow(ia110, now.Add(59*time.Second)))
+		assert.True(t, l.Allow(ia110, now.Add(time.Minute)))
+	})
+	t.Run("prune", func(t *testing.T) {
+		l := newRegLimiter(1, time.Minute)
+		assert.True(t, l.Allow(ia110, now))
+		assert.True(t, l.Allow(ia111, now.Add(2*time.Minute)))
+		assert.Len(t, l.windows, 1)
+	})
+}
+
+func TestQuotaStorageStoreSegs(t *testing.T) {
+	metrics.InitPSMetrics()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := newTestGraph(ctrl)
+	ia222 := xtest.MustParseIA("2-ff00:0:222")
+	now := time.Now()
+
+	meta := func(ps *seg.PathSegment) *seghandler.SegWithHP {
+		return &seghandler.SegWithHP{Seg: seg.NewMeta(ps, proto.PathSegType_down)}
+	}
+	result := func(ps *seg.PathSegment, lastUpdate time.Time) *query.Result {
+		return &query.Result{Seg: ps, LastUpdate: lastUpdate, Type: proto.PathSegType_down}
+	}
+	mustID := func(ps *seg.PathSegment) common.RawBytes {
+		id, err := ps.ID()
+		require.NoError(t, err)
+		return id
+	}
+	originQuery := &query.Params{
+		SegTypes: []proto.PathSegType{proto.PathSegType_up, proto.PathSegType_down},
+		EndsAt:   []addr.IA{ia222},
+	}
+
+	tests := map[string]struct {
+		MaxSegs  int
+		Stored   query.Results
+		Segs     []*seghandler.SegWithHP
+		Evicted  []common.RawBytes
+		Expected []*seghandler.SegWithHP
+	}{
+		"within quota": {
+			MaxSegs:  2,
+			Stored:   query.Results{result(g.seg210_222, now)},
+			Segs:     []*seghandler.SegWithHP{meta(g.seg220_222)},
+			Expected: []*seghandler.SegWithHP{meta(g.seg220_222)},
+		},
+		"evict oldest": {
+			MaxSegs:  1,
+			Stored:   query.Results{result(g.seg210_222, now)},
+			Segs:     []*seghandler.SegWithHP{meta(g.seg220_222)},
+			Evicted:  []common.RawBytes{mustID(g.seg210_222)},
+			Expected: []*seghandler.SegWithHP{meta(g.seg220_222)},
+		},
+		"update does not count": {
+			MaxSegs:  1,
+			Stored:   query.Results{result(g.seg210_222, now)},
+			Segs:     []*seghandler.SegWithHP{meta(g.seg210_222)},
+			Expected: []*seghandler.SegWithHP{meta(g.seg210_222)},
+		},
+		"drop exceeding": {
+			MaxSegs:  1,
+			Segs:     []*seghandler.SegWithHP{meta(g.seg210_222), meta(g.seg220_222)},
+			Expected: []*seghandler.SegWithHP{meta(g.seg210_222)},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			pathDB := mock_pathdb.NewMockPathDB(ctrl)
+			inner := mock_seghandler.NewMockStorage(ctrl)
+			pathDB.EXPECT().Get(gomock.Any(), originQuery).Return(test.Stored, nil)
+			if test.Evicted != nil {
+				pathDB.EXPECT().Delete(gomock.Any(), &query.Params{SegIDs: test.Evicted}).
+					Return(len(test.Evicted), nil)
+			}
+			inner.EXPECT().StoreSegs(gomock.Any(), test.Expected)
+			s := &quotaStorage{
+				Storage: inner,
+				pathDB:  pathDB,
+				maxSegs: test.MaxSegs,
+				mu:      &sync.Mutex{},
+			}
+			_, err := s.StoreSegs(context.Background(), test.Segs)
+			assert.NoError(t, err)
+		})
+	}
+}
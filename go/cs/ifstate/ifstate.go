@@ -168,6 +168,15 @@ func (intf *Interface) Revoke() bool {
 	return intf.state == Revoked
 }
 
+// Expire marks the interface as not activated since the keepalive timeout,
+// such that it is revoked on the next call to Revoke. This is used when the
+// border router detects that the link is down.
+func (intf *Interface) Expire() {
+	intf.mu.Lock()
+	defer intf.mu.Unlock()
+	intf.lastActivate = time.Time{}
+}
+
 // SetRevocation sets the revocation for this interface. This can only be
 // invoked when the interface is in revoked state. Otherwise it is assumed that
 // the interface has been activated in the meantime and should not be revoked.
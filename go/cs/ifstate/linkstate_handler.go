@@ -0,0 +1,110 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ifstate
+
+import (
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+type linkStateHandler struct {
+	ia           addr.IA
+	intfs        *Interfaces
+	topoProvider topology.Provider
+	request      *infra.Request
+}
+
+// NewLinkStateHandler creates a handler for interface state infos that are
+// sent by the local border routers when they detect that the link of an
+// interface is down. The affected interfaces are expired, such that the
+// revoker revokes them in its next run instead of waiting for the keepalive
+// timeout. The infos are not signed, thus they are only accepted from the
+// control addresses of the border routers in the topology.
+func NewLinkStateHandler(ia addr.IA, intfs *Interfaces,
+	topoProvider topology.Provider) infra.Handler {
+
+	f := func(r *infra.Request) *infra.HandlerResult {
+		handler := &linkStateHandler{
+			ia:           ia,
+			intfs:        intfs,
+			topoProvider: topoProvider,
+			request:      r,
+		}
+		return handler.Handle()
+	}
+	return infra.HandlerFunc(f)
+}
+
+func (h *linkStateHandler) Handle() *infra.HandlerResult {
+	logger := log.FromCtx(h.request.Context())
+	infos, ok := h.request.Message.(*path_mgmt.IFStateInfos)
+	if !ok {
+		logger.Error("[LinkStateHandler] Wrong message type",
+			"type", common.TypeOf(h.request.Message))
+		return infra.MetricsErrInternal
+	}
+	peer, ok := h.request.Peer.(*snet.UDPAddr)
+	if !ok {
+		logger.Error("[LinkStateHandler] Invalid peer address type, expected *snet.UDPAddr",
+			"peer", h.request.Peer, "type", common.TypeOf(h.request.Peer))
+		return infra.MetricsErrInvalid
+	}
+	if !peer.IA.Equal(h.ia) {
+		logger.Warn("[LinkStateHandler] Ignoring interface state infos from remote AS",
+			"peer", peer)
+		return infra.MetricsErrInvalid
+	}
+	if !h.fromBR(peer) {
+		logger.Warn("[LinkStateHandler] Ignoring interface state infos from non-BR peer",
+			"peer", peer)
+		return infra.MetricsErrInvalid
+	}
+	for _, info := range infos.Infos {
+		if info.Active {
+			continue
+		}
+		intf := h.intfs.Get(info.IfID)
+		if intf == nil {
+			logger.Warn("[LinkStateHandler] Unknown interface", "ifid", info.IfID)
+			continue
+		}
+		logger.Info("[LinkStateHandler] Link down reported by border router",
+			"ifid", info.IfID, "peer", peer)
+		intf.Expire()
+	}
+	return infra.MetricsResultOk
+}
+
+// fromBR returns whether the peer is the control address of a border router
+// in the topology.
+func (h *linkStateHandler) fromBR(peer *snet.UDPAddr) bool {
+	if peer.Host == nil {
+		return false
+	}
+	topo := h.topoProvider.Get()
+	for _, name := range topo.BRNames() {
+		br := topo.SBRAddress(name)
+		if br != nil && br.Host != nil && br.Host.IP.Equal(peer.Host.IP) &&
+			br.Host.Port == peer.Host.Port {
+			return true
+		}
+	}
+	return false
+}
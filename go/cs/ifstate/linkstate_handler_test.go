@@ -0,0 +1,107 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ifstate
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/itopo/itopotest"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestLinkStateHandler(t *testing.T) {
+	topoProvider := itopotest.TopoProviderFromFile(t, "testdata/topology.json")
+	localIA := topoProvider.Get().IA()
+	// The control address of br1-ff00_0_111-2 in the topology.
+	brHost := &net.UDPAddr{IP: net.IP{127, 0, 0, 82}, Port: 31031}
+	down := &path_mgmt.IFStateInfos{
+		Infos: []*path_mgmt.IFStateInfo{{IfID: 1, Active: false}},
+	}
+	tests := map[string]struct {
+		peer       net.Addr
+		msg        proto.Cerealizable
+		result     *infra.HandlerResult
+		revokedIF1 bool
+	}{
+		"local border router": {
+			peer:       &snet.UDPAddr{IA: localIA, Host: brHost},
+			msg:        down,
+			result:     infra.MetricsResultOk,
+			revokedIF1: true,
+		},
+		"remote AS": {
+			peer:   &snet.UDPAddr{IA: xtest.MustParseIA("1-ff00:0:110"), Host: brHost},
+			msg:    down,
+			result: infra.MetricsErrInvalid,
+		},
+		"local non-BR host": {
+			peer: &snet.UDPAddr{
+				IA:   localIA,
+				Host: &net.UDPAddr{IP: net.IP{127, 0, 0, 99}, Port: 31031},
+			},
+			msg:    down,
+			result: infra.MetricsErrInvalid,
+		},
+		"wrong BR port": {
+			peer: &snet.UDPAddr{
+				IA:   localIA,
+				Host: &net.UDPAddr{IP: brHost.IP, Port: 31032},
+			},
+			msg:    down,
+			result: infra.MetricsErrInvalid,
+		},
+		"no host": {
+			peer:   &snet.UDPAddr{IA: localIA},
+			msg:    down,
+			result: infra.MetricsErrInvalid,
+		},
+		"active info": {
+			peer: &snet.UDPAddr{IA: localIA, Host: brHost},
+			msg: &path_mgmt.IFStateInfos{
+				Infos: []*path_mgmt.IFStateInfo{{IfID: 1, Active: true}},
+			},
+			result: infra.MetricsResultOk,
+		},
+		"unknown interface": {
+			peer: &snet.UDPAddr{IA: localIA, Host: brHost},
+			msg: &path_mgmt.IFStateInfos{
+				Infos: []*path_mgmt.IFStateInfo{{IfID: 42, Active: false}},
+			},
+			result: infra.MetricsResultOk,
+		},
+		"wrong message": {
+			peer:   &snet.UDPAddr{IA: localIA, Host: brHost},
+			msg:    &path_mgmt.IFStateReq{},
+			result: infra.MetricsErrInternal,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			intfs := testInterfaces(t)
+			h := NewLinkStateHandler(localIA, intfs, topoProvider)
+			req := infra.NewRequest(context.Background(), test.msg, nil, test.peer, 0)
+			assert.Equal(t, test.result, h.Handle(req))
+			assert.Equal(t, test.revokedIF1, intfs.Get(1).Revoke())
+		})
+	}
+}
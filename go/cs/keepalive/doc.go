@@ -22,7 +22,12 @@
 // active, the handler immediately pushes in IfStateInfo update to all border
 // routers and starts beaconing on the activated interface.
 //
+// If a verifier is configured, the handler verifies the keepalive signature
+// before the interface is activated. Depending on the configuration,
+// keepalives that fail verification are either dropped or only logged.
+//
 // Sender
 //
-// The sender periodically creates keepalive messages for all links.
+// The sender periodically creates keepalive messages for all links. The
+// messages are signed with the configured signer.
 package keepalive
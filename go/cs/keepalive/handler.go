@@ -22,6 +22,7 @@ import (
 	"github.com/scionproto/scion/go/cs/metrics"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl"
 	"github.com/scionproto/scion/go/lib/ctrl/ifid"
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/log"
@@ -35,6 +36,8 @@ const (
 	IfStatePushTimeout = time.Second
 	// DropRevTimeout is the timeout for dropping revocations.
 	DropRevTimeout = time.Second
+	// VerifyTimeout is the timeout for verifying the keepalive signature.
+	VerifyTimeout = time.Second
 )
 
 // IfStatePusher is used to push interface state changes to the border
@@ -56,15 +59,31 @@ type StateChangeTasks struct {
 	RevDropper    RevDropper
 }
 
+// Verification configures the verification of the keepalive signatures.
+type Verification struct {
+	// Verifier verifies the keepalive signatures. If nil, keepalives are not
+	// verified.
+	Verifier infra.Verifier
+	// Enforce indicates whether keepalives that fail verification are
+	// dropped. Otherwise, the failure is only logged and the keepalive is
+	// processed.
+	Enforce bool
+}
+
 // NewHandler returns an infra.Handler for IFID keepalive messages. The state
 // change tasks must all be set. Nil tasks will cause the handler to panic.
-func NewHandler(ia addr.IA, intfs *ifstate.Interfaces, tasks StateChangeTasks) infra.Handler {
+// Keepalives are verified before the interface state is updated according to
+// the verification configuration.
+func NewHandler(ia addr.IA, intfs *ifstate.Interfaces, tasks StateChangeTasks,
+	verification Verification) infra.Handler {
+
 	f := func(r *infra.Request) *infra.HandlerResult {
 		handler := &handler{
-			ia:      ia,
-			request: r,
-			intfs:   intfs,
-			tasks:   tasks,
+			ia:           ia,
+			request:      r,
+			intfs:        intfs,
+			tasks:        tasks,
+			verification: verification,
 		}
 		return handler.Handle()
 	}
@@ -73,10 +92,11 @@ func NewHandler(ia addr.IA, intfs *ifstate.Interfaces, tasks StateChangeTasks) i
 }
 
 type handler struct {
-	ia      addr.IA
-	intfs   *ifstate.Interfaces
-	tasks   StateChangeTasks
-	request *infra.Request
+	ia           addr.IA
+	intfs        *ifstate.Interfaces
+	tasks        StateChangeTasks
+	verification Verification
+	request      *infra.Request
 }
 
 // Handle handles IFID keepalive messages.
@@ -105,6 +125,16 @@ func (h *handler) handle(logger log.Logger) (*infra.HandlerResult, error) {
 		return infra.MetricsErrInvalid, err
 	}
 	labels.IfID = ifid
+	if err := h.verify(info.TopoInfo().IA); err != nil {
+		if h.verification.Enforce {
+			labels.Result = metrics.ErrVerify
+			metrics.Keepalive.Receives(labels).Inc()
+			return infra.MetricsErrInvalid, common.NewBasicError(
+				"Unable to verify keepalive", err, "ifid", ifid)
+		}
+		logger.Info("[KeepaliveHandler] Processing unverified keepalive",
+			"ifid", ifid, "err", err)
+	}
 	if lastState := info.Activate(keepalive.OrigIfID); lastState != ifstate.Active {
 		logger.Info("[KeepaliveHandler] Activated interface", "ifid", ifid)
 		h.startPush(ifid)
@@ -142,6 +172,35 @@ func (h *handler) getIntfInfo() (common.IFIDType, *ifstate.Interface, error) {
 	return hopF.ConsIngress, info, nil
 }
 
+// verify verifies the signature of the keepalive. The crypto material is
+// fetched from the origin of the keepalive, if necessary.
+func (h *handler) verify(originIA addr.IA) error {
+	if h.verification.Verifier == nil {
+		return nil
+	}
+	signedPld, ok := h.request.FullMessage.(*ctrl.SignedPld)
+	if !ok {
+		return common.NewBasicError("Wrong message type, expected ctrl.SignedPld", nil,
+			"type", common.TypeOf(h.request.FullMessage))
+	}
+	peer := h.request.Peer.(*snet.UDPAddr)
+	peerPath, err := peer.GetPath()
+	if err != nil {
+		return common.NewBasicError("path error", err)
+	}
+	server := &snet.SVCAddr{
+		IA:      peer.IA,
+		Path:    peerPath.Path(),
+		NextHop: peerPath.OverlayNextHop(),
+		SVC:     addr.SvcBS,
+	}
+	ctx, cancelF := context.WithTimeout(h.request.Context(), VerifyTimeout)
+	defer cancelF()
+	verifier := h.verification.Verifier.WithServer(server).WithIA(originIA)
+	_, err = signedPld.GetVerifiedPld(ctx, verifier)
+	return err
+}
+
 func (h *handler) startPush(ifid common.IFIDType) {
 	go func() {
 		defer log.HandlePanic()
@@ -16,6 +16,7 @@ package keepalive
 
 import (
 	"context"
+	"errors"
 	"net"
 	"os"
 	"sync"
@@ -33,11 +34,16 @@ import (
 	"github.com/scionproto/scion/go/lib/ctrl"
 	"github.com/scionproto/scion/go/lib/ctrl/ifid"
 	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/mock_infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/mock_trust"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/spath"
 	"github.com/scionproto/scion/go/lib/topology"
 	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
 )
 
 var (
@@ -77,7 +83,7 @@ func TestNewHandler(t *testing.T) {
 		handler := NewHandler(localIA, testInterfaces(t), StateChangeTasks{
 			IfStatePusher: pusher,
 			RevDropper:    dropper,
-		})
+		}, Verification{})
 		req := infra.NewRequest(context.Background(), &ifid.IFID{OrigIfID: originIF}, nil,
 			&snet.UDPAddr{IA: originIA, Path: testPath(localIF)}, 0)
 		res := handler.Handle(req)
@@ -89,7 +95,7 @@ func TestNewHandler(t *testing.T) {
 	t.Run("Active interface should cause no tasks to execute", func(t *testing.T) {
 		intfs := testInterfaces(t)
 		intfs.Get(localIF).Activate(42)
-		handler := NewHandler(localIA, intfs, zeroCallTasks(mctrl))
+		handler := NewHandler(localIA, intfs, zeroCallTasks(mctrl), Verification{})
 		req := infra.NewRequest(context.Background(), &ifid.IFID{OrigIfID: originIF}, nil,
 			&snet.UDPAddr{IA: originIA, Path: testPath(localIF)}, 0)
 		res := handler.Handle(req)
@@ -97,7 +103,8 @@ func TestNewHandler(t *testing.T) {
 	})
 
 	t.Run("Invalid requests cause an error", func(t *testing.T) {
-		handler := NewHandler(localIA, testInterfaces(t), zeroCallTasks(mctrl))
+		handler := NewHandler(localIA, testInterfaces(t), zeroCallTasks(mctrl),
+			Verification{})
 
 		tests := []struct {
 			msg string
@@ -145,6 +152,131 @@ func TestNewHandler(t *testing.T) {
 	})
 }
 
+func TestHandlerVerification(t *testing.T) {
+	tests := map[string]struct {
+		Enforce   bool
+		VerifyErr error
+		Expected  *infra.HandlerResult
+		Activated bool
+	}{
+		"valid signature": {
+			Enforce:   true,
+			Expected:  infra.MetricsResultOk,
+			Activated: true,
+		},
+		"invalid signature enforced": {
+			Enforce:   true,
+			VerifyErr: errors.New("invalid signature"),
+			Expected:  infra.MetricsErrInvalid,
+		},
+		"invalid signature not enforced": {
+			VerifyErr: errors.New("invalid signature"),
+			Expected:  infra.MetricsResultOk,
+			Activated: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+			spld := &ctrl.SignedPld{}
+			verifier := mock_infra.NewMockVerifier(mctrl)
+			verifier.EXPECT().WithServer(gomock.Any()).Return(verifier)
+			verifier.EXPECT().WithIA(originIA).Return(verifier)
+			verifier.EXPECT().VerifyPld(gomock.Any(), spld).Return(nil, test.VerifyErr)
+
+			tasks := zeroCallTasks(mctrl)
+			wg := &sync.WaitGroup{}
+			if test.Activated {
+				pusher := mock_keepalive.NewMockIfStatePusher(mctrl)
+				dropper := mock_keepalive.NewMockRevDropper(mctrl)
+				wg.Add(1)
+				pusher.EXPECT().Push(gomock.Any(), localIF).Do(
+					func(_ ...interface{}) { wg.Done() })
+				dropper.EXPECT().DeleteRevocation(gomock.Any(), gomock.Any(),
+					gomock.Any()).Times(2)
+				tasks = StateChangeTasks{IfStatePusher: pusher, RevDropper: dropper}
+			}
+			intfs := testInterfaces(t)
+			handler := NewHandler(localIA, intfs, tasks,
+				Verification{Verifier: verifier, Enforce: test.Enforce})
+			req := infra.NewRequest(context.Background(), &ifid.IFID{OrigIfID: originIF}, spld,
+				&snet.UDPAddr{IA: originIA, Path: testPath(localIF)}, 0)
+			res := handler.Handle(req)
+			waitTimeout(t, wg)
+			assert.Equal(t, test.Expected, res)
+			assert.Equal(t, test.Activated, intfs.Get(localIF).State() == ifstate.Active)
+		})
+	}
+}
+
+func TestHandlerTrustVerifier(t *testing.T) {
+	pub, priv, err := scrypto.GenKeyPair(scrypto.Ed25519)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		Timestamp time.Time
+		Expected  *infra.HandlerResult
+		Activated bool
+	}{
+		"fresh keepalive": {
+			Timestamp: time.Now(),
+			Expected:  infra.MetricsResultOk,
+			Activated: true,
+		},
+		"stale keepalive": {
+			Timestamp: time.Now().Add(-time.Minute),
+			Expected:  infra.MetricsErrInvalid,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+			provider := mock_trust.NewMockCryptoProvider(mctrl)
+			provider.EXPECT().AnnounceTRC(gomock.Any(), gomock.Any(),
+				gomock.Any()).AnyTimes()
+			provider.EXPECT().GetASKey(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+				scrypto.KeyMeta{Key: pub, Algorithm: scrypto.Ed25519}, nil).AnyTimes()
+
+			keepalive := &ifid.IFID{OrigIfID: originIF}
+			cpld, err := ctrl.NewPld(keepalive, nil)
+			require.NoError(t, err)
+			blob, err := proto.PackRoot(cpld)
+			require.NoError(t, err)
+			src := ctrl.SignSrcDef{IA: originIA, ChainVer: 1, TRCVer: 1}
+			sign := proto.NewSignS(proto.SignType_ed25519, src.Pack())
+			sign.SetTimestamp(test.Timestamp)
+			sign.Signature, err = scrypto.Sign(sign.SigInput(blob, false), priv,
+				scrypto.Ed25519)
+			require.NoError(t, err)
+			spld := &ctrl.SignedPld{Blob: blob, Sign: sign}
+
+			tasks := zeroCallTasks(mctrl)
+			wg := &sync.WaitGroup{}
+			if test.Activated {
+				pusher := mock_keepalive.NewMockIfStatePusher(mctrl)
+				dropper := mock_keepalive.NewMockRevDropper(mctrl)
+				wg.Add(1)
+				pusher.EXPECT().Push(gomock.Any(), localIF).Do(
+					func(_ ...interface{}) { wg.Done() })
+				dropper.EXPECT().DeleteRevocation(gomock.Any(), gomock.Any(),
+					gomock.Any()).Times(2)
+				tasks = StateChangeTasks{IfStatePusher: pusher, RevDropper: dropper}
+			}
+			intfs := testInterfaces(t)
+			handler := NewHandler(localIA, intfs, tasks,
+				Verification{Verifier: trust.NewVerifier(provider), Enforce: true})
+			req := infra.NewRequest(context.Background(), keepalive, spld,
+				&snet.UDPAddr{IA: originIA, Path: testPath(localIF)}, 0)
+			res := handler.Handle(req)
+			waitTimeout(t, wg)
+			assert.Equal(t, test.Expected, res)
+			assert.Equal(t, test.Activated, intfs.Get(localIF).State() == ifstate.Active)
+		})
+	}
+}
+
 func testInterfaces(t *testing.T) *ifstate.Interfaces {
 	infoMap := topology.IfInfoMap{localIF: topology.IFInfo{IA: originIA}}
 	intfs := ifstate.NewInterfaces(infoMap, ifstate.Config{KeepaliveTimeout: time.Nanosecond})
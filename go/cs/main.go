@@ -34,8 +34,10 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/beaconapi"
 	"github.com/scionproto/scion/go/cs/beaconing"
 	"github.com/scionproto/scion/go/cs/beaconstorage"
+	"github.com/scionproto/scion/go/cs/certrenewal"
 	"github.com/scionproto/scion/go/cs/config"
 	"github.com/scionproto/scion/go/cs/handlers"
 	"github.com/scionproto/scion/go/cs/ifstate"
@@ -81,6 +83,9 @@ var (
 
 	intfs *ifstate.Interfaces
 	tasks *periodicTasks
+	// tasksMtx protects the tasks variable, which is also read by the config
+	// reload on SIGHUP.
+	tasksMtx sync.Mutex
 
 	helpPolicy bool
 )
@@ -251,6 +256,12 @@ func realMain() int {
 		log.Crit("Error initializing signer", "err", err)
 		return 1
 	}
+	issuing, err := inspector.HasAttributes(context.Background(), topo.IA(),
+		infra.ASInspectorOpts{RequiredAttributes: []infra.Attribute{infra.Issuing}})
+	if err != nil {
+		log.Crit("Unable to determine whether AS is issuing", "err", err)
+		return 1
+	}
 
 	beaconStore, err := loadStore(topo.Core(), topo.IA(), cfg)
 	if err != nil {
@@ -267,6 +278,8 @@ func realMain() int {
 	msgr.AddHandler(infra.Chain, trustStore.NewChainPushHandler(topo.IA()))
 	msgr.AddHandler(infra.TRC, trustStore.NewTRCPushHandler(topo.IA()))
 	msgr.AddHandler(infra.IfStateReq, ifstate.NewHandler(intfs))
+	msgr.AddHandler(infra.IfStateInfos, ifstate.NewLinkStateHandler(topo.IA(), intfs,
+		itopo.Provider()))
 	msgr.AddHandler(infra.Seg, beaconing.NewHandler(topo.IA(), intfs, beaconStore,
 		trust.NewVerifier(trustStore)))
 	msgr.AddHandler(infra.IfId, keepalive.NewHandler(topo.IA(), intfs,
@@ -278,14 +291,33 @@ func realMain() int {
 				TopoProvider: itopo.Provider(),
 			}.New(),
 		},
+		keepaliveVerification(cfg.BS.KeepaliveAuth, trust.NewVerifier(trustStore)),
 	))
 
 	segReqHandler := segreq.NewHandler(args)
 	msgr.AddHandler(infra.SegRequest, segReqHandler)
-	msgr.AddHandler(infra.SegReg, handlers.NewSegRegHandler(args))
+	msgr.AddHandler(infra.SegReg, handlers.NewSegRegHandler(args, handlers.RegQuota{
+		MaxSegs:  cfg.PS.MaxSegsPerOrigin,
+		MaxRegs:  cfg.PS.MaxRegsPerOrigin,
+		Interval: cfg.PS.RegQuotaInterval.Duration,
+	}))
 	if topo.Core() {
 		// Old down segment sync mechanism
 		msgr.AddHandler(infra.SegSync, handlers.NewSyncHandler(args))
+		msgr.AddHandler(infra.SegChangesIdReq, handlers.NewSegChangesIdHandler(args))
+		msgr.AddHandler(infra.SegChangesReq, handlers.NewSegChangesHandler(args))
+	}
+	if issuing {
+		msgr.AddHandler(infra.ChainIssueRequest, certrenewal.NewHandler(
+			certrenewal.Issuer{
+				IA:          topo.IA(),
+				Provider:    trustStore,
+				KeyRing:     gen.KeyRing,
+				MaxValidity: cfg.Renewal.MaxValidity.Duration,
+			},
+			trustStore,
+			5*time.Second,
+		))
 	}
 	msgr.AddHandler(infra.SignedRev, &chainedHandler{
 		handlers: []infra.Handler{
@@ -330,6 +362,14 @@ func realMain() int {
 		log.Crit("Unable to create SCION packet conn", "err", err)
 		return 1
 	}
+	var staticInfo *beaconing.StaticInfoCfg
+	if cfg.BS.StaticInfo != "" {
+		if staticInfo, err = beaconing.LoadStaticInfoCfg(cfg.BS.StaticInfo); err != nil {
+			log.Crit("Unable to load static info config", "err", err)
+			return 1
+		}
+	}
+	tasksMtx.Lock()
 	tasks = &periodicTasks{
 		args:         args,
 		intfs:        intfs,
@@ -347,8 +387,13 @@ func realMain() int {
 				},
 			},
 		),
+		staticInfo:  staticInfo,
+		issuing:     issuing,
+		trustRouter: trustRouter,
+		signer:      certrenewal.NewRenewableSigner(signer),
 	}
-	msgr.UpdateSigner(signer, []infra.MessageType{infra.Seg, infra.ChainIssueRequest})
+	tasksMtx.Unlock()
+	msgr.UpdateSigner(signer, signedMsgTypes)
 	// TODO(scrye): this breaks Interface Keepalives if it is enabled
 	// msgr.UpdateVerifier(trust.NewVerifier(trustStore))
 
@@ -362,6 +407,13 @@ func realMain() int {
 		return 1
 	}
 	defer tasks.Kill()
+	if cfg.BS.AdminAddress != "" {
+		adminServer := startAdminServer(cfg.BS.AdminAddress, &beaconapi.API{
+			Store:    beaconStore,
+			Triggers: tasks.Triggers(),
+		})
+		defer adminServer.Close()
+	}
 
 	select {
 	case <-fatal.ShutdownChan():
@@ -398,6 +450,12 @@ type periodicTasks struct {
 	topoProvider    topology.Provider
 	allowIsdLoop    bool
 	addressRewriter *messenger.AddressRewriter
+	staticInfo      *beaconing.StaticInfoCfg
+	issuing         bool
+	trustRouter     snet.Router
+	// signer is shared by all tasks. It is updated after the certificate
+	// chain has been renewed.
+	signer *certrenewal.RenewableSigner
 
 	keepalive  *periodic.Runner
 	originator *periodic.Runner
@@ -416,6 +474,10 @@ type periodicTasks struct {
 	cryptosyncer  *periodic.Runner
 	rcCleaner     *periodic.Runner
 
+	// beaconing contains the beaconing tasks indexed by task name. They can
+	// be triggered and their period can be changed at runtime.
+	beaconing map[string][]beaconingTask
+
 	mtx     sync.Mutex
 	running bool
 }
@@ -428,6 +490,7 @@ func (t *periodicTasks) Start() error {
 		return nil
 	}
 	t.running = true
+	t.beaconing = make(map[string][]beaconingTask)
 	topo := t.topoProvider.Get()
 	bs := topo.PublicAddress(addr.SvcBS, cfg.General.ID)
 	if bs == nil {
@@ -458,10 +521,13 @@ func (t *periodicTasks) Start() error {
 		beaconstorage.NewRevocationCleaner(t.store), 5*time.Second, 5*time.Second)
 
 	// t.corePusher = t.startCorePusher()
-	// t.reissuance = t.startReissuance(t.corePusher)
+	if !t.issuing {
+		t.reissuance = t.startReissuance()
+	}
 
 	if itopo.Get().Core() {
-		t.segSyncers, err = segsyncer.StartAll(t.args, t.msgr)
+		t.segSyncers, err = segsyncer.StartAll(t.args, t.msgr,
+			cfg.PS.SegSyncMode == config.SegSyncIncremental)
 		if err != nil {
 			return common.NewBasicError("Unable to start seg syncer", err)
 		}
@@ -482,16 +548,11 @@ func (t *periodicTasks) Start() error {
 }
 
 func (t *periodicTasks) startRevoker() (*periodic.Runner, error) {
-	topo := t.topoProvider.Get()
-	signer, err := t.createSigner(topo.IA())
-	if err != nil {
-		return nil, err
-	}
 	r := ifstate.RevokerConf{
 		Intfs:        t.intfs,
 		Msgr:         t.msgr,
 		RevInserter:  t.store,
-		Signer:       signer,
+		Signer:       t.signer,
 		TopoProvider: t.topoProvider,
 		RevConfig: ifstate.RevConfig{
 			RevTTL:     cfg.BS.RevTTL.Duration,
@@ -510,7 +571,7 @@ func (t *periodicTasks) startKeepaliveSender(a *net.UDPAddr) (*periodic.Runner,
 			MAC:  t.genMac(),
 			Addr: a,
 		},
-		Signer:       infra.NullSigner,
+		Signer:       t.signer,
 		TopoProvider: t.topoProvider,
 	}
 	return periodic.Start(s, cfg.BS.KeepaliveInterval.Duration,
@@ -522,10 +583,6 @@ func (t *periodicTasks) startOriginator(a *net.UDPAddr) (*periodic.Runner, error
 	if !topo.Core() {
 		return nil, nil
 	}
-	signer, err := t.createSigner(topo.IA())
-	if err != nil {
-		return nil, err
-	}
 	s, err := beaconing.OriginatorConf{
 		BeaconSender: &onehop.BeaconSender{
 			Sender: onehop.Sender{
@@ -541,26 +598,25 @@ func (t *periodicTasks) startOriginator(a *net.UDPAddr) (*periodic.Runner, error
 			Intfs:         t.intfs,
 			Mac:           t.genMac(),
 			MTU:           topo.MTU(),
-			Signer:        signer,
+			Signer:        t.signer,
 			GetMaxExpTime: maxExpTimeFactory(t.store, beacon.PropPolicy),
+			StaticInfo:    t.staticInfo,
 		},
 		Period: cfg.BS.OriginationInterval.Duration,
 	}.New()
 	if err != nil {
 		return nil, common.NewBasicError("Unable to start originator", err)
 	}
-	return periodic.Start(s, 500*time.Millisecond,
-		cfg.BS.OriginationInterval.Duration), nil
+	r := periodic.Start(s, 500*time.Millisecond, cfg.BS.OriginationInterval.Duration)
+	t.addBeaconingTask(beaconapi.TaskOrigination, s, r)
+	return r, nil
 }
 
 func (t *periodicTasks) startPropagator(a *net.UDPAddr) (*periodic.Runner, error) {
 	topo := t.topoProvider.Get()
-	signer, err := t.createSigner(topo.IA())
-	if err != nil {
-		return nil, err
-	}
 	p, err := beaconing.PropagatorConf{
 		BeaconProvider: t.store,
+		EgressPolicies: t.store,
 		AllowIsdLoop:   t.allowIsdLoop,
 		Core:           topo.Core(),
 		BeaconSender: &onehop.BeaconSender{
@@ -577,16 +633,18 @@ func (t *periodicTasks) startPropagator(a *net.UDPAddr) (*periodic.Runner, error
 			Intfs:         t.intfs,
 			Mac:           t.genMac(),
 			MTU:           topo.MTU(),
-			Signer:        signer,
+			Signer:        t.signer,
 			GetMaxExpTime: maxExpTimeFactory(t.store, beacon.PropPolicy),
+			StaticInfo:    t.staticInfo,
 		},
 		Period: cfg.BS.PropagationInterval.Duration,
 	}.New()
 	if err != nil {
 		return nil, common.NewBasicError("Unable to start propagator", err)
 	}
-	return periodic.Start(p, 500*time.Millisecond,
-		cfg.BS.PropagationInterval.Duration), nil
+	r := periodic.Start(p, 500*time.Millisecond, cfg.BS.PropagationInterval.Duration)
+	t.addBeaconingTask(beaconapi.TaskPropagation, p, r)
+	return r, nil
 }
 
 func (t *periodicTasks) startSegRegRunners() (segRegRunners, error) {
@@ -614,10 +672,6 @@ func (t *periodicTasks) startSegRegRunners() (segRegRunners, error) {
 func (t *periodicTasks) startRegistrar(topo topology.Topology, segType proto.PathSegType,
 	policyType beacon.PolicyType) (*periodic.Runner, error) {
 
-	signer, err := t.createSigner(topo.IA())
-	if err != nil {
-		return nil, err
-	}
 	r, err := beaconing.RegistrarConf{
 		Msgr:         t.msgr,
 		SegProvider:  t.store,
@@ -629,29 +683,136 @@ func (t *periodicTasks) startRegistrar(topo topology.Topology, segType proto.Pat
 			Intfs:         t.intfs,
 			Mac:           t.genMac(),
 			MTU:           topo.MTU(),
-			Signer:        signer,
+			Signer:        t.signer,
 			GetMaxExpTime: maxExpTimeFactory(t.store, policyType),
+			StaticInfo:    t.staticInfo,
 		},
 	}.New()
 	if err != nil {
 		return nil, common.NewBasicError("unable to start registrar", err, "type", segType)
 	}
-	return periodic.Start(r, 500*time.Millisecond,
-		cfg.BS.RegistrationInterval.Duration), nil
+	runner := periodic.Start(r, 500*time.Millisecond, cfg.BS.RegistrationInterval.Duration)
+	t.addBeaconingTask(beaconapi.TaskRegistration, r, runner)
+	return runner, nil
+}
+
+// controllableTask is a beaconing task that can be forced to run and whose
+// period can be changed at runtime.
+type controllableTask interface {
+	Force()
+	SetPeriod(time.Duration)
+}
+
+// beaconingTask is a running beaconing task.
+type beaconingTask struct {
+	task   controllableTask
+	runner *periodic.Runner
+}
+
+func (t *periodicTasks) addBeaconingTask(name string, task controllableTask,
+	runner *periodic.Runner) {
+
+	t.beaconing[name] = append(t.beaconing[name], beaconingTask{task: task, runner: runner})
+}
+
+// Triggers returns the triggers of the running beaconing tasks. A trigger
+// forces the task to act on its next run and immediately triggers the run. If
+// a task has multiple instances, e.g., the up and down segment registrars, its
+// trigger forces all of them.
+func (t *periodicTasks) Triggers() map[string]beaconapi.Trigger {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	res := make(map[string]beaconapi.Trigger, len(t.beaconing))
+	for name, tasks := range t.beaconing {
+		tasks := tasks
+		res[name] = func() {
+			for _, bt := range tasks {
+				bt.task.Force()
+				bt.runner.TriggerRun()
+			}
+		}
+	}
+	return res
 }
 
-func (t *periodicTasks) createSigner(ia addr.IA) (infra.Signer, error) {
+// Reload applies the beaconing policies and intervals of the configuration
+// to the running tasks. All policies are loaded and validated, and then
+// replaced at once.
+func (t *periodicTasks) Reload(bs config.BSConfig) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if !t.running {
+		return serrors.New("tasks not running")
+	}
+	policies, err := loadPolicyList(t.topoProvider.Get().Core(), bs.Policies)
+	if err != nil {
+		return err
+	}
 	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
 	defer cancelF()
-	gen := trust.SignerGen{
-		IA:       itopo.Get().IA(),
+	if err := t.store.UpdatePolicies(ctx, policies...); err != nil {
+		return common.NewBasicError("Unable to update policies", err)
+	}
+	periods := map[string]time.Duration{
+		beaconapi.TaskOrigination:  bs.OriginationInterval.Duration,
+		beaconapi.TaskPropagation:  bs.PropagationInterval.Duration,
+		beaconapi.TaskRegistration: bs.RegistrationInterval.Duration,
+	}
+	for name, tasks := range t.beaconing {
+		for _, bt := range tasks {
+			bt.task.SetPeriod(periods[name])
+		}
+	}
+	return nil
+}
+
+func (t *periodicTasks) startReissuance() *periodic.Runner {
+	r := &certrenewal.Requester{
+		IA:       t.topoProvider.Get().IA(),
 		Provider: t.trustStore,
 		KeyRing: keyconf.LoadingRing{
 			Dir: filepath.Join(cfg.General.ConfigDir, "keys"),
-			IA:  ia,
+			IA:  t.topoProvider.Get().IA(),
+		},
+		Router:   t.trustRouter,
+		RPC:      t.msgr,
+		Inserter: t.trustStore,
+		LeadTime: cfg.Renewal.LeadTime.Duration,
+		SignerUpdater: &signerUpdater{
+			gen: trust.SignerGen{
+				IA:       t.topoProvider.Get().IA(),
+				Provider: t.trustStore,
+				KeyRing: keyconf.LoadingRing{
+					Dir: filepath.Join(cfg.General.ConfigDir, "keys"),
+					IA:  t.topoProvider.Get().IA(),
+				},
+			},
+			signer: t.signer,
+			msgr:   t.msgr,
 		},
 	}
-	return gen.Signer(ctx)
+	return periodic.Start(r, 10*time.Minute, 10*time.Minute)
+}
+
+// signedMsgTypes are the message types the messenger signs.
+var signedMsgTypes = []infra.MessageType{infra.Seg, infra.ChainIssueRequest}
+
+// signerUpdater regenerates the signer from the latest certificate chain and
+// updates the signer of the tasks and the messenger.
+type signerUpdater struct {
+	gen    trust.SignerGen
+	signer *certrenewal.RenewableSigner
+	msgr   infra.Messenger
+}
+
+func (u *signerUpdater) UpdateSigners(ctx context.Context) error {
+	signer, err := u.gen.Signer(ctx)
+	if err != nil {
+		return serrors.WrapStr("unable to generate signer", err)
+	}
+	u.signer.Update(signer)
+	u.msgr.UpdateSigner(signer, signedMsgTypes)
+	return nil
 }
 
 func (t *periodicTasks) Kill() {
@@ -681,6 +842,20 @@ func (t *periodicTasks) Kill() {
 	log.Info("Stopped periodic tasks.")
 }
 
+// keepaliveVerification returns the keepalive verification for the
+// authentication mode.
+func keepaliveVerification(mode config.KeepaliveAuthMode,
+	verifier infra.Verifier) keepalive.Verification {
+
+	switch mode {
+	case config.KeepaliveAuthWarn:
+		return keepalive.Verification{Verifier: verifier}
+	case config.KeepaliveAuthEnforce:
+		return keepalive.Verification{Verifier: verifier, Enforce: true}
+	}
+	return keepalive.Verification{}
+}
+
 func macGenFactory() (func() hash.Hash, error) {
 	mk, err := keyconf.LoadMaster(filepath.Join(cfg.General.ConfigDir, "keys"))
 	if err != nil {
@@ -730,6 +905,34 @@ func setup() error {
 	return initTopo(topo)
 }
 
+// reloadConfig reloads the configuration file and applies the beaconing
+// policies and intervals to the running tasks. Changes to any other
+// configuration value require a restart.
+func reloadConfig() {
+	tasksMtx.Lock()
+	t := tasks
+	tasksMtx.Unlock()
+	if t == nil {
+		log.Info("Ignoring config reload, tasks not started")
+		return
+	}
+	var newCfg config.Config
+	if _, err := toml.DecodeFile(env.ConfigFile(), &newCfg); err != nil {
+		log.Error("Unable to reload config", "err", err, "file", env.ConfigFile())
+		return
+	}
+	newCfg.InitDefaults()
+	if err := newCfg.BS.Validate(); err != nil {
+		log.Error("Unable to validate reloaded config", "err", err)
+		return
+	}
+	if err := t.Reload(newCfg.BS); err != nil {
+		log.Error("Unable to apply reloaded config", "err", err)
+		return
+	}
+	log.Info("Reloaded beaconing policies and intervals")
+}
+
 func handleTopoUpdate() {
 	if intfs == nil {
 		return
@@ -741,7 +944,7 @@ func initTopo(topo topology.Topology) error {
 	if err := itopo.Update(topo); err != nil {
 		return serrors.WrapStr("Unable to set initial static topology", err)
 	}
-	infraenv.InitInfraEnvironment(cfg.General.Topology())
+	infraenv.InitInfraEnvironmentFunc(cfg.General.Topology(), reloadConfig)
 	return nil
 }
 
@@ -760,6 +963,31 @@ func loadStore(core bool, ia addr.IA, cfg config.Config) (beaconstorage.Store, e
 	return cfg.BeaconDB.NewStore(ia, policies)
 }
 
+// loadPolicyList loads and validates all policies that apply to a core or
+// non-core AS.
+func loadPolicyList(core bool, cfg config.Policies) ([]beacon.Policy, error) {
+	if core {
+		policies, err := loadCorePolicies(cfg)
+		if err != nil {
+			return nil, err
+		}
+		policies.InitDefaults()
+		if err := policies.Validate(); err != nil {
+			return nil, err
+		}
+		return []beacon.Policy{policies.Prop, policies.CoreReg}, nil
+	}
+	policies, err := loadPolicies(cfg)
+	if err != nil {
+		return nil, err
+	}
+	policies.InitDefaults()
+	if err := policies.Validate(); err != nil {
+		return nil, err
+	}
+	return []beacon.Policy{policies.Prop, policies.UpReg, policies.DownReg}, nil
+}
+
 func loadCorePolicies(cfg config.Policies) (beacon.CorePolicies, error) {
 	var err error
 	var policies beacon.CorePolicies
@@ -842,6 +1070,24 @@ func (h *chainedHandler) Handle(r *infra.Request) *infra.HandlerResult {
 	}
 }
 
+// startAdminServer serves the beacon store admin API on a dedicated listener.
+// The API allows triggering the beaconing tasks, thus it is not exposed on the
+// unauthenticated metrics and status pages.
+func startAdminServer(address string, api *beaconapi.API) *http.Server {
+	mux := http.NewServeMux()
+	api.Register(mux)
+	server := &http.Server{Addr: address, Handler: mux}
+	log.Info("Serving beacon admin API", "addr", address)
+	go func() {
+		defer log.HandlePanic()
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			fatal.Fatal(common.NewBasicError("Admin API ListenAndServe error", err,
+				"address", address))
+		}
+	}()
+	return server
+}
+
 func configHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/plain")
 	var buf bytes.Buffer
@@ -58,6 +58,8 @@ const (
 	ErrNotClassified = prom.ErrNotClassified
 	// ErrNoPath indicates no path is available to send a message.
 	ErrNoPath = "err_nopath"
+	// ErrQuota indicates that a quota was exceeded.
+	ErrQuota = "err_quota"
 
 	// OkFiltered indicates beacon was filtered by policy.
 	OkFiltered = "ok_filtered"
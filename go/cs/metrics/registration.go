@@ -27,7 +27,7 @@ import (
 
 // regResults lists all possible results for registrations.
 var regResults = []string{OkRegistrationNew, OkRegiststrationUpdated, ErrParse, ErrInternal,
-	ErrCrypto, ErrDB, ErrInternal, ErrTimeout}
+	ErrCrypto, ErrDB, ErrInternal, ErrTimeout, ErrQuota}
 
 // RegistrationLabels contains the label values for registration metrics.
 type RegistrationLabels struct {
@@ -46,11 +46,30 @@ func (l RegistrationLabels) Values() []string {
 	return []string{l.Result, l.Type.String(), l.Src.String()}
 }
 
+// QuotaLabels contains the label values for registration quota metrics.
+type QuotaLabels struct {
+	Src addr.IA
+}
+
+// Labels returns the labels.
+func (l QuotaLabels) Labels() []string {
+	return []string{"src"}
+}
+
+// Values returns the values.
+func (l QuotaLabels) Values() []string {
+	return []string{l.Src.String()}
+}
+
 // Registration contains metrics for segments registrations. The metrics are the
 // following:
 // ps_registrations_total (total number of registrations)
+// ps_registrations_quota_evicted_total (segments evicted to enforce the quota)
+// ps_registrations_quota_dropped_total (segments dropped because of the quota)
 type Registration struct {
-	regsTotal *prometheus.CounterVec
+	regsTotal    *prometheus.CounterVec
+	quotaEvicted *prometheus.CounterVec
+	quotaDropped *prometheus.CounterVec
 }
 
 func newRegistration() Registration {
@@ -59,6 +78,14 @@ func newRegistration() Registration {
 			fmt.Sprintf("Number of path registrations. \"result\" can be one of: [%s]",
 				strings.Join(regResults, ",")),
 			RegistrationLabels{}),
+		quotaEvicted: prom.NewCounterVecWithLabels(PSNamespace, "",
+			"registrations_quota_evicted_total",
+			"Number of stored segments evicted to enforce the per-origin quota.",
+			QuotaLabels{}),
+		quotaDropped: prom.NewCounterVecWithLabels(PSNamespace, "",
+			"registrations_quota_dropped_total",
+			"Number of registered segments dropped because of the per-origin quota.",
+			QuotaLabels{}),
 	}
 }
 
@@ -66,3 +93,13 @@ func newRegistration() Registration {
 func (r Registration) ResultsTotal(l RegistrationLabels) prometheus.Counter {
 	return r.regsTotal.WithLabelValues(l.Values()...)
 }
+
+// QuotaEvicted returns the counter for segments evicted to enforce the quota.
+func (r Registration) QuotaEvicted(l QuotaLabels) prometheus.Counter {
+	return r.quotaEvicted.WithLabelValues(l.Values()...)
+}
+
+// QuotaDropped returns the counter for segments dropped because of the quota.
+func (r Registration) QuotaDropped(l QuotaLabels) prometheus.Counter {
+	return r.quotaDropped.WithLabelValues(l.Values()...)
+}
@@ -23,4 +23,5 @@ import (
 
 func TestRegistrationsLabels(t *testing.T) {
 	promtest.CheckLabelsStruct(t, metrics.RegistrationLabels{})
+	promtest.CheckLabelsStruct(t, metrics.QuotaLabels{})
 }
@@ -65,9 +65,34 @@ func (l SyncPushLabels) WithResult(result string) SyncPushLabels {
 	return l
 }
 
+// SyncPullLabels contains the label values for incremental synchronization
+// pulls.
+type SyncPullLabels struct {
+	Result string
+	Src    addr.IA
+}
+
+// Labels returns the labels.
+func (l SyncPullLabels) Labels() []string {
+	return []string{prom.LabelResult, prom.LabelSrc}
+}
+
+// Values returns the values.
+func (l SyncPullLabels) Values() []string {
+	return []string{l.Result, l.Src.String()}
+}
+
+// WithResult return the labels with a changed result.
+func (l SyncPullLabels) WithResult(result string) SyncPullLabels {
+	l.Result = result
+	return l
+}
+
 type sync struct {
 	registrations *prometheus.CounterVec
 	pushes        *prometheus.CounterVec
+	pulls         *prometheus.CounterVec
+	pulledSegs    *prometheus.CounterVec
 }
 
 func newSync() sync {
@@ -78,6 +103,10 @@ func newSync() sync {
 			SyncRegLabels{}),
 		pushes: prom.NewCounterVecWithLabels(PSNamespace, subsystem, "pushes_total",
 			"Number of pushes towards a destination", SyncPushLabels{}),
+		pulls: prom.NewCounterVecWithLabels(PSNamespace, subsystem, "pulls_total",
+			"Number of incremental synchronization rounds with a source", SyncPullLabels{}),
+		pulledSegs: prom.NewCounterVecWithLabels(PSNamespace, subsystem, "pulled_segs_total",
+			"Number of segments fetched in incremental synchronizations", SyncPullLabels{}),
 	}
 }
 
@@ -96,3 +125,14 @@ func (s sync) RegistrationSuccess(l SyncRegLabels, inserted, updated int) {
 func (s sync) Pushes(l SyncPushLabels) prometheus.Counter {
 	return s.pushes.WithLabelValues(l.Values()...)
 }
+
+// Pulls returns the counter for incremental synchronization rounds.
+func (s sync) Pulls(l SyncPullLabels) prometheus.Counter {
+	return s.pulls.WithLabelValues(l.Values()...)
+}
+
+// PulledSegs returns the counter for segments fetched in incremental
+// synchronizations.
+func (s sync) PulledSegs(l SyncPullLabels) prometheus.Counter {
+	return s.pulledSegs.WithLabelValues(l.Values()...)
+}
@@ -24,4 +24,5 @@ import (
 func TestSyncLabels(t *testing.T) {
 	promtest.CheckLabelsStruct(t, metrics.SyncRegLabels{})
 	promtest.CheckLabelsStruct(t, metrics.SyncPushLabels{})
+	promtest.CheckLabelsStruct(t, metrics.SyncPullLabels{})
 }
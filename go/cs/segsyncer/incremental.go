@@ -0,0 +1,236 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segsyncer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/cs/handlers"
+	"github.com/scionproto/scion/go/cs/metrics"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/messenger"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// fullSyncInterval is the interval in which all segment IDs are requested
+	// from the remote, instead of only the ones that changed since the last
+	// check. This recovers from lost updates and clock skew.
+	fullSyncInterval = 5 * time.Minute
+	// lastCheckMargin is subtracted from the start of the previous round to
+	// account for clock skew between the core ASes.
+	lastCheckMargin = 10 * time.Second
+)
+
+// SegChangesRPC is used to fetch segment changes from a remote core AS.
+type SegChangesRPC interface {
+	GetSegChangesIds(ctx context.Context, msg *path_mgmt.SegChangesIdReq,
+		a net.Addr, id uint64) (*path_mgmt.SegChangesIdReply, error)
+	GetSegChanges(ctx context.Context, msg *path_mgmt.SegChangesReq,
+		a net.Addr, id uint64) (*path_mgmt.SegChangesReply, error)
+}
+
+var _ periodic.Task = (*IncrementalSyncer)(nil)
+
+// IncrementalSyncer synchronizes the down segments of a remote core AS using
+// the seg changes protocol. In every round, the syncer requests the IDs of the
+// segments that changed since the last round, and only fetches the segments
+// that are missing or differ in the local path DB.
+type IncrementalSyncer struct {
+	coreRemote
+	rpc       SegChangesRPC
+	handler   seghandler.Handler
+	lastCheck time.Time
+	lastFull  time.Time
+}
+
+func newIncrementalSyncer(remote coreRemote, rpc SegChangesRPC,
+	verifierFactory infra.VerificationFactory) *IncrementalSyncer {
+
+	return &IncrementalSyncer{
+		coreRemote: remote,
+		rpc:        rpc,
+		handler: seghandler.Handler{
+			Verifier: &seghandler.DefaultVerifier{
+				Verifier: verifierFactory.NewVerifier(),
+			},
+			Storage: &seghandler.DefaultStorage{
+				PathDB:   remote.pathDB,
+				RevCache: remote.revCache,
+			},
+		},
+	}
+}
+
+func (s *IncrementalSyncer) Name() string {
+	return fmt.Sprintf("ps_segsyncer_incrementalSyncer_%s", s.dstIA)
+}
+
+func (s *IncrementalSyncer) Run(ctx context.Context) {
+	labels := metrics.SyncPullLabels{
+		Result: metrics.ErrInternal,
+		Src:    s.dstIA,
+	}
+	logger := log.FromCtx(ctx)
+	cPs, err := s.getDstAddr(ctx)
+	if err != nil {
+		logger.Error("[segsyncer.IncrementalSyncer] Failed to find path to remote",
+			"dstIA", s.dstIA, "err", err)
+		metrics.Sync.Pulls(labels.WithResult(errToMetricsLabel(err))).Inc()
+		return
+	}
+	start := time.Now()
+	full := start.Sub(s.lastFull) > fullSyncInterval
+	cnt, err := s.runInternal(ctx, cPs, full)
+	if err != nil {
+		logger.Error("[segsyncer.IncrementalSyncer] Failed to sync segments",
+			"dstIA", s.dstIA, "err", err)
+		metrics.Sync.Pulls(labels.WithResult(errToMetricsLabel(err))).Inc()
+		return
+	}
+	s.lastCheck = start.Add(-lastCheckMargin)
+	if full {
+		s.lastFull = start
+	}
+	if cnt > 0 {
+		logger.Debug("[segsyncer.IncrementalSyncer] Fetched down segments",
+			"dstIA", s.dstIA, "cnt", cnt)
+	}
+	metrics.Sync.PulledSegs(labels.WithResult(metrics.OkSuccess)).Add(float64(cnt))
+	metrics.Sync.Pulls(labels.WithResult(metrics.OkSuccess)).Inc()
+}
+
+func (s *IncrementalSyncer) runInternal(ctx context.Context, cPs net.Addr,
+	full bool) (int, error) {
+
+	req := &path_mgmt.SegChangesIdReq{}
+	if !full && !s.lastCheck.IsZero() {
+		req.LastCheck = uint32(s.lastCheck.Unix())
+	}
+	fetched := 0
+	// The IDs are paged by the remote, request pages until the cursor is
+	// exhausted. A cursor that does not advance is ignored, it would result
+	// in an endless loop.
+	for {
+		reply, err := s.rpc.GetSegChangesIds(ctx, req, cPs, messenger.NextId())
+		if err != nil {
+			return fetched, serrors.Wrap(errNet, err)
+		}
+		missing, err := s.missing(ctx, reply.Ids)
+		if err != nil {
+			return fetched, err
+		}
+		for len(missing) > 0 {
+			n := len(missing)
+			if n > handlers.MaxSegChanges {
+				n = handlers.MaxSegChanges
+			}
+			cnt, err := s.fetch(ctx, cPs, missing[:n])
+			if err != nil {
+				return fetched, err
+			}
+			fetched += cnt
+			missing = missing[n:]
+		}
+		if reply.Cursor == 0 || reply.Cursor <= req.Cursor {
+			return fetched, nil
+		}
+		req = &path_mgmt.SegChangesIdReq{LastCheck: req.LastCheck, Cursor: reply.Cursor}
+	}
+}
+
+// missing returns the IDs of the segments that are not present in the local
+// path DB, or for which the local version differs from the remote one.
+func (s *IncrementalSyncer) missing(ctx context.Context,
+	ids []*path_mgmt.SegIds) ([]common.RawBytes, error) {
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	segIds := make([]common.RawBytes, 0, len(ids))
+	for _, id := range ids {
+		segIds = append(segIds, id.SegId)
+	}
+	res, err := s.pathDB.Get(ctx, &query.Params{SegIDs: segIds})
+	if err != nil {
+		return nil, serrors.Wrap(errPathDB, err)
+	}
+	local := make(map[string]common.RawBytes, len(res))
+	for _, r := range res {
+		id, err := r.Seg.ID()
+		if err != nil {
+			return nil, err
+		}
+		fullId, err := r.Seg.FullId()
+		if err != nil {
+			return nil, err
+		}
+		local[string(id)] = fullId
+	}
+	var missing []common.RawBytes
+	for _, id := range ids {
+		if fullId, ok := local[string(id.SegId)]; ok && bytes.Equal(fullId, id.FullId) {
+			continue
+		}
+		missing = append(missing, id.SegId)
+	}
+	return missing, nil
+}
+
+func (s *IncrementalSyncer) fetch(ctx context.Context, cPs net.Addr,
+	ids []common.RawBytes) (int, error) {
+
+	reply, err := s.rpc.GetSegChanges(ctx, &path_mgmt.SegChangesReq{SegIds: ids}, cPs,
+		messenger.NextId())
+	if err != nil {
+		return 0, serrors.Wrap(errNet, err)
+	}
+	if reply.SegRecs == nil || len(reply.Recs) == 0 {
+		return 0, nil
+	}
+	labels := metrics.SyncRegLabels{
+		Result: metrics.ErrInternal,
+		Src:    s.dstIA,
+	}
+	segs := seghandler.Segments{
+		Segs:      reply.Recs,
+		SRevInfos: reply.SRevInfos,
+	}
+	res := s.handler.Handle(ctx, segs, cPs, nil)
+	// wait until processing is done.
+	<-res.FullReplyProcessed()
+	if err := res.Err(); err != nil {
+		metrics.Sync.Registrations(labels.WithResult(metrics.ErrDB)).Inc()
+		return 0, serrors.Wrap(errPathDB, err)
+	}
+	if len(res.VerificationErrors()) > 0 {
+		log.FromCtx(ctx).Warn("[segsyncer.IncrementalSyncer] Error during verification of "+
+			"segments/revocations", "errors", res.VerificationErrors().ToError())
+	}
+	metrics.Sync.RegistrationSuccess(labels,
+		res.Stats().SegsInserted(),
+		res.Stats().SegsUpdated())
+	return len(reply.Recs), nil
+}
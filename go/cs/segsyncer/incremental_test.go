@@ -0,0 +1,319 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segsyncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/handlers"
+	"github.com/scionproto/scion/go/cs/metrics"
+	"github.com/scionproto/scion/go/cs/segsyncer/mock_segsyncer"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler/mock_seghandler"
+	"github.com/scionproto/scion/go/lib/infra/modules/segverifier"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathdb/mock_pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/lib/xtest/graph"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestMain(m *testing.M) {
+	metrics.InitPSMetrics()
+	log.Discard()
+	os.Exit(m.Run())
+}
+
+func TestIncrementalSyncerRunInternal(t *testing.T) {
+	cPs := &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 30254}
+	lastCheck := time.Unix(time.Now().Add(-time.Minute).Unix(), 0)
+
+	type mocks struct {
+		rpc     *mock_segsyncer.MockSegChangesRPC
+		pathDB  *mock_pathdb.MockPathDB
+		storage *mock_seghandler.MockStorage
+	}
+	tests := map[string]struct {
+		LastCheck time.Time
+		Full      bool
+		Prepare   func(t *testing.T, m mocks, segA, segB *seg.PathSegment)
+		Fetched   int
+		ErrAssert assert.ErrorAssertionFunc
+	}{
+		"initial sync fetches missing segments": {
+			Prepare: func(t *testing.T, m mocks, segA, segB *seg.PathSegment) {
+				m.rpc.EXPECT().GetSegChangesIds(gomock.Any(), &path_mgmt.SegChangesIdReq{},
+					cPs, gomock.Any()).Return(segChangesIdReply(t, segA, segB), nil)
+				m.pathDB.EXPECT().Get(gomock.Any(), &query.Params{
+					SegIDs: []common.RawBytes{segID(t, segA), segID(t, segB)},
+				}).Return([]*query.Result{{Seg: segA}}, nil)
+				m.rpc.EXPECT().GetSegChanges(gomock.Any(), &path_mgmt.SegChangesReq{
+					SegIds: []common.RawBytes{segID(t, segB)},
+				}, cPs, gomock.Any()).Return(segChangesReply(segB), nil)
+				m.storage.EXPECT().StoreSegs(gomock.Any(), gomock.Any()).Return(
+					seghandler.SegStats{InsertedSegs: []string{segB.GetLoggingID()}}, nil)
+			},
+			Fetched:   1,
+			ErrAssert: assert.NoError,
+		},
+		"delta since last check": {
+			LastCheck: lastCheck,
+			Prepare: func(t *testing.T, m mocks, segA, segB *seg.PathSegment) {
+				m.rpc.EXPECT().GetSegChangesIds(gomock.Any(), &path_mgmt.SegChangesIdReq{
+					LastCheck: uint32(lastCheck.Unix()),
+				}, cPs, gomock.Any()).Return(segChangesIdReply(t, segA), nil)
+				m.pathDB.EXPECT().Get(gomock.Any(), gomock.Any()).Return(
+					[]*query.Result{{Seg: segA}}, nil)
+			},
+			ErrAssert: assert.NoError,
+		},
+		"full sync ignores last check": {
+			LastCheck: lastCheck,
+			Full:      true,
+			Prepare: func(t *testing.T, m mocks, segA, segB *seg.PathSegment) {
+				m.rpc.EXPECT().GetSegChangesIds(gomock.Any(), &path_mgmt.SegChangesIdReq{},
+					cPs, gomock.Any()).Return(&path_mgmt.SegChangesIdReply{}, nil)
+			},
+			ErrAssert: assert.NoError,
+		},
+		"changed segment is fetched": {
+			LastCheck: lastCheck,
+			Prepare: func(t *testing.T, m mocks, segA, segB *seg.PathSegment) {
+				reply := segChangesIdReply(t, segA)
+				reply.Ids[0].FullId = common.RawBytes("changed")
+				m.rpc.EXPECT().GetSegChangesIds(gomock.Any(), gomock.Any(), cPs,
+					gomock.Any()).Return(reply, nil)
+				m.pathDB.EXPECT().Get(gomock.Any(), gomock.Any()).Return(
+					[]*query.Result{{Seg: segA}}, nil)
+				m.rpc.EXPECT().GetSegChanges(gomock.Any(), &path_mgmt.SegChangesReq{
+					SegIds: []common.RawBytes{segID(t, segA)},
+				}, cPs, gomock.Any()).Return(segChangesReply(segA), nil)
+				m.storage.EXPECT().StoreSegs(gomock.Any(), gomock.Any()).Return(
+					seghandler.SegStats{UpdatedSegs: []string{segA.GetLoggingID()}}, nil)
+			},
+			Fetched:   1,
+			ErrAssert: assert.NoError,
+		},
+		"missing segments are fetched in batches": {
+			Prepare: func(t *testing.T, m mocks, segA, segB *seg.PathSegment) {
+				reply := &path_mgmt.SegChangesIdReply{}
+				for i := 0; i < handlers.MaxSegChanges+1; i++ {
+					reply.Ids = append(reply.Ids, &path_mgmt.SegIds{
+						SegId:  common.RawBytes{byte(i)},
+						FullId: common.RawBytes{byte(i)},
+					})
+				}
+				m.rpc.EXPECT().GetSegChangesIds(gomock.Any(), gomock.Any(), cPs,
+					gomock.Any()).Return(reply, nil)
+				m.pathDB.EXPECT().Get(gomock.Any(), gomock.Any())
+				gomock.InOrder(
+					m.rpc.EXPECT().GetSegChanges(gomock.Any(),
+						segChangesReqLen(handlers.MaxSegChanges), cPs, gomock.Any(),
+					).Return(&path_mgmt.SegChangesReply{}, nil),
+					m.rpc.EXPECT().GetSegChanges(gomock.Any(),
+						segChangesReqLen(1), cPs, gomock.Any(),
+					).Return(&path_mgmt.SegChangesReply{}, nil),
+				)
+			},
+			ErrAssert: assert.NoError,
+		},
+		"ids are requested page by page": {
+			LastCheck: lastCheck,
+			Prepare: func(t *testing.T, m mocks, segA, segB *seg.PathSegment) {
+				first := segChangesIdReply(t, segA)
+				first.Cursor = 5
+				gomock.InOrder(
+					m.rpc.EXPECT().GetSegChangesIds(gomock.Any(), &path_mgmt.SegChangesIdReq{
+						LastCheck: uint32(lastCheck.Unix()),
+					}, cPs, gomock.Any()).Return(first, nil),
+					m.rpc.EXPECT().GetSegChangesIds(gomock.Any(), &path_mgmt.SegChangesIdReq{
+						LastCheck: uint32(lastCheck.Unix()),
+						Cursor:    5,
+					}, cPs, gomock.Any()).Return(segChangesIdReply(t, segB), nil),
+				)
+				gomock.InOrder(
+					m.pathDB.EXPECT().Get(gomock.Any(), gomock.Any()).Return(
+						[]*query.Result{{Seg: segA}}, nil),
+					m.pathDB.EXPECT().Get(gomock.Any(), gomock.Any()),
+				)
+				m.rpc.EXPECT().GetSegChanges(gomock.Any(), &path_mgmt.SegChangesReq{
+					SegIds: []common.RawBytes{segID(t, segB)},
+				}, cPs, gomock.Any()).Return(segChangesReply(segB), nil)
+				m.storage.EXPECT().StoreSegs(gomock.Any(), gomock.Any()).Return(
+					seghandler.SegStats{InsertedSegs: []string{segB.GetLoggingID()}}, nil)
+			},
+			Fetched:   1,
+			ErrAssert: assert.NoError,
+		},
+		"cursor that does not advance is ignored": {
+			Prepare: func(t *testing.T, m mocks, segA, segB *seg.PathSegment) {
+				first := segChangesIdReply(t, segA)
+				first.Cursor = 5
+				gomock.InOrder(
+					m.rpc.EXPECT().GetSegChangesIds(gomock.Any(), &path_mgmt.SegChangesIdReq{},
+						cPs, gomock.Any()).Return(first, nil),
+					m.rpc.EXPECT().GetSegChangesIds(gomock.Any(),
+						&path_mgmt.SegChangesIdReq{Cursor: 5}, cPs, gomock.Any(),
+					).Return(first, nil),
+				)
+				m.pathDB.EXPECT().Get(gomock.Any(), gomock.Any()).Return(
+					[]*query.Result{{Seg: segA}}, nil).Times(2)
+			},
+			ErrAssert: assert.NoError,
+		},
+		"ids request fails": {
+			Prepare: func(t *testing.T, m mocks, segA, segB *seg.PathSegment) {
+				m.rpc.EXPECT().GetSegChangesIds(gomock.Any(), gomock.Any(), cPs,
+					gomock.Any()).Return(nil, serrors.New("test error"))
+			},
+			ErrAssert: errIs(errNet),
+		},
+		"path db fails": {
+			Prepare: func(t *testing.T, m mocks, segA, segB *seg.PathSegment) {
+				m.rpc.EXPECT().GetSegChangesIds(gomock.Any(), gomock.Any(), cPs,
+					gomock.Any()).Return(segChangesIdReply(t, segA), nil)
+				m.pathDB.EXPECT().Get(gomock.Any(), gomock.Any()).Return(
+					nil, serrors.New("test error"))
+			},
+			ErrAssert: errIs(errPathDB),
+		},
+		"segments request fails": {
+			Prepare: func(t *testing.T, m mocks, segA, segB *seg.PathSegment) {
+				m.rpc.EXPECT().GetSegChangesIds(gomock.Any(), gomock.Any(), cPs,
+					gomock.Any()).Return(segChangesIdReply(t, segA), nil)
+				m.pathDB.EXPECT().Get(gomock.Any(), gomock.Any())
+				m.rpc.EXPECT().GetSegChanges(gomock.Any(), gomock.Any(), cPs,
+					gomock.Any()).Return(nil, serrors.New("test error"))
+			},
+			ErrAssert: errIs(errNet),
+		},
+		"storing fails": {
+			Prepare: func(t *testing.T, m mocks, segA, segB *seg.PathSegment) {
+				m.rpc.EXPECT().GetSegChangesIds(gomock.Any(), gomock.Any(), cPs,
+					gomock.Any()).Return(segChangesIdReply(t, segA), nil)
+				m.pathDB.EXPECT().Get(gomock.Any(), gomock.Any())
+				m.rpc.EXPECT().GetSegChanges(gomock.Any(), gomock.Any(), cPs,
+					gomock.Any()).Return(segChangesReply(segA), nil)
+				m.storage.EXPECT().StoreSegs(gomock.Any(), gomock.Any()).Return(
+					seghandler.SegStats{}, serrors.New("test error"))
+			},
+			ErrAssert: errIs(errPathDB),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			g := graph.NewDefaultGraph(ctrl)
+			segA := g.Beacon([]common.IFIDType{graph.If_120_X_111_B})
+			segB := g.Beacon([]common.IFIDType{graph.If_130_A_131_X})
+
+			m := mocks{
+				rpc:     mock_segsyncer.NewMockSegChangesRPC(ctrl),
+				pathDB:  mock_pathdb.NewMockPathDB(ctrl),
+				storage: mock_seghandler.NewMockStorage(ctrl),
+			}
+			test.Prepare(t, m, segA, segB)
+			s := &IncrementalSyncer{
+				coreRemote: coreRemote{
+					pathDB: m.pathDB,
+					dstIA:  xtest.MustParseIA("1-ff00:0:120"),
+				},
+				rpc: m.rpc,
+				handler: seghandler.Handler{
+					Verifier: acceptAllVerifier{},
+					Storage:  m.storage,
+				},
+				lastCheck: test.LastCheck,
+			}
+			fetched, err := s.runInternal(context.Background(), cPs, test.Full)
+			test.ErrAssert(t, err)
+			assert.Equal(t, test.Fetched, fetched)
+		})
+	}
+}
+
+// acceptAllVerifier accepts all segments and revocations.
+type acceptAllVerifier struct{}
+
+func (acceptAllVerifier) Verify(_ context.Context, recs seghandler.Segments,
+	_ net.Addr) (chan segverifier.UnitResult, int) {
+
+	ch := make(chan segverifier.UnitResult, len(recs.Segs))
+	for _, s := range recs.Segs {
+		ch <- segverifier.UnitResult{
+			Unit:   &segverifier.Unit{SegMeta: s},
+			Errors: make(map[int]error),
+		}
+	}
+	return ch, len(recs.Segs)
+}
+
+func segID(t *testing.T, s *seg.PathSegment) common.RawBytes {
+	id, err := s.ID()
+	require.NoError(t, err)
+	return id
+}
+
+func segChangesIdReply(t *testing.T, segs ...*seg.PathSegment) *path_mgmt.SegChangesIdReply {
+	reply := &path_mgmt.SegChangesIdReply{}
+	for _, s := range segs {
+		fullId, err := s.FullId()
+		require.NoError(t, err)
+		reply.Ids = append(reply.Ids, &path_mgmt.SegIds{SegId: segID(t, s), FullId: fullId})
+	}
+	return reply
+}
+
+func segChangesReply(segs ...*seg.PathSegment) *path_mgmt.SegChangesReply {
+	reply := &path_mgmt.SegChangesReply{SegRecs: &path_mgmt.SegRecs{}}
+	for _, s := range segs {
+		reply.Recs = append(reply.Recs, seg.NewMeta(s, proto.PathSegType_down))
+	}
+	return reply
+}
+
+func errIs(target error) assert.ErrorAssertionFunc {
+	return func(t assert.TestingT, err error, _ ...interface{}) bool {
+		return assert.True(t, errors.Is(err, target), "expected %v, got %v", target, err)
+	}
+}
+
+// segChangesReqLen matches seg changes requests with n requested segments.
+type segChangesReqLen int
+
+func (n segChangesReqLen) Matches(x interface{}) bool {
+	req, ok := x.(*path_mgmt.SegChangesReq)
+	return ok && len(req.SegIds) == int(n)
+}
+
+func (n segChangesReqLen) String() string {
+	return fmt.Sprintf("seg changes request with %d segments", int(n))
+}
@@ -51,17 +51,19 @@ var (
 )
 
 type SegSyncer struct {
+	coreRemote
 	latestUpdate *time.Time
-	pathDB       pathdb.PathDB
-	revCache     revcache.RevCache
 	msger        infra.Messenger
-	dstIA        addr.IA
-	localIA      addr.IA
-	topoProvider topology.Provider
 	repErrCnt    int
 }
 
-func StartAll(args handlers.HandlerArgs, msger infra.Messenger) ([]*periodic.Runner, error) {
+// StartAll starts a segment syncer for every other core AS in the local ISD.
+// If incremental is set, the down segments are synchronized with the
+// incremental seg changes protocol. Otherwise, all down segments are pushed to
+// the remote core ASes.
+func StartAll(args handlers.HandlerArgs, msger infra.Messenger,
+	incremental bool) ([]*periodic.Runner, error) {
+
 	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
 	defer cancelF()
 	primaryArgs := infra.ASInspectorOpts{
@@ -77,14 +79,20 @@ func StartAll(args handlers.HandlerArgs, msger infra.Messenger) ([]*periodic.Run
 		if coreAS.Equal(args.IA) {
 			continue
 		}
-		syncer := &SegSyncer{
+		remote := coreRemote{
 			pathDB:       args.PathDB,
 			revCache:     args.RevCache,
-			msger:        msger,
 			dstIA:        coreAS,
 			localIA:      args.IA,
 			topoProvider: args.TopoProvider,
 		}
+		var syncer periodic.Task = &SegSyncer{
+			coreRemote: remote,
+			msger:      msger,
+		}
+		if incremental {
+			syncer = newIncrementalSyncer(remote, msger, args.VerifierFactory)
+		}
 		// TODO(lukedirtwalker): either log or add metric to indicate
 		// if task takes longer than ticker often.
 		segSyncers = append(segSyncers,
@@ -128,7 +136,16 @@ func (s *SegSyncer) Run(ctx context.Context) {
 	s.repErrCnt = 0
 }
 
-func (s *SegSyncer) getDstAddr(ctx context.Context) (net.Addr, error) {
+// coreRemote holds the information required to reach a remote core AS.
+type coreRemote struct {
+	pathDB       pathdb.PathDB
+	revCache     revcache.RevCache
+	dstIA        addr.IA
+	localIA      addr.IA
+	topoProvider topology.Provider
+}
+
+func (s *coreRemote) getDstAddr(ctx context.Context) (net.Addr, error) {
 	coreSegs, err := s.fetchCoreSegsFromDB(ctx)
 	if err != nil {
 		return nil, serrors.WrapStr("Failed to get core segs", err)
@@ -147,7 +164,7 @@ func (s *SegSyncer) getDstAddr(ctx context.Context) (net.Addr, error) {
 	return nil, err
 }
 
-func (s *SegSyncer) fetchCoreSegsFromDB(ctx context.Context) ([]*seg.PathSegment, error) {
+func (s *coreRemote) fetchCoreSegsFromDB(ctx context.Context) ([]*seg.PathSegment, error) {
 	params := &query.Params{
 		SegTypes: []proto.PathSegType{proto.PathSegType_core},
 		StartsAt: []addr.IA{s.dstIA},
@@ -165,6 +165,10 @@ func (p path) Expiry() time.Time {
 	return p.expiry
 }
 
+func (p path) Metadata() *snet.PathMetadata {
+	return nil
+}
+
 func (p path) Copy() snet.Path {
 	return path{
 		interfaces: append(p.interfaces[:0:0], p.interfaces...),
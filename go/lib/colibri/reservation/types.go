@@ -15,6 +15,9 @@
 package reservation
 
 import (
+	"crypto/subtle"
+	"hash"
+	"math"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -109,6 +112,14 @@ func (b BWCls) Validate() error {
 	return nil
 }
 
+// ToKbps returns the bandwidth in kbps that corresponds to the bandwidth class.
+func (b BWCls) ToKbps() uint64 {
+	if b == 0 {
+		return 0
+	}
+	return uint64(16 * math.Sqrt(math.Pow(2, float64(b-1))))
+}
+
 // SplitCls is the traffic split parameter. split = sqrt(2^c). The split divides the bandwidth
 // in control traffic (BW * split) and end to end traffic (BW * (1-s)). 0 <= splitCls <= 256 .
 type SplitCls uint8
@@ -236,3 +247,83 @@ func (f *InfoField) Read(b []byte) (int, error) {
 	b[7] = 0 // b[7] is padding
 	return 8, nil
 }
+
+// HopField is the per-AS part of a COLIBRI reservation that is carried in the
+// packets using the reservation.
+// 0B       1        2        3        4        5        6        7
+// +--------+--------+--------+--------+--------+--------+--------+--------+
+// | Ingress IF      | Egress IF       | MAC (4B)                          |
+// +--------+--------+--------+--------+--------+--------+--------+--------+
+//
+// The interfaces are in the direction of travel. The MAC authenticates the
+// interfaces together with the reservation ID and the InfoField.
+type HopField struct {
+	Ingress common.IFIDType
+	Egress  common.IFIDType
+	Mac     [HopFieldMacLen]byte
+}
+
+const (
+	// HopFieldLen is the length in bytes of the HopField.
+	HopFieldLen = 8
+	// HopFieldMacLen is the length in bytes of the MAC of a HopField.
+	HopFieldMacLen = 4
+)
+
+// HopFieldFromRaw builds a HopField from the HopFieldLen bytes buffer.
+func HopFieldFromRaw(raw []byte) (*HopField, error) {
+	if len(raw) < HopFieldLen {
+		return nil, serrors.New("Buffer too small", "min size", HopFieldLen,
+			"current size", len(raw))
+	}
+	hf := HopField{
+		Ingress: common.IFIDType(common.Order.Uint16(raw[0:2])),
+		Egress:  common.IFIDType(common.Order.Uint16(raw[2:4])),
+	}
+	copy(hf.Mac[:], raw[4:8])
+	return &hf, nil
+}
+
+// Read serializes this HopField into an array of HopFieldLen bytes.
+func (hf *HopField) Read(b []byte) (int, error) {
+	if len(b) < HopFieldLen {
+		return 0, serrors.New("Buffer too short", "size", len(b))
+	}
+	common.Order.PutUint16(b[0:2], uint16(hf.Ingress))
+	common.Order.PutUint16(b[2:4], uint16(hf.Egress))
+	copy(b[4:8], hf.Mac[:])
+	return HopFieldLen, nil
+}
+
+// CalcMac computes the MAC of the hop field for the reservation with the given
+// raw ID and InfoField. The hash is reset before use.
+func (hf *HopField) CalcMac(h hash.Hash, id []byte, info *InfoField) ([]byte, error) {
+	input := make([]byte, InfoFieldLen+len(id)+4)
+	if _, err := info.Read(input); err != nil {
+		return nil, err
+	}
+	copy(input[InfoFieldLen:], id)
+	common.Order.PutUint16(input[InfoFieldLen+len(id):], uint16(hf.Ingress))
+	common.Order.PutUint16(input[InfoFieldLen+len(id)+2:], uint16(hf.Egress))
+	h.Reset()
+	if _, err := h.Write(input); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil)[:HopFieldMacLen], nil
+}
+
+// VerifyMac checks that the MAC of the hop field is valid for the reservation
+// with the given raw ID and InfoField.
+func (hf *HopField) VerifyMac(h hash.Hash, id []byte, info *InfoField) error {
+	mac, err := hf.CalcMac(h, id, info)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(mac, hf.Mac[:]) != 1 {
+		return ErrBadMac
+	}
+	return nil
+}
+
+// ErrBadMac indicates that the MAC of a HopField is invalid.
+var ErrBadMac = serrors.New("bad hop field MAC")
@@ -16,6 +16,8 @@ package reservation
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/hex"
 	"testing"
 	"time"
@@ -256,3 +258,56 @@ func TestInfoFieldRead(t *testing.T) {
 			hex.EncodeToString(raw), hex.EncodeToString(rawReference))
 	}
 }
+
+func TestBWClsToKbps(t *testing.T) {
+	cases := map[BWCls]uint64{0: 0, 1: 16, 3: 32, 5: 64, 11: 512}
+	for cls, kbps := range cases {
+		if got := cls.ToKbps(); got != kbps {
+			t.Fatalf("Bad bandwidth for class %d: %d != %d", cls, got, kbps)
+		}
+	}
+}
+
+func TestHopFieldRead(t *testing.T) {
+	hf := HopField{Ingress: 1, Egress: 0x102}
+	copy(hf.Mac[:], xtest.MustParseHexString("deadbeef"))
+	raw := make([]byte, HopFieldLen)
+	n, err := hf.Read(raw)
+	if err != nil {
+		t.Fatalf("Unexpect error: %v", err)
+	}
+	if n != HopFieldLen {
+		t.Fatalf("Unexpected read size %d. Expected %d", n, HopFieldLen)
+	}
+	rawReference := xtest.MustParseHexString("00010102deadbeef")
+	if bytes.Compare(raw, rawReference) != 0 {
+		t.Fatalf("Fail to serialize HopField. %v != %v",
+			hex.EncodeToString(raw), hex.EncodeToString(rawReference))
+	}
+	parsed, err := HopFieldFromRaw(raw)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if *parsed != hf {
+		t.Fatalf("Bad HopField %v != %v", *parsed, hf)
+	}
+}
+
+func TestHopFieldMac(t *testing.T) {
+	h := hmac.New(sha256.New, []byte("secret"))
+	id := xtest.MustParseHexString("ffaa00001101facecafe000000000000")
+	hf := HopField{Ingress: 1, Egress: 2}
+	mac, err := hf.CalcMac(h, id, &reference)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	copy(hf.Mac[:], mac)
+	if err := hf.VerifyMac(h, id, &reference); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Changing the interfaces invalidates the MAC.
+	hf.Egress = 3
+	if err := hf.VerifyMac(h, id, &reference); err != ErrBadMac {
+		t.Fatalf("Expected bad MAC, got %v", err)
+	}
+}
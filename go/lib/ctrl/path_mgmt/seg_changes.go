@@ -25,6 +25,8 @@ var _ proto.Cerealizable = (*SegChangesIdReq)(nil)
 
 type SegChangesIdReq struct {
 	LastCheck uint32
+	// Cursor is the cursor of the previous reply, 0 to request the first page.
+	Cursor uint64
 }
 
 func (s *SegChangesIdReq) ProtoId() proto.ProtoIdType {
@@ -32,7 +34,7 @@ func (s *SegChangesIdReq) ProtoId() proto.ProtoIdType {
 }
 
 func (s *SegChangesIdReq) String() string {
-	return fmt.Sprintf("LastCheck: %d", s.LastCheck)
+	return fmt.Sprintf("LastCheck: %d Cursor: %d", s.LastCheck, s.Cursor)
 }
 
 type SegIds struct {
@@ -44,6 +46,9 @@ var _ proto.Cerealizable = (*SegChangesIdReply)(nil)
 
 type SegChangesIdReply struct {
 	Ids []*SegIds
+	// Cursor is set if more IDs are available. It is used in the next request
+	// to fetch the next page.
+	Cursor uint64
 }
 
 func (s *SegChangesIdReply) ProtoId() proto.ProtoIdType {
@@ -51,7 +56,7 @@ func (s *SegChangesIdReply) ProtoId() proto.ProtoIdType {
 }
 
 func (s *SegChangesIdReply) String() string {
-	return fmt.Sprintf("Ids: %v", s.Ids)
+	return fmt.Sprintf("Ids: %v Cursor: %d", s.Ids, s.Cursor)
 }
 
 var _ proto.Cerealizable = (*SegChangesReq)(nil)
@@ -0,0 +1,146 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the Go representation of the static info extension. The
+// extension carries operator-configured metadata about the interfaces of an AS
+// that are part of the segment.
+
+package seg
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// LinkType describes the underlying network of an inter-AS link.
+type LinkType uint8
+
+const (
+	// LinkTypeUnset indicates that the link type is not known.
+	LinkTypeUnset LinkType = iota
+	// LinkTypeDirect indicates a direct physical connection.
+	LinkTypeDirect
+	// LinkTypeMultihop indicates a connection with local routing/switching.
+	LinkTypeMultihop
+	// LinkTypeOpennet indicates a connection overlayed over the public
+	// Internet.
+	LinkTypeOpennet
+)
+
+func (t LinkType) String() string {
+	switch t {
+	case LinkTypeUnset:
+		return "unset"
+	case LinkTypeDirect:
+		return "direct"
+	case LinkTypeMultihop:
+		return "multihop"
+	case LinkTypeOpennet:
+		return "opennet"
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", uint8(t))
+}
+
+var _ proto.Cerealizable = (*StaticInfoExtn)(nil)
+
+// StaticInfoExtn is the static info extension of an AS entry. It describes
+// the interfaces of the AS entry and the latency between them.
+type StaticInfoExtn struct {
+	Interfaces     []*StaticInfoInterface
+	IntraLatencies []*StaticInfoLatency
+}
+
+func (e *StaticInfoExtn) ProtoId() proto.ProtoIdType {
+	return proto.StaticInfoExtn_TypeID
+}
+
+// Interface returns the static info of the interface with the given ID, or
+// nil if the extension does not contain it.
+func (e *StaticInfoExtn) Interface(ifid common.IFIDType) *StaticInfoInterface {
+	if e == nil {
+		return nil
+	}
+	for _, intf := range e.Interfaces {
+		if intf.IfID == ifid {
+			return intf
+		}
+	}
+	return nil
+}
+
+// IntraLatency returns the latency between the two interfaces. The second
+// return value indicates whether the latency is known.
+func (e *StaticInfoExtn) IntraLatency(a, b common.IFIDType) (time.Duration, bool) {
+	if e == nil {
+		return 0, false
+	}
+	for _, l := range e.IntraLatencies {
+		if (l.IfID == a && l.OtherIfID == b) || (l.IfID == b && l.OtherIfID == a) {
+			return l.Duration(), true
+		}
+	}
+	return 0, false
+}
+
+func (e *StaticInfoExtn) String() string {
+	if e == nil {
+		return "<nil>"
+	}
+	intfs := make([]string, 0, len(e.Interfaces))
+	for _, intf := range e.Interfaces {
+		intfs = append(intfs, intf.String())
+	}
+	return fmt.Sprintf("Interfaces: [%s] IntraLatencies: %d", strings.Join(intfs, ", "),
+		len(e.IntraLatencies))
+}
+
+// StaticInfoInterface contains the static information of a single interface.
+type StaticInfoInterface struct {
+	IfID common.IFIDType `capnp:"ifID"`
+	// LinkLatency is the latency of the inter-AS link in microseconds.
+	LinkLatency uint32
+	// Bandwidth is the bandwidth of the inter-AS link in Kbit/s.
+	Bandwidth uint64
+	Latitude  float32
+	Longitude float32
+	Address   string
+	LinkType  LinkType
+}
+
+// LinkDuration returns the latency of the inter-AS link.
+func (i *StaticInfoInterface) LinkDuration() time.Duration {
+	return time.Duration(i.LinkLatency) * time.Microsecond
+}
+
+func (i *StaticInfoInterface) String() string {
+	return fmt.Sprintf("IfID: %d Latency: %s Bandwidth: %dKbps Geo: (%f, %f, %q) LinkType: %s",
+		i.IfID, i.LinkDuration(), i.Bandwidth, i.Latitude, i.Longitude, i.Address, i.LinkType)
+}
+
+// StaticInfoLatency is the intra-AS latency between two interfaces.
+type StaticInfoLatency struct {
+	IfID      common.IFIDType `capnp:"ifID"`
+	OtherIfID common.IFIDType `capnp:"otherIfID"`
+	// Latency is the latency in microseconds.
+	Latency uint32
+}
+
+// Duration returns the latency.
+func (l *StaticInfoLatency) Duration() time.Duration {
+	return time.Duration(l.Latency) * time.Microsecond
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/spath"
+)
+
+func TestStaticInfoExtnRoundTrip(t *testing.T) {
+	ase := &ASEntry{
+		RawIA:    as110.IAInt(),
+		MTU:      1500,
+		IfIDSize: 12,
+		HopEntries: []*HopEntry{
+			{
+				RemoteOutIF: 23,
+				RawOutIA:    as111.IAInt(),
+				RawHopField: make(common.RawBytes, spath.HopFieldLength),
+			},
+		},
+	}
+	ase.Exts.StaticInfo = &StaticInfoExtn{
+		Interfaces: []*StaticInfoInterface{
+			{
+				IfID:        1,
+				LinkLatency: 1500,
+				Bandwidth:   1000000,
+				Latitude:    47.3769,
+				Longitude:   8.5417,
+				Address:     "Zurich",
+				LinkType:    LinkTypeDirect,
+			},
+			{IfID: 2, LinkType: LinkTypeOpennet},
+		},
+		IntraLatencies: []*StaticInfoLatency{
+			{IfID: 1, OtherIfID: 2, Latency: 300},
+		},
+	}
+	raw, err := ase.Pack()
+	require.NoError(t, err)
+	parsed, err := NewASEntryFromRaw(raw)
+	require.NoError(t, err)
+	assert.Equal(t, ase.Exts.StaticInfo, parsed.Exts.StaticInfo)
+}
+
+func TestStaticInfoExtnLookup(t *testing.T) {
+	e := &StaticInfoExtn{
+		Interfaces: []*StaticInfoInterface{{IfID: 1}, {IfID: 2}},
+		IntraLatencies: []*StaticInfoLatency{
+			{IfID: 1, OtherIfID: 2, Latency: 300},
+		},
+	}
+	assert.Equal(t, common.IFIDType(2), e.Interface(2).IfID)
+	assert.Nil(t, e.Interface(3))
+	l, ok := e.IntraLatency(2, 1)
+	assert.True(t, ok)
+	assert.Equal(t, 300*time.Microsecond, l)
+	_, ok = e.IntraLatency(1, 3)
+	assert.False(t, ok)
+
+	var nilExtn *StaticInfoExtn
+	assert.Nil(t, nilExtn.Interface(1))
+	_, ok = nilExtn.IntraLatency(1, 2)
+	assert.False(t, ok)
+}
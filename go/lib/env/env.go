@@ -20,6 +20,7 @@
 package env
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -310,3 +311,15 @@ func InfoHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/plain")
 	fmt.Fprintf(w, info)
 }
+
+// WriteJSON writes v as indented JSON to the HTTP response. If v cannot be
+// marshaled, an internal server error is replied.
+func WriteJSON(w http.ResponseWriter, v interface{}) {
+	raw, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, string(raw)+"\n")
+}
@@ -374,6 +374,8 @@ type ResponseWriter interface {
 	SendCertChainReply(ctx context.Context, msg *cert_mgmt.Chain) error
 	SendChainIssueReply(ctx context.Context, msg *cert_mgmt.ChainIssRep) error
 	SendSegReply(ctx context.Context, msg *path_mgmt.SegReply) error
+	SendSegChangesIdReply(ctx context.Context, msg *path_mgmt.SegChangesIdReply) error
+	SendSegChangesReply(ctx context.Context, msg *path_mgmt.SegChangesReply) error
 	SendIfStateInfoReply(ctx context.Context, msg *path_mgmt.IFStateInfos) error
 	SendHPSegReply(ctx context.Context, msg *path_mgmt.HPSegReply) error
 	SendHPCfgReply(ctx context.Context, msg *path_mgmt.HPCfgReply) error
@@ -54,6 +54,18 @@ func (rw *UDPResponseWriter) SendSegReply(ctx context.Context, msg *path_mgmt.Se
 	return rw.Messenger.SendSegReply(ctx, msg, rw.Remote, rw.ID)
 }
 
+func (rw *UDPResponseWriter) SendSegChangesIdReply(ctx context.Context,
+	msg *path_mgmt.SegChangesIdReply) error {
+
+	return rw.Messenger.SendSegChangesIdReply(ctx, msg, rw.Remote, rw.ID)
+}
+
+func (rw *UDPResponseWriter) SendSegChangesReply(ctx context.Context,
+	msg *path_mgmt.SegChangesReply) error {
+
+	return rw.Messenger.SendSegChangesReply(ctx, msg, rw.Remote, rw.ID)
+}
+
 func (rw *UDPResponseWriter) SendIfStateInfoReply(ctx context.Context,
 	msg *path_mgmt.IFStateInfos) error {
 
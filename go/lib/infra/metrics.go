@@ -52,6 +52,9 @@ var (
 	metricsErrRevCache   = &HandlerResult{Result: "err_revcache", Status: prom.StatusErr}
 	metricsErrRevCacheTo = &HandlerResult{Result: "err_revcache_to", Status: prom.StatusTimeout}
 
+	metricsErrPathDB   = &HandlerResult{Result: "err_pathdb", Status: prom.StatusErr}
+	metricsErrPathDBTo = &HandlerResult{Result: "err_pathdb_to", Status: prom.StatusTimeout}
+
 	MetricsResultOk = &HandlerResult{Result: prom.Success, Status: prom.StatusOk}
 )
 
@@ -63,6 +66,10 @@ func MetricsErrRevCache(err error) *HandlerResult {
 	return MetricsErrWithTimeout(err, metricsErrRevCacheTo, metricsErrRevCache)
 }
 
+func MetricsErrPathDB(err error) *HandlerResult {
+	return MetricsErrWithTimeout(err, metricsErrPathDBTo, metricsErrPathDB)
+}
+
 func MetricsErrMsger(err error) *HandlerResult {
 	return MetricsErrWithTimeout(err, metricsErrMsgerTimeout, metricsErrMsger)
 }
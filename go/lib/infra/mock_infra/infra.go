@@ -610,6 +610,34 @@ func (mr *MockResponseWriterMockRecorder) SendIfStateInfoReply(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendIfStateInfoReply", reflect.TypeOf((*MockResponseWriter)(nil).SendIfStateInfoReply), arg0, arg1)
 }
 
+// SendSegChangesIdReply mocks base method
+func (m *MockResponseWriter) SendSegChangesIdReply(arg0 context.Context, arg1 *path_mgmt.SegChangesIdReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendSegChangesIdReply", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendSegChangesIdReply indicates an expected call of SendSegChangesIdReply
+func (mr *MockResponseWriterMockRecorder) SendSegChangesIdReply(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendSegChangesIdReply", reflect.TypeOf((*MockResponseWriter)(nil).SendSegChangesIdReply), arg0, arg1)
+}
+
+// SendSegChangesReply mocks base method
+func (m *MockResponseWriter) SendSegChangesReply(arg0 context.Context, arg1 *path_mgmt.SegChangesReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendSegChangesReply", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendSegChangesReply indicates an expected call of SendSegChangesReply
+func (mr *MockResponseWriterMockRecorder) SendSegChangesReply(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendSegChangesReply", reflect.TypeOf((*MockResponseWriter)(nil).SendSegChangesReply), arg0, arg1)
+}
+
 // SendSegReply mocks base method
 func (m *MockResponseWriter) SendSegReply(arg0 context.Context, arg1 *path_mgmt.SegReply) error {
 	m.ctrl.T.Helper()
@@ -71,6 +71,9 @@ type Path struct {
 	Weight     int
 	Mtu        uint16
 	Interfaces []sciond.PathInterface
+	// Metadata is the metadata aggregated from the AS entries. It is nil for
+	// the empty path.
+	Metadata *sciond.PathMetadata
 }
 
 func (p *Path) writeTestString(w io.Writer) {
@@ -277,6 +277,7 @@ func (solution *PathSolution) GetFwdPathMetadata() *Path {
 		Weight: solution.cost,
 		Mtu:    ^uint16(0),
 	}
+	mtus := newMTUInfo()
 	for edgeIdx, solEdge := range solution.edges {
 		currentSeg := &Segment{
 			Type: solEdge.segment.Type,
@@ -355,12 +356,14 @@ func (solution *PathSolution) GetFwdPathMetadata() *Path {
 				// The first HE in a segment has MTU 0, so we ignore those
 				path.Mtu = minUint16(path.Mtu, forwardingLinkMtu)
 			}
+			mtus.add(asEntry.IA(), asEntry.MTU, outIFID, forwardingLinkMtu)
 			currentSeg.Interfaces = append(currentSeg.Interfaces,
 				getPathInterfaces(asEntry.IA(), inIFID, outIFID)...)
 		}
 	}
 	path.reverseDownSegment()
 	path.aggregateInterfaces()
+	path.Metadata = solution.metadata(path, mtus)
 	return path
 }
 
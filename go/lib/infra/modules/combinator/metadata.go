@@ -0,0 +1,160 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator
+
+import (
+	"sort"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/sciond"
+)
+
+// Names of the AS entry extensions reported in the path metadata. Only the
+// presence of an extension is reported, its content is not part of the
+// metadata.
+const (
+	ExtnHiddenPathSeg = "hidden_path_seg"
+	ExtnStaticInfo    = "static_info"
+)
+
+// mtuInfo collects the MTUs announced in the AS entries that are used by a
+// path.
+type mtuInfo struct {
+	// links maps an interface to the MTU of the inter-AS link attached to it.
+	links map[sciond.PathInterface]uint16
+	// internal maps an AS to its internal MTU.
+	internal map[addr.IA]uint16
+}
+
+func newMTUInfo() mtuInfo {
+	return mtuInfo{
+		links:    make(map[sciond.PathInterface]uint16),
+		internal: make(map[addr.IA]uint16),
+	}
+}
+
+// add records the internal MTU of the AS and the MTU of the link attached to
+// the interface. Zero values are unknown and ignored.
+func (m mtuInfo) add(ia addr.IA, internal uint16, ifid common.IFIDType, link uint16) {
+	if internal != 0 {
+		if prev, ok := m.internal[ia]; !ok || internal < prev {
+			m.internal[ia] = internal
+		}
+	}
+	if ifid != 0 && link != 0 {
+		m.links[sciond.PathInterface{RawIsdas: ia.IAInt(), IfID: ifid}] = link
+	}
+}
+
+// link returns the MTU of the link between the two interfaces. Only one end
+// of the link announces the MTU.
+func (m mtuInfo) link(a, b sciond.PathInterface) uint16 {
+	if mtu, ok := m.links[a]; ok {
+		return mtu
+	}
+	return m.links[b]
+}
+
+// metadata aggregates the metadata of the path from the AS entries of the
+// solution. For the empty path, nil is returned.
+func (solution *PathSolution) metadata(path *Path, mtus mtuInfo) *sciond.PathMetadata {
+	intfs := path.Interfaces
+	if len(intfs) == 0 {
+		return nil
+	}
+	m := solution.staticInfos().metadata(intfs)
+	if m == nil {
+		m = &sciond.PathMetadata{}
+	}
+	for _, segment := range path.Segments {
+		// A segment that crosses a peering link is also a shortcut, so both
+		// flags are set independently.
+		if segment.InfoField.Peer {
+			m.Peering = true
+		}
+		if segment.InfoField.Shortcut {
+			m.Shortcut = true
+		}
+	}
+	exts := solution.extensions()
+	// The first AS only has an egress interface, the last AS only has an
+	// ingress interface, and every AS in between has both.
+	m.Hops = make([]sciond.PathHopMetadata, 0, len(intfs)/2+1)
+	for i := -1; i < len(intfs); i += 2 {
+		var hop sciond.PathHopMetadata
+		if i >= 0 {
+			hop.RawIsdas = intfs[i].RawIsdas
+			hop.Ingress = intfs[i].IfID
+			hop.IngressMTU = mtus.link(intfs[i-1], intfs[i])
+		}
+		if i+1 < len(intfs) {
+			hop.RawIsdas = intfs[i+1].RawIsdas
+			hop.Egress = intfs[i+1].IfID
+			if i+2 < len(intfs) {
+				hop.EgressMTU = mtus.link(intfs[i+1], intfs[i+2])
+			}
+		}
+		hop.InternalMTU = mtus.internal[hop.IA()]
+		hop.Extensions = exts[hop.IA()]
+		m.Hops = append(m.Hops, hop)
+	}
+	for _, hop := range m.Hops {
+		isd := hop.IA().I
+		if n := len(m.ISDHops); n > 0 && m.ISDHops[n-1].ISD == isd {
+			m.ISDHops[n-1].Hops++
+			continue
+		}
+		m.ISDHops = append(m.ISDHops, sciond.ISDHopCount{ISD: isd, Hops: 1})
+	}
+	return m
+}
+
+// extensions returns the names of the extensions found in the AS entries of
+// the segments of the solution, per AS.
+func (solution *PathSolution) extensions() map[addr.IA][]string {
+	sets := make(map[addr.IA]map[string]struct{})
+	for _, solEdge := range solution.edges {
+		for _, asEntry := range solEdge.segment.ASEntries {
+			ia := asEntry.IA()
+			for _, name := range asEntryExtensions(asEntry) {
+				if sets[ia] == nil {
+					sets[ia] = make(map[string]struct{})
+				}
+				sets[ia][name] = struct{}{}
+			}
+		}
+	}
+	exts := make(map[addr.IA][]string, len(sets))
+	for ia, set := range sets {
+		for name := range set {
+			exts[ia] = append(exts[ia], name)
+		}
+		sort.Strings(exts[ia])
+	}
+	return exts
+}
+
+func asEntryExtensions(asEntry *seg.ASEntry) []string {
+	var names []string
+	if asEntry.Exts.HiddenPathSeg != nil {
+		names = append(names, ExtnHiddenPathSeg)
+	}
+	if asEntry.Exts.StaticInfo != nil {
+		names = append(names, ExtnStaticInfo)
+	}
+	return names
+}
@@ -0,0 +1,122 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/lib/xtest/graph"
+)
+
+func TestCombineMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	ia112 := xtest.MustParseIA("1-ff00:0:112")
+	ia130 := xtest.MustParseIA("1-ff00:0:130")
+
+	t.Run("up segment", func(t *testing.T) {
+		up := g.Beacon([]common.IFIDType{graph.If_130_B_111_A, graph.If_111_A_112_X})
+		// The AS entries are 130, 111 and 112 in construction direction.
+		up.ASEntries[0].MTU = 1500
+		up.ASEntries[1].MTU = 1400
+		up.ASEntries[2].MTU = 1472
+		up.ASEntries[2].Exts.StaticInfo = &seg.StaticInfoExtn{}
+		paths := Combine(ia112, ia130, []*seg.PathSegment{up}, nil, nil)
+		require.Len(t, paths, 1)
+		m := paths[0].Metadata
+		require.NotNil(t, m)
+		expected := []sciond.PathHopMetadata{
+			{
+				RawIsdas:    ia112.IAInt(),
+				Egress:      graph.If_112_X_111_A,
+				InternalMTU: 1472,
+				EgressMTU:   1280,
+				Extensions:  []string{ExtnStaticInfo},
+			},
+			{
+				RawIsdas:    ia111.IAInt(),
+				Ingress:     graph.If_111_A_112_X,
+				Egress:      graph.If_111_A_130_B,
+				IngressMTU:  1280,
+				InternalMTU: 1400,
+				EgressMTU:   1280,
+			},
+			{
+				RawIsdas:    ia130.IAInt(),
+				Ingress:     graph.If_130_B_111_A,
+				IngressMTU:  1280,
+				InternalMTU: 1500,
+			},
+		}
+		assert.Equal(t, expected, m.Hops)
+		assert.Equal(t, []sciond.ISDHopCount{{ISD: 1, Hops: 3}}, m.ISDHops)
+		assert.False(t, m.Peering)
+		assert.False(t, m.Shortcut)
+	})
+	t.Run("peering", func(t *testing.T) {
+		ups := []*seg.PathSegment{
+			g.Beacon([]common.IFIDType{graph.If_130_B_111_A, graph.If_111_A_112_X}),
+		}
+		cores := []*seg.PathSegment{
+			g.Beacon([]common.IFIDType{graph.If_120_A_130_B}),
+		}
+		downs := []*seg.PathSegment{
+			g.Beacon([]common.IFIDType{graph.If_120_B_121_X, graph.If_121_X_122_X}),
+		}
+		paths := Combine(ia112, xtest.MustParseIA("1-ff00:0:122"), ups, cores, downs)
+		var peering int
+		for _, p := range paths {
+			require.NotNil(t, p.Metadata)
+			assert.Len(t, p.Metadata.Hops, len(p.Interfaces)/2+1)
+			if p.Metadata.Peering {
+				peering++
+				assert.True(t, p.Metadata.Shortcut)
+			}
+		}
+		assert.Equal(t, 1, peering)
+	})
+	t.Run("shortcut", func(t *testing.T) {
+		ups := []*seg.PathSegment{
+			g.Beacon([]common.IFIDType{graph.If_210_X1_211_A, graph.If_211_A1_212_X}),
+		}
+		downs := []*seg.PathSegment{
+			g.Beacon([]common.IFIDType{graph.If_210_X1_211_A, graph.If_211_A_222_X}),
+		}
+		paths := Combine(xtest.MustParseIA("2-ff00:0:212"), xtest.MustParseIA("2-ff00:0:222"),
+			ups, nil, downs)
+		require.Len(t, paths, 1)
+		require.NotNil(t, paths[0].Metadata)
+		assert.True(t, paths[0].Metadata.Shortcut)
+		assert.False(t, paths[0].Metadata.Peering)
+	})
+	t.Run("empty path", func(t *testing.T) {
+		up := g.Beacon([]common.IFIDType{graph.If_130_B_111_A})
+		paths := Combine(ia130, ia130, []*seg.PathSegment{up}, nil, nil)
+		for _, p := range paths {
+			assert.Nil(t, p.Metadata)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator
+
+import (
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/sciond"
+)
+
+// staticInfos maps an AS to the static info extensions found in the AS
+// entries of that AS. An AS can have multiple AS entries in a path, e.g., at a
+// crossover between two segments.
+type staticInfos map[addr.IA][]*seg.StaticInfoExtn
+
+// staticInfos collects the static info extensions of all AS entries in the
+// segments of the solution.
+func (solution *PathSolution) staticInfos() staticInfos {
+	infos := make(staticInfos)
+	for _, solEdge := range solution.edges {
+		for _, asEntry := range solEdge.segment.ASEntries {
+			if asEntry.Exts.StaticInfo == nil {
+				continue
+			}
+			ia := asEntry.IA()
+			infos[ia] = append(infos[ia], asEntry.Exts.StaticInfo)
+		}
+	}
+	return infos
+}
+
+// metadata aggregates the static info along the interfaces of the path. If no
+// static info is available, nil is returned.
+func (infos staticInfos) metadata(intfs []sciond.PathInterface) *sciond.PathMetadata {
+	if len(infos) == 0 || len(intfs) == 0 {
+		return nil
+	}
+	m := &sciond.PathMetadata{
+		Latency:   make([]uint32, 0, len(intfs)-1),
+		Bandwidth: make([]uint64, 0, len(intfs)-1),
+		Geo:       make([]sciond.GeoCoordinates, 0, len(intfs)),
+		LinkType:  make([]uint8, 0, len(intfs)/2),
+	}
+	for i, intf := range intfs {
+		info := infos.intf(intf.IA(), intf.IfID)
+		m.Geo = append(m.Geo, sciond.GeoCoordinates{
+			Latitude:  info.Latitude,
+			Longitude: info.Longitude,
+			Address:   info.Address,
+		})
+		if i == len(intfs)-1 {
+			break
+		}
+		next := intfs[i+1]
+		if intf.IA().Equal(next.IA()) {
+			latency := infos.intraLatency(intf.IA(), intf.IfID, next.IfID)
+			m.Latency = append(m.Latency, uint32(latency/time.Microsecond))
+			m.Bandwidth = append(m.Bandwidth, 0)
+			continue
+		}
+		// Both ends of an inter-AS link can announce the link properties. The
+		// first announced value is used.
+		nextInfo := infos.intf(next.IA(), next.IfID)
+		latency := info.LinkLatency
+		if latency == 0 {
+			latency = nextInfo.LinkLatency
+		}
+		bandwidth := info.Bandwidth
+		if bandwidth == 0 {
+			bandwidth = nextInfo.Bandwidth
+		}
+		linkType := info.LinkType
+		if linkType == seg.LinkTypeUnset {
+			linkType = nextInfo.LinkType
+		}
+		m.Latency = append(m.Latency, latency)
+		m.Bandwidth = append(m.Bandwidth, bandwidth)
+		m.LinkType = append(m.LinkType, uint8(linkType))
+	}
+	return m
+}
+
+// intf returns the static info of the interface. If the interface is unknown,
+// the zero value is returned.
+func (infos staticInfos) intf(ia addr.IA, ifid common.IFIDType) seg.StaticInfoInterface {
+	for _, extn := range infos[ia] {
+		if info := extn.Interface(ifid); info != nil {
+			return *info
+		}
+	}
+	return seg.StaticInfoInterface{}
+}
+
+// intraLatency returns the latency between the two interfaces of the AS. If
+// the latency is unknown, zero is returned.
+func (infos staticInfos) intraLatency(ia addr.IA, a, b common.IFIDType) time.Duration {
+	for _, extn := range infos[ia] {
+		if l, ok := extn.IntraLatency(a, b); ok {
+			return l
+		}
+	}
+	return 0
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/lib/xtest/graph"
+)
+
+func TestStaticInfoMetadata(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	ia112 := xtest.MustParseIA("1-ff00:0:112")
+	intfs := []sciond.PathInterface{
+		{RawIsdas: ia110.IAInt(), IfID: 1},
+		{RawIsdas: ia111.IAInt(), IfID: 2},
+		{RawIsdas: ia111.IAInt(), IfID: 3},
+		{RawIsdas: ia112.IAInt(), IfID: 4},
+	}
+
+	t.Run("no static info", func(t *testing.T) {
+		assert.Nil(t, staticInfos{}.metadata(intfs))
+	})
+	t.Run("empty path", func(t *testing.T) {
+		infos := staticInfos{ia110: {&seg.StaticInfoExtn{}}}
+		assert.Nil(t, infos.metadata(nil))
+	})
+	t.Run("aggregated", func(t *testing.T) {
+		infos := staticInfos{
+			ia110: {
+				&seg.StaticInfoExtn{
+					Interfaces: []*seg.StaticInfoInterface{
+						{
+							IfID:        1,
+							LinkLatency: 1000,
+							Bandwidth:   100,
+							Address:     "A",
+							LinkType:    seg.LinkTypeDirect,
+						},
+					},
+				},
+			},
+			ia111: {
+				// The ingress side announces nothing about the link to 110.
+				&seg.StaticInfoExtn{
+					Interfaces: []*seg.StaticInfoInterface{{IfID: 2, Address: "B"}},
+				},
+				&seg.StaticInfoExtn{
+					Interfaces: []*seg.StaticInfoInterface{
+						{IfID: 3, LinkLatency: 3000, LinkType: seg.LinkTypeOpennet},
+					},
+					IntraLatencies: []*seg.StaticInfoLatency{
+						{IfID: 3, OtherIfID: 2, Latency: 200},
+					},
+				},
+			},
+		}
+		expected := &sciond.PathMetadata{
+			Latency:   []uint32{1000, 200, 3000},
+			Bandwidth: []uint64{100, 0, 0},
+			Geo: []sciond.GeoCoordinates{
+				{Address: "A"}, {Address: "B"}, {}, {},
+			},
+			LinkType: []uint8{uint8(seg.LinkTypeDirect), uint8(seg.LinkTypeOpennet)},
+		}
+		assert.Equal(t, expected, infos.metadata(intfs))
+	})
+}
+
+func TestCombineStaticInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+
+	up := g.Beacon([]common.IFIDType{graph.If_130_B_111_A, graph.If_111_A_112_X})
+	// The last AS entry is the one of 1-ff00:0:112.
+	up.ASEntries[len(up.ASEntries)-1].Exts.StaticInfo = &seg.StaticInfoExtn{
+		Interfaces: []*seg.StaticInfoInterface{
+			{IfID: graph.If_112_X_111_A, LinkLatency: 5000},
+		},
+	}
+	paths := Combine(xtest.MustParseIA("1-ff00:0:112"), xtest.MustParseIA("1-ff00:0:130"),
+		[]*seg.PathSegment{up}, nil, nil)
+	require.Len(t, paths, 1)
+	m := paths[0].Metadata
+	require.NotNil(t, m)
+	assert.Len(t, m.Latency, len(paths[0].Interfaces)-1)
+	assert.Len(t, m.Geo, len(paths[0].Interfaces))
+	assert.Equal(t, uint32(5000), m.Latency[0])
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// WriteLock serializes the writers of an in-memory database. In contrast to a
+// mutex, acquiring the lock can be aborted through the context. A transaction
+// holds the lock from the moment it begins until it is committed or rolled
+// back.
+type WriteLock struct {
+	ch chan struct{}
+}
+
+// NewWriteLock returns a new unlocked write lock.
+func NewWriteLock() *WriteLock {
+	return &WriteLock{ch: make(chan struct{}, 1)}
+}
+
+// Acquire blocks until the lock is acquired or the context is done.
+func (l *WriteLock) Acquire(ctx context.Context) error {
+	select {
+	case l.ch <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release releases the lock.
+func (l *WriteLock) Release() {
+	<-l.ch
+}
+
+// Begin acquires the lock and returns a transaction that holds it.
+func (l *WriteLock) Begin(ctx context.Context) (*WriteTx, error) {
+	if err := l.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	return &WriteTx{lock: l}, nil
+}
+
+// WriteTx tracks whether a transaction on an in-memory database is done.
+// Committing or rolling back a done transaction fails with sql.ErrTxDone.
+type WriteTx struct {
+	mu sync.Mutex
+	// lock is nil once the transaction is committed or rolled back.
+	lock *WriteLock
+}
+
+// Commit calls apply to publish the transaction state and releases the
+// lock.
+func (tx *WriteTx) Commit(apply func()) error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if tx.lock == nil {
+		return sql.ErrTxDone
+	}
+	apply()
+	tx.lock.Release()
+	tx.lock = nil
+	return nil
+}
+
+// Rollback releases the lock and discards the transaction state.
+func (tx *WriteTx) Rollback() error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if tx.lock == nil {
+		return sql.ErrTxDone
+	}
+	tx.lock.Release()
+	tx.lock = nil
+	return nil
+}
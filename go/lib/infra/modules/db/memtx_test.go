@@ -0,0 +1,51 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/infra/modules/db"
+)
+
+func TestWriteTx(t *testing.T) {
+	lock := db.NewWriteLock()
+	tx, err := lock.Begin(context.Background())
+	require.NoError(t, err)
+
+	// The lock is held until the transaction is done.
+	ctx, cancelF := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelF()
+	assert.Error(t, lock.Acquire(ctx))
+
+	applied := false
+	require.NoError(t, tx.Commit(func() { applied = true }))
+	assert.True(t, applied)
+	assert.Equal(t, sql.ErrTxDone, tx.Commit(func() { t.Fatal("applied twice") }))
+	assert.Equal(t, sql.ErrTxDone, tx.Rollback())
+
+	tx, err = lock.Begin(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback())
+	assert.Equal(t, sql.ErrTxDone, tx.Rollback())
+	require.NoError(t, lock.Acquire(context.Background()))
+	lock.Release()
+}
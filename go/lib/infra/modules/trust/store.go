@@ -101,6 +101,18 @@ func (s Store) NewChainPushHandler(ia addr.IA) infra.Handler {
 	return infra.HandlerFunc(f)
 }
 
+// InsertChain decodes, verifies and inserts the raw certificate chain. The
+// issuing TRC is fetched through the crypto provider. This should be used for
+// chains that are not received through a chain push, e.g., chains issued by
+// the local AS or received in a chain issuance reply.
+func (s Store) InsertChain(ctx context.Context, raw []byte) error {
+	dec, err := decoded.DecodeChain(raw)
+	if err != nil {
+		return err
+	}
+	return s.Inserter.InsertChain(ctx, dec, newTRCGetter(s.CryptoProvider, nil))
+}
+
 // LoadCryptoMaterial loads the crypto material from the file system and
 // populates the trust database.
 func (s Store) LoadCryptoMaterial(ctx context.Context, dir string) error {
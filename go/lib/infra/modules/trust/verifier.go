@@ -74,7 +74,7 @@ func (v *verifier) VerifyPld(ctx context.Context, spld *ctrl.SignedPld) (*ctrl.P
 		return cpld, nil
 	}
 
-	if age := time.Now().Sub(spld.Sign.Time()); age < v.MaxAge {
+	if age := time.Now().Sub(spld.Sign.Time()); age > v.MaxAge {
 		return nil, serrors.New("Invalid timestamp. Signature age", "age", age)
 	}
 
@@ -21,9 +21,11 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/ctrl/ifid"
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/infra/modules/trust"
 	"github.com/scionproto/scion/go/lib/infra/modules/trust/mock_trust"
@@ -60,6 +62,33 @@ func TestVerifyPld(t *testing.T) {
 			tc.wantErr(t, err)
 		})
 	}
+
+	t.Run("fresh signature", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		p := mock_trust.NewMockCryptoProvider(ctrl)
+		p.EXPECT().AnnounceTRC(gomock.Any(), trust.TRCID{ISD: 1, Version: 2}, gomock.Any()).Return(
+			nil,
+		)
+		p.EXPECT().GetASKey(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+			scrypto.KeyMeta{Key: public, Algorithm: scrypto.Ed25519}, nil,
+		)
+
+		spld := signedIFID(t, time.Now())
+		pld, err := trust.NewVerifier(p).VerifyPld(context.Background(), spld)
+		require.NoError(t, err)
+		assert.Equal(t, &ifid.IFID{OrigIfID: 42}, pld.IfID)
+	})
+
+	t.Run("stale signature", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		p := mock_trust.NewMockCryptoProvider(ctrl)
+
+		spld := signedIFID(t, time.Now().Add(-time.Minute))
+		_, err := trust.NewVerifier(p).VerifyPld(context.Background(), spld)
+		assert.Error(t, err)
+	})
 }
 
 func TestVerify(t *testing.T) {
@@ -128,6 +157,10 @@ func TestVerifierWithIA(t *testing.T) {
 }
 
 func validSignS(msg, rawIA string) *proto.SignS {
+	return signS(msg, rawIA, time.Now())
+}
+
+func signS(msg, rawIA string, ts time.Time) *proto.SignS {
 	ia, _ := addr.IAFromString(rawIA)
 	src := ctrl.SignSrcDef{
 		IA:       ia,
@@ -135,11 +168,19 @@ func validSignS(msg, rawIA string) *proto.SignS {
 		TRCVer:   2,
 	}
 	sign := proto.NewSignS(proto.SignType_ed25519, src.Pack())
-	sign.SetTimestamp(time.Now())
+	sign.SetTimestamp(ts)
 	sign.Signature, _ = scrypto.Sign(sign.SigInput([]byte(msg), false), priv, scrypto.Ed25519)
 	return sign
 }
 
+func signedIFID(t *testing.T, ts time.Time) *ctrl.SignedPld {
+	cpld, err := ctrl.NewPld(&ifid.IFID{OrigIfID: 42}, nil)
+	require.NoError(t, err)
+	blob, err := proto.PackRoot(cpld)
+	require.NoError(t, err)
+	return &ctrl.SignedPld{Blob: blob, Sign: signS(string(blob), "1-ff00:0:110", ts)}
+}
+
 func invalidSignS(msg, ia string) *proto.SignS {
 	ret := validSignS(msg, ia)
 	ret.Src = []byte("wrongcontent")
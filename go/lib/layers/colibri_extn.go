@@ -0,0 +1,202 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package layers
+
+import (
+	"fmt"
+
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var _ common.Extension = (*ExtnCOLIBRI)(nil)
+
+// ExtnCOLIBRI is the COLIBRI hop-by-hop extension. Packets that carry it are
+// forwarded along a bandwidth reservation, using the reservation hop fields
+// instead of the SCION path header.
+//
+// 0B       1        2        3        4        5        6        7
+// +--------+--------+--------+--------+--------+--------+--------+--------+
+// | xxxxxxxxxxxxxxxxxxxxxxxx | Flags  | CurrHF | NumHFs |     padding     |
+// +--------+--------+--------+--------+--------+--------+--------+--------+
+// | Reservation ID (16B)                                                  |
+// +--------+--------+--------+--------+--------+--------+--------+--------+
+// |                                                                       |
+// +--------+--------+--------+--------+--------+--------+--------+--------+
+// | InfoField (8B)                                                        |
+// +--------+--------+--------+--------+--------+--------+--------+--------+
+// | HopField (8B)                                                         |
+// +--------+--------+--------+--------+--------+--------+--------+--------+
+// | ...                                                                   |
+// +--------+--------+--------+--------+--------+--------+--------+--------+
+//
+// The reservation ID is an E2EID for E2E reservations, and a SegmentID padded
+// with zeros otherwise. CurrHF is the index of the hop field of the AS the
+// packet is currently in.
+type ExtnCOLIBRI struct {
+	// Flags is reserved for future use.
+	Flags uint8
+	// CurrHF is the index of the current hop field.
+	CurrHF uint8
+	// ID is the reservation ID.
+	ID [reservation.E2EIDLen]byte
+	// InfoField is the reservation info field.
+	InfoField reservation.InfoField
+	// HopFields are the reservation hop fields, in the direction of travel.
+	HopFields []reservation.HopField
+}
+
+const (
+	// ExtnCOLIBRIHdrLen is the length of the extension without the hop
+	// fields, excluding the 3B subheader.
+	ExtnCOLIBRIHdrLen = common.ExtnFirstLineLen + reservation.E2EIDLen +
+		reservation.InfoFieldLen
+	// ExtnCOLIBRICurrHFOff is the offset of the CurrHF field, excluding the 3B
+	// subheader.
+	ExtnCOLIBRICurrHFOff = 1
+)
+
+func NewExtnCOLIBRIFromLayer(extension *Extension) (*ExtnCOLIBRI, error) {
+	var extn ExtnCOLIBRI
+	if err := extn.DecodeFromLayer(extension); err != nil {
+		return nil, err
+	}
+	return &extn, nil
+}
+
+func (e *ExtnCOLIBRI) DecodeFromLayer(extension *Extension) error {
+	return e.DecodeFromBytes(extension.Data)
+}
+
+// DecodeFromBytes parses the extension, excluding the 3B subheader.
+func (e *ExtnCOLIBRI) DecodeFromBytes(b []byte) error {
+	if len(b) < ExtnCOLIBRIHdrLen {
+		return serrors.New("bad length for COLIBRI extension", "actual", len(b),
+			"min", ExtnCOLIBRIHdrLen)
+	}
+	e.Flags = b[0]
+	e.CurrHF = b[ExtnCOLIBRICurrHFOff]
+	numHFs := int(b[2])
+	if len(b) != ExtnCOLIBRIHdrLen+numHFs*reservation.HopFieldLen {
+		return serrors.New("bad length for COLIBRI extension", "actual", len(b),
+			"hop_fields", numHFs)
+	}
+	if numHFs == 0 || int(e.CurrHF) >= numHFs {
+		return serrors.New("invalid current hop field", "curr", e.CurrHF,
+			"hop_fields", numHFs)
+	}
+	off := common.ExtnFirstLineLen
+	copy(e.ID[:], b[off:off+reservation.E2EIDLen])
+	off += reservation.E2EIDLen
+	info, err := reservation.InfoFieldFromRaw(b[off:])
+	if err != nil {
+		return err
+	}
+	e.InfoField = *info
+	off += reservation.InfoFieldLen
+	e.HopFields = make([]reservation.HopField, numHFs)
+	for i := range e.HopFields {
+		hf, err := reservation.HopFieldFromRaw(b[off:])
+		if err != nil {
+			return err
+		}
+		e.HopFields[i] = *hf
+		off += reservation.HopFieldLen
+	}
+	return nil
+}
+
+func ExtnCOLIBRIFromRaw(b common.RawBytes) (*ExtnCOLIBRI, error) {
+	e := &ExtnCOLIBRI{}
+	if err := e.DecodeFromBytes(b); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// CurrHopField returns the current hop field.
+func (e *ExtnCOLIBRI) CurrHopField() *reservation.HopField {
+	return &e.HopFields[e.CurrHF]
+}
+
+// IsLastHop returns whether the current hop field is the last one.
+func (e *ExtnCOLIBRI) IsLastHop() bool {
+	return int(e.CurrHF) == len(e.HopFields)-1
+}
+
+func (e *ExtnCOLIBRI) Write(b common.RawBytes) error {
+	if len(b) < e.Len() {
+		return serrors.New("buffer too short for COLIBRI extension", "actual", len(b),
+			"min", e.Len())
+	}
+	if len(e.HopFields) > 255 {
+		return serrors.New("too many hop fields", "hop_fields", len(e.HopFields))
+	}
+	b[0] = e.Flags
+	b[ExtnCOLIBRICurrHFOff] = e.CurrHF
+	b[2] = uint8(len(e.HopFields))
+	b[3], b[4] = 0, 0
+	off := common.ExtnFirstLineLen
+	off += copy(b[off:], e.ID[:])
+	if _, err := e.InfoField.Read(b[off:]); err != nil {
+		return err
+	}
+	off += reservation.InfoFieldLen
+	for i := range e.HopFields {
+		if _, err := e.HopFields[i].Read(b[off:]); err != nil {
+			return err
+		}
+		off += reservation.HopFieldLen
+	}
+	return nil
+}
+
+func (e *ExtnCOLIBRI) Pack() (common.RawBytes, error) {
+	b := make(common.RawBytes, e.Len())
+	if err := e.Write(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (e *ExtnCOLIBRI) Copy() common.Extension {
+	c := *e
+	c.HopFields = append([]reservation.HopField(nil), e.HopFields...)
+	return &c
+}
+
+func (e *ExtnCOLIBRI) Reverse() (bool, error) {
+	// Reservations are unidirectional, reversing removes the extension.
+	return false, nil
+}
+
+func (e *ExtnCOLIBRI) Len() int {
+	return ExtnCOLIBRIHdrLen + len(e.HopFields)*reservation.HopFieldLen
+}
+
+func (e *ExtnCOLIBRI) Class() common.L4ProtocolType {
+	return common.HopByHopClass
+}
+
+func (e *ExtnCOLIBRI) Type() common.ExtnType {
+	return common.ExtnCOLIBRIType
+}
+
+func (e *ExtnCOLIBRI) String() string {
+	return fmt.Sprintf("COLIBRI Ext(%dB): ID: %x Type: %d Idx: %d BWCls: %d CurrHF: %d/%d",
+		e.Len(), e.ID, e.InfoField.PathType, e.InfoField.Idx, e.InfoField.BWCls, e.CurrHF,
+		len(e.HopFields))
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package layers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+func newTestExtnCOLIBRI() *ExtnCOLIBRI {
+	e := &ExtnCOLIBRI{
+		CurrHF: 1,
+		InfoField: reservation.InfoField{
+			ExpirationTick: 384555855,
+			BWCls:          13,
+			RLC:            4,
+			Idx:            2,
+			PathType:       reservation.E2EPath,
+		},
+		HopFields: []reservation.HopField{
+			{Ingress: 0, Egress: 1, Mac: [4]byte{1, 2, 3, 4}},
+			{Ingress: 2, Egress: 3, Mac: [4]byte{5, 6, 7, 8}},
+			{Ingress: 4, Egress: 0, Mac: [4]byte{9, 10, 11, 12}},
+		},
+	}
+	copy(e.ID[:], "0123456789abcdef")
+	return e
+}
+
+func TestExtnCOLIBRIPackDecode(t *testing.T) {
+	e := newTestExtnCOLIBRI()
+	raw, err := e.Pack()
+	require.NoError(t, err)
+	// Including the subheader, the extension is aligned to full lines.
+	assert.Zero(t, (len(raw)+common.ExtnSubHdrLen)%common.LineLen)
+	parsed, err := ExtnCOLIBRIFromRaw(raw)
+	require.NoError(t, err)
+	assert.Equal(t, e, parsed)
+	assert.Equal(t, &e.HopFields[1], parsed.CurrHopField())
+	assert.False(t, parsed.IsLastHop())
+}
+
+func TestExtnCOLIBRIDecodeErrors(t *testing.T) {
+	raw, err := newTestExtnCOLIBRI().Pack()
+	require.NoError(t, err)
+	tests := map[string]func(b []byte) []byte{
+		"truncated header": func(b []byte) []byte { return b[:ExtnCOLIBRIHdrLen-1] },
+		"truncated hop field": func(b []byte) []byte {
+			return b[:len(b)-1]
+		},
+		"no hop fields": func(b []byte) []byte {
+			b[2] = 0
+			return b[:ExtnCOLIBRIHdrLen]
+		},
+		"current hop field out of range": func(b []byte) []byte {
+			b[ExtnCOLIBRICurrHFOff] = 3
+			return b
+		},
+	}
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := modify(append([]byte(nil), raw...))
+			_, err := ExtnCOLIBRIFromRaw(b)
+			assert.Error(t, err)
+		})
+	}
+}
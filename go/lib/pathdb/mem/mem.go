@@ -0,0 +1,583 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mem implements the pathdb.PathDB interface with an in-memory
+// backend. The semantics mirror the sqlite backend. Segments are stored in
+// packed form and parsed on every read, such that callers never share state
+// with the database.
+//
+// A transaction works on a snapshot of the segments and next query times that
+// replaces the stored state on commit. Inserts and deletes outside of the
+// transaction wait until it is done, while reads see the last committed state.
+package mem
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/infra/modules/db"
+	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var noInsertion = pathdb.InsertStats{}
+
+var _ pathdb.PathDB = (*Backend)(nil)
+
+// Backend is an in-memory path database.
+type Backend struct {
+	*executor
+	// writeLock is held by open transactions and single writes.
+	writeLock *db.WriteLock
+}
+
+// New returns a new empty in-memory backend.
+func New() *Backend {
+	return &Backend{
+		executor: &executor{
+			segments:  make(map[string]*segEntry),
+			nextQuery: make(map[nqKey]time.Time),
+		},
+		writeLock: db.NewWriteLock(),
+	}
+}
+
+// SetMaxOpenConns is a no-op for the in-memory backend.
+func (b *Backend) SetMaxOpenConns(_ int) {}
+
+// SetMaxIdleConns is a no-op for the in-memory backend.
+func (b *Backend) SetMaxIdleConns(_ int) {}
+
+// Close closes the database.
+func (b *Backend) Close() error {
+	return nil
+}
+
+// BeginTransaction begins a transaction on a snapshot of the database. It
+// waits for the running transaction or write to finish.
+func (b *Backend) BeginTransaction(ctx context.Context,
+	_ *sql.TxOptions) (pathdb.Transaction, error) {
+
+	tx, err := b.writeLock.Begin(ctx)
+	if err != nil {
+		return nil, common.NewBasicError("Failed to create transaction", err)
+	}
+	return &transaction{
+		executor: b.executor.clone(),
+		backend:  b,
+		tx:       tx,
+	}, nil
+}
+
+func (b *Backend) Insert(ctx context.Context, segMeta *seg.Meta) (pathdb.InsertStats, error) {
+	return b.InsertWithHPCfgIDs(ctx, segMeta, []*query.HPCfgID{&query.NullHpCfgID})
+}
+
+func (b *Backend) InsertWithHPCfgIDs(ctx context.Context, segMeta *seg.Meta,
+	hpCfgIDs []*query.HPCfgID) (pathdb.InsertStats, error) {
+
+	if err := b.writeLock.Acquire(ctx); err != nil {
+		return noInsertion, err
+	}
+	defer b.writeLock.Release()
+	return b.executor.InsertWithHPCfgIDs(ctx, segMeta, hpCfgIDs)
+}
+
+func (b *Backend) Delete(ctx context.Context, params *query.Params) (int, error) {
+	if err := b.writeLock.Acquire(ctx); err != nil {
+		return 0, err
+	}
+	defer b.writeLock.Release()
+	return b.executor.Delete(ctx, params)
+}
+
+func (b *Backend) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	if err := b.writeLock.Acquire(ctx); err != nil {
+		return 0, err
+	}
+	defer b.writeLock.Release()
+	return b.executor.DeleteExpired(ctx, now)
+}
+
+func (b *Backend) InsertNextQuery(ctx context.Context, src, dst addr.IA,
+	policy pathdb.PolicyHash, nextQuery time.Time) (bool, error) {
+
+	if err := b.writeLock.Acquire(ctx); err != nil {
+		return false, err
+	}
+	defer b.writeLock.Release()
+	return b.executor.InsertNextQuery(ctx, src, dst, policy, nextQuery)
+}
+
+func (b *Backend) DeleteExpiredNQ(ctx context.Context, now time.Time) (int, error) {
+	if err := b.writeLock.Acquire(ctx); err != nil {
+		return 0, err
+	}
+	defer b.writeLock.Release()
+	return b.executor.DeleteExpiredNQ(ctx, now)
+}
+
+func (b *Backend) DeleteNQ(ctx context.Context, src, dst addr.IA,
+	policy pathdb.PolicyHash) (int, error) {
+
+	if err := b.writeLock.Acquire(ctx); err != nil {
+		return 0, err
+	}
+	defer b.writeLock.Release()
+	return b.executor.DeleteNQ(ctx, src, dst, policy)
+}
+
+var _ (pathdb.Transaction) = (*transaction)(nil)
+
+type transaction struct {
+	*executor
+	backend *Backend
+	tx      *db.WriteTx
+}
+
+func (tx *transaction) Commit() error {
+	return tx.tx.Commit(func() {
+		tx.RLock()
+		defer tx.RUnlock()
+		tx.backend.Lock()
+		defer tx.backend.Unlock()
+		tx.backend.nextRowID = tx.nextRowID
+		tx.backend.segments = tx.segments
+		tx.backend.nextQuery = tx.nextQuery
+	})
+}
+
+func (tx *transaction) Rollback() error {
+	return tx.tx.Rollback()
+}
+
+// segEntry is a stored segment. Entries are never modified in place once they
+// are stored.
+type segEntry struct {
+	// rowID is assigned on insertion and kept on updates. It breaks ties
+	// between segments with equal last update time.
+	rowID       int64
+	segID       common.RawBytes
+	infoTime    time.Time
+	expiration  time.Time
+	lastUpdated time.Time
+	start       addr.IA
+	end         addr.IA
+	packed      common.RawBytes
+	intfs       []query.IntfSpec
+	types       []proto.PathSegType
+	hpCfgIDs    []query.HPCfgID
+}
+
+type nqKey struct {
+	src    addr.IA
+	dst    addr.IA
+	policy string
+}
+
+var _ (pathdb.ReadWrite) = (*executor)(nil)
+
+type executor struct {
+	sync.RWMutex
+	nextRowID int64
+	segments  map[string]*segEntry
+	nextQuery map[nqKey]time.Time
+}
+
+// clone returns a snapshot for a transaction. A segEntry is replaced rather
+// than updated, so a shallow copy of the maps suffices.
+func (e *executor) clone() *executor {
+	e.RLock()
+	defer e.RUnlock()
+	c := &executor{
+		nextRowID: e.nextRowID,
+		segments:  make(map[string]*segEntry, len(e.segments)),
+		nextQuery: make(map[nqKey]time.Time, len(e.nextQuery)),
+	}
+	for k, v := range e.segments {
+		c.segments[k] = v
+	}
+	for k, v := range e.nextQuery {
+		c.nextQuery[k] = v
+	}
+	return c
+}
+
+func (e *executor) Insert(ctx context.Context, segMeta *seg.Meta) (pathdb.InsertStats, error) {
+	return e.InsertWithHPCfgIDs(ctx, segMeta, []*query.HPCfgID{&query.NullHpCfgID})
+}
+
+func (e *executor) InsertWithHPCfgIDs(_ context.Context, segMeta *seg.Meta,
+	hpCfgIDs []*query.HPCfgID) (pathdb.InsertStats, error) {
+
+	pseg := segMeta.Segment
+	segID, err := pseg.ID()
+	if err != nil {
+		return noInsertion, err
+	}
+	if _, err := pseg.FullId(); err != nil {
+		return noInsertion, err
+	}
+	info, err := pseg.InfoF()
+	if err != nil {
+		return noInsertion, err
+	}
+	packed, err := pseg.Pack()
+	if err != nil {
+		return noInsertion, common.NewBasicError("Failed to pack segment", err)
+	}
+	intfs, err := interfaces(pseg.ASEntries)
+	if err != nil {
+		return noInsertion, err
+	}
+	entry := &segEntry{
+		segID:       segID,
+		infoTime:    info.Timestamp(),
+		expiration:  pseg.MaxExpiry(),
+		lastUpdated: time.Now(),
+		start:       pseg.FirstIA(),
+		end:         pseg.LastIA(),
+		packed:      packed,
+		intfs:       intfs,
+	}
+
+	e.Lock()
+	defer e.Unlock()
+	key := string(segID)
+	existing, ok := e.segments[key]
+	if !ok {
+		e.nextRowID++
+		entry.rowID = e.nextRowID
+		entry.types = addType(nil, segMeta.Type)
+		entry.hpCfgIDs = addHPCfgIDs(nil, hpCfgIDs)
+		e.segments[key] = entry
+		return pathdb.InsertStats{Inserted: 1}, nil
+	}
+	// Only update the existing segment if the new segment is more recent.
+	if !entry.infoTime.After(existing.infoTime) {
+		return noInsertion, nil
+	}
+	// The segment keeps the types and hidden path config IDs it was
+	// registered with before.
+	entry.rowID = existing.rowID
+	entry.types = addType(existing.types, segMeta.Type)
+	entry.hpCfgIDs = addHPCfgIDs(existing.hpCfgIDs, hpCfgIDs)
+	e.segments[key] = entry
+	return pathdb.InsertStats{Updated: 1}, nil
+}
+
+// interfaces returns the interfaces the segment is indexed by. These are the
+// ingress interfaces of all hop entries and the egress interface of the first
+// hop entry of each AS entry.
+func interfaces(ases []*seg.ASEntry) ([]query.IntfSpec, error) {
+	var intfs []query.IntfSpec
+	for _, as := range ases {
+		ia := as.IA()
+		for idx, hop := range as.HopEntries {
+			hof, err := hop.HopField()
+			if err != nil {
+				return nil, common.NewBasicError("Failed to extract hop field", err)
+			}
+			if hof.ConsIngress != 0 {
+				intfs = append(intfs, query.IntfSpec{IA: ia, IfID: hof.ConsIngress})
+			}
+			if idx == 0 && hof.ConsEgress != 0 {
+				intfs = append(intfs, query.IntfSpec{IA: ia, IfID: hof.ConsEgress})
+			}
+		}
+	}
+	return intfs, nil
+}
+
+// addType returns types extended by segType. The input is never modified.
+func addType(types []proto.PathSegType, segType proto.PathSegType) []proto.PathSegType {
+	for _, t := range types {
+		if t == segType {
+			return types
+		}
+	}
+	res := append(make([]proto.PathSegType, 0, len(types)+1), types...)
+	return append(res, segType)
+}
+
+// addHPCfgIDs returns a copy of ids that additionally contains the missing
+// IDs of add.
+func addHPCfgIDs(ids []query.HPCfgID, add []*query.HPCfgID) []query.HPCfgID {
+	res := append(make([]query.HPCfgID, 0, len(ids)+len(add)), ids...)
+	for _, id := range add {
+		if !containsHPCfgID(res, id) {
+			res = append(res, *id)
+		}
+	}
+	return res
+}
+
+func containsHPCfgID(ids []query.HPCfgID, id *query.HPCfgID) bool {
+	for i := range ids {
+		if ids[i].Equal(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *executor) Delete(_ context.Context, params *query.Params) (int, error) {
+	e.Lock()
+	defer e.Unlock()
+	var deleted int
+	for k, entry := range e.segments {
+		if _, ok := match(entry, params); ok {
+			delete(e.segments, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (e *executor) DeleteExpired(_ context.Context, now time.Time) (int, error) {
+	e.Lock()
+	defer e.Unlock()
+	var deleted int
+	for k, entry := range e.segments {
+		if entry.expiration.Unix() < now.Unix() {
+			delete(e.segments, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (e *executor) Get(ctx context.Context, params *query.Params) (query.Results, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	e.RLock()
+	defer e.RUnlock()
+	return e.get(params)
+}
+
+// get returns the results matching the params, ordered by last update time.
+// The caller must hold the lock.
+func (e *executor) get(params *query.Params) (query.Results, error) {
+	entries := make([]*segEntry, 0, len(e.segments))
+	for _, entry := range e.segments {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if !a.lastUpdated.Equal(b.lastUpdated) {
+			return a.lastUpdated.Before(b.lastUpdated)
+		}
+		return a.rowID < b.rowID
+	})
+	var res query.Results
+	for _, entry := range entries {
+		r, ok := match(entry, params)
+		if !ok {
+			continue
+		}
+		var err error
+		if r.Seg, err = seg.NewSegFromRaw(entry.packed); err != nil {
+			return nil, common.NewBasicError("Error unmarshalling segment", err)
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+// match checks whether the entry matches the params. If it matches, the
+// result without the parsed segment is returned. The result only contains the
+// types and hidden path config IDs that match the params.
+func match(entry *segEntry, params *query.Params) (*query.Result, bool) {
+	if params == nil {
+		params = &query.Params{}
+	}
+	types := entry.types
+	if len(params.SegTypes) > 0 {
+		types = nil
+		for _, t := range entry.types {
+			for _, want := range params.SegTypes {
+				if t == want {
+					types = append(types, t)
+					break
+				}
+			}
+		}
+	}
+	var hpCfgIDs []*query.HPCfgID
+	for i := range entry.hpCfgIDs {
+		id := entry.hpCfgIDs[i]
+		if len(params.HpCfgIDs) > 0 && !containsHPCfgIDPtr(params.HpCfgIDs, &id) {
+			continue
+		}
+		hpCfgIDs = append(hpCfgIDs, &id)
+	}
+	// As in the sqlite backend, segments without type or hidden path config ID
+	// are never returned.
+	if len(types) == 0 || len(hpCfgIDs) == 0 {
+		return nil, false
+	}
+	if len(params.SegIDs) > 0 && !containsSegID(params.SegIDs, entry.segID) {
+		return nil, false
+	}
+	if len(params.Intfs) > 0 && !containsIntf(entry.intfs, params.Intfs) {
+		return nil, false
+	}
+	if len(params.StartsAt) > 0 && !matchesIA(entry.start, params.StartsAt) {
+		return nil, false
+	}
+	if len(params.EndsAt) > 0 && !matchesIA(entry.end, params.EndsAt) {
+		return nil, false
+	}
+	if params.MinLastUpdate != nil && !entry.lastUpdated.After(*params.MinLastUpdate) {
+		return nil, false
+	}
+	return &query.Result{
+		LastUpdate: entry.lastUpdated,
+		HpCfgIDs:   hpCfgIDs,
+		Type:       types[0],
+	}, true
+}
+
+func containsHPCfgIDPtr(ids []*query.HPCfgID, id *query.HPCfgID) bool {
+	for _, other := range ids {
+		if other.Equal(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSegID(segIDs []common.RawBytes, segID common.RawBytes) bool {
+	for _, other := range segIDs {
+		if bytes.Equal(other, segID) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsIntf(intfs []query.IntfSpec, specs []*query.IntfSpec) bool {
+	for _, intf := range intfs {
+		for _, spec := range specs {
+			if intf.IA.Equal(spec.IA) && intf.IfID == spec.IfID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesIA checks whether ia matches any of the patterns. A pattern with
+// wildcard AS matches all ASes of the ISD.
+func matchesIA(ia addr.IA, patterns []addr.IA) bool {
+	for _, pattern := range patterns {
+		if pattern.I == ia.I && (pattern.A == 0 || pattern.A == ia.A) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *executor) GetAll(ctx context.Context) (<-chan query.ResultOrErr, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	e.RLock()
+	defer e.RUnlock()
+	res, err := e.get(nil)
+	if err != nil {
+		return nil, err
+	}
+	// The read lock is released on return, hand out all results buffered.
+	resCh := make(chan query.ResultOrErr, len(res))
+	for _, r := range res {
+		resCh <- query.ResultOrErr{Result: r}
+	}
+	close(resCh)
+	return resCh, nil
+}
+
+func (e *executor) InsertNextQuery(_ context.Context, src, dst addr.IA,
+	policy pathdb.PolicyHash, nextQuery time.Time) (bool, error) {
+
+	if policy == nil {
+		policy = pathdb.NoPolicy
+	}
+	e.Lock()
+	defer e.Unlock()
+	key := nqKey{src: src, dst: dst, policy: string(policy)}
+	if existing, ok := e.nextQuery[key]; ok && !nextQuery.After(existing) {
+		return false, nil
+	}
+	e.nextQuery[key] = nextQuery
+	return true, nil
+}
+
+func (e *executor) GetNextQuery(ctx context.Context, src, dst addr.IA,
+	policy pathdb.PolicyHash) (time.Time, error) {
+
+	if err := ctx.Err(); err != nil {
+		return time.Time{}, err
+	}
+	if policy == nil {
+		policy = pathdb.NoPolicy
+	}
+	e.RLock()
+	defer e.RUnlock()
+	return e.nextQuery[nqKey{src: src, dst: dst, policy: string(policy)}], nil
+}
+
+func (e *executor) DeleteExpiredNQ(_ context.Context, now time.Time) (int, error) {
+	e.Lock()
+	defer e.Unlock()
+	var deleted int
+	for k, nextQuery := range e.nextQuery {
+		if nextQuery.Before(now) {
+			delete(e.nextQuery, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (e *executor) DeleteNQ(_ context.Context, src, dst addr.IA,
+	policy pathdb.PolicyHash) (int, error) {
+
+	e.Lock()
+	defer e.Unlock()
+	var deleted int
+	for k := range e.nextQuery {
+		if !src.IsZero() && !src.Equal(k.src) {
+			continue
+		}
+		if !dst.IsZero() && !dst.Equal(k.dst) {
+			continue
+		}
+		if policy != nil && string(policy) != k.policy {
+			continue
+		}
+		delete(e.nextQuery, k)
+		deleted++
+	}
+	return deleted, nil
+}
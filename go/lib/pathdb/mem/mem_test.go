@@ -0,0 +1,100 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mem
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pathdb/pathdbtest"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var _ pathdbtest.TestablePathDB = (*TestPathDB)(nil)
+
+type TestPathDB struct {
+	*Backend
+}
+
+func (b *TestPathDB) Prepare(t *testing.T, _ context.Context) {
+	b.Backend = New()
+}
+
+func TestPathDBSuite(t *testing.T) {
+	tdb := &TestPathDB{}
+	pathdbtest.TestPathDB(t, tdb)
+}
+
+// TestReturnedSegmentsAreCopies tests that modifying a returned segment does
+// not alter the stored segment.
+func TestReturnedSegmentsAreCopies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	db := New()
+	pseg, segID := pathdbtest.AllocPathSegment(t, ctrl, []uint64{0, 5, 2, 3, 6, 3, 1, 0}, 10)
+	pathdbtest.InsertSeg(t, ctx, db, pseg, []*query.HPCfgID{&query.NullHpCfgID})
+	params := &query.Params{SegIDs: []common.RawBytes{segID}}
+	res, err := db.Get(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	res[0].Seg.ASEntries = nil
+	res[0].HpCfgIDs[0].ID = 42
+	res, err = db.Get(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, pseg, res[0].Seg)
+	assert.Equal(t, []*query.HPCfgID{&query.NullHpCfgID}, res[0].HpCfgIDs)
+}
+
+// TestWriteBlockedByTransaction tests that writes outside of a transaction
+// wait until the transaction is done.
+func TestWriteBlockedByTransaction(t *testing.T) {
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	db := New()
+	src, dst := xtest.MustParseIA("1-ff00:0:110"), xtest.MustParseIA("1-ff00:0:120")
+	nextQuery := time.Now().Add(time.Minute)
+	tx, err := db.BeginTransaction(ctx, nil)
+	require.NoError(t, err)
+	_, err = tx.InsertNextQuery(ctx, src, dst, nil, nextQuery)
+	require.NoError(t, err)
+
+	shortCtx, shortCancelF := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer shortCancelF()
+	_, err = db.DeleteNQ(shortCtx, src, dst, nil)
+	assert.Error(t, err)
+	// Uncommitted changes are not visible outside of the transaction.
+	dbT, err := db.GetNextQuery(ctx, src, dst, nil)
+	require.NoError(t, err)
+	assert.Zero(t, dbT)
+
+	require.NoError(t, tx.Commit())
+	assert.Error(t, tx.Commit())
+	dbT, err = db.GetNextQuery(ctx, src, dst, nil)
+	require.NoError(t, err)
+	assert.Equal(t, nextQuery, dbT)
+	deleted, err := db.DeleteNQ(ctx, src, dst, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+}
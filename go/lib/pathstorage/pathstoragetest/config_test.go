@@ -46,3 +46,33 @@ func TestRevCacheConfSample(t *testing.T) {
 	assert.Empty(t, meta.Undecoded())
 	CheckTestRevCacheConf(t, &cfg)
 }
+
+func TestPathDBConfValidate(t *testing.T) {
+	tests := map[string]struct {
+		Cfg       pathstorage.PathDBConf
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"sqlite": {
+			Cfg:       pathstorage.PathDBConf{"backend": "sqlite", "connection": "test.db"},
+			Assertion: assert.NoError,
+		},
+		"sqlite without connection": {
+			Cfg:       pathstorage.PathDBConf{"backend": "sqlite"},
+			Assertion: assert.Error,
+		},
+		"mem without connection": {
+			Cfg:       pathstorage.PathDBConf{"backend": "mem"},
+			Assertion: assert.NoError,
+		},
+		"unknown backend": {
+			Cfg:       pathstorage.PathDBConf{"backend": "unknown", "connection": "test.db"},
+			Assertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.Cfg.InitDefaults()
+			test.Assertion(t, test.Cfg.Validate())
+		})
+	}
+}
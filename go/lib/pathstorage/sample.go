@@ -15,10 +15,11 @@
 package pathstorage
 
 const pathDbSample = `
-# The type of pathdb backend.
+# The type of pathdb backend. Either "sqlite" or "mem". (default "sqlite")
 backend = "sqlite"
 
-# Path to the path database. (required)
+# Path to the path database. Not used by the "mem" backend. (required for
+# "sqlite")
 connection = "/var/lib/scion/pathdb/%s.path.db"
 
 # The maximum number of open connections to the database. In case of the
@@ -35,7 +35,11 @@ type Ring struct {
 	writable   int
 	readable   int
 	closed     bool
-	metrics    metrics.Ringbuf
+	// prio holds the entries of the priority lane, which are read before all
+	// other entries. Its capacity is fixed, and zero if the ring has no
+	// priority lane.
+	prio    EntryList
+	metrics metrics.Ringbuf
 }
 
 // New allocates a new Ring instance, with capacity for count entries. If newf
@@ -64,6 +68,16 @@ func New(count int, newf NewEntryF, ringID string) *Ring {
 	return r
 }
 
+// NewPrio allocates a new Ring instance without pre-allocated entries, with
+// capacity for count entries and an additional priority lane with capacity
+// for prioCount entries.
+func NewPrio(count, prioCount int, ringID string) *Ring {
+	r := New(count, nil, ringID)
+	r.prio = make(EntryList, 0, prioCount)
+	r.metrics.MaxEntries.Set(float64(count + prioCount))
+	return r
+}
+
 // Write copies entries to the internal ring buffer. If block is true, then
 // Write will block until it is able to write at least one entry (or the Ring
 // is closed). Otherwise it will return immediately if there's on space left
@@ -95,7 +109,40 @@ func (r *Ring) Write(entries EntryList, block bool) (int, bool) {
 	r.readable += n
 	r.readableC.Broadcast()
 	r.metrics.WriteEntries.Observe(float64(n))
-	r.metrics.UsedEntries.Set(float64(r.readable))
+	r.metrics.UsedEntries.Set(float64(r.used()))
+	return n, blocked
+}
+
+// WritePrio copies entries to the priority lane of the ring buffer. Entries in
+// the priority lane are read before all entries written with Write. The
+// blocking behavior and return values are the same as for Write. If the ring
+// has no priority lane, WritePrio is equivalent to Write.
+func (r *Ring) WritePrio(entries EntryList, block bool) (int, bool) {
+	if cap(r.prio) == 0 {
+		return r.Write(entries, block)
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var blocked bool
+	r.metrics.WriteCalls.Inc()
+	if len(entries) > 0 && len(r.prio) == cap(r.prio) && !r.closed {
+		if !block {
+			return 0, blocked
+		}
+		r.metrics.WritesBlocked.Inc()
+		for len(r.prio) == cap(r.prio) && !r.closed {
+			blocked = true
+			r.writableC.Wait()
+		}
+	}
+	if r.closed {
+		return -1, blocked
+	}
+	n := min(cap(r.prio)-len(r.prio), len(entries))
+	r.prio = append(r.prio, entries[:n]...)
+	r.readableC.Broadcast()
+	r.metrics.WriteEntries.Observe(float64(n))
+	r.metrics.UsedEntries.Set(float64(r.used()))
 	return n, blocked
 }
 
@@ -111,29 +158,30 @@ func (r *Ring) Read(entries EntryList, block bool) (int, bool) {
 	defer r.mutex.Unlock()
 	var blocked bool
 	r.metrics.ReadCalls.Inc()
-	if len(entries) > 0 && r.readable == 0 && !r.closed {
+	if len(entries) > 0 && r.used() == 0 && !r.closed {
 		if !block {
 			return 0, blocked
 		}
 		r.metrics.ReadsBlocked.Inc()
-		for r.readable == 0 && !r.closed {
+		for r.used() == 0 && !r.closed {
 			blocked = true
 			r.readableC.Wait()
 		}
 	}
-	if r.closed && r.readable == 0 {
+	if r.closed && r.used() == 0 {
 		// Don't return -1 so long as there are still readable entries
 		// available.
 		return -1, blocked
 	}
-	n := min(r.readable, len(entries))
-	r.read(entries[:n])
+	p := r.readPrio(entries)
+	n := min(r.readable, len(entries)-p)
+	r.read(entries[p : p+n])
 	r.readable -= n
 	r.writable += n
 	r.writableC.Broadcast()
-	r.metrics.ReadEntries.Observe(float64(n))
-	r.metrics.UsedEntries.Set(float64(r.readable))
-	return n, blocked
+	r.metrics.ReadEntries.Observe(float64(p + n))
+	r.metrics.UsedEntries.Set(float64(r.used()))
+	return p + n, blocked
 }
 
 // Close closes the ring buffer, and causes all blocked readers/writers to be
@@ -176,6 +224,27 @@ func (r *Ring) read(entries EntryList) {
 	}
 }
 
+// readPrio moves entries from the priority lane to entries, and returns the
+// number of entries moved.
+func (r *Ring) readPrio(entries EntryList) int {
+	n := copy(entries, r.prio)
+	if n == 0 {
+		return 0
+	}
+	remaining := copy(r.prio, r.prio[n:])
+	// Remove references that were just read.
+	for i := remaining; i < len(r.prio); i++ {
+		r.prio[i] = nil
+	}
+	r.prio = r.prio[:remaining]
+	return n
+}
+
+// used returns the number of entries that can be read.
+func (r *Ring) used() int {
+	return r.readable + len(r.prio)
+}
+
 func min(x, y int) int {
 	if x < y {
 		return x
@@ -0,0 +1,159 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ringbuf_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/ringbuf"
+)
+
+func TestPrioOrder(t *testing.T) {
+	r := ringbuf.NewPrio(4, 2, "test")
+	n, _ := r.Write(ringbuf.EntryList{1, 2}, false)
+	assert.Equal(t, 2, n)
+	n, _ = r.WritePrio(ringbuf.EntryList{10, 11}, false)
+	assert.Equal(t, 2, n)
+	n, _ = r.Write(ringbuf.EntryList{3}, false)
+	assert.Equal(t, 1, n)
+
+	// The priority lane is read first, in order, and the remaining space is
+	// filled from the regular entries.
+	entries := make(ringbuf.EntryList, 3)
+	n, _ = r.Read(entries, false)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, ringbuf.EntryList{10, 11, 1}, entries)
+	// A priority entry written later is still read before the regular ones.
+	r.WritePrio(ringbuf.EntryList{12}, false)
+	n, _ = r.Read(entries, false)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, ringbuf.EntryList{12, 2, 3}, entries)
+	n, _ = r.Read(entries, false)
+	assert.Equal(t, 0, n)
+}
+
+func TestPrioPartialRead(t *testing.T) {
+	r := ringbuf.NewPrio(4, 4, "test")
+	r.Write(ringbuf.EntryList{1}, false)
+	r.WritePrio(ringbuf.EntryList{10, 11, 12}, false)
+	entries := make(ringbuf.EntryList, 2)
+	n, _ := r.Read(entries, false)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, ringbuf.EntryList{10, 11}, entries)
+	n, _ = r.Read(entries, false)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, ringbuf.EntryList{12, 1}, entries)
+}
+
+func TestPrioFull(t *testing.T) {
+	r := ringbuf.NewPrio(2, 2, "test")
+	n, blocked := r.WritePrio(ringbuf.EntryList{10, 11, 12}, false)
+	assert.Equal(t, 2, n, "only the capacity of the lane is written")
+	assert.False(t, blocked)
+	n, blocked = r.WritePrio(ringbuf.EntryList{12}, false)
+	assert.Equal(t, 0, n, "full lane")
+	assert.False(t, blocked)
+	// A full priority lane does not prevent regular writes.
+	n, _ = r.Write(ringbuf.EntryList{1, 2}, false)
+	assert.Equal(t, 2, n)
+}
+
+func TestPrioBlocking(t *testing.T) {
+	r := ringbuf.NewPrio(2, 1, "test")
+	r.WritePrio(ringbuf.EntryList{10}, false)
+	type result struct {
+		n       int
+		blocked bool
+	}
+	done := make(chan result, 1)
+	go func() {
+		n, blocked := r.WritePrio(ringbuf.EntryList{11}, true)
+		done <- result{n: n, blocked: blocked}
+	}()
+	select {
+	case <-done:
+		t.Fatal("write to full lane did not block")
+	case <-time.After(50 * time.Millisecond):
+	}
+	entries := make(ringbuf.EntryList, 1)
+	n, _ := r.Read(entries, true)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, ringbuf.EntryList{10}, entries)
+	select {
+	case res := <-done:
+		assert.Equal(t, result{n: 1, blocked: true}, res)
+	case <-time.After(time.Second):
+		t.Fatal("write not unblocked by read")
+	}
+	n, _ = r.Read(entries, true)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, ringbuf.EntryList{11}, entries)
+}
+
+func TestPrioBlockingRead(t *testing.T) {
+	r := ringbuf.NewPrio(2, 1, "test")
+	done := make(chan ringbuf.Entry, 1)
+	go func() {
+		entries := make(ringbuf.EntryList, 1)
+		r.Read(entries, true)
+		done <- entries[0]
+	}()
+	time.Sleep(50 * time.Millisecond)
+	r.WritePrio(ringbuf.EntryList{10}, false)
+	select {
+	case e := <-done:
+		assert.Equal(t, 10, e)
+	case <-time.After(time.Second):
+		t.Fatal("read not unblocked by priority write")
+	}
+}
+
+func TestPrioClose(t *testing.T) {
+	r := ringbuf.NewPrio(1, 1, "test")
+	r.WritePrio(ringbuf.EntryList{10}, false)
+	done := make(chan int, 1)
+	go func() {
+		n, _ := r.WritePrio(ringbuf.EntryList{11}, true)
+		done <- n
+	}()
+	time.Sleep(50 * time.Millisecond)
+	r.Close()
+	select {
+	case n := <-done:
+		assert.Equal(t, -1, n)
+	case <-time.After(time.Second):
+		t.Fatal("write not unblocked by close")
+	}
+	// Remaining priority entries can still be read after closing.
+	entries := make(ringbuf.EntryList, 1)
+	n, _ := r.Read(entries, false)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, ringbuf.EntryList{10}, entries)
+	n, _ = r.Read(entries, false)
+	assert.Equal(t, -1, n)
+}
+
+func TestWritePrioWithoutLane(t *testing.T) {
+	r := ringbuf.New(2, nil, "test")
+	r.Write(ringbuf.EntryList{1}, false)
+	n, _ := r.WritePrio(ringbuf.EntryList{2, 3}, false)
+	assert.Equal(t, 1, n, "same as Write")
+	entries := make(ringbuf.EntryList, 2)
+	r.Read(entries, false)
+	assert.Equal(t, ringbuf.EntryList{1, 2}, entries)
+}
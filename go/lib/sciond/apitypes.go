@@ -97,6 +97,15 @@ func ifinfoReplyToMap(ifinfoReply *IFInfoReply) map[common.IFIDType]*net.UDPAddr
 	return m
 }
 
+// PathUpdate is a path set pushed by SCIOND on a path subscription.
+type PathUpdate struct {
+	// Paths is the current path set.
+	Paths []snet.Path
+	// Err is set if the path lookup failed, or if the subscription ended
+	// because of an error.
+	Err error
+}
+
 type Path struct {
 	interfaces []pathInterface
 	overlay    *net.UDPAddr
@@ -104,6 +113,7 @@ type Path struct {
 	mtu        uint16
 	expiry     time.Time
 	dst        addr.IA
+	metadata   *snet.PathMetadata
 }
 
 func pathReplyToPaths(pathReply *PathReply, dst addr.IA) ([]snet.Path, error) {
@@ -138,6 +148,7 @@ func pathReplyEntryToPath(pe PathReplyEntry, dst addr.IA) (Path, error) {
 		spath:      sp,
 		mtu:        pe.Path.Mtu,
 		expiry:     pe.Path.Expiry(),
+		metadata:   pathMetadata(pe.Path.Metadata),
 	}
 	for _, intf := range pe.Path.Interfaces {
 		p.interfaces = append(p.interfaces, pathInterface{ia: intf.IA(), id: intf.ID()})
@@ -145,6 +156,51 @@ func pathReplyEntryToPath(pe PathReplyEntry, dst addr.IA) (Path, error) {
 	return p, nil
 }
 
+func pathMetadata(m *PathMetadata) *snet.PathMetadata {
+	if m == nil {
+		return nil
+	}
+	res := &snet.PathMetadata{
+		Latency:   make([]time.Duration, 0, len(m.Latency)),
+		Bandwidth: append([]uint64(nil), m.Bandwidth...),
+		Geo:       make([]snet.GeoCoordinates, 0, len(m.Geo)),
+		LinkType:  make([]snet.LinkType, 0, len(m.LinkType)),
+	}
+	for _, l := range m.Latency {
+		res.Latency = append(res.Latency, time.Duration(l)*time.Microsecond)
+	}
+	for _, g := range m.Geo {
+		res.Geo = append(res.Geo, snet.GeoCoordinates{
+			Latitude:  g.Latitude,
+			Longitude: g.Longitude,
+			Address:   g.Address,
+		})
+	}
+	for _, t := range m.LinkType {
+		res.LinkType = append(res.LinkType, snet.LinkType(t))
+	}
+	for _, h := range m.Hops {
+		res.Hops = append(res.Hops, snet.HopMetadata{
+			IA:          h.IA(),
+			Ingress:     h.Ingress,
+			Egress:      h.Egress,
+			IngressMTU:  h.IngressMTU,
+			InternalMTU: h.InternalMTU,
+			EgressMTU:   h.EgressMTU,
+			Extensions:  append([]string(nil), h.Extensions...),
+		})
+	}
+	if len(m.ISDHops) > 0 {
+		res.ISDHops = make(map[addr.ISD]int, len(m.ISDHops))
+		for _, c := range m.ISDHops {
+			res.ISDHops[c.ISD] += int(c.Hops)
+		}
+	}
+	res.Peering = m.Peering
+	res.Shortcut = m.Shortcut
+	return res
+}
+
 func (p Path) Fingerprint() snet.PathFingerprint {
 	if len(p.interfaces) == 0 {
 		return ""
@@ -201,6 +257,10 @@ func (p Path) Expiry() time.Time {
 	return p.expiry
 }
 
+func (p Path) Metadata() *snet.PathMetadata {
+	return p.metadata.Copy()
+}
+
 func (p Path) Copy() snet.Path {
 	return Path{
 		interfaces: append(p.interfaces[:0:0], p.interfaces...),
@@ -208,6 +268,7 @@ func (p Path) Copy() snet.Path {
 		spath:      p.Path(),           // creates copy
 		mtu:        p.mtu,
 		expiry:     p.expiry,
+		metadata:   p.metadata.Copy(),
 	}
 }
 
@@ -118,6 +118,10 @@ func (p Path) Expiry() time.Time {
 	return p.expirationTime
 }
 
+func (p Path) Metadata() *snet.PathMetadata {
+	return nil
+}
+
 func (p Path) Copy() snet.Path {
 	return &Path{
 		JSONFingerprint: p.JSONFingerprint,
@@ -187,6 +191,12 @@ func (c connector) Paths(_ context.Context, _, _ addr.IA,
 	return c.adapter(entry.Paths[:intMax]), nil
 }
 
+func (c connector) SubscribePaths(ctx context.Context, dst, src addr.IA,
+	flags sciond.PathReqFlags) (<-chan sciond.PathUpdate, error) {
+
+	panic("not implemented")
+}
+
 func (c connector) adapter(paths []*Path) []snet.Path {
 	var snetPaths []snet.Path
 	for _, path := range paths {
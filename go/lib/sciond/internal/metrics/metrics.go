@@ -57,6 +57,8 @@ func (l resultLabel) Values() []string {
 var (
 	// PathRequests contains metrics for path requests.
 	PathRequests = newPathRequest()
+	// PathSubscriptions contains metrics for path subscriptions.
+	PathSubscriptions = newPathSubscription()
 	// Revocations contains metrics for revocations.
 	Revocations = newRevocation()
 	// ASInfos contains metrics for AS info requests.
@@ -93,6 +95,13 @@ func newPathRequest() Request {
 	}
 }
 
+func newPathSubscription() Request {
+	return Request{
+		count: prom.NewCounterVecWithLabels(Namespace, subsystemPath, "subscriptions_total",
+			"The amount of Path subscriptions sent.", resultLabel{}),
+	}
+}
+
 func newRevocation() Request {
 	return Request{
 		count: prom.NewCounterVecWithLabels(Namespace, subsystemRevocation, "requests_total",
@@ -31,6 +31,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	capnp "zombiezen.com/go/capnproto2"
 	"zombiezen.com/go/capnproto2/pogs"
@@ -38,6 +39,7 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/sciond/internal/metrics"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -84,6 +86,16 @@ func (s *service) Connect(ctx context.Context) (Connector, error) {
 type Connector interface {
 	// Paths requests from SCIOND a set of end to end paths between src and
 	Paths(ctx context.Context, dst, src addr.IA, f PathReqFlags) ([]snet.Path, error)
+	// SubscribePaths subscribes to the end to end paths between src and dst.
+	// The current path set is sent on the returned channel immediately, and
+	// an update is sent whenever the path set changes. The context covers the
+	// whole subscription, i.e., a context deadline ends the subscription. The
+	// subscription ends when the context is done or the connection to SCIOND
+	// fails. In both cases, a final update with the error is sent before the
+	// channel is closed. Once the context is done, an update that has not been
+	// received yet is replaced by the final update.
+	SubscribePaths(ctx context.Context, dst, src addr.IA,
+		f PathReqFlags) (<-chan PathUpdate, error)
 	// ASInfo requests from SCIOND information about AS ia.
 	ASInfo(ctx context.Context, ia addr.IA) (*ASInfoReply, error)
 	// IFInfo requests from SCIOND addresses and ports of interfaces. Slice
@@ -177,6 +189,99 @@ func (c *conn) Paths(ctx context.Context, dst, src addr.IA,
 	return pathReplyToPaths(reply.PathReply, dst)
 }
 
+func (c *conn) SubscribePaths(ctx context.Context, dst, src addr.IA,
+	f PathReqFlags) (<-chan PathUpdate, error) {
+
+	conn, err := c.connect(ctx)
+	if err != nil {
+		metrics.PathSubscriptions.Inc(errorToPrometheusLabel(err))
+		return nil, serrors.Wrap(ErrUnableToConnect, err)
+	}
+	err = Send(
+		&Pld{
+			TraceId: tracing.IDFromCtx(ctx),
+			Which:   proto.SCIONDMsg_Which_pathSubscriptionReq,
+			PathSubscriptionReq: &PathReq{
+				Dst:   dst.IAInt(),
+				Src:   src.IAInt(),
+				Flags: f,
+			},
+		},
+		conn,
+	)
+	if err == nil {
+		err = conn.SetDeadline(time.Time{})
+	}
+	if err != nil {
+		conn.Close()
+		metrics.PathSubscriptions.Inc(errorToPrometheusLabel(err))
+		return nil, serrors.WrapStr("[sciond-API] Failed to subscribe to Paths", err)
+	}
+	metrics.PathSubscriptions.Inc(metrics.OkSuccess)
+	// The buffer allows sending the final update without blocking on a
+	// caller that stopped receiving.
+	updates := make(chan PathUpdate, 1)
+	done := make(chan struct{})
+	go func() {
+		defer log.HandlePanic()
+		// Unblock the receiving goroutine when the subscription is canceled.
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		conn.Close()
+	}()
+	go func() {
+		defer log.HandlePanic()
+		defer close(updates)
+		defer close(done)
+		receivePathUpdates(ctx, conn, dst, updates)
+		if ctx.Err() == nil {
+			return
+		}
+		// Drop the pending update to make room for the final update.
+		select {
+		case <-updates:
+		default:
+		}
+		updates <- PathUpdate{
+			Err: serrors.WrapStr("[sciond-API] Path subscription ended", ctx.Err()),
+		}
+	}()
+	return updates, nil
+}
+
+// receivePathUpdates forwards the path updates received on conn until the
+// context is done or the subscription fails.
+func receivePathUpdates(ctx context.Context, conn net.Conn, dst addr.IA,
+	updates chan<- PathUpdate) {
+
+	for {
+		pld, err := receive(conn)
+		if ctx.Err() != nil {
+			return
+		}
+		var update PathUpdate
+		switch {
+		case err != nil:
+			update.Err = serrors.WrapStr("[sciond-API] Path subscription failed", err)
+		case pld.Which != proto.SCIONDMsg_Which_pathUpdate:
+			update.Err = serrors.New("[sciond-API] Unexpected message on path subscription",
+				"type", pld.Which)
+		default:
+			update.Paths, update.Err = pathReplyToPaths(pld.PathUpdate, dst)
+		}
+		select {
+		case updates <- update:
+		case <-ctx.Done():
+			return
+		}
+		if err != nil || pld.Which != proto.SCIONDMsg_Which_pathUpdate {
+			return
+		}
+	}
+}
+
 func (c *conn) ASInfo(ctx context.Context, ia addr.IA) (*ASInfoReply, error) {
 	conn, err := c.connect(ctx)
 	if err != nil {
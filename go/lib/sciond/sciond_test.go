@@ -0,0 +1,176 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sciond
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestSubscribePaths(t *testing.T) {
+	dst := xtest.MustParseIA("1-ff00:0:110")
+	src := xtest.MustParseIA("1-ff00:0:111")
+	found := &PathReply{
+		ErrorCode: ErrorOk,
+		Entries:   []PathReplyEntry{{Path: &FwdPathMeta{}}},
+	}
+	notFound := &PathReply{ErrorCode: ErrorNoPaths}
+
+	t.Run("connection closed by SCIOND", func(t *testing.T) {
+		subs := serveSubscriptions(t, dst, src)
+		c, err := NewService(subs.addr).Connect(context.Background())
+		require.NoError(t, err)
+		updates, err := c.SubscribePaths(context.Background(), dst, src, PathReqFlags{})
+		require.NoError(t, err)
+
+		conn := subs.accept(t)
+		sendUpdate(t, conn, found)
+		update := receiveUpdate(t, updates)
+		assert.NoError(t, update.Err)
+		assert.Len(t, update.Paths, 1)
+		// Failed lookups do not end the subscription.
+		sendUpdate(t, conn, notFound)
+		update = receiveUpdate(t, updates)
+		assert.Error(t, update.Err)
+		assert.Empty(t, update.Paths)
+
+		require.NoError(t, conn.Close())
+		update = receiveUpdate(t, updates)
+		assert.Error(t, update.Err)
+		assertClosed(t, updates)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		subs := serveSubscriptions(t, dst, src)
+		c, err := NewService(subs.addr).Connect(context.Background())
+		require.NoError(t, err)
+		ctx, cancelF := context.WithCancel(context.Background())
+		defer cancelF()
+		updates, err := c.SubscribePaths(ctx, dst, src, PathReqFlags{})
+		require.NoError(t, err)
+
+		conn := subs.accept(t)
+		defer conn.Close()
+		sendUpdate(t, conn, found)
+		update := receiveUpdate(t, updates)
+		assert.NoError(t, update.Err)
+
+		// The update that is not received is replaced by the final update.
+		sendUpdate(t, conn, found)
+		time.Sleep(10 * time.Millisecond)
+		cancelF()
+		update = receiveUpdate(t, updates)
+		assert.True(t, errors.Is(update.Err, context.Canceled), update.Err)
+		assertClosed(t, updates)
+	})
+
+	t.Run("deadline ends subscription", func(t *testing.T) {
+		subs := serveSubscriptions(t, dst, src)
+		c, err := NewService(subs.addr).Connect(context.Background())
+		require.NoError(t, err)
+		ctx, cancelF := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancelF()
+		updates, err := c.SubscribePaths(ctx, dst, src, PathReqFlags{})
+		require.NoError(t, err)
+
+		conn := subs.accept(t)
+		defer conn.Close()
+		update := receiveUpdate(t, updates)
+		assert.True(t, errors.Is(update.Err, context.DeadlineExceeded), update.Err)
+		assertClosed(t, updates)
+	})
+}
+
+// subscriptionServer is a minimal SCIOND that accepts path subscriptions.
+type subscriptionServer struct {
+	addr  string
+	conns chan net.Conn
+}
+
+// serveSubscriptions starts a server that checks the subscription request
+// and hands out the connection. Connections without request, i.e., the
+// liveness check of the connector, are closed.
+func serveSubscriptions(t *testing.T, dst, src addr.IA) *subscriptionServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &subscriptionServer{addr: listener.Addr().String(), conns: make(chan net.Conn, 1)}
+	go func() {
+		defer listener.Close()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			pld, err := receive(conn)
+			if err != nil {
+				conn.Close()
+				continue
+			}
+			assert.Equal(t, proto.SCIONDMsg_Which_pathSubscriptionReq, pld.Which)
+			if pld.PathSubscriptionReq != nil {
+				assert.Equal(t, dst, pld.PathSubscriptionReq.Dst.IA())
+				assert.Equal(t, src, pld.PathSubscriptionReq.Src.IA())
+			}
+			s.conns <- conn
+			return
+		}
+	}()
+	return s
+}
+
+func (s *subscriptionServer) accept(t *testing.T) net.Conn {
+	select {
+	case conn := <-s.conns:
+		return conn
+	case <-time.After(time.Second):
+		t.Fatal("Timed out")
+	}
+	return nil
+}
+
+func sendUpdate(t *testing.T, conn net.Conn, reply *PathReply) {
+	pld := &Pld{Which: proto.SCIONDMsg_Which_pathUpdate, PathUpdate: reply}
+	require.NoError(t, Send(pld, conn))
+}
+
+func receiveUpdate(t *testing.T, updates <-chan PathUpdate) PathUpdate {
+	select {
+	case update, ok := <-updates:
+		require.True(t, ok, "channel closed")
+		return update
+	case <-time.After(time.Second):
+		t.Fatal("Timed out")
+	}
+	return PathUpdate{}
+}
+
+func assertClosed(t *testing.T, updates <-chan PathUpdate) {
+	select {
+	case _, ok := <-updates:
+		assert.False(t, ok, "channel not closed")
+	case <-time.After(time.Second):
+		t.Fatal("Timed out")
+	}
+}
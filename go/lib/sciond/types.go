@@ -74,6 +74,11 @@ type Pld struct {
 	IfInfoReply        *IFInfoReply
 	ServiceInfoRequest *ServiceInfoRequest
 	ServiceInfoReply   *ServiceInfoReply
+	// PathSubscriptionReq subscribes to the paths to a destination. SCIOND
+	// keeps the connection open and pushes the path set as PathUpdate
+	// whenever it changes.
+	PathSubscriptionReq *PathReq
+	PathUpdate          *PathReply
 }
 
 func NewPldFromRaw(b common.RawBytes) (*Pld, error) {
@@ -118,6 +123,10 @@ func (p *Pld) union() (interface{}, error) {
 		return p.ServiceInfoRequest, nil
 	case proto.SCIONDMsg_Which_serviceInfoReply:
 		return p.ServiceInfoReply, nil
+	case proto.SCIONDMsg_Which_pathSubscriptionReq:
+		return p.PathSubscriptionReq, nil
+	case proto.SCIONDMsg_Which_pathUpdate:
+		return p.PathUpdate, nil
 	}
 	return nil, common.NewBasicError("Unsupported SCIOND union type", nil, "type", p.Which)
 }
@@ -149,6 +158,9 @@ type PathReqFlags struct {
 	PathCount uint16 `capnp:"-"`
 	Refresh   bool
 	Hidden    bool
+	// Disjoint requests the paths to be ranked such that the returned paths
+	// are as link and AS disjoint as possible.
+	Disjoint bool
 }
 
 type PathReply struct {
@@ -188,6 +200,7 @@ type FwdPathMeta struct {
 	Mtu        uint16
 	Interfaces []PathInterface
 	ExpTime    uint32
+	Metadata   *PathMetadata
 }
 
 func (fpm *FwdPathMeta) SrcIA() addr.IA {
@@ -220,6 +233,7 @@ func (fpm *FwdPathMeta) Copy() *FwdPathMeta {
 		res.Interfaces = make([]PathInterface, len(fpm.Interfaces))
 		copy(res.Interfaces, fpm.Interfaces)
 	}
+	res.Metadata = fpm.Metadata.Copy()
 	return res
 }
 
@@ -245,6 +259,88 @@ func (fpm *FwdPathMeta) fmtIfaces() []string {
 	return hops
 }
 
+// PathMetadata contains the metadata of a path, as announced by the ASes on
+// the path. The static metadata (latency, bandwidth, geo and link type) is
+// only set if any AS on the path carries the static info extension. Unknown
+// values are zero.
+type PathMetadata struct {
+	// Latency lists the latencies in microseconds between any two consecutive
+	// interfaces. Entry i describes the latency between interface i and i+1.
+	Latency []uint32
+	// Bandwidth lists the bandwidth in Kbit/s between any two consecutive
+	// interfaces. Entry i describes the bandwidth between interface i and i+1.
+	Bandwidth []uint64
+	// Geo lists the location of each interface.
+	Geo []GeoCoordinates
+	// LinkType lists the type of each inter-AS link. Entry i describes the
+	// link between interface 2*i and 2*i+1.
+	LinkType []uint8
+	// Hops lists the metadata of each AS hop in the direction of the path.
+	Hops []PathHopMetadata
+	// ISDHops lists the number of AS hops in each ISD, in the order the ISDs
+	// are traversed.
+	ISDHops []ISDHopCount `capnp:"isdHops"`
+	// Peering indicates whether the path crosses a peering link.
+	Peering bool
+	// Shortcut indicates whether the path uses a shortcut.
+	Shortcut bool
+}
+
+func (m *PathMetadata) Copy() *PathMetadata {
+	if m == nil {
+		return nil
+	}
+	res := &PathMetadata{
+		Latency:   append([]uint32(nil), m.Latency...),
+		Bandwidth: append([]uint64(nil), m.Bandwidth...),
+		Geo:       append([]GeoCoordinates(nil), m.Geo...),
+		LinkType:  append([]uint8(nil), m.LinkType...),
+		ISDHops:   append([]ISDHopCount(nil), m.ISDHops...),
+		Peering:   m.Peering,
+		Shortcut:  m.Shortcut,
+	}
+	for _, hop := range m.Hops {
+		hop.Extensions = append([]string(nil), hop.Extensions...)
+		res.Hops = append(res.Hops, hop)
+	}
+	return res
+}
+
+// GeoCoordinates is the location of an interface.
+type GeoCoordinates struct {
+	Latitude  float32
+	Longitude float32
+	Address   string
+}
+
+// PathHopMetadata is the metadata of an AS hop on a path.
+type PathHopMetadata struct {
+	RawIsdas addr.IAInt `capnp:"isdas"`
+	// Ingress is the ingress interface. It is zero for the first AS.
+	Ingress common.IFIDType
+	// Egress is the egress interface. It is zero for the last AS.
+	Egress common.IFIDType
+	// IngressMTU is the MTU of the link attached to the ingress interface.
+	IngressMTU uint16 `capnp:"ingressMTU"`
+	// InternalMTU is the MTU within the AS.
+	InternalMTU uint16 `capnp:"internalMTU"`
+	// EgressMTU is the MTU of the link attached to the egress interface.
+	EgressMTU uint16 `capnp:"egressMTU"`
+	// Extensions lists the names of the extensions in the AS entries. Only the
+	// presence of an extension is reported, not its content.
+	Extensions []string
+}
+
+func (h PathHopMetadata) IA() addr.IA {
+	return h.RawIsdas.IA()
+}
+
+// ISDHopCount is the number of AS hops of a path in an ISD.
+type ISDHopCount struct {
+	ISD  addr.ISD `capnp:"isd"`
+	Hops uint16
+}
+
 type PathInterface struct {
 	RawIsdas addr.IAInt `capnp:"isdas"`
 	IfID     common.IFIDType
@@ -0,0 +1,99 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renewal
+
+import (
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// PrivateKey is a private key that is used to sign the renewal request, or to
+// create a proof of possession.
+type PrivateKey struct {
+	// Type indicates the key type.
+	Type KeyType
+	// Version is the key version.
+	Version scrypto.KeyVersion
+	// Algorithm indicates the algorithm associated with the key.
+	Algorithm string
+	// Key is the raw private key.
+	Key []byte
+}
+
+// NewSignedRequest creates a signed renewal request. For every key in pops, a
+// proof of possession is added. The whole request is signed with the
+// currently active signing key of the requester.
+func NewSignedRequest(info *RequestInfo, signingKey PrivateKey,
+	pops ...PrivateKey) (SignedRequest, error) {
+
+	if signingKey.Type != SigningKey {
+		return SignedRequest{}, serrors.WithCtx(ErrInvalidKeyType, "expected", SigningKey,
+			"actual", signingKey.Type)
+	}
+	encInfo, err := EncodeRequestInfo(info)
+	if err != nil {
+		return SignedRequest{}, serrors.WrapStr("unable to encode request info", err)
+	}
+	req := Request{Encoded: encInfo}
+	for _, key := range pops {
+		pop, err := newPOP(encInfo, key)
+		if err != nil {
+			return SignedRequest{}, serrors.WrapStr("unable to create proof of possession", err,
+				"key_type", key.Type)
+		}
+		req.POPs = append(req.POPs, pop)
+	}
+	enc, err := EncodeRequest(&req)
+	if err != nil {
+		return SignedRequest{}, serrors.WrapStr("unable to encode request", err)
+	}
+	protected, err := encodeProtected(signingKey)
+	if err != nil {
+		return SignedRequest{}, err
+	}
+	signed := SignedRequest{
+		Encoded:          enc,
+		EncodedProtected: protected,
+	}
+	if signed.Signature, err = scrypto.Sign(signed.SigInput(), signingKey.Key,
+		signingKey.Algorithm); err != nil {
+		return SignedRequest{}, serrors.WrapStr("unable to sign request", err)
+	}
+	return signed, nil
+}
+
+func newPOP(info EncodedRequestInfo, key PrivateKey) (POP, error) {
+	protected, err := encodeProtected(key)
+	if err != nil {
+		return POP{}, err
+	}
+	pop := POP{Protected: protected}
+	if pop.Signature, err = scrypto.Sign(pop.SigInput(info), key.Key, key.Algorithm); err != nil {
+		return POP{}, err
+	}
+	return pop, nil
+}
+
+func encodeProtected(key PrivateKey) (EncodedProtected, error) {
+	protected, err := EncodeProtected(Protected{
+		Algorithm:  key.Algorithm,
+		KeyType:    key.Type,
+		KeyVersion: key.Version,
+	})
+	if err != nil {
+		return "", serrors.WrapStr("unable to encode protected", err, "key_type", key.Type)
+	}
+	return protected, nil
+}
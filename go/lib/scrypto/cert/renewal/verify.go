@@ -0,0 +1,135 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renewal
+
+import (
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var (
+	// ErrUnexpectedSubject indicates that the request subject does not match
+	// the subject of the AS certificate.
+	ErrUnexpectedSubject = serrors.New("unexpected subject")
+	// ErrInvalidProtected indicates that the protected header of the request
+	// does not match the key in the AS certificate.
+	ErrInvalidProtected = serrors.New("invalid protected meta")
+	// ErrMissingPOP indicates that the proof of possession for a requested
+	// key is missing.
+	ErrMissingPOP = serrors.New("missing proof of possession")
+	// ErrUnexpectedPOP indicates a proof of possession for a key that is not
+	// requested, or a duplicate proof of possession.
+	ErrUnexpectedPOP = serrors.New("unexpected proof of possession")
+)
+
+// RequestVerifier verifies the signed renewal request based on the currently
+// active AS certificate of the requester. The caller must ensure that the AS
+// certificate is verified.
+type RequestVerifier struct {
+	AS *cert.AS
+}
+
+// Verify verifies the signature and all proofs of possession of the signed
+// request. It returns the request info and the metadata of all keys that
+// should be authenticated by the new certificate.
+func (v RequestVerifier) Verify(signed SignedRequest) (RequestInfo,
+	map[cert.KeyType]scrypto.KeyMeta, error) {
+
+	p, err := signed.EncodedProtected.Decode()
+	if err != nil {
+		return RequestInfo{}, nil, serrors.WrapStr("unable to decode protected", err)
+	}
+	meta, ok := v.AS.Keys[cert.SigningKey]
+	if !ok {
+		return RequestInfo{}, nil, serrors.New("AS certificate without signing key")
+	}
+	expected := Protected{
+		Algorithm:  meta.Algorithm,
+		KeyType:    SigningKey,
+		KeyVersion: meta.KeyVersion,
+	}
+	if p != expected {
+		return RequestInfo{}, nil, serrors.WithCtx(ErrInvalidProtected,
+			"expected", expected, "actual", p)
+	}
+	if err := scrypto.Verify(signed.SigInput(), signed.Signature, meta.Key,
+		meta.Algorithm); err != nil {
+		return RequestInfo{}, nil, serrors.WrapStr("invalid request signature", err)
+	}
+	req, err := signed.Encoded.Decode()
+	if err != nil {
+		return RequestInfo{}, nil, serrors.WrapStr("unable to decode request", err)
+	}
+	info, keys, err := VerifyPOPs(req)
+	if err != nil {
+		return RequestInfo{}, nil, err
+	}
+	if !info.Subject.Equal(v.AS.Subject) {
+		return RequestInfo{}, nil, serrors.WithCtx(ErrUnexpectedSubject,
+			"expected", v.AS.Subject, "actual", info.Subject)
+	}
+	return info, keys, nil
+}
+
+// VerifyPOPs verifies the proofs of possession of the request. It returns the
+// request info and the metadata of all keys that should be authenticated by
+// the new certificate. The signing key is mandatory, the revocation key is
+// optional.
+func VerifyPOPs(req Request) (RequestInfo, map[cert.KeyType]scrypto.KeyMeta, error) {
+	info, err := req.Encoded.Decode()
+	if err != nil {
+		return RequestInfo{}, nil, serrors.WrapStr("unable to decode request info", err)
+	}
+	requested := map[KeyType]KeyMeta{SigningKey: info.Keys.Signing}
+	if len(info.Keys.Revocation.Key) != 0 {
+		requested[RevocationKey] = info.Keys.Revocation
+	}
+	keys := make(map[cert.KeyType]scrypto.KeyMeta, len(requested))
+	for _, pop := range req.POPs {
+		p, err := pop.Protected.Decode()
+		if err != nil {
+			return RequestInfo{}, nil, serrors.WrapStr("unable to decode POP protected", err)
+		}
+		keyType := certKeyType(p.KeyType)
+		key, ok := requested[p.KeyType]
+		if _, dup := keys[keyType]; !ok || dup {
+			return RequestInfo{}, nil, serrors.WithCtx(ErrUnexpectedPOP, "key_type", p.KeyType)
+		}
+		if err := scrypto.Verify(pop.SigInput(req.Encoded), pop.Signature, key.Key,
+			p.Algorithm); err != nil {
+			return RequestInfo{}, nil, serrors.WrapStr("invalid proof of possession", err,
+				"key_type", p.KeyType)
+		}
+		keys[keyType] = scrypto.KeyMeta{
+			KeyVersion: p.KeyVersion,
+			Algorithm:  p.Algorithm,
+			Key:        key.Key,
+		}
+	}
+	for keyType := range requested {
+		if _, ok := keys[certKeyType(keyType)]; !ok {
+			return RequestInfo{}, nil, serrors.WithCtx(ErrMissingPOP, "key_type", keyType)
+		}
+	}
+	return info, keys, nil
+}
+
+func certKeyType(t KeyType) cert.KeyType {
+	if t == RevocationKey {
+		return cert.RevocationKey
+	}
+	return cert.SigningKey
+}
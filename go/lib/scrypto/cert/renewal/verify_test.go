@@ -0,0 +1,190 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renewal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/scrypto/cert"
+	"github.com/scionproto/scion/go/lib/scrypto/cert/renewal"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestRequestVerifierVerify(t *testing.T) {
+	curPub, curPriv := genKey(t)
+	newSignPub, newSignPriv := genKey(t)
+	newRevPub, newRevPriv := genKey(t)
+	_, otherPriv := genKey(t)
+
+	current := &cert.AS{
+		Base: cert.Base{
+			Subject: xtest.MustParseIA("1-ff00:0:111"),
+			Version: 1,
+			Keys: map[cert.KeyType]scrypto.KeyMeta{
+				cert.SigningKey: {
+					KeyVersion: 1,
+					Algorithm:  scrypto.Ed25519,
+					Key:        curPub,
+				},
+			},
+		},
+	}
+	signingKey := renewal.PrivateKey{
+		Type:      renewal.SigningKey,
+		Version:   1,
+		Algorithm: scrypto.Ed25519,
+		Key:       curPriv,
+	}
+	signPOP := renewal.PrivateKey{
+		Type:      renewal.SigningKey,
+		Version:   2,
+		Algorithm: scrypto.Ed25519,
+		Key:       newSignPriv,
+	}
+	revPOP := renewal.PrivateKey{
+		Type:      renewal.RevocationKey,
+		Version:   1,
+		Algorithm: scrypto.Ed25519,
+		Key:       newRevPriv,
+	}
+
+	tests := map[string]struct {
+		Modify     func(info *renewal.RequestInfo)
+		SigningKey renewal.PrivateKey
+		POPs       []renewal.PrivateKey
+		Assertion  assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			Modify:     func(*renewal.RequestInfo) {},
+			SigningKey: signingKey,
+			POPs:       []renewal.PrivateKey{signPOP, revPOP},
+			Assertion:  assert.NoError,
+		},
+		"valid without revocation key": {
+			Modify: func(info *renewal.RequestInfo) {
+				info.Keys.Revocation = renewal.KeyMeta{}
+			},
+			SigningKey: signingKey,
+			POPs:       []renewal.PrivateKey{signPOP},
+			Assertion:  assert.NoError,
+		},
+		"wrong subject": {
+			Modify: func(info *renewal.RequestInfo) {
+				info.Subject = xtest.MustParseIA("1-ff00:0:112")
+			},
+			SigningKey: signingKey,
+			POPs:       []renewal.PrivateKey{signPOP, revPOP},
+			Assertion:  assert.Error,
+		},
+		"signed with unknown key version": {
+			Modify: func(*renewal.RequestInfo) {},
+			SigningKey: renewal.PrivateKey{
+				Type:      renewal.SigningKey,
+				Version:   2,
+				Algorithm: scrypto.Ed25519,
+				Key:       curPriv,
+			},
+			POPs:      []renewal.PrivateKey{signPOP, revPOP},
+			Assertion: assert.Error,
+		},
+		"signed with wrong key": {
+			Modify: func(*renewal.RequestInfo) {},
+			SigningKey: renewal.PrivateKey{
+				Type:      renewal.SigningKey,
+				Version:   1,
+				Algorithm: scrypto.Ed25519,
+				Key:       otherPriv,
+			},
+			POPs:      []renewal.PrivateKey{signPOP, revPOP},
+			Assertion: assert.Error,
+		},
+		"missing POP": {
+			Modify:     func(*renewal.RequestInfo) {},
+			SigningKey: signingKey,
+			POPs:       []renewal.PrivateKey{signPOP},
+			Assertion:  assert.Error,
+		},
+		"duplicate POP": {
+			Modify:     func(*renewal.RequestInfo) {},
+			SigningKey: signingKey,
+			POPs:       []renewal.PrivateKey{signPOP, signPOP, revPOP},
+			Assertion:  assert.Error,
+		},
+		"POP with wrong key": {
+			Modify:     func(*renewal.RequestInfo) {},
+			SigningKey: signingKey,
+			POPs: []renewal.PrivateKey{signPOP, {
+				Type:      renewal.RevocationKey,
+				Version:   1,
+				Algorithm: scrypto.Ed25519,
+				Key:       otherPriv,
+			}},
+			Assertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			info := newRequestInfo(time.Now())
+			info.Keys = renewal.Keys{
+				Signing:    renewal.KeyMeta{Key: newSignPub},
+				Revocation: renewal.KeyMeta{Key: newRevPub},
+			}
+			test.Modify(&info)
+			signed, err := renewal.NewSignedRequest(&info, test.SigningKey, test.POPs...)
+			require.NoError(t, err)
+
+			v := renewal.RequestVerifier{AS: current}
+			decoded, keys, err := v.Verify(signed)
+			test.Assertion(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, info, decoded)
+			assert.Equal(t, scrypto.KeyMeta{
+				KeyVersion: 2,
+				Algorithm:  scrypto.Ed25519,
+				Key:        newSignPub,
+			}, keys[cert.SigningKey])
+			if len(info.Keys.Revocation.Key) != 0 {
+				assert.Equal(t, newRevPub, []byte(keys[cert.RevocationKey].Key))
+			} else {
+				assert.NotContains(t, keys, cert.RevocationKey)
+			}
+		})
+	}
+}
+
+func TestNewSignedRequestWrongKeyType(t *testing.T) {
+	_, priv := genKey(t)
+	info := newRequestInfo(time.Now())
+	_, err := renewal.NewSignedRequest(&info, renewal.PrivateKey{
+		Type:      renewal.RevocationKey,
+		Version:   1,
+		Algorithm: scrypto.Ed25519,
+		Key:       priv,
+	})
+	assert.Error(t, err)
+}
+
+func genKey(t *testing.T) ([]byte, []byte) {
+	pub, priv, err := scrypto.GenKeyPair(scrypto.Ed25519)
+	require.NoError(t, err)
+	return pub, priv
+}
@@ -60,6 +60,9 @@ type Path interface {
 	// Expiry returns the expiration time of the path. If the result is a zero
 	// value expiration time is unknown.
 	Expiry() time.Time
+	// Metadata returns the static metadata of the path. If the metadata is
+	// not available the result is nil.
+	Metadata() *PathMetadata
 	// Copy create a copy of the path.
 	Copy() Path
 }
@@ -114,6 +117,10 @@ func (p *partialPath) Expiry() time.Time {
 	return time.Time{}
 }
 
+func (p *partialPath) Metadata() *PathMetadata {
+	return nil
+}
+
 func (p *partialPath) Copy() Path {
 	if p == nil {
 		return nil
@@ -0,0 +1,161 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// LinkType describes the underlying network of an inter-AS link.
+type LinkType uint8
+
+const (
+	// LinkTypeUnset indicates that the link type is not known.
+	LinkTypeUnset LinkType = iota
+	// LinkTypeDirect indicates a direct physical connection.
+	LinkTypeDirect
+	// LinkTypeMultihop indicates a connection with local routing/switching.
+	LinkTypeMultihop
+	// LinkTypeOpennet indicates a connection overlayed over the public
+	// Internet.
+	LinkTypeOpennet
+)
+
+func (t LinkType) String() string {
+	switch t {
+	case LinkTypeUnset:
+		return "unset"
+	case LinkTypeDirect:
+		return "direct"
+	case LinkTypeMultihop:
+		return "multihop"
+	case LinkTypeOpennet:
+		return "opennet"
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", uint8(t))
+}
+
+// PathMetadata contains the metadata of a path, as announced by the ASes on
+// the path. The static metadata (latency, bandwidth, geo and link type) is
+// only set if any AS on the path announces it. Unknown values are zero.
+type PathMetadata struct {
+	// Latency lists the latencies between any two consecutive interfaces.
+	// Entry i describes the latency between interface i and i+1.
+	Latency []time.Duration
+	// Bandwidth lists the bandwidth in Kbit/s between any two consecutive
+	// interfaces. Entry i describes the bandwidth between interface i and i+1.
+	Bandwidth []uint64
+	// Geo lists the location of each interface.
+	Geo []GeoCoordinates
+	// LinkType lists the type of each inter-AS link. Entry i describes the
+	// link between interface 2*i and 2*i+1.
+	LinkType []LinkType
+	// Hops lists the metadata of each AS hop in the direction of the path.
+	Hops []HopMetadata
+	// ISDHops maps each ISD on the path to the number of AS hops in it.
+	ISDHops map[addr.ISD]int
+	// Peering indicates whether the path crosses a peering link.
+	Peering bool
+	// Shortcut indicates whether the path uses a shortcut.
+	Shortcut bool
+}
+
+// HopMetadata is the metadata of an AS hop on a path. Unknown values are
+// zero.
+type HopMetadata struct {
+	IA addr.IA
+	// Ingress is the ingress interface. It is zero for the first AS.
+	Ingress common.IFIDType
+	// Egress is the egress interface. It is zero for the last AS.
+	Egress common.IFIDType
+	// IngressMTU is the MTU of the link attached to the ingress interface.
+	IngressMTU uint16
+	// InternalMTU is the MTU within the AS.
+	InternalMTU uint16
+	// EgressMTU is the MTU of the link attached to the egress interface.
+	EgressMTU uint16
+	// Extensions lists the names of the extensions in the AS entries of the
+	// AS.
+	Extensions []string
+}
+
+// TotalLatency returns the sum of all known latencies on the path. The
+// second return value indicates whether the latencies of all hops are known.
+func (m *PathMetadata) TotalLatency() (time.Duration, bool) {
+	if m == nil || len(m.Latency) == 0 {
+		return 0, false
+	}
+	var total time.Duration
+	complete := true
+	for _, l := range m.Latency {
+		if l == 0 {
+			complete = false
+		}
+		total += l
+	}
+	return total, complete
+}
+
+// MinBandwidth returns the minimum known bandwidth in Kbit/s on the path. If
+// no bandwidth is known, zero is returned.
+func (m *PathMetadata) MinBandwidth() uint64 {
+	if m == nil {
+		return 0
+	}
+	var min uint64
+	for _, b := range m.Bandwidth {
+		if b != 0 && (min == 0 || b < min) {
+			min = b
+		}
+	}
+	return min
+}
+
+// Copy creates a deep copy of the metadata.
+func (m *PathMetadata) Copy() *PathMetadata {
+	if m == nil {
+		return nil
+	}
+	res := &PathMetadata{
+		Latency:   append([]time.Duration(nil), m.Latency...),
+		Bandwidth: append([]uint64(nil), m.Bandwidth...),
+		Geo:       append([]GeoCoordinates(nil), m.Geo...),
+		LinkType:  append([]LinkType(nil), m.LinkType...),
+		Peering:   m.Peering,
+		Shortcut:  m.Shortcut,
+	}
+	for _, hop := range m.Hops {
+		hop.Extensions = append([]string(nil), hop.Extensions...)
+		res.Hops = append(res.Hops, hop)
+	}
+	if m.ISDHops != nil {
+		res.ISDHops = make(map[addr.ISD]int, len(m.ISDHops))
+		for isd, n := range m.ISDHops {
+			res.ISDHops[isd] = n
+		}
+	}
+	return res
+}
+
+// GeoCoordinates is the location of an interface.
+type GeoCoordinates struct {
+	Latitude  float32
+	Longitude float32
+	Address   string
+}
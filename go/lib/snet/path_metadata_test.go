@@ -0,0 +1,89 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestPathMetadataTotalLatency(t *testing.T) {
+	tests := map[string]struct {
+		Metadata *snet.PathMetadata
+		Latency  time.Duration
+		Complete bool
+	}{
+		"nil": {},
+		"empty": {
+			Metadata: &snet.PathMetadata{},
+		},
+		"complete": {
+			Metadata: &snet.PathMetadata{
+				Latency: []time.Duration{time.Millisecond, 2 * time.Millisecond},
+			},
+			Latency:  3 * time.Millisecond,
+			Complete: true,
+		},
+		"partial": {
+			Metadata: &snet.PathMetadata{
+				Latency: []time.Duration{time.Millisecond, 0, time.Millisecond},
+			},
+			Latency: 2 * time.Millisecond,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			l, complete := test.Metadata.TotalLatency()
+			assert.Equal(t, test.Latency, l)
+			assert.Equal(t, test.Complete, complete)
+		})
+	}
+}
+
+func TestPathMetadataMinBandwidth(t *testing.T) {
+	var nilMetadata *snet.PathMetadata
+	assert.Equal(t, uint64(0), nilMetadata.MinBandwidth())
+	m := &snet.PathMetadata{Bandwidth: []uint64{0, 400, 0, 100, 300}}
+	assert.Equal(t, uint64(100), m.MinBandwidth())
+}
+
+func TestPathMetadataCopy(t *testing.T) {
+	m := &snet.PathMetadata{
+		Latency:   []time.Duration{time.Millisecond},
+		Bandwidth: []uint64{100},
+		Geo:       []snet.GeoCoordinates{{Address: "Zurich"}, {Address: "Bern"}},
+		LinkType:  []snet.LinkType{snet.LinkTypeDirect},
+		Hops: []snet.HopMetadata{
+			{IA: xtest.MustParseIA("1-ff00:0:110"), Egress: 1, Extensions: []string{"a"}},
+			{IA: xtest.MustParseIA("1-ff00:0:111"), Ingress: 2},
+		},
+		ISDHops: map[addr.ISD]int{1: 2},
+		Peering: true,
+	}
+	c := m.Copy()
+	assert.Equal(t, m, c)
+	c.Latency[0] = 0
+	c.Hops[0].Extensions[0] = "b"
+	c.ISDHops[1] = 3
+	assert.Equal(t, time.Millisecond, m.Latency[0])
+	assert.Equal(t, "a", m.Hops[0].Extensions[0])
+	assert.Equal(t, 2, m.ISDHops[1])
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tokenbucket implements a thread-safe token bucket.
+//
+// A bucket holds up to burst tokens and is refilled at a constant rate. An
+// operation of size n conforms if the bucket holds at least n tokens, in which
+// case the tokens are removed from the bucket.
+package tokenbucket
+
+import (
+	"sync"
+	"time"
+)
+
+// Bucket is a token bucket. It is safe for concurrent use.
+type Bucket struct {
+	mtx    sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// New returns a bucket that is refilled with rate tokens per second, up to
+// burst tokens. The bucket is initially full.
+func New(rate, burst float64) *Bucket {
+	return &Bucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+	}
+}
+
+// Allow returns whether an operation of size n conforms to the bucket at time
+// now. If it does, n tokens are removed from the bucket.
+func (b *Bucket) Allow(now time.Time, n float64) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.refill(now)
+	if b.tokens < n {
+		return false
+	}
+	b.tokens -= n
+	return true
+}
+
+// Refund returns n tokens to the bucket, e.g., if an operation that was
+// allowed by the bucket is not carried out after all. The bucket does not
+// exceed burst tokens.
+func (b *Bucket) Refund(n float64) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.tokens += n
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// Tokens returns the number of tokens in the bucket at time now.
+func (b *Bucket) Tokens(now time.Time) float64 {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.refill(now)
+	return b.tokens
+}
+
+func (b *Bucket) refill(now time.Time) {
+	if b.last.IsZero() {
+		b.last = now
+		return
+	}
+	// Protect against the clock moving backwards.
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+}
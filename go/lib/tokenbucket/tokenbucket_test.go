@@ -0,0 +1,60 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenbucket_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/tokenbucket"
+)
+
+func TestBucketAllow(t *testing.T) {
+	now := time.Now()
+	b := tokenbucket.New(100, 200)
+	assert.True(t, b.Allow(now, 150), "initially full")
+	assert.False(t, b.Allow(now, 100), "only 50 tokens left")
+	assert.True(t, b.Allow(now, 50))
+	assert.False(t, b.Allow(now, 1), "empty")
+	// After 500ms, 50 tokens have been refilled.
+	now = now.Add(500 * time.Millisecond)
+	assert.InDelta(t, 50, b.Tokens(now), 0.001)
+	assert.True(t, b.Allow(now, 50))
+	// Refill is capped at burst.
+	now = now.Add(time.Hour)
+	assert.InDelta(t, 200, b.Tokens(now), 0.001)
+	assert.False(t, b.Allow(now, 201), "larger than burst")
+}
+
+func TestBucketClockBackwards(t *testing.T) {
+	now := time.Now()
+	b := tokenbucket.New(100, 100)
+	assert.True(t, b.Allow(now, 100))
+	assert.False(t, b.Allow(now.Add(-time.Second), 1))
+	assert.InDelta(t, 10, b.Tokens(now.Add(100*time.Millisecond)), 0.001)
+}
+
+func TestBucketRefund(t *testing.T) {
+	now := time.Now()
+	b := tokenbucket.New(100, 200)
+	assert.True(t, b.Allow(now, 150))
+	b.Refund(100)
+	assert.InDelta(t, 150, b.Tokens(now), 0.001)
+	// Refunds are capped at burst.
+	b.Refund(100)
+	assert.InDelta(t, 200, b.Tokens(now), 0.001)
+}
@@ -3,6 +3,7 @@
 package proto
 
 import (
+	math "math"
 	capnp "zombiezen.com/go/capnproto2"
 	text "zombiezen.com/go/capnproto2/encoding/text"
 	schemas "zombiezen.com/go/capnproto2/schemas"
@@ -236,32 +237,363 @@ func (p HiddenPathSegExtn_Promise) Struct() (HiddenPathSegExtn, error) {
 	return HiddenPathSegExtn{s}, err
 }
 
-const schema_e6c88f91b6a1209e = "x\xda\x8c\xce?\x8b\x13A\x1c\xc6\xf1\xe7\x99\xd9d\x8d" +
-	"h\x925\xfb\x02\xc4RD\x08\"\x88\x8d\x7fH\xc0t" +
-	";\xc6F\x10t\xc9\x8e\xc9Bvva'\xb0[\x05" +
-	"A\xad\x04I\xa1b#\xe45\x08bk\xa1\xf8\x1a\xae" +
-	";\xb8?/\xe0\xaa\xab\xf6\xd8\x14w\x1c\x97\xe2\xda\x1f" +
-	"\x9fy\xe6\xdb\xfd\xf6X\xf4\x1b\x1d\x02\xeaZ\xa3Y\x1d" +
-	"\xef\xdd\x7f\xf0{\xe7\xcfW\xa8\x0eE\xf5\xe3\xe6\xfa\xd7" +
-	"\xea\xf3\xff\x034\xe8\x02\xfdD\xd0+]\xc0[\x1c\x82" +
-	"\xd5\xfa\xdf\x9dwm\xfd\xf1oMyF\x1d\x17\xb8\xf7" +
-	"\x927\xd8\x8b\xebG=\xcdG`u\xf4i\x7f\xf7\xfb" +
-	"\xcf\xb2\xda\x86?\xf0*{_6x\xb5\xc1a\x9e\xbc" +
-	"\xd6\x85\xcd\xe5\xddI\x98\x99\xec\xe1\xf3tac3\x0d" +
-	"\xd2y<)\x87\x85E@\xaa\xaet\x00\x87\x80\x17\xde" +
-	"\x02\xd4+I5\x13\xf4H\x9f\xf5Q?\x05\xd4\x1bI" +
-	"5\x17\xf4\x04}\x0a\xc0\x8bo\x03*\x92T\x99 \xa5" +
-	"O\x09xI\x0dg\x92\xea\xbd\xa0\x9bkKB\x90\xe0" +
-	"2K\xe7/\xcaL\xb3\x09\xc1&\xd8\x89\xdf\x8e\x06l" +
-	"A\xb0\x05.\xe3<\x0as\x9d\xb3\x0d\x06\x92\x9bs{" +
-	"K\xf9h<xbL\xba0\x13\x9dhc\x87\x05m" +
-	"\xdd\xee\x9c\xb6_\xaf\xdb\xafH*\xff\xfc\xef\x17\x96\x9e" +
-	"\xc5Q\xa4M\x10\xda\xd9XO\x87\x855\xc0\xe5\x96N" +
-	"\x02\x00\x00\xff\xff#\x1dpa"
+type StaticInfoExtn struct{ capnp.Struct }
+
+// StaticInfoExtn_TypeID is the unique identifier for the type StaticInfoExtn.
+const StaticInfoExtn_TypeID = 0xdb505e3694652d57
+
+func NewStaticInfoExtn(s *capnp.Segment) (StaticInfoExtn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return StaticInfoExtn{st}, err
+}
+
+func NewRootStaticInfoExtn(s *capnp.Segment) (StaticInfoExtn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return StaticInfoExtn{st}, err
+}
+
+func ReadRootStaticInfoExtn(msg *capnp.Message) (StaticInfoExtn, error) {
+	root, err := msg.RootPtr()
+	return StaticInfoExtn{root.Struct()}, err
+}
+
+func (s StaticInfoExtn) String() string {
+	str, _ := text.Marshal(0xdb505e3694652d57, s.Struct)
+	return str
+}
+
+func (s StaticInfoExtn) Interfaces() (StaticInfoInterface_List, error) {
+	p, err := s.Struct.Ptr(0)
+	return StaticInfoInterface_List{List: p.List()}, err
+}
+
+func (s StaticInfoExtn) HasInterfaces() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoExtn) SetInterfaces(v StaticInfoInterface_List) error {
+	return s.Struct.SetPtr(0, v.List.ToPtr())
+}
+
+// NewInterfaces sets the interfaces field to a newly
+// allocated StaticInfoInterface_List, preferring placement in s's segment.
+func (s StaticInfoExtn) NewInterfaces(n int32) (StaticInfoInterface_List, error) {
+	l, err := NewStaticInfoInterface_List(s.Struct.Segment(), n)
+	if err != nil {
+		return StaticInfoInterface_List{}, err
+	}
+	err = s.Struct.SetPtr(0, l.List.ToPtr())
+	return l, err
+}
+
+func (s StaticInfoExtn) IntraLatencies() (StaticInfoLatency_List, error) {
+	p, err := s.Struct.Ptr(1)
+	return StaticInfoLatency_List{List: p.List()}, err
+}
+
+func (s StaticInfoExtn) HasIntraLatencies() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoExtn) SetIntraLatencies(v StaticInfoLatency_List) error {
+	return s.Struct.SetPtr(1, v.List.ToPtr())
+}
+
+// NewIntraLatencies sets the intraLatencies field to a newly
+// allocated StaticInfoLatency_List, preferring placement in s's segment.
+func (s StaticInfoExtn) NewIntraLatencies(n int32) (StaticInfoLatency_List, error) {
+	l, err := NewStaticInfoLatency_List(s.Struct.Segment(), n)
+	if err != nil {
+		return StaticInfoLatency_List{}, err
+	}
+	err = s.Struct.SetPtr(1, l.List.ToPtr())
+	return l, err
+}
+
+// StaticInfoExtn_List is a list of StaticInfoExtn.
+type StaticInfoExtn_List struct{ capnp.List }
+
+// NewStaticInfoExtn creates a new list of StaticInfoExtn.
+func NewStaticInfoExtn_List(s *capnp.Segment, sz int32) (StaticInfoExtn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return StaticInfoExtn_List{l}, err
+}
+
+func (s StaticInfoExtn_List) At(i int) StaticInfoExtn { return StaticInfoExtn{s.List.Struct(i)} }
+
+func (s StaticInfoExtn_List) Set(i int, v StaticInfoExtn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s StaticInfoExtn_List) String() string {
+	str, _ := text.MarshalList(0xdb505e3694652d57, s.List)
+	return str
+}
+
+// StaticInfoExtn_Promise is a wrapper for a StaticInfoExtn promised by a client call.
+type StaticInfoExtn_Promise struct{ *capnp.Pipeline }
+
+func (p StaticInfoExtn_Promise) Struct() (StaticInfoExtn, error) {
+	s, err := p.Pipeline.Struct()
+	return StaticInfoExtn{s}, err
+}
+
+type StaticInfoInterface struct{ capnp.Struct }
+
+// StaticInfoInterface_TypeID is the unique identifier for the type StaticInfoInterface.
+const StaticInfoInterface_TypeID = 0x93580641434cc516
+
+func NewStaticInfoInterface(s *capnp.Segment) (StaticInfoInterface, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 1})
+	return StaticInfoInterface{st}, err
+}
+
+func NewRootStaticInfoInterface(s *capnp.Segment) (StaticInfoInterface, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 1})
+	return StaticInfoInterface{st}, err
+}
+
+func ReadRootStaticInfoInterface(msg *capnp.Message) (StaticInfoInterface, error) {
+	root, err := msg.RootPtr()
+	return StaticInfoInterface{root.Struct()}, err
+}
+
+func (s StaticInfoInterface) String() string {
+	str, _ := text.Marshal(0x93580641434cc516, s.Struct)
+	return str
+}
+
+func (s StaticInfoInterface) IfID() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s StaticInfoInterface) SetIfID(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s StaticInfoInterface) LinkLatency() uint32 {
+	return s.Struct.Uint32(8)
+}
+
+func (s StaticInfoInterface) SetLinkLatency(v uint32) {
+	s.Struct.SetUint32(8, v)
+}
+
+func (s StaticInfoInterface) Bandwidth() uint64 {
+	return s.Struct.Uint64(16)
+}
+
+func (s StaticInfoInterface) SetBandwidth(v uint64) {
+	s.Struct.SetUint64(16, v)
+}
+
+func (s StaticInfoInterface) Latitude() float32 {
+	return math.Float32frombits(s.Struct.Uint32(12))
+}
+
+func (s StaticInfoInterface) SetLatitude(v float32) {
+	s.Struct.SetUint32(12, math.Float32bits(v))
+}
+
+func (s StaticInfoInterface) Longitude() float32 {
+	return math.Float32frombits(s.Struct.Uint32(24))
+}
+
+func (s StaticInfoInterface) SetLongitude(v float32) {
+	s.Struct.SetUint32(24, math.Float32bits(v))
+}
+
+func (s StaticInfoInterface) Address() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s StaticInfoInterface) HasAddress() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoInterface) AddressBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s StaticInfoInterface) SetAddress(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s StaticInfoInterface) LinkType() uint8 {
+	return s.Struct.Uint8(28)
+}
+
+func (s StaticInfoInterface) SetLinkType(v uint8) {
+	s.Struct.SetUint8(28, v)
+}
+
+// StaticInfoInterface_List is a list of StaticInfoInterface.
+type StaticInfoInterface_List struct{ capnp.List }
+
+// NewStaticInfoInterface creates a new list of StaticInfoInterface.
+func NewStaticInfoInterface_List(s *capnp.Segment, sz int32) (StaticInfoInterface_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 1}, sz)
+	return StaticInfoInterface_List{l}, err
+}
+
+func (s StaticInfoInterface_List) At(i int) StaticInfoInterface {
+	return StaticInfoInterface{s.List.Struct(i)}
+}
+
+func (s StaticInfoInterface_List) Set(i int, v StaticInfoInterface) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s StaticInfoInterface_List) String() string {
+	str, _ := text.MarshalList(0x93580641434cc516, s.List)
+	return str
+}
+
+// StaticInfoInterface_Promise is a wrapper for a StaticInfoInterface promised by a client call.
+type StaticInfoInterface_Promise struct{ *capnp.Pipeline }
+
+func (p StaticInfoInterface_Promise) Struct() (StaticInfoInterface, error) {
+	s, err := p.Pipeline.Struct()
+	return StaticInfoInterface{s}, err
+}
+
+type StaticInfoLatency struct{ capnp.Struct }
+
+// StaticInfoLatency_TypeID is the unique identifier for the type StaticInfoLatency.
+const StaticInfoLatency_TypeID = 0xef11aded5d2edc3d
+
+func NewStaticInfoLatency(s *capnp.Segment) (StaticInfoLatency, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0})
+	return StaticInfoLatency{st}, err
+}
+
+func NewRootStaticInfoLatency(s *capnp.Segment) (StaticInfoLatency, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0})
+	return StaticInfoLatency{st}, err
+}
+
+func ReadRootStaticInfoLatency(msg *capnp.Message) (StaticInfoLatency, error) {
+	root, err := msg.RootPtr()
+	return StaticInfoLatency{root.Struct()}, err
+}
+
+func (s StaticInfoLatency) String() string {
+	str, _ := text.Marshal(0xef11aded5d2edc3d, s.Struct)
+	return str
+}
+
+func (s StaticInfoLatency) IfID() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s StaticInfoLatency) SetIfID(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s StaticInfoLatency) OtherIfID() uint64 {
+	return s.Struct.Uint64(8)
+}
+
+func (s StaticInfoLatency) SetOtherIfID(v uint64) {
+	s.Struct.SetUint64(8, v)
+}
+
+func (s StaticInfoLatency) Latency() uint32 {
+	return s.Struct.Uint32(16)
+}
+
+func (s StaticInfoLatency) SetLatency(v uint32) {
+	s.Struct.SetUint32(16, v)
+}
+
+// StaticInfoLatency_List is a list of StaticInfoLatency.
+type StaticInfoLatency_List struct{ capnp.List }
+
+// NewStaticInfoLatency creates a new list of StaticInfoLatency.
+func NewStaticInfoLatency_List(s *capnp.Segment, sz int32) (StaticInfoLatency_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0}, sz)
+	return StaticInfoLatency_List{l}, err
+}
+
+func (s StaticInfoLatency_List) At(i int) StaticInfoLatency {
+	return StaticInfoLatency{s.List.Struct(i)}
+}
+
+func (s StaticInfoLatency_List) Set(i int, v StaticInfoLatency) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s StaticInfoLatency_List) String() string {
+	str, _ := text.MarshalList(0xef11aded5d2edc3d, s.List)
+	return str
+}
+
+// StaticInfoLatency_Promise is a wrapper for a StaticInfoLatency promised by a client call.
+type StaticInfoLatency_Promise struct{ *capnp.Pipeline }
+
+func (p StaticInfoLatency_Promise) Struct() (StaticInfoLatency, error) {
+	s, err := p.Pipeline.Struct()
+	return StaticInfoLatency{s}, err
+}
+
+const schema_e6c88f91b6a1209e = "x\xda\x85\x93OH\x14Q\x1c\xc7\xdf\xef\xbd\xd9q\x13" +
+	"S\xb7Y*B(:\x95\x94$QD j)8" +
+	"\xb1\xc1\xae\x0aI\xd8\x9fq\xe6\xad;\xb4\xbeYvF" +
+	"t\xbbHP\x9d\x02\x93\xfe\x80\x97\xc0C\xc7\xa8\x88B" +
+	"\xa1 !\xc3\xa0C\x81\x97j\x83\x82\xac.\x11\x16\x1d" +
+	":\xc4\xf6{\xb3\xeb\xec\xba\x18\x1d\x06\xde|\xe6\xfb~" +
+	"\xef\xfb\xbe\xbf\xdf\xec\xbb\x0a\x1d\xb45\xb4\x85\x12\x92\xd8" +
+	"\x1aR\x0b\x9b\x17bG;\xd5\x81k$\xd1\x00J\xe1" +
+	"\xd6\x8e\x99GS\x93\x8b\x9fI\x08j\x08i]\xd9\x06" +
+	"\x1a\xf8\xab?\x93@\xa0\xf0\xfb\xd3\x81C\xb3o\xe7o" +
+	"J1\xad\x12\xef\x9f\xa6\x14\xb4\xdb\x14\x97\xda\x0c\xfd\x82" +
+	"\xea\x99\xe7{.\xd4\xf3\xcb\x0bR\x0de\xb5\"\xc5\x97" +
+	"\xd8&\xd0n0)\x9eb\xed(>\xb1\x97_?x" +
+	":\xfe\x8eD\x1a*\xb4!\xbf\xdc}\xf6C{\xe2k" +
+	"\xe7\xd8\x18j\xdb\xf2-\xa7\xbe\xdd\x89|\x97\x85YU" +
+	"\xe16\xa5\x16\xb4\xe3r\xa9\xe9\xca]\x14\xff\xbc\xb2\xfc" +
+	"q\xfaA\xae\xb0\x9e\x8be)\xfe\xe5\x8bW\x14\xe9\xc2" +
+	"pG\xce\xf0q\xcfe-\xa6\x91\x11\x99\xc3}\x9e\xe1" +
+	"\xd9\xa6.\x92\x8e.<\x9eM\x1a\xcc\xe4q\x80D\x13" +
+	"S\x08Q\x80\x90\xc8\xc3f\x0c\xf2\x1e\x83\xc4c\x0a\x11" +
+	"\xa0Q\x90pn\x08\xe1,\xc2g\x08)BL;2" +
+	"\xdf\x8b\xf0)\xc2\x97\x08\x19\x8b\x02C\xf8\xe2\x18\xc2E" +
+	"\x84K\x08\x155\x0aX7\xf2Z*_!\xccS\x80" +
+	"P\x14B\xc8\xde\x1cA\xb6\x84\xec\x03\x0a\xd5\xa6(\xa8" +
+	"\x08\xdf\xcb\xddy\x84_)4\xd8I\xbd\x0b6\x10\x8a" +
+	"\x0f\x14\xd2\xb68\x173<Nj\x84\x99\x830\xd20" +
+	"\xd2!CXc\xb6\xe5\x11H\x95\x95xCo\xd4\xe2" +
+	"\x84\x10\xa8EV+\x99#\x86%$\xc0W\xd9\x84a" +
+	"YY\xee\xbaP\x87\xefu\xa5\x13\xfas\x19\x7f\x9f\x8a" +
+	"L]'\xbe^g\xd4\xb3\xc5p\xdcI\xdbf\xae{" +
+	"\xdc#2\xbb\xc6 ;c'\xda\x1fD\xfb)\x99\x1d" +
+	"\x14\xb3\xe3\xf2\xa2g\x11\xa6evP\xcc\xce\x96)[" +
+	"\x083\x98H)\xba\x11)L!\xbbH\xa1\xc6\xe5\x1e" +
+	"\xee\xc6\x8f\xe84\xe3\xa4\xa5\xb1UWk\x82\x99\xb0]" +
+	"\xcbp\xb9\x0b\xf5\x04\xe2\x0c|\\\xbf\x8es\xbd\xaf\xab" +
+	"S\x08gT\x98|\x84\x0b\xaf{\x1c<\xe9]\x09\xbc" +
+	"o\x94\xde\xc3xzt\xed\xe9A%Z=B\xdb\x1d" +
+	"L@\xc8*\xe1\xa0\xca\xee\x93Xe\x17V\xe9\xaaH" +
+	"\xa0\xf3<\xc2\x0e\x84\x83\x14\x0avq\xeeL\xc2\xca\xa6" +
+	"\x1b\xcb\x7f-\x01\xdf>\xaa\xb2\x06\xf6\x9b\xb4sa\xda" +
+	"\x95\xca\xe0_))\xff=\xe1r\\pX\x88\xdf\xa4" +
+	"\xba\xc0bws\xc9M\xac\xc2\xa2.'\xb4\x07a\xbf" +
+	"l\x92RlRB6$\x86p\xa0z\x1a\x1d/\xc5" +
+	"\xb3zR'PnD\xbax\\0\x9b\xd5\xc6zl" +
+	"\xcb\xe2\"nx\xa9>>,\x93+\x1a\xfb\x7f\x07\xfe" +
+	"\x02\x8c\xc0%\xd2"
 
 func init() {
 	schemas.Register(schema_e6c88f91b6a1209e,
+		0x93580641434cc516,
 		0x96c1dab83835e4f9,
 		0xc586650e812cc6a1,
+		0xdb505e3694652d57,
+		0xef11aded5d2edc3d,
 		0xff79b399e1e58cf3)
 }
@@ -321,12 +321,12 @@ type SegChangesIdReq struct{ capnp.Struct }
 const SegChangesIdReq_TypeID = 0xc88dfa6be7a1d091
 
 func NewSegChangesIdReq(s *capnp.Segment) (SegChangesIdReq, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
 	return SegChangesIdReq{st}, err
 }
 
 func NewRootSegChangesIdReq(s *capnp.Segment) (SegChangesIdReq, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
 	return SegChangesIdReq{st}, err
 }
 
@@ -348,12 +348,20 @@ func (s SegChangesIdReq) SetLastCheck(v uint32) {
 	s.Struct.SetUint32(0, v)
 }
 
+func (s SegChangesIdReq) Cursor() uint64 {
+	return s.Struct.Uint64(8)
+}
+
+func (s SegChangesIdReq) SetCursor(v uint64) {
+	s.Struct.SetUint64(8, v)
+}
+
 // SegChangesIdReq_List is a list of SegChangesIdReq.
 type SegChangesIdReq_List struct{ capnp.List }
 
 // NewSegChangesIdReq creates a new list of SegChangesIdReq.
 func NewSegChangesIdReq_List(s *capnp.Segment, sz int32) (SegChangesIdReq_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0}, sz)
 	return SegChangesIdReq_List{l}, err
 }
 
@@ -461,12 +469,12 @@ type SegChangesIdReply struct{ capnp.Struct }
 const SegChangesIdReply_TypeID = 0xbd56ceeaf8c65140
 
 func NewSegChangesIdReply(s *capnp.Segment) (SegChangesIdReply, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
 	return SegChangesIdReply{st}, err
 }
 
 func NewRootSegChangesIdReply(s *capnp.Segment) (SegChangesIdReply, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
 	return SegChangesIdReply{st}, err
 }
 
@@ -505,12 +513,20 @@ func (s SegChangesIdReply) NewIds(n int32) (SegIds_List, error) {
 	return l, err
 }
 
+func (s SegChangesIdReply) Cursor() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s SegChangesIdReply) SetCursor(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
 // SegChangesIdReply_List is a list of SegChangesIdReply.
 type SegChangesIdReply_List struct{ capnp.List }
 
 // NewSegChangesIdReply creates a new list of SegChangesIdReply.
 func NewSegChangesIdReply_List(s *capnp.Segment, sz int32) (SegChangesIdReply_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
 	return SegChangesIdReply_List{l}, err
 }
 
@@ -1982,107 +1998,107 @@ func (p PathMgmt_Promise) HpCfgReply() HPCfgReply_Promise {
 	return HPCfgReply_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
-const schema_8fcd13516850d142 = "x\xda|V\x7flTY\x15>\xe7\xde\x99\xce\xefv" +
-	"\xc6\xfb\x8c\xdd\xb8\xb1\xbb\x1bL\xb6H7l\x81\xecn" +
-	"\x83\xf6\x07%\xcb,\x8b\xf6\xf5%\xeb\xee\xc6U\x87\x99" +
-	"7\xd3\xa1\xd3\xe9t\xde@S\x89\xa9\x9a\xa81\x8a\x04" +
-	"D\x03F\x02\x18\x08)\x88\x86&$\xa4\xa4(\x1a\x10" +
-	"\x1b!\x14\xc1\x10Pc\x11#!\x10\x91\xdf\x14\xea\xdb" +
-	"\x9c;\xd3\xf7\xa6o\xa6\xfdo\xe6~\xdf;\xe7\xbb\xe7" +
-	"\x9e\xfb\x9d\xbb4\xe0jc\xaf\xbb\xbf\xef\x06P\xdb\xdc" +
-	"5\xe6\x8b'[7\x0f\xdcT\xb7\x82\x1aF4;&" +
-	"\xbbzTqn+\xb8\xd1\x03 F\xd8\x848\xc6\xe8" +
-	"\xd7Q6\x08h\x8e\x7fQ\x99\x09\x1c\\\xfb\x13\x88\x84" +
-	"\xcb\xb9\x92\xf12\xff\xbdh\xe4\xf4\xeb\xb3\x9c\xb8'\xae" +
-	"\xbdqmt\xea\x83\xaa\xdc\x03|B\x1c\x95\xdc#\x92" +
-	"\xfb\x99\xef\xb9\xbe\xc9V\xe0n\x12\xc1m\xb2\x8b\x18>" +
-	"\xd7\x98\x88\xc8_!\xd7o\x00\xcd\x89\x0b?\x0fvL" +
-	"/\xd9\xe7\x10\xbc\x1a=\x11\x00\xf1\x03\xd7\x84\xf8)\xb1" +
-	"\x97ms\xfd\x96\x03\x9aS\xd7\xbf2z\xf6g\xbd\x07" +
-	"\x1c2d\xf2\x11\xefEq\xcc+\xb7\xe7\xa5\xd0?<" +
-	"\xb3\xfc\x97\xeb\x83G\x0e:\xb8\xb2\x14\x1f\xf8\xee\x09\xdd" +
-	"G\xbfb\xbeV@\xf3\xaf?N\xb6o\x9fy|\xa8" +
-	"\x1aw\x9b\xef\xaa\xd8-\xb9\xbb$w\xed\xaf\xb7<\xfa" +
-	"\xc3\xd0\xd3\xc3\xd5J\xf1'\xdf\x98\x98\x94\xdcs>*" +
-	"\xc5\x8f\x0e]\x0fM=\xda<Z-n\xa3\xff\xaaX" +
-	"\xe1\xa7_\xaf\xfb)n\xfd\xea\x1b_\x10\x7f|y\x8c" +
-	"J\xc1\x1ce\x8b\xf9/\x8a>\xc9M\xfb)n\x9bz" +
-	"\xe6\xc9\xad\xf3\xef\x8dW\x89\xbb\xec\xb4\xff\x13(.K" +
-	"\xf2\xa4\x0c\xbc\xed\xc2\xbe\xff\xf4No9\xeb\xa8\xb1\xac" +
-	"+\x06\x18\x8aP@\x1eM\x80\xc8_=\xfc\xb9\x13\x9f" +
-	"<\xb2\xf6b\x15\xb2h\x0aL\x88\xb7$w\x85\xe4n" +
-	"\xfat\xe7\xb3u\x8d;\xfe\x01\xea\xa7\x90\xdb\xc7\xaex" +
-	"\x90J\x1c\xb8\x05(>\x0a\x90\xdc\xf8\x0b\xdf8\xee\x0f" +
-	"{\xee;\xdbRF\x1d\x0f\x8c\x8a\xd3\x14u\xd9\xa9\xc0" +
-	"\x97\x11\xd0\xcc\xc5\x0a=_\xebK\xf5\xb1\xc2k\xf1X" +
-	".\x9bkY\xd3\xa5\xe9\xa9n}\x00\xa0\x0bQ\xf5r" +
-	"\x17\x80\x0b\x01\"\x8d\xcd\x00\xea\"\x8e\xeaR\x86\x88\x0a" +
-	"\xd2Z\xd3;\x00\xea\x12\x8e\xea\x1a\x86\x0d\x09\xa3\x10m" +
-	"G\x1f0\xf4\x01\x9a\xa9|\xff\xc6\\4a\x00\x00\xd6" +
-	"\x02vq\xc4\xb0]u@Z\xacL.S\xc7\x0dg" +
-	"\xea\xc5\xa5\xd4m\x0c#\xb3\xb9?\xdf\x0d\xa0\xae\xe4\xa8" +
-	"\xbe\xcf\xb0.\xaf\xc7\x0d;M\xdd\x98\x7f\xe5\xe6\xf7v" +
-	"\xee\x99Mct\xeb\x9b\xa2\xd9d?`\x19\xa9\xfb_" +
-	"\xd3o|\xf7\xed\xe6=\x0bk\xc9e\x86*\x0a\xf1\x8a" +
-	"]\x08KM\x13I|\x95\xa3\xba\x9c\xa1'\xaf\x0f`" +
-	"\xd8>\"@\x0cCIc\xd86\x82\xe2\xb2\x95\x17g" +
-	"\xf3\xb6\x16\xebOI\x83V\xd2\xd5T\xfd6\x8e\xea\xbb" +
-	"eI\xa3\xb4\xd8\xc9Q\xedb\x88\x0c\xcbz$\xb2\xae" +
-	"\x19X\x83\x91\x8f\xdb'2\xf7|\x1a\x92\x99X\xca\xa8" +
-	"\xdctW\xac\xd0\xb3.\xd5W(n\xba\x93\xbb\x82\xa6" +
-	"I\x02\xc49l\x06\xd0\xce\"G\xed\x122\x0c\xe1\xff" +
-	"M)BLb\x0b\x80\xf6g\x02\xae\x10\xc0fL\x05" +
-	"\x19\x80\xb8\x8c\xef\x00h\x97\x08\xf8'\x01\xfc\xb9\xa9 " +
-	"\x07\x10\x7f\x97_\\!\xe0\x06\x01\xaeg\xa6\x82.\x00" +
-	"1\x85\x1d\x00\xda\xdf\x08\xb8I\x80{\xdaT\xd0\x0d " +
-	"\xfe-C\xdd \xe0.\x015OM\x05k\x00\xc4\x1d" +
-	"\xfc\x10@\xbbM\xc0\x13\x02<OLE\xde\xf7\x87\xb8" +
-	"\x01@{\x80\x1c\xbb\x19\xc3\x90\xf7\xb1\xa9\xa0\x17@\xcc" +
-	"\xe0w\x00\xb4\xe7\xf4\x81\x97\x00\xdf#SA\x1f\x80p" +
-	"\xb3\xed\x00\x9a\x97q\xd4\x14\x02\xfc\x0fM\x05\xfd\x00\"" +
-	"\xc2\xf2\x00Z\x98\x80\x17\x09\x08<0\x15\x0c\x00\x88\x17" +
-	"\x18\x85\xaa'`\x11\x01\xc1\xfb\xa6\x82A\xb2sFj" +
-	"_\"`\x09\x01\xa1{\xa6\x82!2!Fj_%" +
-	"`9\x01\xb5\xff3\x15\xac%O\x92_,%`%" +
-	"\x01uwM\x05\xeb\x00\xc4[\x12x\x93\x80N\x02\xc2" +
-	"\xff5\x15\x0c\x03\x88v\x19\xaa\x8d\x80w\x19\xc3\x86\x8d" +
-	"YC/@M\xab!{\xa7\xb2\xfbL\xc3\xeaf\xc0" +
-	"\xb0=a\x8ah\xf1\xb3Tew\x0e\x1bzJ\x1b\xca" +
-	"\xc6\xab\xf4\xadu\xa9d\xc0\xf2\xdbDh:\xa9\x15b" +
-	"\x05\xbd\x1b\xb8T3\x92\xfc\xc5\xa9\xde\xc1;7\x1dp" +
-	"\x14\xea\xb2\xc9~\xba\x15'\x03\xcf\x1f6\x9f\x7f\xe9/" +
-	"erW\xf5\xc4\xb2)\xd4\x8dh\x82\xdc\x08\xc3\xb6\xb5" +
-	"V\xe7\xe428\x84a\xdb\xad\x1d,h\xd0\x8dbi" +
-	"\xacYU\x19\xa7X\xa2*\xbb\xed\xc9\xcd\xba\"\xa1\xd6" +
-	"\xe4\x9f\x8b\xe6\x80gH\x825\xe0\xe6\xc2\xa9\xe2\xc7\xd6" +
-	"X\xb5\xd0UI;\xb45\x12\xe6\xa2\xb3\xa1\xad\x19\xe7" +
-	"p\x0f\xa7}\xc7\xd1p\x18HG5\x03Y\\2\x90" +
-	"\xaf3\x8c0&\xefm\xe4#\xf2\xb7\xf79\xaa\x09\x86" +
-	"\xc3%\x1bwx\xb7eg\xf3Y\xaeG\xcf\xe71\x08" +
-	"\x0c\x83\xf38\xab,x\xf1DH\xa7\xcb\xd2\x19j\x01" +
-	"P\xbd\x1c\xd5EL\xb6e4ae\x09\x01\xab\xee\xd4" +
-	"\xa5=\xe7xf\xc8\x11l\xb1\x1d\xcc!\xb8\xfc\x14j" +
-	"\xe7\xb3\xe1h\xc2\x98\x7f\x08\xda\xde\xdfb{\x7f\x83\x94" +
-	",\xa5\x86\x00[\x93\x1b3\x19\xfbo5\xe5\xc5\xd3]" +
-	"Xy<\x99*Sn\xcd\xf7\xf9\x06\xd7\x9a\xae\xb7\xe5" +
-	"\xa9a\xc2!\xbe\xa3\\\xbc\xab$\xbe\xc3\x16?\xdc?" +
-	"\x98\xd5\xf3\xed\xda\xec\x90\xb0N\xdf\x03\x0c=e\xa9\xb8" +
-	"\xf3$\xed\x1b\xe8\xd8\xc6+\xf66<\xe9D\xd9.\xac" +
-	"\x87\x9dc\x17\xf3\x84\x1e\x00G`\x9a\xfeA\x8ej=" +
-	"C3\x133\x0a\xabz\xf48`/z\x81\xa1w\x81" +
-	"R\x97\xde5e\x916\x94E\x8a\xcb\x8c\x09\x0d\xea\xd2" +
-	"\xd9\xb8>\x7f\xb0\xa2\x19\xbc\x96\xac\xa3)J%\x0e\x7f" +
-	"\xabxy\xe66\xc8\xb7\xe5\xc0\x8b4u\x97\x9eIo" +
-	"R\x83\xa4\xd7\xe7c\x88\xc0\x90\x9e_\xf1X\xbcG\xff" +
-	"R6\x038d\xadU\xb4b\x83\xd4N\xb2\xeb-\xd9" +
-	"\xbb\xe8\xdcvpT\xf7\xda\xcf\xb1\xdd\xb4\xb6\x93\xa3\xba" +
-	"\xbf\xec:\xef\xa37\xda^\x8e\xea\xaf\x18F8\x16\x15" +
-	"\x8d\x10s?G\xf5w\x0c#.&\xc7od\x9c\x16" +
-	"\x8fsT/1\x8c\xb8\xb9\x1c\xbd\x91\xc9\x0f\x01\xd4\x0b" +
-	"\x1c\xd5\xdb\x0b\xb9\xc1\xf0&=o\xa4\xfb\xb3V\xc5d" +
-	"'E\xb5N2\xb5R\xf7\x0c\x0f\xe6\xd3\x05=o\xf5" +
-	"\x80\xafx\x9d\x87\xf3z,Q\xb9l\xe6\xf5T\xda(" +
-	"\xe4\xd3\xc0u'\xf6q\x00\x00\x00\xff\xff4\x06O\xa2"
+const schema_8fcd13516850d142 = "x\xda\x85\x96\x0dlSU\x14\xc7\xef\xbd\xaf[\xbf\xd7" +
+	"\xd67\xe3\x88\xc4\x82\xc1\x84!\x10\x18\x10`a\xee\x83" +
+	"-P\x07\xba\xb6\x09\x82\xf1\xab\xb4\xaf]]\xd7u\xef" +
+	"u,\x93\x98\xa9\x89\x1a\xa3H@4`$\x80\x81\x90" +
+	"\x81\xd3\xb0\x84\x84\x8c\x80_\x11q\x11\xc2\x10\x0c\x015" +
+	"\x0e1\x12\x02\x11\xf9f0\xeb9\xb7\xed{\xddk7" +
+	"\x935y;\xbf\xf3\xce\xf9\xdf{\xee=\xe7\xcd*7" +
+	"\xd4\xb0\xd9Eo\x17\x11\xe2\xad)*NM<\\\xbd" +
+	"\xb6\xfd\xa2w=\xf1:)M\xd5\x0d65{\xc5c" +
+	"\xebI\x115\x12\"\xf6\xb0\x01q?\xc3\xa7}\xac\x93" +
+	"\xd0\xd4\xa1\xa7JG\xac\xbb\x1b? .g\xae/\xf7" +
+	"\x98,|#\x96\x0b\xf8\xf4\x98\x80\xbe\x07\xcf\xcd?\xd7" +
+	"7\xb4\xaa\xa0\xef.a@\xdc\xc7}{\xb9\xef#o" +
+	"\x19^e\xf3\xe8V\x14!h\xce\x06\xf40\x1b\xfaE" +
+	"\x17\x7f\xb2\x1b\xbe\x00\xdf\x81\x13\x1f\xdb\xea\x86\xa7\xef\xd0" +
+	"\x09n\xa0F\x17\xf8\xbcc\x18\x10?D\xef9\x1b\x0c" +
+	"_\x0a\xe0>t\xfe\xb9\xbe\xa3\x1f\xb5\xec\xd2\xc9\xe0\xc9" +
+	"{L'\xc5\xfd&\xbe<\x13\x86~\xf7\xc8\xdcOW" +
+	"\xdbzw\xeb|\xf9V\xac2_\x13%3>\x05\xcc" +
+	"\xd5\xe0\xfb\xf3\xfb\xe1\xda\x8d#\xb7\xf7\x14\xf2\xdd`>" +
+	"+n\xe5\xbe[\xb8o\xe3\xe7\xebn}\xdbuwo" +
+	"\xa1\xad\xf8\xc1\xdc/\x0er\xdfcf\xdc\x8a\xf7\xf6\x9c" +
+	"\xb7\x0f\xddZ\xdbW(n\xb9\xe5\xac8\xcf\x82O\xb3" +
+	"-\x18\xb7\xac\xe1\xc2\x13\xe2\xf7\x93\xfbq+\x98n\xdb" +
+	"\x02\x96\x93b+\xf7\x8dZ0n\x8d\xf7\xc8\x9dK\xc7" +
+	"W\x1c*T\xe79\xdfY\x1e\xa0\xe2i\xee=\xc8\xbd" +
+	"7\x9c\xd8\xf1W\xcb\xf0\xba\xa3\x05\"\xcf\xa9\xb22*" +
+	"z\xac\xe8\xdc`E\xe7\x17\xf6>~\xf0\xc1\xde\xc6\x93" +
+	"\xba\xd0\\\xc6.+T\x9a\xfb\xf6ZQ\xf2\x9a\x87\xeb" +
+	"\xef-/\xdf\xf4\x1b\xf1>\x04\x95V\xeb^j\xa4\x98" +
+	"\xdbz\x89\x80\x0e\x1e48\xe1\x95\x03\x16\xa7\xf1\xba^" +
+	"/\x8f:\xcf\xd6'V\xd9P\xccB\xdb3\x14\xbc\x13" +
+	"\x81d\xf3\x8b\xad\x91V\x96\x9c\x19\x0c$\xe2\x89\xca\xa5" +
+	"M~)\xe2\x93\xda\x09i\xa2\xd4k\x12\x0c\x84\x18 " +
+	"\x85\xab\xbc\x02\xce\xfd\x14\x81zg1Ji)E\xdb" +
+	"\x8c'\xc16\x1dlK\x19u\x87\x94\xa4\xa7\x96\x9a\x09" +
+	"\x83\x1fME\xe4\xb6\x8e\x84'\xa4\x10Bh\x09\xa1M" +
+	"\x02\xa5Nm\xdb\x09Ec~r\x9e:\xa8\xe8SO" +
+	"\xcb\xa4\xaea\xd4\x95\xcd]\xe5\x03\xe3\"0\xaed\xd4" +
+	"!\xc3KZ\x1aG\xbfe\xd1\xda\x15\x9b\xb7e\xd3(" +
+	">i\x8d'\x1en#4\xc7\xc9\xf7\xc7\xf0\xfc7\x97" +
+	"Tl\x1b_K\"\xd6\x95\xb7\x11\x8fj\x1b\xa1\xaa\x99" +
+	"\x81\x12\xa7\x82q.\xa3FYj\x87\x04j\x89 \x81" +
+	"\x93d4:\xb5N\x906\xabyi6ouz\xff" +
+	"1\xa9MM\xda\x80\xbb_\x03\xf1\x97\xe5$\xf5\xa0\xb1" +
+	"\x1e\x8cMP\x12\xf8\xd3\xce\x88ky\x05anE\x0e" +
+	"j\x15\x19]\x1fw8\x16\x88(\xf9\x8bn\x02\xc3\xf2" +
+	"Hk2\xbd\xe8z\xc1`K\xa5P\x80x\x8cB2" +
+	"\xffQ*P\xff)\xc8e\xa7\xff\xa6\xb8\x08q\x90V" +
+	"\x02\xf8\x11\xc1\x19\x04l\x04\x00\x03p\x9a\xc2\xe9\x00g" +
+	"\x00\xbf#\x10\xee\x03\x10\x00\xfc\xca\xdf8\x83\xe0\x02\x02" +
+	"\xc3=\x00\xb0Pq\x88\xd6\x01\xf8\x05\xc1E\x04E\xc3" +
+	"\x00\xa0\xdd\x8a\x7f\xf2P\x17\x10\\EP|\x17@1" +
+	"\x80+\xf4Y\x00\x97\x11\xdcA`\xbc\x03\x00\xcf\xf9M" +
+	"\xfa2\x80\x1b\x00|\x0c\xec\xa6\xdb`7\x81}\x84\xbe" +
+	"\x01\xf6\xfb\xf8\x82\x09\x81\xf9\x16\x003\x80\"\xb6\x11\x80" +
+	"\x89\x01(E`\xb9\x09\xc0\x02\xc0\xc5d\x00N\x04\x13" +
+	"\x11Xo\x00\xb0\x02\x98\xc00T\x19\x82)\x08l\xd7" +
+	"\x01\xd8\xb0\x9f3T;\x09\xc1t\x04\xf6k\x00\xec\xd8" +
+	"\x85\x18\xaa\x9d\x8a`.\x82\x92\x7f\x00\x94`S\xe2o" +
+	"\xccB\xb0\x08\x81\xe3*\x00\x07\x80\x85\x1c,@P\x8f" +
+	"\xc0\xf97\x00'\x80Z\x1e\xaa\x06\xc12\x00\xee\x8e\xb8" +
+	"\"%Iq\xb5\xc2\xcfN\xfe\xe9K)\xeai&@" +
+	"\xd5\x11\x93\xa6\xe9\xd7\"\xf9\xa7\xb3\x1b\x80\xbf+\x1e," +
+	"pn\xd5K\xc5\x03\xe6\xde&\xa4\xd1\xb0?\x19HJ" +
+	">\"p5=\xe1O\xben\xe9\xbcrQ\x87=\xc4" +
+	"\x01\x11\xf0V\x1c\xb6\xde\xbfYq|\xd2O9r\x17" +
+	"7\x07\xe2\x11*)\x9e\x10v#\xf0Q[ka\x9f" +
+	"D\x8cv\x81\x97\xda\xaeu^\xc4-)\xe9\xadQ\x87" +
+	"U~\x9c\xf4\x16\x15Xms\"\xdb\x15\x91\xaa\xa3\x7f" +
+	"4M\x10!\x86\x12\xd4\x097\x1aG\xd2/\xabsU" +
+	"\xa5\x8b\xc3Zhu$\x8c\xa6\xd9\xd0\xea\x90\xd3u\x0f" +
+	"}\xfb\x0eRE\xd7@\xea\x0a5\x90i\x99\x06\xf2\x12" +
+	"\x18\x19\xe3\xf7\xd6\xf5<\xf6\xb7\x95`\x0c1\xda\x9di" +
+	"\xe3\xba\xde\xad\xb6\xb3\xb1Z\xaeQ\x92e\xb8\x0b\x0c~" +
+	"\x85;+\xdf\xf0tEP\xa7A\xd5i\x87\xde\x00\xcd" +
+	"\x96z\xa70~,a\x84d\xb3\xd8!^\xc98k" +
+	"N\xc0\x16\xe9\x82M\xd3\x82\xe9\x04\xe7V\xa1d\xac6" +
+	"\x0c\xc9\xc7\x1e\x82Z\xef\xaf\xd4z\xbf\x9bK\xe6R\xed" +
+	"p\xaf\xc2\x1d\xb1\x98\xf6o!\xe5\xe9\xea\x8e\xaf<\x18" +
+	"\x8e\xe4(W\xe7\xfbX\x83ki\xd3\x12^5\x1a\xd2" +
+	"\x89\xaf\xcb\x15o\xc8\x88\xaf\xd3\xc4w\xb7u\xc6%\xb9" +
+	"\xd6\x9f\x1d\x12j\xf5\x8d\xf0\xbf1'\x95\xa0\xaf\xa4v" +
+	"\x03\xc7\x98\x955\xdaGC\x15n\xd7\x02\xb0\xd5C\xd0" +
+	"h(ge\xea\xd7^ze\xd5\xc1\x0eYi\x93\xd5" +
+	"O\x8a\xff\xc9\xde\xae\x9f\xd3>mej\xadfWf" +
+	"\xbeX\x160\x9a\x8a\x05\x94\xe4\xe2f)Hh\x0b\x0c" +
+	"\x07\x06\xbf1s\xea+\x96\xf9<\xca)\x18\xcc\x1b\xb8" +
+	"n\xd4[\x06\x81\x83\\U\xc8O\x1c\xd1xP\xca\xc6" +
+	"\x1e\xe3\x13\xa3}f\xd8\x81\xc3\x18\xa5;_K\xdf\xc1" +
+	"\xd1\xe7\xecu>7]3|\x9av\xb7\x12]-\x07" +
+	"`I\xb0\xaf\xf8\xcd\x17\x086KO\xc7c\x04Z`" +
+	"\xd6\x96w\xa2\xdd\\;\xca.Seo\xc1\xf2o\x82" +
+	"\x98\xdb\xb5\x02mE\xdbf\xb0\xed\xcc\xe9\x0a;\xf0S" +
+	"o;\x18?\x03\xa3@\xd3\x8az\xd0s'\x18\xbf\x02" +
+	"\xa3\x81\xf1)\xee:\x84\xc6\x03`<\x05\xc6\"\x81O" +
+	"p\xd7 \x8c+\xef\x090^\x1e\xaf\xa9t\xaf\x91d" +
+	"%\xda\x16Ww\x8c\x1fH\x8f\xbf\x1e{c\xe6\x10v" +
+	"w\xca\xd1$\xb8e\x8f\x8d9\xdd\x15\xbae)\x10\xca" +
+	"7\xa7d)\x12U\x92r\x14\x06\x91\x9e\xfd\x07\x9e\xe0" +
+	"[\xf8"
 
 func init() {
 	schemas.Register(schema_8fcd13516850d142,
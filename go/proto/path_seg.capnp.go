@@ -174,12 +174,12 @@ type ASEntry_exts ASEntry
 const ASEntry_TypeID = 0xd4a209e8e78874ff
 
 func NewASEntry(s *capnp.Segment) (ASEntry, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 4})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 5})
 	return ASEntry{st}, err
 }
 
 func NewRootASEntry(s *capnp.Segment) (ASEntry, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 4})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 5})
 	return ASEntry{st}, err
 }
 
@@ -335,12 +335,37 @@ func (s ASEntry_exts) NewHiddenPathSeg() (HiddenPathSegExtn, error) {
 	return ss, err
 }
 
+func (s ASEntry_exts) StaticInfo() (StaticInfoExtn, error) {
+	p, err := s.Struct.Ptr(4)
+	return StaticInfoExtn{Struct: p.Struct()}, err
+}
+
+func (s ASEntry_exts) HasStaticInfo() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s ASEntry_exts) SetStaticInfo(v StaticInfoExtn) error {
+	return s.Struct.SetPtr(4, v.Struct.ToPtr())
+}
+
+// NewStaticInfo sets the staticInfo field to a newly
+// allocated StaticInfoExtn struct, preferring placement in s's segment.
+func (s ASEntry_exts) NewStaticInfo() (StaticInfoExtn, error) {
+	ss, err := NewStaticInfoExtn(s.Struct.Segment())
+	if err != nil {
+		return StaticInfoExtn{}, err
+	}
+	err = s.Struct.SetPtr(4, ss.Struct.ToPtr())
+	return ss, err
+}
+
 // ASEntry_List is a list of ASEntry.
 type ASEntry_List struct{ capnp.List }
 
 // NewASEntry creates a new list of ASEntry.
 func NewASEntry_List(s *capnp.Segment, sz int32) (ASEntry_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 4}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 5}, sz)
 	return ASEntry_List{l}, err
 }
 
@@ -383,6 +408,10 @@ func (p ASEntry_exts_Promise) HiddenPathSeg() HiddenPathSegExtn_Promise {
 	return HiddenPathSegExtn_Promise{Pipeline: p.Pipeline.GetPipeline(3)}
 }
 
+func (p ASEntry_exts_Promise) StaticInfo() StaticInfoExtn_Promise {
+	return StaticInfoExtn_Promise{Pipeline: p.Pipeline.GetPipeline(4)}
+}
+
 type HopEntry struct{ capnp.Struct }
 
 // HopEntry_TypeID is the unique identifier for the type HopEntry.
@@ -729,66 +758,67 @@ func (l PathSegType_List) Set(i int, v PathSegType) {
 	ul.Set(i, uint16(v))
 }
 
-const schema_fb8053d9fb34b837 = "x\xda\xacT_h\x1c\xd5\x17>\xdf=\xb3\xbbI~" +
-	"\xbf8{\xd9\x95\xa6\"\xac\x15\x05]\xb5\xd4\xb4\x92R" +
-	"\x84\xd8\x9a\xb6\x89\x10\xdc\xdb\xc4JE\xd1\xe9\xee4\x19" +
-	"0\xb3\xc3\xce]\xda\x8d\x94\xd6\x87\xf8\x07\x15\x1f\x14\xb1" +
-	"\xa5%mQ0\xd0\xd0\x8a-\xa4A!-\x85\"\x08" +
-	"\xbe\x88h\xa0`\xb5E\x0b\xbe\xf9 m\xa9#w7" +
-	"\xb3\xbb$\xed\x9bow\x0e\xe7~\xe7|\xdf\xfd\xbeY" +
-	"w\x03\xcf\x88'\x13\x97\x04\x91z \x91\x8c\xfe\xf7\xd8" +
-	"\xdbgN]\xfe\xf2=R6\x12Q\xdf\xdc\x86[?" +
-	"\x8f\x1c\xbcE\x09\xa4\x882C\xb8\x90Q\xe6\xb4~\x18" +
-	"/\x82\x10\xdd\x0c\xe6\xdf:\xbcp\xe4#\x926\xda\x9a" +
-	"\x85i>.\x163\xb3\xf5\xd3\x8c\xd8K\x88\xec\xf9\xae" +
-	"\xa7\xdf\xd8\xf9\xe9\xb4A\xc6r\xe4\xd5\xbc\x98y\x98\xcd" +
-	"i\x0d\x9b\xe6\x83\x1bOt\xb93\x7f\x1f'i\x8bV" +
-	"/!\xb3\x9f\x173\xef\xd6\x1b\xa7x\x8c\x10E\xd3S" +
-	"G\xff\xe9\xd2\x17H\xad\x82\x15E\xfa\x9d\xdf\xff\xe8<" +
-	"\xf1\x03\xddk\xa5`&\xf3\xaf\x84\xcc,\x9f\"D\xf9" +
-	"?'W\xf5L~\x7fi\xd9\xae\x0d:V\x1e\x99W" +
-	",\x03\xbb\xcb\xea7\xb01\x90\xb2a\xb5u\xd7[j" +
-	"\xd6|\xe6MsZ\xbf\xdf\xfa\xd0\xc8pM\xd5\xfa\x8a" +
-	"\xdb\x17~\xbc#\xb5\xdb\x89\xc3\x99D\xd2\x9c\x904\xd4" +
-	"\x02G\x8f\xbf\x1a\xbacbm\xd1\x09\xfc`\xd3`9" +
-	"\xd8\xea\xebJ\x8d\x0a\x80\xeaa\x8b\xc8\x02\x91<\x94'" +
-	"R\x1f3\xd41\x01\x09da\x8aG_\"RG\x18" +
-	"\xea\x0b\x01):\xb2\x10D\xf2\xf3^\"u\x8c\xa1N" +
-	"\x0aH\xe6,\x98H\xce\x98\xe2g\x0cuZ@ZV" +
-	"\x16\x16\x91\x9c\xddM\xa4N2\xd4\x9c\x00\x12Y$\x88" +
-	"\xe4Y3\xe74C}-`{\xfe\xd0ft\x92@" +
-	"'!\xaa\xb8\x13e\xed\x0e\xf9\xc4C\xdb\xe2b\xce\xf3" +
-	"\x87G_@\x8a\x04R\x84\\\xb9\xaaW\\x\xbeJ" +
-	")\xdd\xbaa\x8f\x97\x83m\xe8&\x81\xee;\x90/8" +
-	"z|\xc4\x1d\x9bp\xd9\xd7\x86\x7fG\x93\xff\xa3\x86\xc0" +
-	"C\x0c\xb5\xae\x8d\xff\x13;\x88\xd4\xe3\x0c5(\x90\x0b" +
-	"K\x8ev\x9a\xc8NhT\xf4\\B\x88{\x08\x05\x06" +
-	"\xd2\xd1\x8e\xdfn\xf6Mm\xef\x9d&\x82)\xdem\xfc" +
-	"\xb0\xcb\xdaY6~Kk|s\xba\x91\xea\x11\x86\xda" +
-	" p h\\E\xba\x15\x02\x02\xd2\x04[\xd7\x02\x17" +
-	"v\xcb\xc2\x04\xd8w\x9f=Z\xe3\xc05\xb3\xd3\xf5\xc7" +
-	"4\xb4\x01\xb9\xe6>\"\x08\xb9:O\x04\x962O\x94" +
-	"\xab\xfa\xa1\xab\xb9\x1a\xd8\xa5\xf2^\xdf.\x96+\xee\x0a" +
-	"\xc8\xcd#u'\xaduS\xfbt\xa8\xfe\xcfV\x1aY" +
-	"$\x89\xe4\xd6\x0a\x91\x1a`\xa8\x82\x11Sd\x8d1\xe5" +
-	"\xb0Qx\x90\xa1F\x8d\x998\x8b\x0e\"\xa9Lg\x81" +
-	"\xa1^\x16\x88*\xe5\xaa\xf6\xfc\xb1\x02\xe5\xca\xaf{\xc5" +
-	"\x1a\xd2\xd1\x8d\xabOm\x9c[<\xff\xc9\x12\xd7\\\xe8" +
-	"\xed\xae8HG\x1f\xbcv}\xd7\xb9\xeb\xb7\xe7\x96\xea" +
-	"\xd1\xb8W*\xb9~\xc1\xa1\\\xac\xd2_\xef_\xbbr" +
-	"\xe8\xabZ\x14w\xc4\xbb\xf3r'\xf8z\xc4\x1b\xf3\xdd" +
-	"\x92=\xe04\x1e\xc5j>J\xb7\xd9\xb8\x83\xa1\xb2\xc2" +
-	"8qOy\xa5\xb1\x10K\xd1\xdf\xd0\xc2\x00\xdc\xdf\x04" +
-	"8\xdb\xdb2{\xd3T\xe76\x11\xa93\x0c\xb5`t" +
-	"\x10\x8dP}c\xde\x7f\x8e\xa1.\x9aP\xf54Bu" +
-	"\xfe9\"\xb5\xc0P\xdf\x09`)S\xdf\x1aS\\d" +
-	"\xa8_\x04d\xa2\xbb\x11\xaa\xcb\x0f\x12\xa9\x9f\x18\xea\xaa" +
-	"\x00\x92h\xfbM\xc9+y\x129/,9a\x9c\x90" +
-	"~])\xeet+\xf1\xe7\x81\xa2[\xd1m\xdf\x91\xb7" +
-	"gh`\xc4\x9bt\x89\x08I\x12H6B\xd5f\xf3" +
-	"\xe6O\xbba\xf3\xd4\x84\xae\xc6\x11\xb5\xdd}:\\\xa1" +
-	"N\x81\x9f\xdd\xf2\x9f\xf9\xdd\xec\x17/\xfbo\x00\x00\x00" +
-	"\xff\xff*<\x8e\xd4"
+const schema_fb8053d9fb34b837 = "x\xda\xad\x94]h\x14W\x14\xc7\xef\xb9wf7I" +
+	"Ig\x87]1\x96\xc2\xd6\xd2\x82M\xabh\xd4F\xa4" +
+	"%\x8d\xf5k\x0b\xa1\xbb\xae\x1f\xd4\x87\xd6q\xf7f3" +
+	"\x90\xcc\x0c;w\xd1M)\xe6%\xfd\xa0\x95>TJ" +
+	"#\x86\xa8\xb4\xd0\x80\xa1J\x156A!\x11A\x0a\x85" +
+	"\xbeHiS\x04?\xb1B\xdf|(U\xda\xf1\x9c\xdd" +
+	"\xcc\xee\x92\xe8[\x1f\x86\xb9\xf77\xe7\x9e\xaf\xfb?\xb3" +
+	"\xb6\x95\xbf\xc5\xd7\xe9W9c\x99\x17\xf4H\xf0\xcc\xab" +
+	"\x9f\x9c\xff\xe1\xfa\xb9\xcfY\xc6\x00=\xe8\xaelx\xf4" +
+	"{v\xe4\x11\xd3!\xcaX<\x05\x97\xe3\x19Z\xad\xef" +
+	"\x83}\xc0 x\xe8\xcd|||\xf6\xc4W\xcc4\xa0" +
+	"\xc9\x98\x93\xf1)>\x1f\x9f\xaa\xae&\xf9!\xb45f" +
+	"\xda\xde\xf8p\xef7\x13\xe4\x19\x16{^!\xe6\xe3/" +
+	"\x0bZ\xad\x14d<\xb2\xe9t\x9b\x9c\xfc\xfb\x14:\xe6" +
+	"\x0d[\x06\xf1\x8f\xd0\xf0\xb3\xaa\xe1\xa8(\xa0a01" +
+	":\xfe_\x9b\xba\xcc2\xcbA\x0b\x02\xf5\xe9\xbd?[" +
+	"O_c\xcb\xf4(Pdq\x0b\xcfL\x89{h\xd9" +
+	"\xf9\xd7\xf0\xf2\x8e\xe1_\xae.\xca\xb5Z\xceQ\xad\x13" +
+	"\xe2\xe3\x1a\xb9\x1d\xd3z\xc8m\xe8\x08\x93\xd5\x9a\xacu" +
+	"2\x99\xd6f\xe2sd\xbc\xfe\x92\xf6%\xb5\xe1n\xa6" +
+	"\xdc\x9d\xdb1\xfb\xeb\x13K{3r<\xbe-B\xab" +
+	"\xde\x08\x95\xe6Yj\xe0\x03_\x16\xf8\x9a\x9c\xe59\xde" +
+	"\xe6\x9d\xae\xb7\xcdQ\xc52K\x03d:\x84\xc6\x98\x86" +
+	"\xa9\x9bc\x9dx#\xc7\x04dNr0\x01\x12@p" +
+	"|?\xc2\x13\x08\xbfG\xc8[\x12\x80\xd7f~\xd7\x85" +
+	"\xf0$\xc23\x08\x85H\x80@8I\xf0[\x84g\x11" +
+	"jZ\x02\xd0\xaf9u\x10\xe1\x19\x84\x15\x0e\xa0'@" +
+	"Gv\x81\xe2\x9cEv\x91\x83a;\xa9^he\x1c" +
+	"\x1f\x08\x8ar\xc8U2\xe50\x91\xda\x1e\xc2\xa4\xed\xf4" +
+	"\xed\xde\x83uq| \xe9\x96\xd4\x92\x03\xef\x96XT" +
+	"5N\x18\x03\xae\xb7\x1d\xdaq\xd3\xfe\x84\xe2\xd3\xb8\xcf" +
+	"\xca\xc2\x90\x14\x8e\xa2\xfa[\xea\xf5\xbfB\x05\xbc\x84y" +
+	"\xadm\xaa\x7f\xf5.\x84\xaf!\xdc\xc9!\xe9\xe7-e" +
+	"\xd5=[>u\xd1\x96\x0c|x\x96AZ\x00\xc4\x82" +
+	"]\xb7\x1fv\x8f\xee\xe8\x9a@\xe5\x10|Z\xf8>)" +
+	"\x94\xb5(\xfc\x96F\xf8ztj\xd5*d\x1b8\x1c" +
+	"\xf1jG1H}\x080H\x0c\x0bVeO\x82\xd1" +
+	"\x900b\xe3\xe9\xb1w\x97\x85')v\xacz\x99T" +
+	"6\x80\xb9\xf29|qs\x05F\x04a\x9a\xf8J\x96" +
+	"\x1c_*Q\xf2\x8c\xbc{\xc81rnQ.q\xd9" +
+	"\x9b\xad*i\x8d\x8c\x1eV~&&\xb4\x18f\x1eA" +
+	"\xafV\x113?\x80\x99\x0fR3y\x82\x84i\xda\xd4" +
+	"\xe1<B\x8f\xc4\x84\xbaiA8D\x96\x83\x08\x0f\x93" +
+	"\x98P7\xad\x08K$;\x85p\x84\xe3-\xe3\xa5\xdb" +
+	"N!\xcd\x92\xee\xa0\x9d+c\x03\xfe\xb9\xb3qSe" +
+	"~\xee\xeb\x85\x06$}\xfb`\xd1B~\xf4\xc0\xfd\xf7" +
+	"\xa6\xef\xff[Y\xe0\xc1\x80\x9d\xcfK'm\xb1d\xd8" +
+	"\xba\x07_\xdc\xbd9\xf6c9\x08-|e);G" +
+	"\x92\xebw\xf1\xf3\xbe\xd5\xf2\xd8\xeb\xef\xa7\xff\x08?\x87" +
+	"\xf5\x8a\xc5\xeaqT\xd6.82ol\xb5j\x17\xa9" +
+	"\xd5/\xb2\x9d\xaal\xc1\xdc\x13\x9c\xd4\xdb\xef.\x15#" +
+	"\x84\xed\xeb\xa9\xf5\x8f\x1c<_wp\xa1\xab1 u" +
+	"!NoFx\x1e\xe1,\xf5\x8e\xd7\x06\xf1\x12i\xa6" +
+	"\x82\xf0\x0a\xf5\xae\xa36\x88s\xef \x9cE\xf83\x0a" +
+	"ia\x0e\x7f\"!]Av\x03\x0d\xf5\xf6\xda ^" +
+	"\x7f\x11\xe1o\x08\xef\xa0a\x04\x9a~m\xe6\xcdN\xc6" +
+	"\x936\xea\xdd\x0f\xa7\xaaG\x15s{e1\xdc\x1e\xc9" +
+	"\xc9\xa2j\xda\x07v\x7fjk\xd6\x1e\x96\x0c[\x17A" +
+	"\x16\xa9\x0db\xd3h\xd4\x7f\xf4\xb5\xd1\x88\x0e\xa9R8" +
+	"\xd6\x86D\xf9,\xe9NZ\xbc\xbd\xe5\x7f\x9b\x11\xca/" +
+	"L\xf61e%\x9c\x88"
 
 func init() {
 	schemas.Register(schema_fb8053d9fb34b837,
@@ -3,6 +3,7 @@
 package proto
 
 import (
+	math "math"
 	strconv "strconv"
 	capnp "zombiezen.com/go/capnproto2"
 	text "zombiezen.com/go/capnproto2/encoding/text"
@@ -13,23 +14,25 @@ type SCIONDMsg struct{ capnp.Struct }
 type SCIONDMsg_Which uint16
 
 const (
-	SCIONDMsg_Which_unset              SCIONDMsg_Which = 0
-	SCIONDMsg_Which_pathReq            SCIONDMsg_Which = 1
-	SCIONDMsg_Which_pathReply          SCIONDMsg_Which = 2
-	SCIONDMsg_Which_asInfoReq          SCIONDMsg_Which = 3
-	SCIONDMsg_Which_asInfoReply        SCIONDMsg_Which = 4
-	SCIONDMsg_Which_revNotification    SCIONDMsg_Which = 5
-	SCIONDMsg_Which_ifInfoRequest      SCIONDMsg_Which = 6
-	SCIONDMsg_Which_ifInfoReply        SCIONDMsg_Which = 7
-	SCIONDMsg_Which_serviceInfoRequest SCIONDMsg_Which = 8
-	SCIONDMsg_Which_serviceInfoReply   SCIONDMsg_Which = 9
-	SCIONDMsg_Which_revReply           SCIONDMsg_Which = 10
-	SCIONDMsg_Which_segTypeHopReq      SCIONDMsg_Which = 11
-	SCIONDMsg_Which_segTypeHopReply    SCIONDMsg_Which = 12
+	SCIONDMsg_Which_unset               SCIONDMsg_Which = 0
+	SCIONDMsg_Which_pathReq             SCIONDMsg_Which = 1
+	SCIONDMsg_Which_pathReply           SCIONDMsg_Which = 2
+	SCIONDMsg_Which_asInfoReq           SCIONDMsg_Which = 3
+	SCIONDMsg_Which_asInfoReply         SCIONDMsg_Which = 4
+	SCIONDMsg_Which_revNotification     SCIONDMsg_Which = 5
+	SCIONDMsg_Which_ifInfoRequest       SCIONDMsg_Which = 6
+	SCIONDMsg_Which_ifInfoReply         SCIONDMsg_Which = 7
+	SCIONDMsg_Which_serviceInfoRequest  SCIONDMsg_Which = 8
+	SCIONDMsg_Which_serviceInfoReply    SCIONDMsg_Which = 9
+	SCIONDMsg_Which_revReply            SCIONDMsg_Which = 10
+	SCIONDMsg_Which_segTypeHopReq       SCIONDMsg_Which = 11
+	SCIONDMsg_Which_segTypeHopReply     SCIONDMsg_Which = 12
+	SCIONDMsg_Which_pathSubscriptionReq SCIONDMsg_Which = 13
+	SCIONDMsg_Which_pathUpdate          SCIONDMsg_Which = 14
 )
 
 func (w SCIONDMsg_Which) String() string {
-	const s = "unsetpathReqpathReplyasInfoReqasInfoReplyrevNotificationifInfoRequestifInfoReplyserviceInfoRequestserviceInfoReplyrevReplysegTypeHopReqsegTypeHopReply"
+	const s = "unsetpathReqpathReplyasInfoReqasInfoReplyrevNotificationifInfoRequestifInfoReplyserviceInfoRequestserviceInfoReplyrevReplysegTypeHopReqsegTypeHopReplypathSubscriptionReqpathUpdate"
 	switch w {
 	case SCIONDMsg_Which_unset:
 		return s[0:5]
@@ -57,6 +60,10 @@ func (w SCIONDMsg_Which) String() string {
 		return s[122:135]
 	case SCIONDMsg_Which_segTypeHopReply:
 		return s[135:150]
+	case SCIONDMsg_Which_pathSubscriptionReq:
+		return s[150:169]
+	case SCIONDMsg_Which_pathUpdate:
+		return s[169:179]
 
 	}
 	return "SCIONDMsg_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
@@ -497,6 +504,72 @@ func (s SCIONDMsg) NewSegTypeHopReply() (SegTypeHopReply, error) {
 	return ss, err
 }
 
+func (s SCIONDMsg) PathSubscriptionReq() (PathReq, error) {
+	if s.Struct.Uint16(8) != 13 {
+		panic("Which() != pathSubscriptionReq")
+	}
+	p, err := s.Struct.Ptr(0)
+	return PathReq{Struct: p.Struct()}, err
+}
+
+func (s SCIONDMsg) HasPathSubscriptionReq() bool {
+	if s.Struct.Uint16(8) != 13 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SCIONDMsg) SetPathSubscriptionReq(v PathReq) error {
+	s.Struct.SetUint16(8, 13)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewPathSubscriptionReq sets the pathSubscriptionReq field to a newly
+// allocated PathReq struct, preferring placement in s's segment.
+func (s SCIONDMsg) NewPathSubscriptionReq() (PathReq, error) {
+	s.Struct.SetUint16(8, 13)
+	ss, err := NewPathReq(s.Struct.Segment())
+	if err != nil {
+		return PathReq{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SCIONDMsg) PathUpdate() (PathReply, error) {
+	if s.Struct.Uint16(8) != 14 {
+		panic("Which() != pathUpdate")
+	}
+	p, err := s.Struct.Ptr(0)
+	return PathReply{Struct: p.Struct()}, err
+}
+
+func (s SCIONDMsg) HasPathUpdate() bool {
+	if s.Struct.Uint16(8) != 14 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SCIONDMsg) SetPathUpdate(v PathReply) error {
+	s.Struct.SetUint16(8, 14)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewPathUpdate sets the pathUpdate field to a newly
+// allocated PathReply struct, preferring placement in s's segment.
+func (s SCIONDMsg) NewPathUpdate() (PathReply, error) {
+	s.Struct.SetUint16(8, 14)
+	ss, err := NewPathReply(s.Struct.Segment())
+	if err != nil {
+		return PathReply{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
 func (s SCIONDMsg) TraceId() ([]byte, error) {
 	p, err := s.Struct.Ptr(1)
 	return []byte(p.Data()), err
@@ -585,6 +658,14 @@ func (p SCIONDMsg_Promise) SegTypeHopReply() SegTypeHopReply_Promise {
 	return SegTypeHopReply_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
+func (p SCIONDMsg_Promise) PathSubscriptionReq() PathReq_Promise {
+	return PathReq_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p SCIONDMsg_Promise) PathUpdate() PathReply_Promise {
+	return PathReply_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
 type PathReq struct{ capnp.Struct }
 type PathReq_flags PathReq
 
@@ -653,6 +734,14 @@ func (s PathReq_flags) SetHidden(v bool) {
 	s.Struct.SetBit(145, v)
 }
 
+func (s PathReq_flags) Disjoint() bool {
+	return s.Struct.Bit(146)
+}
+
+func (s PathReq_flags) SetDisjoint(v bool) {
+	s.Struct.SetBit(146, v)
+}
+
 func (s PathReq) HpCfgs() (HPGroupId_List, error) {
 	p, err := s.Struct.Ptr(0)
 	return HPGroupId_List{List: p.List()}, err
@@ -1013,12 +1102,12 @@ type FwdPathMeta struct{ capnp.Struct }
 const FwdPathMeta_TypeID = 0x8adfcabe5ff9daf4
 
 func NewFwdPathMeta(s *capnp.Segment) (FwdPathMeta, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
 	return FwdPathMeta{st}, err
 }
 
 func NewRootFwdPathMeta(s *capnp.Segment) (FwdPathMeta, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
 	return FwdPathMeta{st}, err
 }
 
@@ -1087,12 +1176,37 @@ func (s FwdPathMeta) SetExpTime(v uint32) {
 	s.Struct.SetUint32(4, v)
 }
 
+func (s FwdPathMeta) Metadata() (PathMetadata, error) {
+	p, err := s.Struct.Ptr(2)
+	return PathMetadata{Struct: p.Struct()}, err
+}
+
+func (s FwdPathMeta) HasMetadata() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s FwdPathMeta) SetMetadata(v PathMetadata) error {
+	return s.Struct.SetPtr(2, v.Struct.ToPtr())
+}
+
+// NewMetadata sets the metadata field to a newly
+// allocated PathMetadata struct, preferring placement in s's segment.
+func (s FwdPathMeta) NewMetadata() (PathMetadata, error) {
+	ss, err := NewPathMetadata(s.Struct.Segment())
+	if err != nil {
+		return PathMetadata{}, err
+	}
+	err = s.Struct.SetPtr(2, ss.Struct.ToPtr())
+	return ss, err
+}
+
 // FwdPathMeta_List is a list of FwdPathMeta.
 type FwdPathMeta_List struct{ capnp.List }
 
 // NewFwdPathMeta creates a new list of FwdPathMeta.
 func NewFwdPathMeta_List(s *capnp.Segment, sz int32) (FwdPathMeta_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3}, sz)
 	return FwdPathMeta_List{l}, err
 }
 
@@ -1113,6 +1227,10 @@ func (p FwdPathMeta_Promise) Struct() (FwdPathMeta, error) {
 	return FwdPathMeta{s}, err
 }
 
+func (p FwdPathMeta_Promise) Metadata() PathMetadata_Promise {
+	return PathMetadata_Promise{Pipeline: p.Pipeline.GetPipeline(2)}
+}
+
 type PathInterface struct{ capnp.Struct }
 
 // PathInterface_TypeID is the unique identifier for the type PathInterface.
@@ -2262,131 +2380,667 @@ func (p SegTypeHopReplyEntry_Promise) Struct() (SegTypeHopReplyEntry, error) {
 	return SegTypeHopReplyEntry{s}, err
 }
 
-const schema_8f4bd412642c9517 = "x\xda\x94W}l\x1bg\x19\x7f\x9e\xf7\xec\xd8Nl" +
-	"\x9f\xddsF\x09\x82\xd0\xaaU\x9bj\xa9\x96t\x85\xae" +
-	"\x82\xa5M\xdb,\x1et\xcd\xd9\x11\x7fLC\xcc\x8b_" +
-	"'FN\xec\xdc]\xd2z\xa2\x84\xa2\x06XaZ\xab" +
-	"-\x82\xd1N\xb4\x1b\x94\x06\x86\xd8J5\xb1\x82&\xd0" +
-	":>\xa2\x09\x98T4-\x1a\xac-\xeb\xdat\xabX" +
-	"\xba\x8e~\xd0r\xe8\xb9;\xdf]/\xd7\x8f\xe5\xaf7" +
-	"\xfe=\xfe\xbd\xcf\xd7\xefy^\xdf1/\xb8\x86\xb5\x05" +
-	"\xff\x1d\x02\x90\x1f\x08\xd6\xe9\x1f<\xf7\x8b\xfd\xef\x9e\x7f" +
-	"\xf8\xdb\x90\x8c\xa1\xfe\xb1\x89\xdb\xf3\xf3\x8e~\xe11\x08" +
-	"b\x08@\xfa\x870-\xcd\x08t:)t\x00\xea\xe7" +
-	"\xa7/}\xe5\xa5\xa9\xb7v\x80\x1cC\xb71#\x93\xc6" +
-	"\xc0\x94\xb4 @\xa7O\x06N\x01\xeaM\xc9=]o" +
-	"+\xdb\x1e\xf3\x18\x1b\x16\xc1\xe0A)\x16\xa4S$H" +
-	"\xc4]/w\x8d\x1d\xda}f\x17\xd92\xc7v\x03\x0b" +
-	"\xc50 \xb5\x04\x0fKmd\xbd\xa25\xb8I\x00\xd4" +
-	"\x9f:\x9d:\xb1t\xfe\xd7\x9f\xf0\xf3\xf9\xcf\xe1)\xe9" +
-	"\xefa:\xbd\x16&\xea}[\x1b\x0e\xac\\S\x9d\xf0" +
-	"P\x1bn`dZ\x8aE\x0c7\"\x9b\x01\xf5\x99\xce" +
-	"\xb7\xc6\x7f:^\xb7\xdb\x8f\x97G\xceH\xc3\x86\xed`" +
-	"\x84x\xa7\xdf\xd8q\xfax\xf0/\xbbAnDA\x7f" +
-	"\xf7\x99#o\xb65\xfe\xe1\x084b\x08\x01\xa4]\x91" +
-	"i@i\xc2`\x9d\xd7\xb6\xb7\xed\x81\xf0\xa6I\x1f\xd6" +
-	"\x15\xb3\x11\x86\xd2U\x83\xf6\x92A{hvR\xbe\x7f" +
-	"\xfe\xc5g\xbd)6\xac[\xeb\xe7\xa1\xf4\xf9z\xb2\xbe" +
-	"\xab\xfe\x97\x80\xfa\xa7\x17?\xbe9\xb8\xa4\xe9\xa0oA" +
-	"f\xea\x0fJ\xb3\x86\xf1\xd9z\xf2\xe3\xf4\xb9\xdbFO" +
-	"\x9e]\xf3\xb2_t\xad\x0dg\xa4\xbb\x1a\xe8\xb4\xb2\x81" +
-	"\xdc\xb0\xe3\x91c(\xccIE\xc3\xcf\xa4A2^Q" +
-	"lhF@\xfd\xbd\xd1\xefWz\x97\xeb\xafx\x98\x0d" +
-	"/\x86\xa3'\xa4\xadQ:U\xa3\xe4\x85\xc8\xff\xba\xb6" +
-	"s\xfb\xa7\xa6\xfc\xda\xe2\x8d\xe8\xb4t\xd2\xb0=\x1e%" +
-	"/~\xf2\xce\xa2=\x07\x9e\xe6\xaf\xfa\xd9b\xec\xb0\x14" +
-	"\x89\x19\xcd\x14#\xdb7\x8f\xfff\xff#\x8f/9\xe5" +
-	"\x9b\xb8\xc5\xb1&\x94V\x1a\xd6m1J\\\xe9X\xe6" +
-	"KM\xaf]8\xe5\x97\x8b\xe3\xb1)\xe9\xaca;c" +
-	"0\xafZ\xf2\xfa\xb7\xfa\x1b_y\xdf\x8fY\xfax\xfc" +
-	"\x9c\xb48N\xa7\x05q\x0a\xaf\xe3\x9d\xbb[^\x98\x11" +
-	"g}\x8d\xb7\xc5\x0fK\x8f\x18\xc6\xe3\x86\xf1\x8b/m" +
-	"\x99\xfc\xee\xeb\xfb/\xf8y\xf1a\xfc\x9c\x84\"\x9d\xae" +
-	"\xc6\xc9\x8bh\xd3?\x7f\xde\xbf\xf8\xe4%\x90oCW" +
-	"\xe1\x1b\x99\xd1o\xad\xe2\x09@\xa9M$\xd6_\xbd\xf0" +
-	"\xf0=\x87\x9ey\xfe\xb2_\xc7?*\x9e\x93\x9e4X" +
-	"'D\xca\x83\xdaW,\x0f\xe5\x97\xf7\xb1\\e\xa8\xb2" +
-	":\xdd\x95\x1e*\x943|x\x84\x0b\xaa\xd6\x83(\x07" +
-	"\x84\x00@\x00\x01\x92\xb1v\x009,\xa0\xbc\x88as" +
-	"\xb1\x90^\xafb\x1c\xb0G@\x8c\x00\xa3\xa3\x87\xabk" +
-	"s\xbe'\xa7\x0dl\xe4Z\x0e\x80\xa8\x126U\xae\x93" +
-	"f\x8f\x80\xf2\x00C\xc4\x14\xd2g|!\x80\xfc\xa0\x80" +
-	"r\x89a\x92a\x0a\x19@\xb2x?\x80< \xa0\xbc" +
-	"\x9daR\xc0\x14\x0a\x00\xc9m\xf4\xed\xaf\x09(\x7f\x87" +
-	"\xe1X\xc1\xbc\x05c\xc00\x06\x18\x1a\xd4F0\x04\x0c" +
-	"C\x80zqH\xe3J!\xd7\x07\x02\xb7}M8\xf3" +
-	"\x00\x90>\x1c\xe3[*\xbd\xc5A\x8ea`\x18vE" +
-	"\x81F\x14\x19>\xda\x9c\xe1\x95R\xd5\x93\x8c\xd5V2" +
-	"R\x0c;\x14\xae\x8e\x944\xfb\xdak\x09\xb2\xeb\xd2\x1d" +
-	"\x9b\xee[\xbfQ\xed'\x86\xcf\xd5\x18\xa4\x09l\x02\xc8" +
-	"\xeeD\x01\xb3{\x90a\x0cu\xddH\x84\xf4$\xb6\x03" +
-	"d\x9f `/\x01\xec\x7f\xba\x91\x0c\xe9)\xec\x04\xc8" +
-	"\xfe\x80\x80\x1f\x13 \\\xd5\x8d\x84H\xfb0\x03\x90\xdd" +
-	"K\xc0\xb3\x04\x04\xae\xe8)\x0c\x00H\x93\x06p\x80\x80" +
-	"C\x04\x04\xff\xab\xa70\x08 =\x8f\x0f\x01d\x9f#" +
-	"\xe0\xb7\x04\xd4]\xd6SX\x07 \xbd\x88\xdf\x04\xc8\xfe" +
-	"\x9a\x80#\x04\x84.\xe9)\xa3\x1b\x7f\x8f\x0a@\xf6w" +
-	"\x04\xbcJ@\xf8\xa2\x9e\xc20\x8d[\x83\xeaO\x04\x1c" +
-	"% rAOa\x84\xa6/\xfe\x10 {\x94\x80c" +
-	"\x04\xd4\xffGOa=-\x15\xdc\x01\x90=F\xc0{" +
-	"\x044|\xa8\xa7\xb0\x81\xd4\x86\xf7\x02dO\x13p\x9e" +
-	"\x80\xe8y=\x85Q\x00i\xd6\xb8\xfc}\x02\xae\x10\x10" +
-	"\xfb@Oa\x8cF\xa6\xe1\xeeE\x02\x02\x8ca2\x8e" +
-	")\x8c\xd3l`\x94\xaa+\xf4y\x981\x14\x8ay\xa3" +
-	"I#\x80\xcd#C*\xd7\xa0n\xac\x92\xd3\x062|" +
-	"\x18\x13\xce\xa8\x03\xc4\x04\xa0n\"\x95\x12`\x15\x13\x8e" +
-	"\x9e-4\xa7\x9a\x12\x01\xa4\xef\xda\x03\xca\x8b\x86*%" +
-	"\xfa\xb6\xbd\xa8,\\\xe1\xa3\xf7\x95\xb5b\x01\x8b}9" +
-	"\xadX\x1e\x02L8K\xc7\xb2)\x16,\x8e\xe6\xe1\x11" +
-	"\xaej\x98pV\xb4\xd7\xc2\xba\xc5\x1ef\x16\xaere" +
-	"\xb4\xd8\xc7\xd3\xe8\x123&\x9c=\xe4kV)U\x81" +
-	"\xdc\xb1g\x92\xe3\xb2\x05\x12j/u\x9b\xa3\xbf\xb7Z" +
-	"\xe1\xdd\xd0\\\xae\x98\xe9\xb4\xe7\xbb\xc7\x02\xc9\x80x0" +
-	"\xe1l\"\xd3fLSr}<\x9d\xaf\xa9\xd83I" +
-	"\xd6f\xd3\x8e\x87\x1e\x1dv:Ci\x8c\x0fiJ\xd1" +
-	"-u{\x10\x9aR\xf7\xd0\xd2\xdcH\x9b#B\xe8\xe3" +
-	"\xc4\x1b\xb6y[h\xd8-\x12P\xbe\x83a\xb26\xa2" +
-	"Z\x97\x01\xc8K\x05\x94\xef\xa4\x09\xa8\xe6sj\xad\xab" +
-	"D\x9a\x87\xb5\x7f<\xd7d\xac\x92\x17\xfbr\"\x95\xdc" +
-	"\x13\xc0\xbd\x00rT@y>C]\xcd\xf0Q\x0a\xd5" +
-	"Lu\xe6_\x97?;~O\xfb\x8f\xecD\xce\xf1>" +
-	"\xc3\x87\x97\x17J9\xa1_%\xd7\x13;\xcd\xf9\xd8\xd2" +
-	"\xe9\xf6}\x971\x0a\x92\xad\xab\x1d\xdf\xc7\x14^P\xb8" +
-	":\x80\x08\x0c\x11\xb0c\xa0\x98\xcf\xf3\xa1\xda\xbf\xf6E" +
-	"\x829\xc0\xac.\xa9\xf5\x92\xaayk\xf0U+\x84\xa5" +
-	"\xcc\xee\xa9^\x10\xab\x15\xa7\x14\xa2\xae\xf5\xff\xed\x13-" +
-	"\xad\x99\x13\xdeR\xd4\xee0{\xc4j\x91\x0dC\x9a\x82" +
-	"\xc6\xc4\x8d\xda\xb7l\xa0U\xb0^@\xf9Agg|" +
-	"9\xe3\xec\x11{g\xf0Ng\x91\xdc\xda\x0a\xd0\xb5\xe2" +
-	" W\xb5\xdc `\xa5\xb6\x06n\xb2\x16\xba\xcbj\xb3" +
-	"F)\xf1\xb4\xcd2'\xf5\xf4\xe7,\xeddk;0" +
-	"\xb1RV\xec=\xd1\x9c\xcb\xe7\x15\xd5SUW\"D" +
-	"\x9f\xads\xc3n\xb7_\x8e\x9e\x14c\xad_Dj\x18" +
-	"bL\xd9\x8c[i\xebn\xb1\x16l-\xad\xdb\x16:" +
-	"\x0b6\xc9\xc2fZ\xc7\xa9Q\xb7\x0b(\xefd\x88\x02" +
-	"\xba\xde\xbf\xc9G\xdb\x81a\xc0\xd8,\xc9\x11\xea\xb3\x8a" +
-	"\x80\xf2\xf7\x18\x86\xf2\xaaV\x13EHU\xfal\x81\x0c" +
-	"\xe6\xb6P\xfb\xaa\xd4\xe7\xb5l\x14J\xb9~\xb5c\xa0" +
-	"\xb2\xae\xd0\xef\x8ai\xfe\x86\xb7\xef\x96\xfe\xb8\xe0\xf0\xf5" +
-	"\x15l5LHS\xaa\xd7\xaf\x85#a\x8a\xe2v\x01" +
-	"\xe5U\x0cE\x1a\xf4\x98p~\xd4X:\x1b(\xab\x9a" +
-	"\xa3B\xfb\xa1\xe5\xabBW\xbd\x043\xb7\xaej-s" +
-	"\xde\x08\xa2V\xadp\x14\xf5o\xacz\xba\x9eO^\xd8" +
-	"Gl\xe2\x9c\x1a\xad\xcd\xa6;L\x99]\xe7\xe9\x95\xf2" +
-	"\x0e\x9e\x1bi\xd5\x14\x92\xa0x\x85\xf4\x90%\xa4\x1eW" +
-	"^6R\xc9\xbb\x05\x94{\x19\"3+.\x93\xbaz" +
-	",!\xd9\xba\x0e\x99\x81\xb8\xf5,\x02\x864\xaddK" +
-	"\xc5N \xba*\xe9\xcec\xfc\xba\x0f\xcf\x8f<\xe2\xed" +
-	"\xb7\xf9\xcdh\x9bi\xa8To$W\x9f\x0e\xb9f\xac" +
-	"\xdfZ_\xd8j\xeb\x18\xb0\xdf\x8d\xae\x1b3\xce\x18\xae" +
-	"\xdd\xd8\xd6i\xdd\xd8\xcdP\xe7\x8aRV\xd6\x95\xf3\x80" +
-	"\xbc&\x8d\xb9A\xdb?\xb7|\x83v5\x81\xef\xd3\xf5" +
-	"\x86\xf9\xb4\x7fE\xf9Rw[)X\x9e\xcb\x87\xf2\x8a" +
-	"j\x06fF1Gn\xcc\xb31\xc5be\xf4\xce\xda" +
-	"\x86\xa7\x7f>s\xf3u\xef\x14\xcd\xd5\xbf$\x865\x02" +
-	"\xca_\xa4\x8b\x02\xe6\xed\xe9\x85\xae\xa6f=\xe6\xed\x1b" +
-	"W;M}\xadl\xdc?\x13:\x8a\xea\xba\xb2\xc2k" +
-	"\xdb\xef\xff\x01\x00\x00\xff\xff\x18V:\xa1"
+type PathMetadata struct{ capnp.Struct }
+
+// PathMetadata_TypeID is the unique identifier for the type PathMetadata.
+const PathMetadata_TypeID = 0xa5cff7314a4335e5
+
+func NewPathMetadata(s *capnp.Segment) (PathMetadata, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 6})
+	return PathMetadata{st}, err
+}
+
+func NewRootPathMetadata(s *capnp.Segment) (PathMetadata, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 6})
+	return PathMetadata{st}, err
+}
+
+func ReadRootPathMetadata(msg *capnp.Message) (PathMetadata, error) {
+	root, err := msg.RootPtr()
+	return PathMetadata{root.Struct()}, err
+}
+
+func (s PathMetadata) String() string {
+	str, _ := text.Marshal(0xa5cff7314a4335e5, s.Struct)
+	return str
+}
+
+func (s PathMetadata) Latency() (capnp.UInt32List, error) {
+	p, err := s.Struct.Ptr(0)
+	return capnp.UInt32List{List: p.List()}, err
+}
+
+func (s PathMetadata) HasLatency() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PathMetadata) SetLatency(v capnp.UInt32List) error {
+	return s.Struct.SetPtr(0, v.List.ToPtr())
+}
+
+// NewLatency sets the latency field to a newly
+// allocated capnp.UInt32List, preferring placement in s's segment.
+func (s PathMetadata) NewLatency(n int32) (capnp.UInt32List, error) {
+	l, err := capnp.NewUInt32List(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.UInt32List{}, err
+	}
+	err = s.Struct.SetPtr(0, l.List.ToPtr())
+	return l, err
+}
+
+func (s PathMetadata) Bandwidth() (capnp.UInt64List, error) {
+	p, err := s.Struct.Ptr(1)
+	return capnp.UInt64List{List: p.List()}, err
+}
+
+func (s PathMetadata) HasBandwidth() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s PathMetadata) SetBandwidth(v capnp.UInt64List) error {
+	return s.Struct.SetPtr(1, v.List.ToPtr())
+}
+
+// NewBandwidth sets the bandwidth field to a newly
+// allocated capnp.UInt64List, preferring placement in s's segment.
+func (s PathMetadata) NewBandwidth(n int32) (capnp.UInt64List, error) {
+	l, err := capnp.NewUInt64List(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.UInt64List{}, err
+	}
+	err = s.Struct.SetPtr(1, l.List.ToPtr())
+	return l, err
+}
+
+func (s PathMetadata) Geo() (GeoCoordinates_List, error) {
+	p, err := s.Struct.Ptr(2)
+	return GeoCoordinates_List{List: p.List()}, err
+}
+
+func (s PathMetadata) HasGeo() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s PathMetadata) SetGeo(v GeoCoordinates_List) error {
+	return s.Struct.SetPtr(2, v.List.ToPtr())
+}
+
+// NewGeo sets the geo field to a newly
+// allocated GeoCoordinates_List, preferring placement in s's segment.
+func (s PathMetadata) NewGeo(n int32) (GeoCoordinates_List, error) {
+	l, err := NewGeoCoordinates_List(s.Struct.Segment(), n)
+	if err != nil {
+		return GeoCoordinates_List{}, err
+	}
+	err = s.Struct.SetPtr(2, l.List.ToPtr())
+	return l, err
+}
+
+func (s PathMetadata) LinkType() (capnp.UInt8List, error) {
+	p, err := s.Struct.Ptr(3)
+	return capnp.UInt8List{List: p.List()}, err
+}
+
+func (s PathMetadata) HasLinkType() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s PathMetadata) SetLinkType(v capnp.UInt8List) error {
+	return s.Struct.SetPtr(3, v.List.ToPtr())
+}
+
+// NewLinkType sets the linkType field to a newly
+// allocated capnp.UInt8List, preferring placement in s's segment.
+func (s PathMetadata) NewLinkType(n int32) (capnp.UInt8List, error) {
+	l, err := capnp.NewUInt8List(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.UInt8List{}, err
+	}
+	err = s.Struct.SetPtr(3, l.List.ToPtr())
+	return l, err
+}
+
+func (s PathMetadata) Hops() (PathHopMetadata_List, error) {
+	p, err := s.Struct.Ptr(4)
+	return PathHopMetadata_List{List: p.List()}, err
+}
+
+func (s PathMetadata) HasHops() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s PathMetadata) SetHops(v PathHopMetadata_List) error {
+	return s.Struct.SetPtr(4, v.List.ToPtr())
+}
+
+// NewHops sets the hops field to a newly
+// allocated PathHopMetadata_List, preferring placement in s's segment.
+func (s PathMetadata) NewHops(n int32) (PathHopMetadata_List, error) {
+	l, err := NewPathHopMetadata_List(s.Struct.Segment(), n)
+	if err != nil {
+		return PathHopMetadata_List{}, err
+	}
+	err = s.Struct.SetPtr(4, l.List.ToPtr())
+	return l, err
+}
+
+func (s PathMetadata) IsdHops() (ISDHopCount_List, error) {
+	p, err := s.Struct.Ptr(5)
+	return ISDHopCount_List{List: p.List()}, err
+}
+
+func (s PathMetadata) HasIsdHops() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s PathMetadata) SetIsdHops(v ISDHopCount_List) error {
+	return s.Struct.SetPtr(5, v.List.ToPtr())
+}
+
+// NewIsdHops sets the isdHops field to a newly
+// allocated ISDHopCount_List, preferring placement in s's segment.
+func (s PathMetadata) NewIsdHops(n int32) (ISDHopCount_List, error) {
+	l, err := NewISDHopCount_List(s.Struct.Segment(), n)
+	if err != nil {
+		return ISDHopCount_List{}, err
+	}
+	err = s.Struct.SetPtr(5, l.List.ToPtr())
+	return l, err
+}
+
+func (s PathMetadata) Peering() bool {
+	return s.Struct.Bit(0)
+}
+
+func (s PathMetadata) SetPeering(v bool) {
+	s.Struct.SetBit(0, v)
+}
+
+func (s PathMetadata) Shortcut() bool {
+	return s.Struct.Bit(1)
+}
+
+func (s PathMetadata) SetShortcut(v bool) {
+	s.Struct.SetBit(1, v)
+}
+
+// PathMetadata_List is a list of PathMetadata.
+type PathMetadata_List struct{ capnp.List }
+
+// NewPathMetadata creates a new list of PathMetadata.
+func NewPathMetadata_List(s *capnp.Segment, sz int32) (PathMetadata_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 6}, sz)
+	return PathMetadata_List{l}, err
+}
+
+func (s PathMetadata_List) At(i int) PathMetadata { return PathMetadata{s.List.Struct(i)} }
+
+func (s PathMetadata_List) Set(i int, v PathMetadata) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s PathMetadata_List) String() string {
+	str, _ := text.MarshalList(0xa5cff7314a4335e5, s.List)
+	return str
+}
+
+// PathMetadata_Promise is a wrapper for a PathMetadata promised by a client call.
+type PathMetadata_Promise struct{ *capnp.Pipeline }
+
+func (p PathMetadata_Promise) Struct() (PathMetadata, error) {
+	s, err := p.Pipeline.Struct()
+	return PathMetadata{s}, err
+}
+
+type GeoCoordinates struct{ capnp.Struct }
+
+// GeoCoordinates_TypeID is the unique identifier for the type GeoCoordinates.
+const GeoCoordinates_TypeID = 0xf7bdaedb09e317d9
+
+func NewGeoCoordinates(s *capnp.Segment) (GeoCoordinates, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return GeoCoordinates{st}, err
+}
+
+func NewRootGeoCoordinates(s *capnp.Segment) (GeoCoordinates, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return GeoCoordinates{st}, err
+}
+
+func ReadRootGeoCoordinates(msg *capnp.Message) (GeoCoordinates, error) {
+	root, err := msg.RootPtr()
+	return GeoCoordinates{root.Struct()}, err
+}
+
+func (s GeoCoordinates) String() string {
+	str, _ := text.Marshal(0xf7bdaedb09e317d9, s.Struct)
+	return str
+}
+
+func (s GeoCoordinates) Latitude() float32 {
+	return math.Float32frombits(s.Struct.Uint32(0))
+}
+
+func (s GeoCoordinates) SetLatitude(v float32) {
+	s.Struct.SetUint32(0, math.Float32bits(v))
+}
+
+func (s GeoCoordinates) Longitude() float32 {
+	return math.Float32frombits(s.Struct.Uint32(4))
+}
+
+func (s GeoCoordinates) SetLongitude(v float32) {
+	s.Struct.SetUint32(4, math.Float32bits(v))
+}
+
+func (s GeoCoordinates) Address() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s GeoCoordinates) HasAddress() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s GeoCoordinates) AddressBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s GeoCoordinates) SetAddress(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+// GeoCoordinates_List is a list of GeoCoordinates.
+type GeoCoordinates_List struct{ capnp.List }
+
+// NewGeoCoordinates creates a new list of GeoCoordinates.
+func NewGeoCoordinates_List(s *capnp.Segment, sz int32) (GeoCoordinates_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return GeoCoordinates_List{l}, err
+}
+
+func (s GeoCoordinates_List) At(i int) GeoCoordinates { return GeoCoordinates{s.List.Struct(i)} }
+
+func (s GeoCoordinates_List) Set(i int, v GeoCoordinates) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s GeoCoordinates_List) String() string {
+	str, _ := text.MarshalList(0xf7bdaedb09e317d9, s.List)
+	return str
+}
+
+// GeoCoordinates_Promise is a wrapper for a GeoCoordinates promised by a client call.
+type GeoCoordinates_Promise struct{ *capnp.Pipeline }
+
+func (p GeoCoordinates_Promise) Struct() (GeoCoordinates, error) {
+	s, err := p.Pipeline.Struct()
+	return GeoCoordinates{s}, err
+}
+
+type PathHopMetadata struct{ capnp.Struct }
+
+// PathHopMetadata_TypeID is the unique identifier for the type PathHopMetadata.
+const PathHopMetadata_TypeID = 0xcccccfa18b247613
+
+func NewPathHopMetadata(s *capnp.Segment) (PathHopMetadata, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 1})
+	return PathHopMetadata{st}, err
+}
+
+func NewRootPathHopMetadata(s *capnp.Segment) (PathHopMetadata, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 1})
+	return PathHopMetadata{st}, err
+}
+
+func ReadRootPathHopMetadata(msg *capnp.Message) (PathHopMetadata, error) {
+	root, err := msg.RootPtr()
+	return PathHopMetadata{root.Struct()}, err
+}
+
+func (s PathHopMetadata) String() string {
+	str, _ := text.Marshal(0xcccccfa18b247613, s.Struct)
+	return str
+}
+
+func (s PathHopMetadata) Isdas() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s PathHopMetadata) SetIsdas(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s PathHopMetadata) Ingress() uint64 {
+	return s.Struct.Uint64(8)
+}
+
+func (s PathHopMetadata) SetIngress(v uint64) {
+	s.Struct.SetUint64(8, v)
+}
+
+func (s PathHopMetadata) Egress() uint64 {
+	return s.Struct.Uint64(16)
+}
+
+func (s PathHopMetadata) SetEgress(v uint64) {
+	s.Struct.SetUint64(16, v)
+}
+
+func (s PathHopMetadata) IngressMTU() uint16 {
+	return s.Struct.Uint16(24)
+}
+
+func (s PathHopMetadata) SetIngressMTU(v uint16) {
+	s.Struct.SetUint16(24, v)
+}
+
+func (s PathHopMetadata) InternalMTU() uint16 {
+	return s.Struct.Uint16(26)
+}
+
+func (s PathHopMetadata) SetInternalMTU(v uint16) {
+	s.Struct.SetUint16(26, v)
+}
+
+func (s PathHopMetadata) EgressMTU() uint16 {
+	return s.Struct.Uint16(28)
+}
+
+func (s PathHopMetadata) SetEgressMTU(v uint16) {
+	s.Struct.SetUint16(28, v)
+}
+
+func (s PathHopMetadata) Extensions() (capnp.TextList, error) {
+	p, err := s.Struct.Ptr(0)
+	return capnp.TextList{List: p.List()}, err
+}
+
+func (s PathHopMetadata) HasExtensions() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PathHopMetadata) SetExtensions(v capnp.TextList) error {
+	return s.Struct.SetPtr(0, v.List.ToPtr())
+}
+
+// NewExtensions sets the extensions field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s PathHopMetadata) NewExtensions(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = s.Struct.SetPtr(0, l.List.ToPtr())
+	return l, err
+}
+
+// PathHopMetadata_List is a list of PathHopMetadata.
+type PathHopMetadata_List struct{ capnp.List }
+
+// NewPathHopMetadata creates a new list of PathHopMetadata.
+func NewPathHopMetadata_List(s *capnp.Segment, sz int32) (PathHopMetadata_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 1}, sz)
+	return PathHopMetadata_List{l}, err
+}
+
+func (s PathHopMetadata_List) At(i int) PathHopMetadata { return PathHopMetadata{s.List.Struct(i)} }
+
+func (s PathHopMetadata_List) Set(i int, v PathHopMetadata) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s PathHopMetadata_List) String() string {
+	str, _ := text.MarshalList(0xcccccfa18b247613, s.List)
+	return str
+}
+
+// PathHopMetadata_Promise is a wrapper for a PathHopMetadata promised by a client call.
+type PathHopMetadata_Promise struct{ *capnp.Pipeline }
+
+func (p PathHopMetadata_Promise) Struct() (PathHopMetadata, error) {
+	s, err := p.Pipeline.Struct()
+	return PathHopMetadata{s}, err
+}
+
+type ISDHopCount struct{ capnp.Struct }
+
+// ISDHopCount_TypeID is the unique identifier for the type ISDHopCount.
+const ISDHopCount_TypeID = 0x9dedea24af138f7b
+
+func NewISDHopCount(s *capnp.Segment) (ISDHopCount, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return ISDHopCount{st}, err
+}
+
+func NewRootISDHopCount(s *capnp.Segment) (ISDHopCount, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return ISDHopCount{st}, err
+}
+
+func ReadRootISDHopCount(msg *capnp.Message) (ISDHopCount, error) {
+	root, err := msg.RootPtr()
+	return ISDHopCount{root.Struct()}, err
+}
+
+func (s ISDHopCount) String() string {
+	str, _ := text.Marshal(0x9dedea24af138f7b, s.Struct)
+	return str
+}
+
+func (s ISDHopCount) Isd() uint16 {
+	return s.Struct.Uint16(0)
+}
+
+func (s ISDHopCount) SetIsd(v uint16) {
+	s.Struct.SetUint16(0, v)
+}
+
+func (s ISDHopCount) Hops() uint16 {
+	return s.Struct.Uint16(2)
+}
+
+func (s ISDHopCount) SetHops(v uint16) {
+	s.Struct.SetUint16(2, v)
+}
+
+// ISDHopCount_List is a list of ISDHopCount.
+type ISDHopCount_List struct{ capnp.List }
+
+// NewISDHopCount creates a new list of ISDHopCount.
+func NewISDHopCount_List(s *capnp.Segment, sz int32) (ISDHopCount_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0}, sz)
+	return ISDHopCount_List{l}, err
+}
+
+func (s ISDHopCount_List) At(i int) ISDHopCount { return ISDHopCount{s.List.Struct(i)} }
+
+func (s ISDHopCount_List) Set(i int, v ISDHopCount) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s ISDHopCount_List) String() string {
+	str, _ := text.MarshalList(0x9dedea24af138f7b, s.List)
+	return str
+}
+
+// ISDHopCount_Promise is a wrapper for a ISDHopCount promised by a client call.
+type ISDHopCount_Promise struct{ *capnp.Pipeline }
+
+func (p ISDHopCount_Promise) Struct() (ISDHopCount, error) {
+	s, err := p.Pipeline.Struct()
+	return ISDHopCount{s}, err
+}
+
+const schema_8f4bd412642c9517 = "x\xda\x95X\x0dl\x14\xd7\x11\xde\xb7{?\xf6\xf9\xec" +
+	"\xf5\xb2w\xc4q\xd3\x1a\x90\x11?\x02\x84\x01\xb7\x045" +
+	"\xb1\xb11\xf8(\x0e\xbe;S\xa5\x88\xaa\x1c\xbe\xf5\xf9" +
+	"\xd2\xe3\xee\xba\xbbgpZ\xeaP\xe1\xb6\x90\"\x92\xa6" +
+	"\xa8?\x04\x15H\xa1\xa1M\x9a\x84\x92\xa8\xd0\x92&\x0a" +
+	"4\x89\x05\x05*\xa2`\x175\x81@bH\x90\x02\x81" +
+	"\x1ah\xe8uf\x7f\xde\xad\xd7k\x92\"!\xed\xcd7" +
+	"o\xde\xcc\xbc\x99\xef\xcd\xf3\xccAO=[\xe3^Z" +
+	"\xcc0\xe1\x94\xdb\x93\xff\xe4\xf9g\xf7|x\xed\xe1\x1f" +
+	"1B)\xc9\xdf\xb5uZ|\xcc\xa9\xafma\xdc\xc4" +
+	"\xcb0\xe2f\xd7\x80\xf8K\x17~mu\xd51$\x7f" +
+	"m\xe0\xe6\xb7^\xee{g\x13\x13.%Ve\x0eU" +
+	"\x8e\xb8\xfa\xc4\x93\xa8<\xfb\x98\xab\x8a\x80v\xa5\xf0\xe4" +
+	"\xc2\xf3\xf2\xfa-6m\xcd\xdeu\xf7>\xf1\xb6\x1b\xbf" +
+	"n\xba\xd1\xf2\xc2\xd7\x16\xf6\xec\xdfv\xe9q\xd4e\x0b" +
+	"\xbaM\xac\x97'.\xf1n\xcfAq\xbc\x07M\x7f\xd1" +
+	"\xf3W\x0e\xd4\xb7\x0f\x06\xceM\xae\xf8\xfe\xcf\x9c\x9c\xbe" +
+	"\xcf\xd7'\x86|\xf8\xd5\xe4C\xd3;\xd7\x95<][" +
+	"\xdf\xbd\xd5fZs#\xe7\x1b\x10\xd7k\xba\xeb|k" +
+	"@\xf7b\xc3;\xbd\xbf\xed\xf5ls\xb2\xdb\xef\xbb$" +
+	"^\xd0t\xcfjv\x07\xfa7\x0d\x9eu\xff}\x1b\x13" +
+	"\x0e\x12.\xff\xe1S\x87\xcf\xd4\x04\xffv\x98\x09\x12/" +
+	"\x01\x1dw\xc9\x00C\xc4\xe2\x92\xe7@\xf3\xbb[\xc4\xe7" +
+	"\xaa/]\xde\xee\x94\x88\xdd%}\xe2\x0b%\xf8\xf5l" +
+	"\x09zp\xa1\xb6qq\xcd\xd0\xf1\xdd\xf6\x14c\xf4b" +
+	"\xb1\xff\x1fb\xd0\x8fy\x10\xfc\xafc\x8a\xc7\xd4\xec\xa8" +
+	"YQ\xb4t\xaf\x83\xbf\xb3\xe7\x97\xb1Dl)\xc3u" +
+	"\xa12tx\xff\x95\xbd\xe1\xe5\x157\x9e\xb1\x9b\xd6\xb4" +
+	"\xd7\x95\x8d!\xe2fM{c\x19:=n\xe2\x13k" +
+	"\xdc\x93*\xf7\xd9\xb5YT\xb9\x97\xdf'\xce\xe7\xb5l" +
+	"\xf3\xe8\xf5\xe0\xd5\xb1]\x17.\xd7\xbf\xe6\x94\xb7u\xfc" +
+	"%q\xa3\xa6\xdb\xcb\xa3\x1b4S`\x98\xb3+\xbf\xc8" +
+	"\xffN<\x84\xca\xb3\x0f\xf0Z\x11}\xd4\xf5\xf3l\xdb" +
+	"\x8c\xfc\x11\x9be\xcd\x8bW\xcb\xcf\x89\xc7\xca\xf1\xeb\xcd" +
+	"r\xf4\x82\x97\x8e\xcfo\xd8\xf0\xa5>\xa7<O\x11\x06" +
+	"\xc4Z\x01\xbfj\x04\xf4b\xf7\xfb\xd5O>\xbdK:" +
+	"\xea\xa4\x1b\x16\x0e\x8a\xdf\xd0t\x97i\xbabW\xf5\xa3" +
+	";\x8f\x1f\xd5t]v\x8fs\xc2%q\xbd\xa0\xa5P" +
+	"\xd8\x82\x1e\x9f9\xfb\xe7=\x1b\x9f\x98\xf4\x81c\x9a'" +
+	"\x8a\x95D\xac\x155?DLs\xea\xdd\xc8\xd7+O" +
+	"\x0e}\xe0\x94\xb9\xb3b\x9fxY\xd3\xbd(\xa2\x1fs" +
+	"'\xbd\xfd\xc3D\xf0\xc8\xc7N\x96\xc5\xbb\x03W\xc5\x89" +
+	"\x01\xfc\x1a\x1f\xc0d\xd4\xbd\x7f\xff\x94\x97.\xf2W\x1c" +
+	"\x95\xd7\x07\x0e\x8a\x1b5\xe5^M\xf9\xc0\xcbk\xf7>" +
+	"\xfa\xf6\x9e!'/\xae\x83a\x12\xc4\xaf\xdb\x01\xf4\xa2" +
+	"\xff\xae\xf7\x8a\xff\xf9\x87CC\x8e\x86\xa7\x07\xcf\x89\xf7" +
+	"j\xca\xb5A\x0c\xcf_\xf9\xaf\xdf'&^\xb8\xc9\x84" +
+	"\xc7\x12KM\x05Y\xadI\xde\x0a\x9e\x83&\xe9\x0f\xa2" +
+	"\x0b\x7f|\xe9\xe1E\xfb\x9fz\xe1\x96S\x9b\xd6\x8e\xbd" +
+	"*\xce\x1f\xab\x95\xdbX\xb4\xaa\xb4'3\xe9\xf8\x8cv" +
+	"6\x96Mg\xe7\x85\x16\x86\xd2\x1d\x99\x88\xf4\x9d\x9c\xc4" +
+	")j+!a\x17\xe7b\x18\x17\xec \x94\xce\x02\x9a" +
+	"+\xe2H\xb8\x9a%U\xc9\x8e\xd0\x02\x85\x941\xa4\x95" +
+	"#\xa4\x98a\xf1\xd3fk\xe1\x9axkL\xedl\x91" +
+	"\xd4\x18\xc3\xa0\xa9\x005\xb5\xae\x01L\xad\x05S\x1bX" +
+	"BH\x80\xa0l\xfd\x04\x90}\x0fd?f\x89\xc0\x82" +
+	"\x90\x05a\xefr\x10n\x00\xe1\x0e\x10r \xe4@\xb8" +
+	"\x1dW\xff\x02\x84\xbf\x01\xa1\x8b\x0d\x100+\xec\\\x0c" +
+	"\xc2\x1d |\x86%=\x1d\xfa\xd6\xa4\x14\x1c+e\x88" +
+	"w\xb5\x9a\x83\x94\xb2\xf0\x9f\xe4\x93iU\x92;b\xed" +
+	"\x0c'\xd1\x00\xca\x0b\xcc\xc6\x10\x14\xf6Hk\xb3m\xc9" +
+	"\xd5\x12)\x82UE\xb0j5D\x11\x8fa$\x0ch" +
+	"Sf\x01\xedrK\xe0D\x0b<\"uUE\xa4l" +
+	"\xaa\xdb\x96\xbfyF\xfe\x02,\xa9\x93%%\x97R\xa9" +
+	"S\xc3\x0dD\x1bCuK\x1fX\xd0\xa2$\xd0\xc2\x02" +
+	"\xd3\x82x\x8cT2L\xf4\x0d\xc2\x91\xe8)\x02\x91\x91" +
+	"|^\xcb\x9dx\x92\xc0\xd9D\x8f\"p\x1a\x01\xf6\xbf" +
+	"y-\x7f\xe2[\x04r\x15=\x81\xc0\x19\x04\xb8\xdby" +
+	"-\x87b?\x89\x00p\x1a\x81\xf3\x08\xb8>\xcdky" +
+	"\x14\xcfj\xc0\xbb\x08|\x84\x80\xfb?\x00\xb8\xb1o\xc8" +
+	"*\x00\x06\x11\xb8\x86\x80\xe7\x16\x00\x1e\x00\xae\x90\x1f\x00" +
+	"\xf01\x02\x9f\"\xe0\xbd\x09\x80v\x1d\x11\x19\x80\x1b\x08" +
+	"\xb8X\x00\x8an\x00P\x04\x00a\xc1T\x84\x05\xb9\x1f" +
+	"\xe5\xc5C /FNf\x7f\x05\x0b\xfc\x08T \xe0" +
+	"\xfb7\x00>\x00\x82\xec&\x00*\x10\xa8F\xa0\xe4:" +
+	"\x00%\xd8\x9f,\x1c{t\x1c\x02\xd3\x10\xf0_\x03\xc0" +
+	"\x8f\x1c\xc5\xe2\xde\x93\x11\x98\x83@\xe9'\x00\x94\"U" +
+	"\xb0\xe8\xedL\x04\xbe\x0a\x80P\x0eEU\x864\xccb" +
+	"\xa6\xe6\xa0\xbc\x1e\x17\x94]\x85\x05<\xb6\x09\xbb\x0b\x80" +
+	"z\x04\x96 \xc0_\x01\xa0\x1co\x02\x16\x8a3\xda\x8c" +
+	"@\x1b\x00\\2\xae5B1C\xaariER\x19" +
+	"OO\x16\x8a\x10\x9a\x09*\x862\xb5Q1:\x92M" +
+	"1\xa4\x1bPJ0\x06\x1aS\xf46d\x08\xae\xa5\xfc" +
+	"jG\xbdPc\x80\xd3\x1b\xdc\xc0e\xa9\xeb\x81\x8c\x9a" +
+	"\xec \xc9\xf6\x98\x0au\x85\x15KocC\x07\xfaW" +
+	"\xb7Q\x05\xad\xae\xa8\xa0A\x87\x17\xbb\x86\xb1\x0beW" +
+	"\xb3\xe6%\xb9+\xd9.\x85\x88\x850@\x8d^\xa3\x8e" +
+	"j`Jk J\x92\x05\x97\x0d\x10Q:\xedP\x1b" +
+	"\x89\xb6\xee\xac\xd4\xccTe\xb2z:\xe9\xf5d\xd3 " +
+	"\xa8\x80v@\x87^\xa4\xbaN\x8f*\xc7\xc0\x8f\xb8I" +
+	"\x0a\xda\x11Ds\xab\x14\xd2.'\xb3\x98'\xef\xe8G" +
+	"\xb5,\x1b\x8f1\x9c*9\x9c\xd5p\xd2\x9b\x1f\x0d\x15" +
+	"\x02\xb5\xf5\x7fC\x81?{\xa4\xb4*'\xad\x04D9" +
+	"[' \x9bYd\xb3\x90N\\\\\xbb\x84v\x8b\xa8" +
+	"\xdd)\xc8\xcb\xd5`w&\x94\xb3\xc9\xa6\xd3\xa7\x82p" +
+	"2\x08\xe7 Y+\xf1\x98b\x16'\x8f\xd4m\xfe\xb0" +
+	"m\x131*\x07\x0a\x87\xc7\x8c\xd8\x02@\x8a\xf5\x83\xcd" +
+	"\x0a\x16\x16\x82.\x86\xaa\x9fX\xe4\xbd[_\xe9]4" +
+	"\xeb\xd7\xceIi\xd5\xdb`FG*\xc6%\x140\xe1" +
+	"*\x7fL\xa7\xf2&\xccI=\x98\\\x82\xbe?\xaeS" +
+	"y\x08\x89r\x01\x08[\xf1&\xf8\xa9\xc62B\x0bn" +
+	"\xbe\x04\x84\x0fB\xf6d\xa9\x03\x08\xb4\x13\"\x85\xfb\x83" +
+	"!u\x9d\xc9x\\J\x9b?\xf3\xf1\xa4\xf2P\x06x" +
+	"\x1e}3e\xb6{.\xba\xa09\x93m\xcc\xe4P\xc9" +
+	"\x96\xce\x09\x9f\x91N/\xa4\xd3$n\xbe3\x93UF" +
+	"\xb0x!\xea\x16\xfd\xda 1\xdcc\x1c\xdd\xe3$\x86" +
+	"}\x14\xcc\x0dZ\xf6\xb8\x00\xc4\x1b>\xcf\x91\x08\xc1\xb0" +
+	"Y\xfd\x02\xbc\x8d\xde\xdc\x00\x8a\x09\xa0\x94\xe3t\xf6\x16" +
+	"\x08\xd2^92\xeb4\x94\xbb\\:yO!\xe0g" +
+	"\xb4\x1a\xe5\xf5(w\xbbu\xee\xbeO\xbb\x06\xe6\xa2\xbc" +
+	"\x0d\xe4\xc4\xa33wX\x13/A\xf1\x83\xa8\xee%:" +
+	"q/\xd3\xcc\xb7\xa1|%\xc8{R1UJ\xb7w" +
+	"\x9b\xc5Zd\\\xf7\xabb\xe9\xf8\x9ad\\eH\xa7" +
+	"m\x12\xf0&\xa4L\xa1\xb4\xe9\x94c\x94v*\x99\xfe" +
+	"6v+\x1e\x8f\xa1\xe4\xd1\xd7\xe9\xe9\xa4\x0b\xe9\xb0h" +
+	"\\\xca\x90\xf8\xe6a\x0a\xf45`(d%IN\xa6" +
+	"\x13\x85C\xef\xcc\xc8j{\xce\xb1\x108\xfd\xaa5\xe8" +
+	"\xc9$1E\xb5w\xedCF\xd1Of)\x99\xb51" +
+	"<\xb8O\xfd\xe0\xf3j\xe2\xc4\x17\xa6L\x8f\x9c\xb37" +
+	"\xaf\xb9\x87NN\x0675A\xeb\x13m6\xf0\xd3]" +
+	"\x9a\x96\x1b%\xbf\xb20\x10}\x13\xeba\x05\xc8:-" +
+	"\x03\x91\x84\x95\xb3\x12\x84)\xf6s\x8e2y\x15\xe6\x18" +
+	"E\x8d\xadfH\xd6\x1cgF\x8c7\xc3\xe7\x8f\xe6\x8c" +
+	"R\xa5bJl\x9d1\xb5\xd0\x19\xf8\xaf0\x91\x0a\xd3" +
+	"g1,\x9f\x85d\x9b\xbdP\x15\x8b\xc7e\xc5\xd6\x11" +
+	"\x96D\xf0\x0e\xf3\xd1\x1d\xf9\x91\xbe\xb8l)&f\xaf" +
+	"\xf1H1\xb61sBa\xcc\x14\x9c\xe7\xcc\"c\xce" +
+	"\\l\xcc\x99\x8fAh\xb0_\xe1E*l\x86\xd0\x88" +
+	"K\xeb#!\x87\xcc\x94\x05\xb5\x9f@\x90q\xb8\xef\x0c" +
+	"\x1a\xf5*r;\xa5\xd4\xd5\xb1\xb5\xd8\xfa\x0a\x16\x9d\x99" +
+	"\x0d`\xbe\x84R\xd7\x99m\xecHXb\xaah:\x7f" +
+	"\xbf\xf8\xfa\xf8\x83\xa3s\xbeQ0^U\xee\x1e\xfd," +
+	"\x0a,\x85QL\x03\xe1\\\x96\xf0xm\xc1\x1e\xf4\xef" +
+	"\x0c\x063wf\x14\xb5\xc0\xdb\xf4\x15\xe1\xc8\xdb\x96\xf3" +
+	"\xe2\xf4\xdcZNkja\x9a\xe5U\xd0\x826xd" +
+	"\xee.\x9f\xb4wh'Z\xe3G\x9c\x11\\\x8duz" +
+	"\x9b\x8d\xf2\xae\x08\xd8\xaf*\x87\x84\x80/\xc8\xa9<\xce" +
+	"\xe2h\xe6\x1ej\xe6E4\xf3<\x98\xf9\x8b%#\x07" +
+	"\xb0\xa6\xf6\x83\xf0\x15\x0b\xa7\x1e\xc2S\xfc\x13\x08\x0f#" +
+	"\xa5\xfa\xf5\x9b\xe8U\xec\xc0W@x\x14\xf9\xb4T\xbf" +
+	"\x89\xde\x8495\xfc\x06\x08O!\x99\x96\xe9Ep\x12" +
+	"\xfb\xf2\x04\x08\xcfP&\x15\xfaq\xf5i\x90]\xb3\xc7" +
+	"\xd0\x03|\x04w\x15\xfd]'\x0d\xfb\x997\xe0\x16\x86" +
+	"k[6\xfc\x8d\x92\x8e\xa5\x18o\x8bE\xaa\xafli" +
+	"c\x88E\xb6\x16\xa8YIf\x18.M\x0b\xcbo{" +
+	"\x8e90\x9dNC\x9cl\xa7\xa1U\x96\x9b\xd7\xcca" +
+	"\x0b6L3\x08\xdb ^#\x85a\xccA\xabAC" +
+	"\x94\x15\xbdz\x19X\xd9\x10\xca\xc0\xab\xaa)J4\xb4" +
+	"\xfc\x88\xa5\x0f\xacUX6\xea\x9b\xf4\xff\x1e\xa9\xe8\x1b" +
+	"\xff\xb3\xccV!%w\xdf\x89\xec\x1c\xfak\xd8\x18\xf5" +
+	"\xf9\xba\x8arU]'}\x1fZv\x8c\x14f\x0cs" +
+	"\xc7\x9a\x06c\xc7f\xc8\xb3$\xcb\x19\xb91\x13g\x88" +
+	"d\x9e\xff\xc8\xa0\xe9\x1fy\x1c\x83\xb6\x14\x81\xe3\x13\xf5" +
+	"\x8e\xf9\xa4\x7f\x8dq4\xbdH\xca4f2r<\x99" +
+	"\x8eyUI\xb1\x15\xd6b\xa7\xc2\x8a\x14F:\xb3\xb0" +
+	"\x965\x18\x85\xb5\x02\x02\x86\xb9#\xa9\xe6\xe2\xda\xa0\xe0" +
+	"\x83\x80}8<d\xd2\x09\x14b\x12\x0cY\x0f\xde5" +
+	"\xd8SX\xf8\xfe\x11\x8e5\x1bg3#\x16\xf7\xc2\x9d" +
+	"\xa4g\\\xf7`\x04\x8b\xb2\xb6Y\x8fOf\xbb\xe6\x98" +
+	"/\x06\xfc\xf1e\xfa|\x18u\xee/T\x93%\xfeY" +
+	"\xd69\xd7\xa5\xef\x1e\x9a`\x9ds[\xf5\xdd[\xe6\x15" +
+	"\xbam8\x93X\xff\x8aQ\x97T\x1a3\xb2d\x0e5" +
+	"\xff\x03\xe6\xa5ai"
 
 func init() {
 	schemas.Register(schema_8f4bd412642c9517,
@@ -2398,6 +3052,8 @@ func init() {
 		0x95794035a80b7da1,
 		0x9b0685a785df42e9,
 		0x9bce05e1e88ad9da,
+		0x9dedea24af138f7b,
+		0xa5cff7314a4335e5,
 		0xa94f085c31a03112,
 		0xacf8185a51a9f1b4,
 		0xb21a270577932520,
@@ -2406,11 +3062,13 @@ func init() {
 		0xc5ff2e54709776ec,
 		0xca1e844241cf650f,
 		0xcc65a2a89c24e6a5,
+		0xcccccfa18b247613,
 		0xe7279389a6bbe1dc,
 		0xe7f7d11a5652e06c,
 		0xf0c5156786d72738,
 		0xf10fe9b6293ee63f,
 		0xf7a6d78ba978beb9,
+		0xf7bdaedb09e317d9,
 		0xf9e52567abde1a0c,
 		0xfab1a3b4477ab6b3)
 }
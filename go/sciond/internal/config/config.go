@@ -17,6 +17,7 @@ package config
 
 import (
 	"io"
+	"net"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/config"
@@ -31,6 +32,9 @@ import (
 
 var (
 	DefaultQueryInterval = 5 * time.Minute
+	// DefaultSubscriptionInterval is the default interval in which the paths
+	// of path subscriptions are looked up again.
+	DefaultSubscriptionInterval = 10 * time.Second
 )
 
 var _ config.Config = (*Config)(nil)
@@ -95,9 +99,16 @@ type SDConfig struct {
 	// Address is the local address to listen on for SCION messages, and to send out messages to
 	// other nodes.
 	Address string `toml:"address,omitempty"`
+	// HTTPAddress is the address to serve the JSON API over HTTP on. If
+	// empty, the HTTP API is disabled.
+	HTTPAddress string `toml:"http_address,omitempty"`
 	// QueryInterval specifies after how much time segments
 	// for a destination should be refetched.
 	QueryInterval util.DurWrap `toml:"query_interval,omitempty"`
+	// SubscriptionInterval specifies the interval in which the paths of path
+	// subscriptions are looked up again. Segment changes are only noticed by
+	// this lookup.
+	SubscriptionInterval util.DurWrap `toml:"subscription_interval,omitempty"`
 }
 
 func (cfg *SDConfig) InitDefaults() {
@@ -107,12 +118,23 @@ func (cfg *SDConfig) InitDefaults() {
 	if cfg.QueryInterval.Duration == 0 {
 		cfg.QueryInterval.Duration = DefaultQueryInterval
 	}
+	if cfg.SubscriptionInterval.Duration == 0 {
+		cfg.SubscriptionInterval.Duration = DefaultSubscriptionInterval
+	}
 }
 
 func (cfg *SDConfig) Validate() error {
 	if cfg.QueryInterval.Duration == 0 {
 		return serrors.New("QueryInterval must not be zero")
 	}
+	if cfg.SubscriptionInterval.Duration == 0 {
+		return serrors.New("SubscriptionInterval must not be zero")
+	}
+	if cfg.HTTPAddress != "" {
+		if _, _, err := net.SplitHostPort(cfg.HTTPAddress); err != nil {
+			return serrors.WrapStr("invalid HTTPAddress", err, "addr", cfg.HTTPAddress)
+		}
+	}
 	return nil
 }
 
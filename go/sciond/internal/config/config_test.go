@@ -61,5 +61,7 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 
 func CheckTestSDConfig(t *testing.T, cfg *SDConfig, id string) {
 	assert.Equal(t, sciond.DefaultSCIONDAddress, cfg.Address)
+	assert.Empty(t, cfg.HTTPAddress)
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
+	assert.Equal(t, DefaultSubscriptionInterval, cfg.SubscriptionInterval.Duration)
 }
@@ -21,6 +21,15 @@ const sdSample = `
 # other nodes (required).
 address = "127.0.0.1:30255"
 
+# Address to serve the JSON API over HTTP on, e.g., "127.0.0.1:30256". If
+# empty, the HTTP API is disabled. (default "")
+http_address = ""
+
 # The time after which segments for a destination are refetched. (default 5m)
 query_interval = "5m"
+
+# The interval in which the paths of path subscriptions are looked up again.
+# New segments reach subscribers with a delay of up to this interval.
+# (default 10s)
+subscription_interval = "10s"
 `
@@ -0,0 +1,110 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher
+
+import (
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// RankDisjoint orders the paths such that every prefix of the result is as
+// link and AS disjoint as possible. Paths are picked greedily: the next path
+// is the one that shares the fewest inter-AS links with the already picked
+// paths, and among those the one that transits the fewest already transited
+// ASes. Ties are broken by the original order of the paths.
+func RankDisjoint(paths []*combinator.Path) []*combinator.Path {
+	intfs := make([][]snet.PathInterface, 0, len(paths))
+	for _, p := range paths {
+		intfs = append(intfs, newPathWrap(p).Interfaces())
+	}
+	ranked := make([]*combinator.Path, 0, len(paths))
+	for _, i := range disjointOrder(intfs) {
+		ranked = append(ranked, paths[i])
+	}
+	return ranked
+}
+
+type intfKey struct {
+	ia   addr.IA
+	ifid common.IFIDType
+}
+
+type linkKey struct {
+	a, b intfKey
+}
+
+// disjointOrder returns the indices of the paths in the order they are picked.
+func disjointOrder(paths [][]snet.PathInterface) []int {
+	linkUses := make(map[linkKey]int)
+	asUses := make(map[addr.IA]int)
+	picked := make([]bool, len(paths))
+	order := make([]int, 0, len(paths))
+	for len(order) < len(paths) {
+		best, bestLinks, bestASes := -1, 0, 0
+		for i, intfs := range paths {
+			if picked[i] {
+				continue
+			}
+			var links, ases int
+			for _, l := range pathLinks(intfs) {
+				links += linkUses[l]
+			}
+			for _, ia := range transitASes(intfs) {
+				ases += asUses[ia]
+			}
+			if best == -1 || links < bestLinks || (links == bestLinks && ases < bestASes) {
+				best, bestLinks, bestASes = i, links, ases
+			}
+		}
+		picked[best] = true
+		order = append(order, best)
+		for _, l := range pathLinks(paths[best]) {
+			linkUses[l]++
+		}
+		for _, ia := range transitASes(paths[best]) {
+			asUses[ia]++
+		}
+	}
+	return order
+}
+
+// pathLinks returns the inter-AS links of the path. Interface 2*i and 2*i+1
+// are the two ends of link i.
+func pathLinks(intfs []snet.PathInterface) []linkKey {
+	links := make([]linkKey, 0, len(intfs)/2)
+	for i := 0; i+1 < len(intfs); i += 2 {
+		links = append(links, linkKey{
+			a: intfKey{ia: intfs[i].IA(), ifid: intfs[i].ID()},
+			b: intfKey{ia: intfs[i+1].IA(), ifid: intfs[i+1].ID()},
+		})
+	}
+	return links
+}
+
+// transitASes returns the ASes on the path, excluding the source and the
+// destination AS which are shared by all paths.
+func transitASes(intfs []snet.PathInterface) []addr.IA {
+	var ases []addr.IA
+	for i := 1; i < len(intfs)-1; i++ {
+		ia := intfs[i].IA()
+		if len(ases) > 0 && ases[len(ases)-1].Equal(ia) {
+			continue
+		}
+		ases = append(ases, ia)
+	}
+	return ases
+}
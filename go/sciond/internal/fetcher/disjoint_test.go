@@ -0,0 +1,85 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetcher_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/sciond/internal/fetcher"
+)
+
+func TestRankDisjoint(t *testing.T) {
+	intf := func(ia string, ifid common.IFIDType) sciond.PathInterface {
+		return sciond.PathInterface{RawIsdas: xtest.MustParseIA(ia).IAInt(), IfID: ifid}
+	}
+	path := func(intfs ...sciond.PathInterface) *combinator.Path {
+		return &combinator.Path{Interfaces: intfs}
+	}
+	// 111 -> 130 -> 110
+	viaA := path(intf("1-ff00:0:111", 1), intf("1-ff00:0:130", 2),
+		intf("1-ff00:0:130", 3), intf("1-ff00:0:110", 4))
+	// 111 -> 130 -> 120 -> 110, shares the link 111#1-130#2 with viaA.
+	viaAB := path(intf("1-ff00:0:111", 1), intf("1-ff00:0:130", 2),
+		intf("1-ff00:0:130", 5), intf("1-ff00:0:120", 6),
+		intf("1-ff00:0:120", 7), intf("1-ff00:0:110", 8))
+	// 111 -> 120 -> 110, link disjoint with viaA.
+	viaB := path(intf("1-ff00:0:111", 9), intf("1-ff00:0:120", 10),
+		intf("1-ff00:0:120", 11), intf("1-ff00:0:110", 12))
+	// 111 -> 130 -> 110, link disjoint with viaA but transits 130 as well.
+	viaA2 := path(intf("1-ff00:0:111", 13), intf("1-ff00:0:130", 14),
+		intf("1-ff00:0:130", 15), intf("1-ff00:0:110", 16))
+	// 111 -> 140 -> 110, link and AS disjoint with viaA.
+	viaC := path(intf("1-ff00:0:111", 17), intf("1-ff00:0:140", 18),
+		intf("1-ff00:0:140", 19), intf("1-ff00:0:110", 20))
+	// 111 -> 110, no transit AS.
+	direct := path(intf("1-ff00:0:111", 21), intf("1-ff00:0:110", 22))
+
+	tests := map[string]struct {
+		Paths    []*combinator.Path
+		Expected []*combinator.Path
+	}{
+		"no paths": {
+			Paths:    nil,
+			Expected: []*combinator.Path{},
+		},
+		"shared link ranked last": {
+			Paths:    []*combinator.Path{viaA, viaAB, viaB},
+			Expected: []*combinator.Path{viaA, viaB, viaAB},
+		},
+		"shared AS ranked after disjoint AS": {
+			Paths:    []*combinator.Path{viaA, viaA2, viaC},
+			Expected: []*combinator.Path{viaA, viaC, viaA2},
+		},
+		"shared link ranked after shared AS": {
+			Paths:    []*combinator.Path{viaA, viaAB, viaA2},
+			Expected: []*combinator.Path{viaA, viaA2, viaAB},
+		},
+		"disjoint paths keep order": {
+			Paths:    []*combinator.Path{viaB, direct, viaC},
+			Expected: []*combinator.Path{viaB, direct, viaC},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, fetcher.RankDisjoint(test.Paths))
+		})
+	}
+}
@@ -122,6 +122,9 @@ func (f *fetcher) GetPaths(ctx context.Context, req *sciond.PathReq,
 	default:
 		return &sciond.PathReply{ErrorCode: sciond.ErrorInternal}, err
 	}
+	if req.Flags.Disjoint {
+		cPaths = RankDisjoint(cPaths)
+	}
 	var paths []sciond.PathReplyEntry
 	var errs serrors.List
 	for _, path := range cPaths {
@@ -179,6 +182,7 @@ func (f *fetcher) translate(path *combinator.Path) (sciond.PathReplyEntry, error
 			Mtu:        path.Mtu,
 			Interfaces: path.Interfaces,
 			ExpTime:    uint32(path.ComputeExpTime().Unix()),
+			Metadata:   path.Metadata,
 		},
 		HostInfo: hostinfo.FromUDPAddr(*nextHop),
 	}
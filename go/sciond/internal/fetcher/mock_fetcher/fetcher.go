@@ -1,15 +1,56 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/go/sciond/internal/fetcher (interfaces: Policy)
+// Source: github.com/scionproto/scion/go/sciond/internal/fetcher (interfaces: Fetcher,Policy)
 
 // Package mock_fetcher is a generated GoMock package.
 package mock_fetcher
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	pathpol "github.com/scionproto/scion/go/lib/pathpol"
+	sciond "github.com/scionproto/scion/go/lib/sciond"
 	reflect "reflect"
+	time "time"
 )
 
+// MockFetcher is a mock of Fetcher interface
+type MockFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockFetcherMockRecorder
+}
+
+// MockFetcherMockRecorder is the mock recorder for MockFetcher
+type MockFetcherMockRecorder struct {
+	mock *MockFetcher
+}
+
+// NewMockFetcher creates a new mock instance
+func NewMockFetcher(ctrl *gomock.Controller) *MockFetcher {
+	mock := &MockFetcher{ctrl: ctrl}
+	mock.recorder = &MockFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockFetcher) EXPECT() *MockFetcherMockRecorder {
+	return m.recorder
+}
+
+// GetPaths mocks base method
+func (m *MockFetcher) GetPaths(arg0 context.Context, arg1 *sciond.PathReq, arg2 time.Duration) (*sciond.PathReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaths", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*sciond.PathReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaths indicates an expected call of GetPaths
+func (mr *MockFetcherMockRecorder) GetPaths(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaths", reflect.TypeOf((*MockFetcher)(nil).GetPaths), arg0, arg1, arg2)
+}
+
 // MockPolicy is a mock of Policy interface
 type MockPolicy struct {
 	ctrl     *gomock.Controller
@@ -49,6 +49,7 @@ const (
 	ErrDB            = prom.ErrDB
 	ErrTimeout       = prom.ErrTimeout
 	ErrParse         = prom.ErrParse
+	ErrVerify        = prom.ErrVerify
 	ErrNotClassified = prom.ErrNotClassified
 )
 
@@ -66,9 +66,7 @@ func (h *PathRequestHandler) Handle(ctx context.Context, conn net.Conn, src net.
 	labels := metrics.PathRequestLabels{Dst: pld.PathReq.Dst.IA().I, Result: metrics.OkSuccess}
 	logger := log.FromCtx(ctx)
 	logger.Debug("[PathRequestHandler] Received request", "req", pld.PathReq)
-	workCtx, workCancelF := context.WithTimeout(ctx, DefaultWorkTimeout)
-	defer workCancelF()
-	getPathsReply, err := h.Fetcher.GetPaths(workCtx, pld.PathReq, DefaultEarlyReply)
+	getPathsReply, err := fetchPaths(ctx, h.Fetcher, pld.PathReq)
 	if err != nil {
 		logger.Error("Unable to get paths", "err", err)
 		labels.Result = segfetcher.ErrToMetricsLabel(err)
@@ -92,6 +90,20 @@ func (h *PathRequestHandler) Handle(ctx context.Context, conn net.Conn, src net.
 	metricsDone(labels)
 }
 
+// fetchPaths looks up the paths for the request. The reply is always set, the
+// returned error is only used for logging and metrics.
+func fetchPaths(ctx context.Context, f fetcher.Fetcher,
+	req *sciond.PathReq) (*sciond.PathReply, error) {
+
+	workCtx, workCancelF := context.WithTimeout(ctx, DefaultWorkTimeout)
+	defer workCancelF()
+	getPathsReply, err := f.GetPaths(workCtx, req, DefaultEarlyReply)
+	if getPathsReply == nil {
+		getPathsReply = &sciond.PathReply{ErrorCode: sciond.ErrorInternal}
+	}
+	return getPathsReply, err
+}
+
 // ASInfoRequestHandler represents the shared global state for the handling of all
 // ASInfoRequest queries. The SCIOND API spawns a goroutine with method Handle
 // for each ASInfoRequest it receives.
@@ -106,12 +118,30 @@ func (h *ASInfoRequestHandler) Handle(ctx context.Context, conn net.Conn, src ne
 	metricsDone := metrics.ASInfos.Start()
 	logger := log.FromCtx(ctx)
 	logger.Debug("[ASInfoRequestHandler] Received request", "req", pld.AsInfoReq)
+	reply := &sciond.Pld{
+		Id:          pld.Id,
+		Which:       proto.SCIONDMsg_Which_asInfoReply,
+		AsInfoReply: h.reply(ctx, pld.AsInfoReq),
+	}
+	conn.SetWriteDeadline(time.Now().Add(DefaultReplyTimeout))
+	if err := sciond.Send(reply, conn); err != nil {
+		logger.Warn("Unable to reply to client", "client", src, "err", err)
+		metricsDone(metrics.ErrNetwork)
+		return
+	}
+	logger.Trace("Sent reply", "asInfo", reply.AsInfoReply)
+	metricsDone(metrics.OkSuccess)
+}
+
+func (h *ASInfoRequestHandler) reply(ctx context.Context,
+	req *sciond.ASInfoReq) *sciond.ASInfoReply {
+
 	workCtx, workCancelF := context.WithTimeout(ctx, DefaultWorkTimeout)
 	defer workCancelF()
 	// NOTE(scrye): Only support single-homed SCIONDs for now (returned slice
 	// will at most contain one element).
 	topo := itopo.Get()
-	reqIA := pld.AsInfoReq.Isdas.IA()
+	reqIA := req.Isdas.IA()
 	if reqIA.IsZero() {
 		reqIA = topo.IA()
 	}
@@ -135,21 +165,9 @@ func (h *ASInfoRequestHandler) Handle(ctx context.Context, conn net.Conn, src ne
 			},
 		}
 	}
-	reply := &sciond.Pld{
-		Id:    pld.Id,
-		Which: proto.SCIONDMsg_Which_asInfoReply,
-		AsInfoReply: &sciond.ASInfoReply{
-			Entries: entries,
-		},
+	return &sciond.ASInfoReply{
+		Entries: entries,
 	}
-	conn.SetWriteDeadline(time.Now().Add(DefaultReplyTimeout))
-	if err := sciond.Send(reply, conn); err != nil {
-		logger.Warn("Unable to reply to client", "client", src, "err", err)
-		metricsDone(metrics.ErrNetwork)
-		return
-	}
-	logger.Trace("Sent reply", "asInfo", reply.AsInfoReply)
-	metricsDone(metrics.OkSuccess)
 }
 
 // IFInfoRequestHandler represents the shared global state for the handling of all
@@ -164,7 +182,26 @@ func (h *IFInfoRequestHandler) Handle(ctx context.Context, conn net.Conn, src ne
 	metricsDone := metrics.IFInfos.Start()
 	logger := log.FromCtx(ctx)
 	logger.Debug("[IFInfoRequestHandler] Received request", "req", pld.IfInfoRequest)
-	ifInfoRequest := pld.IfInfoRequest
+	ifInfoReply := h.reply(ctx, pld.IfInfoRequest)
+	reply := &sciond.Pld{
+		Id:          pld.Id,
+		Which:       proto.SCIONDMsg_Which_ifInfoReply,
+		IfInfoReply: ifInfoReply,
+	}
+	conn.SetWriteDeadline(time.Now().Add(DefaultReplyTimeout))
+	if err := sciond.Send(reply, conn); err != nil {
+		logger.Warn("Unable to reply to client", "client", src, "err", err)
+		metricsDone(metrics.ErrNetwork)
+		return
+	}
+	logger.Trace("Sent reply", "ifInfo", ifInfoReply)
+	metricsDone(metrics.OkSuccess)
+}
+
+func (h *IFInfoRequestHandler) reply(ctx context.Context,
+	ifInfoRequest *sciond.IFInfoRequest) *sciond.IFInfoReply {
+
+	logger := log.FromCtx(ctx)
 	ifInfoReply := &sciond.IFInfoReply{}
 	topo := itopo.Get()
 	if len(ifInfoRequest.IfIDs) == 0 {
@@ -194,19 +231,7 @@ func (h *IFInfoRequestHandler) Handle(ctx context.Context, conn net.Conn, src ne
 			})
 		}
 	}
-	reply := &sciond.Pld{
-		Id:          pld.Id,
-		Which:       proto.SCIONDMsg_Which_ifInfoReply,
-		IfInfoReply: ifInfoReply,
-	}
-	conn.SetWriteDeadline(time.Now().Add(DefaultReplyTimeout))
-	if err := sciond.Send(reply, conn); err != nil {
-		logger.Warn("Unable to reply to client", "client", src, "err", err)
-		metricsDone(metrics.ErrNetwork)
-		return
-	}
-	logger.Trace("Sent reply", "ifInfo", ifInfoReply)
-	metricsDone(metrics.OkSuccess)
+	return ifInfoReply
 }
 
 // SVCInfoRequestHandler represents the shared global state for the handling of all
@@ -221,7 +246,25 @@ func (h *SVCInfoRequestHandler) Handle(ctx context.Context, conn net.Conn,
 	metricsDone := metrics.SVCInfos.Start()
 	logger := log.FromCtx(ctx)
 	logger.Debug("[SVCInfoRequestHandler] Received request", "req", pld.ServiceInfoRequest)
-	svcInfoRequest := pld.ServiceInfoRequest
+	svcInfoReply := h.reply(pld.ServiceInfoRequest)
+	reply := &sciond.Pld{
+		Id:               pld.Id,
+		Which:            proto.SCIONDMsg_Which_serviceInfoReply,
+		ServiceInfoReply: svcInfoReply,
+	}
+	conn.SetWriteDeadline(time.Now().Add(DefaultReplyTimeout))
+	if err := sciond.Send(reply, conn); err != nil {
+		logger.Warn("Unable to reply to client", "client", src, "err", err)
+		metricsDone(metrics.ErrNetwork)
+		return
+	}
+	logger.Trace("Sent reply", "svcInfo", svcInfoReply)
+	metricsDone(metrics.OkSuccess)
+}
+
+func (h *SVCInfoRequestHandler) reply(
+	svcInfoRequest *sciond.ServiceInfoRequest) *sciond.ServiceInfoReply {
+
 	svcInfoReply := &sciond.ServiceInfoReply{}
 	topo := itopo.Get()
 	for _, t := range svcInfoRequest.ServiceTypes {
@@ -237,19 +280,7 @@ func (h *SVCInfoRequestHandler) Handle(ctx context.Context, conn net.Conn,
 		}
 		svcInfoReply.Entries = append(svcInfoReply.Entries, replyEntry)
 	}
-	reply := &sciond.Pld{
-		Id:               pld.Id,
-		Which:            proto.SCIONDMsg_Which_serviceInfoReply,
-		ServiceInfoReply: svcInfoReply,
-	}
-	conn.SetWriteDeadline(time.Now().Add(DefaultReplyTimeout))
-	if err := sciond.Send(reply, conn); err != nil {
-		logger.Warn("Unable to reply to client", "client", src, "err", err)
-		metricsDone(metrics.ErrNetwork)
-		return
-	}
-	logger.Trace("Sent reply", "svcInfo", svcInfoReply)
-	metricsDone(metrics.OkSuccess)
+	return svcInfoReply
 }
 
 // RevNotificationHandler represents the shared global state for the handling of all
@@ -273,15 +304,34 @@ func (h *RevNotificationHandler) Handle(ctx context.Context, conn net.Conn,
 	logger := log.FromCtx(ctx)
 	logger.Debug("[RevNotificationHandler] Received revocation",
 		"notification", pld.RevNotification)
+	revReply, revInfo := h.reply(ctx, pld.RevNotification)
+	reply := &sciond.Pld{
+		Id:       pld.Id,
+		Which:    proto.SCIONDMsg_Which_revReply,
+		RevReply: revReply,
+	}
+	conn.SetWriteDeadline(time.Now().Add(DefaultReplyTimeout))
+	if err := sciond.Send(reply, conn); err != nil {
+		logger.Warn("Unable to reply to client", "client", src, "err", err)
+		metricsDone(labels.WithResult(metrics.ErrNetwork))
+		return
+	}
+	logger.Trace("Sent reply", "revInfo", revInfo)
+	metricsDone(labels.WithResult(revResultLabel(revReply.Result)))
+}
+
+// reply verifies the revocation and inserts it into the revocation cache.
+func (h *RevNotificationHandler) reply(ctx context.Context,
+	revNotification *sciond.RevNotification) (*sciond.RevReply, *path_mgmt.RevInfo) {
+
 	workCtx, workCancelF := context.WithTimeout(ctx, DefaultWorkTimeout)
 	defer workCancelF()
-	revNotification := pld.RevNotification
 	revReply := &sciond.RevReply{}
 	revInfo, err := h.verifySRevInfo(workCtx, revNotification.SRevInfo)
 	if err == nil {
 		_, err = h.RevCache.Insert(workCtx, revNotification.SRevInfo)
 		if err != nil {
-			logger.Error("Failed to insert revocations", "err", err)
+			log.FromCtx(ctx).Error("Failed to insert revocations", "err", err)
 		}
 	}
 	switch {
@@ -296,19 +346,16 @@ func (h *RevNotificationHandler) Handle(ctx context.Context, conn net.Conn,
 	default:
 		panic(fmt.Sprintf("unknown error type, err = %v", err))
 	}
-	reply := &sciond.Pld{
-		Id:       pld.Id,
-		Which:    proto.SCIONDMsg_Which_revReply,
-		RevReply: revReply,
-	}
-	conn.SetWriteDeadline(time.Now().Add(DefaultReplyTimeout))
-	if err := sciond.Send(reply, conn); err != nil {
-		logger.Warn("Unable to reply to client", "client", src, "err", err)
-		metricsDone(labels.WithResult(metrics.ErrNetwork))
-		return
+	return revReply, revInfo
+}
+
+// revResultLabel returns the metrics label for the result of a revocation
+// notification.
+func revResultLabel(result sciond.RevResult) string {
+	if result == sciond.RevValid {
+		return metrics.OkSuccess
 	}
-	logger.Trace("Sent reply", "revInfo", revInfo)
-	metricsDone(labels.WithResult(metrics.OkSuccess))
+	return metrics.ErrVerify
 }
 
 // verifySRevInfo first checks if the RevInfo can be extracted from sRevInfo,
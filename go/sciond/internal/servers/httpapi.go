@@ -0,0 +1,330 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/hostinfo"
+	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/proto"
+	"github.com/scionproto/scion/go/sciond/internal/metrics"
+)
+
+// HTTPAPI serves the SCIOND API as JSON over HTTP. The requests are answered
+// by the same handlers that serve the Cap'n Proto API.
+//
+// The API provides the following endpoints:
+//
+//	GET  /paths        Lists the paths to the AS given by the dst query
+//	                   parameter. The optional parameters are src, max_paths,
+//	                   refresh and disjoint.
+//	GET  /as_info      Returns information about the AS given by the ia query
+//	                   parameter. If ia is not set, the local AS is used.
+//	GET  /interfaces   Lists the addresses of the interfaces given by the
+//	                   comma-separated ifids query parameter. If ifids is not
+//	                   set, all interfaces are listed.
+//	GET  /services     Lists the addresses of the services given by the
+//	                   comma-separated types query parameter, e.g., cs,ps.
+//	POST /revocations  Submits the signed revocation in the body. The body is
+//	                   a JSON object with the base64 encoded signed revocation
+//	                   in the signed_rev_info field.
+type HTTPAPI struct {
+	Paths       *PathRequestHandler
+	ASInfo      *ASInfoRequestHandler
+	IFInfo      *IFInfoRequestHandler
+	SVCInfo     *SVCInfoRequestHandler
+	Revocations *RevNotificationHandler
+}
+
+// Register registers the endpoints of the API with the mux.
+func (a *HTTPAPI) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/paths", a.paths)
+	mux.HandleFunc("/as_info", a.asInfo)
+	mux.HandleFunc("/interfaces", a.interfaces)
+	mux.HandleFunc("/services", a.services)
+	mux.HandleFunc("/revocations", a.revocations)
+}
+
+func (a *HTTPAPI) paths(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	pathReq, err := parsePathsQuery(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	metricsDone := metrics.PathRequests.Start()
+	labels := metrics.PathRequestLabels{Dst: pathReq.Dst.IA().I, Result: metrics.OkSuccess}
+	log.Debug("[HTTPAPI] Received path request", "req", pathReq)
+	reply, err := fetchPaths(req.Context(), a.Paths.Fetcher, pathReq)
+	if err != nil {
+		log.Error("Unable to get paths", "err", err)
+		labels.Result = segfetcher.ErrToMetricsLabel(err)
+	}
+	rep := pathsJSON{
+		ErrorCode: reply.ErrorCode.String(),
+		Paths:     []pathJSON{},
+	}
+	for _, e := range reply.Entries {
+		rep.Paths = append(rep.Paths, newPathJSON(e))
+	}
+	env.WriteJSON(w, rep)
+	metricsDone(labels)
+}
+
+func parsePathsQuery(req *http.Request) (*sciond.PathReq, error) {
+	query := req.URL.Query()
+	dst, err := addr.IAFromString(query.Get("dst"))
+	if err != nil {
+		return nil, serrors.WrapStr("invalid dst", err)
+	}
+	pathReq := &sciond.PathReq{Dst: dst.IAInt()}
+	if raw := query.Get("src"); raw != "" {
+		src, err := addr.IAFromString(raw)
+		if err != nil {
+			return nil, serrors.WrapStr("invalid src", err)
+		}
+		pathReq.Src = src.IAInt()
+	}
+	if raw := query.Get("max_paths"); raw != "" {
+		count, err := strconv.ParseUint(raw, 10, 16)
+		if err != nil {
+			return nil, serrors.WrapStr("invalid max_paths", err)
+		}
+		pathReq.Flags.PathCount = uint16(count)
+	}
+	if raw := query.Get("refresh"); raw != "" {
+		refresh, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, serrors.WrapStr("invalid refresh", err)
+		}
+		pathReq.Flags.Refresh = refresh
+	}
+	if raw := query.Get("disjoint"); raw != "" {
+		disjoint, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, serrors.WrapStr("invalid disjoint", err)
+		}
+		pathReq.Flags.Disjoint = disjoint
+	}
+	return pathReq, nil
+}
+
+func (a *HTTPAPI) asInfo(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var ia addr.IA
+	if raw := req.URL.Query().Get("ia"); raw != "" {
+		var err error
+		if ia, err = addr.IAFromString(raw); err != nil {
+			http.Error(w, serrors.WrapStr("invalid ia", err).Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	metricsDone := metrics.ASInfos.Start()
+	reply := a.ASInfo.reply(req.Context(), &sciond.ASInfoReq{Isdas: ia.IAInt()})
+	rep := []asInfoJSON{}
+	for _, e := range reply.Entries {
+		rep = append(rep, asInfoJSON{
+			IA:     e.ISD_AS(),
+			MTU:    e.Mtu,
+			IsCore: e.IsCore,
+		})
+	}
+	env.WriteJSON(w, rep)
+	metricsDone(metrics.OkSuccess)
+}
+
+func (a *HTTPAPI) interfaces(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var ifids []common.IFIDType
+	for _, raw := range splitList(req.URL.Query().Get("ifids")) {
+		ifid, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			http.Error(w, serrors.WrapStr("invalid ifids", err).Error(), http.StatusBadRequest)
+			return
+		}
+		ifids = append(ifids, common.IFIDType(ifid))
+	}
+	metricsDone := metrics.IFInfos.Start()
+	reply := a.IFInfo.reply(req.Context(), &sciond.IFInfoRequest{IfIDs: ifids})
+	rep := []interfaceJSON{}
+	for _, e := range reply.RawEntries {
+		rep = append(rep, interfaceJSON{
+			IFID:    e.IfID,
+			Address: hostAddr(e.HostInfo),
+		})
+	}
+	env.WriteJSON(w, rep)
+	metricsDone(metrics.OkSuccess)
+}
+
+func (a *HTTPAPI) services(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var svcTypes []proto.ServiceType
+	for _, raw := range splitList(req.URL.Query().Get("types")) {
+		svcType := proto.ServiceTypeFromString(raw)
+		if svcType == proto.ServiceType_unset {
+			http.Error(w, fmt.Sprintf("invalid service type: %q", raw), http.StatusBadRequest)
+			return
+		}
+		svcTypes = append(svcTypes, svcType)
+	}
+	metricsDone := metrics.SVCInfos.Start()
+	reply := a.SVCInfo.reply(&sciond.ServiceInfoRequest{ServiceTypes: svcTypes})
+	rep := []serviceJSON{}
+	for _, e := range reply.Entries {
+		entry := serviceJSON{
+			Type:      e.ServiceType.String(),
+			TTL:       e.Ttl,
+			Addresses: []string{},
+		}
+		for _, h := range e.HostInfos {
+			entry.Addresses = append(entry.Addresses, hostAddr(h))
+		}
+		rep = append(rep, entry)
+	}
+	env.WriteJSON(w, rep)
+	metricsDone(metrics.OkSuccess)
+}
+
+func (a *HTTPAPI) revocations(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var body struct {
+		SignedRevInfo []byte `json:"signed_rev_info"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		http.Error(w, serrors.WrapStr("invalid body", err).Error(), http.StatusBadRequest)
+		return
+	}
+	sRevInfo, err := path_mgmt.NewSignedRevInfoFromRaw(body.SignedRevInfo)
+	if err != nil {
+		http.Error(w, serrors.WrapStr("invalid signed_rev_info", err).Error(),
+			http.StatusBadRequest)
+		return
+	}
+	metricsDone := metrics.Revocations.Start()
+	labels := metrics.RevocationLabels{Src: metrics.RevSrcNotification}
+	log.Debug("[HTTPAPI] Received revocation", "rev", sRevInfo)
+	reply, _ := a.Revocations.reply(req.Context(),
+		&sciond.RevNotification{SRevInfo: sRevInfo})
+	env.WriteJSON(w, revocationJSON{Result: reply.Result.String()})
+	metricsDone(labels.WithResult(revResultLabel(reply.Result)))
+}
+
+// splitList splits a comma-separated list. Empty elements are ignored.
+func splitList(raw string) []string {
+	var res []string
+	for _, e := range strings.Split(raw, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+func hostAddr(h hostinfo.Host) string {
+	if a := h.UDP(); a != nil {
+		return a.String()
+	}
+	return ""
+}
+
+type pathsJSON struct {
+	ErrorCode string     `json:"error_code"`
+	Paths     []pathJSON `json:"paths"`
+}
+
+type pathJSON struct {
+	Interfaces []pathInterfaceJSON `json:"interfaces"`
+	MTU        uint16              `json:"mtu"`
+	Expiration time.Time           `json:"expiration"`
+	NextHop    string              `json:"next_hop,omitempty"`
+	FwdPath    []byte              `json:"fwd_path"`
+}
+
+func newPathJSON(e sciond.PathReplyEntry) pathJSON {
+	entry := pathJSON{
+		Interfaces: []pathInterfaceJSON{},
+	}
+	if e.HostInfo.Host() != nil {
+		entry.NextHop = e.HostInfo.Overlay().String()
+	}
+	if e.Path == nil {
+		return entry
+	}
+	entry.MTU = e.Path.Mtu
+	entry.Expiration = e.Path.Expiry()
+	entry.FwdPath = e.Path.FwdPath
+	for _, intf := range e.Path.Interfaces {
+		entry.Interfaces = append(entry.Interfaces, pathInterfaceJSON{
+			IA:   intf.IA(),
+			IFID: intf.ID(),
+		})
+	}
+	return entry
+}
+
+type pathInterfaceJSON struct {
+	IA   addr.IA         `json:"isd_as"`
+	IFID common.IFIDType `json:"ifid"`
+}
+
+type asInfoJSON struct {
+	IA     addr.IA `json:"isd_as"`
+	MTU    uint16  `json:"mtu"`
+	IsCore bool    `json:"core"`
+}
+
+type interfaceJSON struct {
+	IFID    common.IFIDType `json:"ifid"`
+	Address string          `json:"address"`
+}
+
+type serviceJSON struct {
+	Type      string   `json:"type"`
+	TTL       uint32   `json:"ttl"`
+	Addresses []string `json:"addresses"`
+}
+
+type revocationJSON struct {
+	Result string `json:"result"`
+}
@@ -0,0 +1,378 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/mock_infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/itopo"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/revcache/mock_revcache"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/sciond/internal/fetcher/mock_fetcher"
+)
+
+func TestMain(m *testing.M) {
+	log.Discard()
+	topo, err := topology.FromJSONFile("testdata/topology.json")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to load topology:", err)
+		os.Exit(1)
+	}
+	itopo.Init(&itopo.Config{})
+	if err := itopo.Update(topo); err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to set topology:", err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+func TestHTTPAPIPaths(t *testing.T) {
+	tests := map[string]struct {
+		Query string
+		// Request is the expected request to the fetcher. If nil, the fetcher
+		// is not called.
+		Request   *sciond.PathReq
+		Reply     *sciond.PathReply
+		FetchErr  error
+		Status    int
+		ErrorCode sciond.PathErrorCode
+		NumPaths  int
+	}{
+		"paths found": {
+			Query: "dst=1-ff00:0:110&src=1-ff00:0:111&max_paths=5&refresh=true&disjoint=false",
+			Request: &sciond.PathReq{
+				Dst:   ia110.IAInt(),
+				Src:   ia111.IAInt(),
+				Flags: sciond.PathReqFlags{PathCount: 5, Refresh: true},
+			},
+			Reply:     testPathReply(1),
+			Status:    http.StatusOK,
+			ErrorCode: sciond.ErrorOk,
+			NumPaths:  1,
+		},
+		"lookup fails": {
+			Query:     "dst=1-ff00:0:110",
+			Request:   &sciond.PathReq{Dst: ia110.IAInt()},
+			FetchErr:  serrors.New("test error"),
+			Status:    http.StatusOK,
+			ErrorCode: sciond.ErrorInternal,
+		},
+		"missing dst": {
+			Status: http.StatusBadRequest,
+		},
+		"invalid dst": {
+			Query:  "dst=1-ff00",
+			Status: http.StatusBadRequest,
+		},
+		"invalid src": {
+			Query:  "dst=1-ff00:0:110&src=foo",
+			Status: http.StatusBadRequest,
+		},
+		"invalid max_paths": {
+			Query:  "dst=1-ff00:0:110&max_paths=-1",
+			Status: http.StatusBadRequest,
+		},
+		"invalid refresh": {
+			Query:  "dst=1-ff00:0:110&refresh=maybe",
+			Status: http.StatusBadRequest,
+		},
+		"invalid disjoint": {
+			Query:  "dst=1-ff00:0:110&disjoint=maybe",
+			Status: http.StatusBadRequest,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+			f := mock_fetcher.NewMockFetcher(mctrl)
+			if test.Request != nil {
+				f.EXPECT().GetPaths(gomock.Any(), test.Request, DefaultEarlyReply).
+					Return(test.Reply, test.FetchErr)
+			}
+			api := &HTTPAPI{Paths: &PathRequestHandler{Fetcher: f}}
+
+			rec := serveHTTP(api, httptest.NewRequest(http.MethodGet, "/paths?"+test.Query, nil))
+			require.Equal(t, test.Status, rec.Code, rec.Body.String())
+			if test.Status != http.StatusOK {
+				return
+			}
+			var rep pathsJSON
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rep))
+			assert.Equal(t, test.ErrorCode.String(), rep.ErrorCode)
+			require.Len(t, rep.Paths, test.NumPaths)
+			for i, p := range rep.Paths {
+				expected := test.Reply.Entries[i].Path
+				assert.Equal(t, expected.Mtu, p.MTU)
+				assert.Equal(t, []byte(expected.FwdPath), p.FwdPath)
+				require.Len(t, p.Interfaces, len(expected.Interfaces))
+				for j, intf := range p.Interfaces {
+					assert.Equal(t, expected.Interfaces[j].IA(), intf.IA)
+					assert.Equal(t, expected.Interfaces[j].ID(), intf.IFID)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPAPIASInfo(t *testing.T) {
+	tests := map[string]struct {
+		Query    string
+		Status   int
+		Expected []asInfoJSON
+	}{
+		"local AS": {
+			Status:   http.StatusOK,
+			Expected: []asInfoJSON{{IA: ia111, MTU: 1472, IsCore: true}},
+		},
+		"remote AS": {
+			Query:    "ia=1-ff00:0:110",
+			Status:   http.StatusOK,
+			Expected: []asInfoJSON{{IA: ia110, IsCore: true}},
+		},
+		"invalid ia": {
+			Query:  "ia=foo",
+			Status: http.StatusBadRequest,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+			inspector := mock_infra.NewMockASInspector(mctrl)
+			inspector.EXPECT().HasAttributes(gomock.Any(), gomock.Any(),
+				gomock.Any()).Return(true, nil).AnyTimes()
+			api := &HTTPAPI{ASInfo: &ASInfoRequestHandler{ASInspector: inspector}}
+
+			rec := serveHTTP(api, httptest.NewRequest(http.MethodGet, "/as_info?"+test.Query,
+				nil))
+			require.Equal(t, test.Status, rec.Code, rec.Body.String())
+			if test.Status != http.StatusOK {
+				return
+			}
+			var rep []asInfoJSON
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rep))
+			assert.Equal(t, test.Expected, rep)
+		})
+	}
+}
+
+func TestHTTPAPIInterfaces(t *testing.T) {
+	tests := map[string]struct {
+		Query    string
+		Status   int
+		Expected []interfaceJSON
+	}{
+		"all interfaces": {
+			Status:   http.StatusOK,
+			Expected: []interfaceJSON{{IFID: 1, Address: "127.0.0.17:30042"}},
+		},
+		"requested interfaces": {
+			Query:    "ifids=1,2",
+			Status:   http.StatusOK,
+			Expected: []interfaceJSON{{IFID: 1, Address: "127.0.0.17:30042"}},
+		},
+		"unknown interface": {
+			Query:    "ifids=2",
+			Status:   http.StatusOK,
+			Expected: []interfaceJSON{},
+		},
+		"invalid ifids": {
+			Query:  "ifids=1,foo",
+			Status: http.StatusBadRequest,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := &HTTPAPI{IFInfo: &IFInfoRequestHandler{}}
+
+			rec := serveHTTP(api, httptest.NewRequest(http.MethodGet, "/interfaces?"+test.Query,
+				nil))
+			require.Equal(t, test.Status, rec.Code, rec.Body.String())
+			if test.Status != http.StatusOK {
+				return
+			}
+			var rep []interfaceJSON
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rep))
+			assert.Equal(t, test.Expected, rep)
+		})
+	}
+}
+
+func TestHTTPAPIServices(t *testing.T) {
+	tests := map[string]struct {
+		Query    string
+		Status   int
+		Expected []serviceJSON
+	}{
+		"no types": {
+			Status:   http.StatusOK,
+			Expected: []serviceJSON{},
+		},
+		"requested types": {
+			Query:  "types=cs,ds",
+			Status: http.StatusOK,
+			Expected: []serviceJSON{
+				{Type: "cs", TTL: DefaultServiceTTL, Addresses: []string{"127.0.0.66:30081"}},
+				{Type: "ds", TTL: DefaultServiceTTL, Addresses: []string{}},
+			},
+		},
+		"invalid type": {
+			Query:  "types=cs,foo",
+			Status: http.StatusBadRequest,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := &HTTPAPI{SVCInfo: &SVCInfoRequestHandler{}}
+
+			rec := serveHTTP(api, httptest.NewRequest(http.MethodGet, "/services?"+test.Query,
+				nil))
+			require.Equal(t, test.Status, rec.Code, rec.Body.String())
+			if test.Status != http.StatusOK {
+				return
+			}
+			var rep []serviceJSON
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rep))
+			assert.Equal(t, test.Expected, rep)
+		})
+	}
+}
+
+func TestHTTPAPIRevocations(t *testing.T) {
+	rev := testRevocation(t, ia110, 1)
+	raw, err := rev.Pack()
+	require.NoError(t, err)
+	valid, err := json.Marshal(map[string][]byte{"signed_rev_info": raw})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		Body      string
+		VerifyErr error
+		// Verify indicates whether the revocation is verified.
+		Verify bool
+		Insert bool
+		Status int
+		Result sciond.RevResult
+	}{
+		"valid revocation": {
+			Body:   string(valid),
+			Verify: true,
+			Insert: true,
+			Status: http.StatusOK,
+			Result: sciond.RevValid,
+		},
+		"verification fails": {
+			Body:      string(valid),
+			Verify:    true,
+			VerifyErr: serrors.New("test error"),
+			Status:    http.StatusOK,
+			Result:    sciond.RevUnknown,
+		},
+		"invalid body": {
+			Body:   "{",
+			Status: http.StatusBadRequest,
+		},
+		"invalid signed_rev_info": {
+			Body:   `{"signed_rev_info": "AAEC"}`,
+			Status: http.StatusBadRequest,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+			verifier := mock_infra.NewMockVerifier(mctrl)
+			if test.Verify {
+				verifier.EXPECT().WithServer(gomock.Any()).Return(verifier)
+				verifier.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(test.VerifyErr)
+			}
+			revCache := mock_revcache.NewMockRevCache(mctrl)
+			if test.Insert {
+				revCache.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(true, nil)
+			}
+			api := &HTTPAPI{
+				Revocations: &RevNotificationHandler{
+					RevCache:        revCache,
+					VerifierFactory: verificationFactory{verifier: verifier},
+				},
+			}
+
+			rec := serveHTTP(api, httptest.NewRequest(http.MethodPost, "/revocations",
+				strings.NewReader(test.Body)))
+			require.Equal(t, test.Status, rec.Code, rec.Body.String())
+			if test.Status != http.StatusOK {
+				return
+			}
+			var rep revocationJSON
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rep))
+			assert.Equal(t, test.Result.String(), rep.Result)
+		})
+	}
+}
+
+func TestHTTPAPIMethodNotAllowed(t *testing.T) {
+	tests := map[string]string{
+		"/paths":       http.MethodPost,
+		"/as_info":     http.MethodPost,
+		"/interfaces":  http.MethodPost,
+		"/services":    http.MethodPost,
+		"/revocations": http.MethodGet,
+	}
+	for path, method := range tests {
+		t.Run(path, func(t *testing.T) {
+			rec := serveHTTP(&HTTPAPI{}, httptest.NewRequest(method, path, nil))
+			assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		})
+	}
+}
+
+func serveHTTP(api *HTTPAPI, req *http.Request) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	api.Register(mux)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+// verificationFactory hands out the same verifier for every call.
+type verificationFactory struct {
+	verifier infra.Verifier
+}
+
+func (f verificationFactory) NewSigner(common.RawBytes, infra.SignerMeta) (infra.Signer, error) {
+	return nil, serrors.New("not supported")
+}
+
+func (f verificationFactory) NewVerifier() infra.Verifier {
+	return f.verifier
+}
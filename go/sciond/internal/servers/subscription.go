@@ -0,0 +1,260 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
+	"github.com/scionproto/scion/go/sciond/internal/fetcher"
+)
+
+const (
+	// DefaultSubscriptionInterval is the default interval in which the paths
+	// of a subscription are looked up again.
+	DefaultSubscriptionInterval = 10 * time.Second
+	// SubscriptionExpiryMargin is the time before the expiration of the first
+	// path in the path set at which the paths of a subscription are refreshed.
+	SubscriptionExpiryMargin = 2 * DefaultSubscriptionInterval
+)
+
+// PathSubscriptionHandler handles path subscriptions. In contrast to the other
+// handlers, the connection is kept open until the client closes it. The
+// handler sends the current path set to the client and pushes the path set
+// again whenever it changes. The paths are looked up again immediately
+// whenever a revocation for an interface on one of the paths is received, and
+// they are refreshed when a path is about to expire.
+//
+// The path database does not announce new or updated segments. Such changes
+// are only picked up by the periodic lookup every CheckInterval, i.e., they
+// reach the client with a delay of up to CheckInterval.
+type PathSubscriptionHandler struct {
+	Fetcher fetcher.Fetcher
+	// Revocations notifies the handler about new revocations. If nil, the
+	// paths are only looked up periodically.
+	Revocations *RevocationNotifier
+	// CheckInterval is the interval in which the paths are looked up, it
+	// bounds the delay until segment changes are pushed. If zero,
+	// DefaultSubscriptionInterval is used.
+	CheckInterval time.Duration
+}
+
+func (h *PathSubscriptionHandler) Handle(ctx context.Context, conn net.Conn, src net.Addr,
+	pld *sciond.Pld) {
+
+	defer conn.Close()
+	logger := log.FromCtx(ctx)
+	logger.Debug("[PathSubscriptionHandler] Received subscription",
+		"req", pld.PathSubscriptionReq)
+	ctx, cancelF := context.WithCancel(ctx)
+	defer cancelF()
+	// The client does not send any further messages, the read only returns
+	// once the client closes the connection.
+	go func() {
+		defer log.HandlePanic()
+		conn.SetReadDeadline(time.Time{})
+		io.Copy(ioutil.Discard, conn)
+		cancelF()
+	}()
+	var revs <-chan *path_mgmt.RevInfo
+	if h.Revocations != nil {
+		var unsubscribe func()
+		revs, unsubscribe = h.Revocations.Subscribe()
+		defer unsubscribe()
+	}
+	interval := h.CheckInterval
+	if interval == 0 {
+		interval = DefaultSubscriptionInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	req := *pld.PathSubscriptionReq
+	var last *sciond.PathReply
+	var lastKey string
+	var refreshedExp uint32
+	for {
+		// Refresh the paths once if the first path is about to expire.
+		req.Flags.Refresh = false
+		if exp, ok := minExpTime(last); ok && exp != refreshedExp &&
+			time.Until(util.SecsToTime(exp)) < SubscriptionExpiryMargin {
+
+			req.Flags.Refresh = true
+			refreshedExp = exp
+		}
+		reply, err := fetchPaths(ctx, h.Fetcher, &req)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.Info("Unable to get paths", "err", err)
+		}
+		if key := pathSetKey(reply); last == nil || key != lastKey {
+			update := &sciond.Pld{
+				Id:         pld.Id,
+				Which:      proto.SCIONDMsg_Which_pathUpdate,
+				PathUpdate: reply,
+			}
+			conn.SetWriteDeadline(time.Now().Add(DefaultReplyTimeout))
+			if err := sciond.Send(update, conn); err != nil {
+				logger.Warn("Unable to send path update to client", "client", src, "err", err)
+				return
+			}
+			logger.Debug("Sent path update", "num_paths", len(reply.Entries),
+				"err_code", reply.ErrorCode)
+			lastKey = key
+		}
+		last = reply
+		if !waitForUpdate(ctx, ticker.C, revs, pathInterfaces(last)) {
+			return
+		}
+	}
+}
+
+// waitForUpdate blocks until the paths must be looked up again. It returns
+// false if the context is done.
+func waitForUpdate(ctx context.Context, tick <-chan time.Time,
+	revs <-chan *path_mgmt.RevInfo, intfs revcache.KeySet) bool {
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-tick:
+			return true
+		case rev := <-revs:
+			if _, ok := intfs[revcache.Key{IA: rev.IA(), IfId: rev.IfID}]; ok {
+				return true
+			}
+		}
+	}
+}
+
+// pathSetKey returns a key that identifies the path set in the reply. The key
+// does not depend on the order of the paths.
+func pathSetKey(reply *sciond.PathReply) string {
+	keys := make([]string, 0, len(reply.Entries))
+	for _, e := range reply.Entries {
+		if e.Path == nil {
+			continue
+		}
+		keys = append(keys, fmt.Sprintf("%x-%d-%d", e.Path.FwdPath, e.Path.ExpTime, e.Path.Mtu))
+	}
+	sort.Strings(keys)
+	return fmt.Sprintf("%d:%s", reply.ErrorCode, strings.Join(keys, ","))
+}
+
+// pathInterfaces returns the set of interfaces on the paths in the reply.
+func pathInterfaces(reply *sciond.PathReply) revcache.KeySet {
+	intfs := make(revcache.KeySet)
+	for _, e := range reply.Entries {
+		if e.Path == nil {
+			continue
+		}
+		for _, intf := range e.Path.Interfaces {
+			intfs[revcache.Key{IA: intf.RawIsdas.IA(), IfId: intf.IfID}] = struct{}{}
+		}
+	}
+	return intfs
+}
+
+// minExpTime returns the earliest expiration time of the paths in the reply.
+func minExpTime(reply *sciond.PathReply) (uint32, bool) {
+	if reply == nil {
+		return 0, false
+	}
+	var exp uint32
+	var found bool
+	for _, e := range reply.Entries {
+		if e.Path == nil {
+			continue
+		}
+		if !found || e.Path.ExpTime < exp {
+			exp = e.Path.ExpTime
+			found = true
+		}
+	}
+	return exp, found
+}
+
+// RevocationNotifier is a revocation cache that notifies subscribers about the
+// revocations that are inserted into the cache.
+type RevocationNotifier struct {
+	revcache.RevCache
+
+	mu          sync.Mutex
+	subscribers map[chan *path_mgmt.RevInfo]struct{}
+}
+
+// NewRevocationNotifier wraps the revocation cache.
+func NewRevocationNotifier(revCache revcache.RevCache) *RevocationNotifier {
+	return &RevocationNotifier{
+		RevCache:    revCache,
+		subscribers: make(map[chan *path_mgmt.RevInfo]struct{}),
+	}
+}
+
+// Insert inserts the revocation into the cache. If the revocation is
+// inserted, the subscribers are notified.
+func (n *RevocationNotifier) Insert(ctx context.Context,
+	rev *path_mgmt.SignedRevInfo) (bool, error) {
+
+	inserted, err := n.RevCache.Insert(ctx, rev)
+	if err != nil || !inserted {
+		return inserted, err
+	}
+	info, err := rev.RevInfo()
+	if err != nil {
+		return inserted, nil
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for sub := range n.subscribers {
+		// Slow subscribers miss revocations, they still look up the paths
+		// periodically.
+		select {
+		case sub <- info:
+		default:
+		}
+	}
+	return inserted, nil
+}
+
+// Subscribe returns a channel on which inserted revocations are delivered and
+// a function to end the subscription.
+func (n *RevocationNotifier) Subscribe() (<-chan *path_mgmt.RevInfo, func()) {
+	sub := make(chan *path_mgmt.RevInfo, 16)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.subscribers[sub] = struct{}{}
+	return sub, func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		delete(n.subscribers, sub)
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright 2020 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	capnp "zombiezen.com/go/capnproto2"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/revcache/mock_revcache"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+	"github.com/scionproto/scion/go/sciond/internal/fetcher/mock_fetcher"
+)
+
+var (
+	ia110 = xtest.MustParseIA("1-ff00:0:110")
+	ia111 = xtest.MustParseIA("1-ff00:0:111")
+)
+
+func TestPathSubscriptionHandler(t *testing.T) {
+	subReq := &sciond.Pld{
+		Id:    1,
+		Which: proto.SCIONDMsg_Which_pathSubscriptionReq,
+		PathSubscriptionReq: &sciond.PathReq{
+			Dst: ia110.IAInt(),
+			Src: ia111.IAInt(),
+		},
+	}
+
+	t.Run("path set changes", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		first, second := testPathReply(1), testPathReply(2)
+		f := mock_fetcher.NewMockFetcher(mctrl)
+		gomock.InOrder(
+			f.EXPECT().GetPaths(gomock.Any(), gomock.Any(), gomock.Any()).Return(first, nil),
+			// The path set is unchanged, no update is pushed.
+			f.EXPECT().GetPaths(gomock.Any(), gomock.Any(), gomock.Any()).Return(first, nil),
+			f.EXPECT().GetPaths(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+				second, nil).AnyTimes(),
+		)
+		h := &PathSubscriptionHandler{Fetcher: f, CheckInterval: 10 * time.Millisecond}
+		client, done := startSubscription(t, h, subReq)
+
+		assertUpdate(t, first, client)
+		assertUpdate(t, second, client)
+		closeSubscription(t, client, done)
+	})
+
+	t.Run("revocation on path", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		revCache := mock_revcache.NewMockRevCache(mctrl)
+		revCache.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+		notifier := NewRevocationNotifier(revCache)
+
+		first, second := testPathReply(1), testPathReply(2)
+		f := mock_fetcher.NewMockFetcher(mctrl)
+		// The periodic lookup never fires, only the revocation on the path
+		// triggers the second lookup.
+		gomock.InOrder(
+			f.EXPECT().GetPaths(gomock.Any(), gomock.Any(), gomock.Any()).Return(first, nil),
+			f.EXPECT().GetPaths(gomock.Any(), gomock.Any(), gomock.Any()).Return(second, nil),
+		)
+		h := &PathSubscriptionHandler{
+			Fetcher:       f,
+			Revocations:   notifier,
+			CheckInterval: time.Hour,
+		}
+		client, done := startSubscription(t, h, subReq)
+
+		assertUpdate(t, first, client)
+		_, err := notifier.Insert(context.Background(), testRevocation(t, ia111, 42))
+		require.NoError(t, err)
+		_, err = notifier.Insert(context.Background(), testRevocation(t, ia111, 1))
+		require.NoError(t, err)
+		assertUpdate(t, second, client)
+		closeSubscription(t, client, done)
+	})
+
+	t.Run("refresh before expiry", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		reply := testPathReply(1)
+		reply.Entries[0].Path.ExpTime = util.TimeToSecs(time.Now().Add(time.Second))
+		refreshed := make(chan struct{})
+		f := mock_fetcher.NewMockFetcher(mctrl)
+		expectRefresh := func(refresh bool, after func()) *gomock.Call {
+			return f.EXPECT().GetPaths(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, req *sciond.PathReq,
+					_ time.Duration) (*sciond.PathReply, error) {
+
+					assert.Equal(t, refresh, req.Flags.Refresh)
+					if after != nil {
+						after()
+					}
+					return reply, nil
+				},
+			)
+		}
+		// The paths are only refreshed once per expiration time.
+		gomock.InOrder(
+			expectRefresh(false, nil),
+			expectRefresh(true, nil),
+			expectRefresh(false, func() { close(refreshed) }),
+			expectRefresh(false, nil).AnyTimes(),
+		)
+		h := &PathSubscriptionHandler{Fetcher: f, CheckInterval: 10 * time.Millisecond}
+		client, done := startSubscription(t, h, subReq)
+
+		assertUpdate(t, reply, client)
+		waitChannel(t, refreshed)
+		closeSubscription(t, client, done)
+	})
+}
+
+func TestWaitForUpdate(t *testing.T) {
+	intfs := revcache.KeySet{{IA: ia111, IfId: 1}: struct{}{}}
+
+	t.Run("tick", func(t *testing.T) {
+		tick := make(chan time.Time, 1)
+		tick <- time.Now()
+		assert.True(t, waitForUpdate(context.Background(), tick, nil, intfs))
+	})
+	t.Run("revocation on path", func(t *testing.T) {
+		revs := make(chan *path_mgmt.RevInfo, 2)
+		revs <- &path_mgmt.RevInfo{RawIsdas: ia111.IAInt(), IfID: 42}
+		revs <- &path_mgmt.RevInfo{RawIsdas: ia111.IAInt(), IfID: 1}
+		assert.True(t, waitForUpdate(context.Background(), nil, revs, intfs))
+		assert.Empty(t, revs)
+	})
+	t.Run("context done", func(t *testing.T) {
+		revs := make(chan *path_mgmt.RevInfo, 1)
+		revs <- &path_mgmt.RevInfo{RawIsdas: ia110.IAInt(), IfID: 1}
+		ctx, cancelF := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancelF()
+		assert.False(t, waitForUpdate(ctx, nil, revs, intfs))
+	})
+}
+
+func TestPathSetKey(t *testing.T) {
+	a, b := testPathReply(1), testPathReply(2)
+	ab := &sciond.PathReply{Entries: append(a.Entries, b.Entries...)}
+	ba := &sciond.PathReply{Entries: append(b.Entries, a.Entries...)}
+	assert.Equal(t, pathSetKey(ab), pathSetKey(ba))
+	assert.NotEqual(t, pathSetKey(a), pathSetKey(b))
+	assert.NotEqual(t, pathSetKey(&sciond.PathReply{ErrorCode: sciond.ErrorOk}),
+		pathSetKey(&sciond.PathReply{ErrorCode: sciond.ErrorNoPaths}))
+}
+
+func TestRevocationNotifier(t *testing.T) {
+	rev := testRevocation(t, ia111, 1)
+
+	t.Run("inserted revocation is delivered", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		revCache := mock_revcache.NewMockRevCache(mctrl)
+		revCache.EXPECT().Insert(gomock.Any(), rev).Return(true, nil)
+		n := NewRevocationNotifier(revCache)
+		sub1, unsubscribe1 := n.Subscribe()
+		defer unsubscribe1()
+		sub2, unsubscribe2 := n.Subscribe()
+		defer unsubscribe2()
+
+		inserted, err := n.Insert(context.Background(), rev)
+		require.NoError(t, err)
+		assert.True(t, inserted)
+		info, err := rev.RevInfo()
+		require.NoError(t, err)
+		assert.Equal(t, info, <-sub1)
+		assert.Equal(t, info, <-sub2)
+	})
+	t.Run("known revocation is not delivered", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		revCache := mock_revcache.NewMockRevCache(mctrl)
+		revCache.EXPECT().Insert(gomock.Any(), rev).Return(false, nil)
+		n := NewRevocationNotifier(revCache)
+		sub, unsubscribe := n.Subscribe()
+		defer unsubscribe()
+
+		inserted, err := n.Insert(context.Background(), rev)
+		require.NoError(t, err)
+		assert.False(t, inserted)
+		assert.Empty(t, sub)
+	})
+	t.Run("failed insert is not delivered", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		revCache := mock_revcache.NewMockRevCache(mctrl)
+		revCache.EXPECT().Insert(gomock.Any(), rev).Return(false, errors.New("test"))
+		n := NewRevocationNotifier(revCache)
+		sub, unsubscribe := n.Subscribe()
+		defer unsubscribe()
+
+		_, err := n.Insert(context.Background(), rev)
+		assert.Error(t, err)
+		assert.Empty(t, sub)
+	})
+	t.Run("unsubscribed", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		revCache := mock_revcache.NewMockRevCache(mctrl)
+		revCache.EXPECT().Insert(gomock.Any(), rev).Return(true, nil)
+		n := NewRevocationNotifier(revCache)
+		sub, unsubscribe := n.Subscribe()
+		unsubscribe()
+
+		_, err := n.Insert(context.Background(), rev)
+		require.NoError(t, err)
+		assert.Empty(t, sub)
+	})
+	t.Run("slow subscriber does not block", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		revCache := mock_revcache.NewMockRevCache(mctrl)
+		revCache.EXPECT().Insert(gomock.Any(), rev).Return(true, nil).Times(20)
+		n := NewRevocationNotifier(revCache)
+		sub, unsubscribe := n.Subscribe()
+		defer unsubscribe()
+
+		for i := 0; i < 20; i++ {
+			_, err := n.Insert(context.Background(), rev)
+			require.NoError(t, err)
+		}
+		assert.Len(t, sub, cap(sub))
+	})
+}
+
+// startSubscription runs the handler on one end of a pipe and returns the
+// other end together with a channel that is closed when the handler returns.
+func startSubscription(t *testing.T, h *PathSubscriptionHandler,
+	pld *sciond.Pld) (net.Conn, <-chan struct{}) {
+
+	client, server := net.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.Handle(context.Background(), server, nil, pld)
+	}()
+	return client, done
+}
+
+// closeSubscription closes the client end and waits for the handler to
+// return.
+func closeSubscription(t *testing.T, client net.Conn, done <-chan struct{}) {
+	require.NoError(t, client.Close())
+	waitChannel(t, done)
+}
+
+// assertUpdate receives the next path update and checks that it contains the
+// expected path set.
+func assertUpdate(t *testing.T, expected *sciond.PathReply, conn net.Conn) {
+	assert.Equal(t, pathSetKey(expected), pathSetKey(receiveUpdate(t, conn)))
+}
+
+func receiveUpdate(t *testing.T, conn net.Conn) *sciond.PathReply {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	msg, err := proto.SafeDecode(capnp.NewDecoder(conn))
+	require.NoError(t, err)
+	root, err := msg.RootPtr()
+	require.NoError(t, err)
+	pld := &sciond.Pld{}
+	require.NoError(t, proto.SafeExtract(pld, proto.SCIONDMsg_TypeID, root.Struct()))
+	assert.Equal(t, uint64(1), pld.Id)
+	require.Equal(t, proto.SCIONDMsg_Which_pathUpdate, pld.Which)
+	return pld.PathUpdate
+}
+
+func waitChannel(t *testing.T, ch <-chan struct{}) {
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatal("Timed out")
+	}
+}
+
+// testPathReply returns a reply with a single path that traverses the
+// interface ifid of AS 1-ff00:0:111.
+func testPathReply(ifid common.IFIDType) *sciond.PathReply {
+	return &sciond.PathReply{
+		ErrorCode: sciond.ErrorOk,
+		Entries: []sciond.PathReplyEntry{
+			{
+				Path: &sciond.FwdPathMeta{
+					FwdPath: []byte{byte(ifid)},
+					Mtu:     1472,
+					Interfaces: []sciond.PathInterface{
+						{RawIsdas: ia111.IAInt(), IfID: ifid},
+						{RawIsdas: ia110.IAInt(), IfID: ifid},
+					},
+					ExpTime: util.TimeToSecs(time.Now().Add(time.Hour)),
+				},
+			},
+		},
+	}
+}
+
+func testRevocation(t *testing.T, ia addr.IA, ifid common.IFIDType) *path_mgmt.SignedRevInfo {
+	rev, err := path_mgmt.NewSignedRevInfo(&path_mgmt.RevInfo{
+		IfID:     ifid,
+		RawIsdas: ia.IAInt(),
+	}, infra.NullSigner)
+	require.NoError(t, err)
+	return rev
+}
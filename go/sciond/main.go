@@ -145,28 +145,42 @@ func realMain() int {
 		return 1
 	}
 
+	// Path subscriptions are notified about the revocations inserted by the
+	// fetcher and the revocation handler.
+	revNotifier := servers.NewRevocationNotifier(revCache)
+	pathFetcher := fetcher.NewFetcher(
+		msger,
+		pathDB,
+		trustStore,
+		verificationFactory{Provider: trustStore},
+		revNotifier,
+		cfg.SD,
+		itopo.Provider(),
+	)
+	pathHandler := &servers.PathRequestHandler{
+		Fetcher: pathFetcher,
+	}
+	asInfoHandler := &servers.ASInfoRequestHandler{
+		ASInspector: trustStore,
+	}
+	ifInfoHandler := &servers.IFInfoRequestHandler{}
+	svcInfoHandler := &servers.SVCInfoRequestHandler{}
+	revHandler := &servers.RevNotificationHandler{
+		RevCache:         revNotifier,
+		VerifierFactory:  verificationFactory{Provider: trustStore},
+		NextQueryCleaner: segfetcher.NextQueryCleaner{PathDB: pathDB},
+	}
 	handlers := servers.HandlerMap{
-		proto.SCIONDMsg_Which_pathReq: &servers.PathRequestHandler{
-			Fetcher: fetcher.NewFetcher(
-				msger,
-				pathDB,
-				trustStore,
-				verificationFactory{Provider: trustStore},
-				revCache,
-				cfg.SD,
-				itopo.Provider(),
-			),
-		},
-		proto.SCIONDMsg_Which_asInfoReq: &servers.ASInfoRequestHandler{
-			ASInspector: trustStore,
-		},
-		proto.SCIONDMsg_Which_ifInfoRequest:      &servers.IFInfoRequestHandler{},
-		proto.SCIONDMsg_Which_serviceInfoRequest: &servers.SVCInfoRequestHandler{},
-		proto.SCIONDMsg_Which_revNotification: &servers.RevNotificationHandler{
-			RevCache:         revCache,
-			VerifierFactory:  verificationFactory{Provider: trustStore},
-			NextQueryCleaner: segfetcher.NextQueryCleaner{PathDB: pathDB},
+		proto.SCIONDMsg_Which_pathReq: pathHandler,
+		proto.SCIONDMsg_Which_pathSubscriptionReq: &servers.PathSubscriptionHandler{
+			Fetcher:       pathFetcher,
+			Revocations:   revNotifier,
+			CheckInterval: cfg.SD.SubscriptionInterval.Duration,
 		},
+		proto.SCIONDMsg_Which_asInfoReq:          asInfoHandler,
+		proto.SCIONDMsg_Which_ifInfoRequest:      ifInfoHandler,
+		proto.SCIONDMsg_Which_serviceInfoRequest: svcInfoHandler,
+		proto.SCIONDMsg_Which_revNotification:    revHandler,
 	}
 	cleaner := periodic.Start(pathdb.NewCleaner(pathDB, "sd_segments"),
 		300*time.Second, 295*time.Second)
@@ -177,6 +191,18 @@ func realMain() int {
 	apiServer, shutdownF := NewServer("tcp", cfg.SD.Address, handlers)
 	defer shutdownF()
 	StartServer(cfg.SD.Address, apiServer)
+	if cfg.SD.HTTPAddress != "" {
+		httpAPI := &servers.HTTPAPI{
+			Paths:       pathHandler,
+			ASInfo:      asInfoHandler,
+			IFInfo:      ifInfoHandler,
+			SVCInfo:     svcInfoHandler,
+			Revocations: revHandler,
+		}
+		httpServer, httpShutdownF := NewHTTPServer(cfg.SD.HTTPAddress, httpAPI)
+		defer httpShutdownF()
+		StartHTTPServer(httpServer)
+	}
 	http.HandleFunc("/config", configHandler)
 	http.HandleFunc("/info", env.InfoHandler)
 	http.HandleFunc("/topology", itopo.TopologyHandler)
@@ -252,6 +278,31 @@ func StartServer(address string, server *servers.Server) {
 	}()
 }
 
+// NewHTTPServer creates a server for the JSON API on the address. The
+// returned function shuts the server down.
+func NewHTTPServer(address string, api *servers.HTTPAPI) (*http.Server, func()) {
+	mux := http.NewServeMux()
+	api.Register(mux)
+	server := &http.Server{Addr: address, Handler: mux}
+	shutdownF := func() {
+		ctx, cancelF := context.WithTimeout(context.Background(), ShutdownWaitTimeout)
+		server.Shutdown(ctx)
+		cancelF()
+	}
+	return server, shutdownF
+}
+
+func StartHTTPServer(server *http.Server) {
+	log.Info("Serving HTTP API", "addr", server.Addr)
+	go func() {
+		defer log.HandlePanic()
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			fatal.Fatal(common.NewBasicError("HTTP API ListenAndServe error", err,
+				"address", server.Addr))
+		}
+	}()
+}
+
 func configHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/plain")
 	var buf bytes.Buffer